@@ -2,123 +2,312 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
-	"task-management-system/backend/internal/adapter/gateway/db"
 	"task-management-system/backend/internal/adapter/http/controller"
 	"task-management-system/backend/internal/adapter/http/generated/openapi"
 	"task-management-system/backend/internal/adapter/http/handler"
+	"task-management-system/backend/internal/adapter/http/middleware"
+	"task-management-system/backend/internal/driver/config"
+	"task-management-system/backend/internal/driver/health"
+	"task-management-system/backend/internal/driver/metrics"
+	"task-management-system/backend/internal/driver/migration"
 	"task-management-system/backend/internal/usecase"
+	"task-management-system/backend/migrations"
 
-	"github.com/golang-migrate/migrate/v4"
-	"github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
+	echomiddleware "github.com/labstack/echo/v4/middleware"
 )
 
+// usage コマンドの使い方
+const usage = `Usage:
+  api [serve] [--config path] [--storage postgres|memory]
+  api migrate [--config path] up|down [N]|to VERSION|force VERSION|status
+
+Commands:
+  serve    Start the API server (default). Refuses to start if the schema is behind or dirty
+  migrate  Apply, roll back or inspect database migrations embedded in the binary
+`
+
 func main() {
-	// データベース接続
-	databaseURL := os.Getenv("DATABASE_URL")
-	if databaseURL == "" {
-		log.Fatal("DATABASE_URL environment variable is required")
+	// サブコマンドを省略した場合はserveとして扱う
+	command, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
 	}
 
-	// マイグレーションを実行（単一接続を使用）
-	if err := runMigrations(databaseURL); err != nil {
-		log.Printf("Warning: Migration failed: %v", err)
-		// マイグレーションエラーは無視して続行（既に実行済みの場合など）
+	var err error
+	switch command {
+	case "serve":
+		err = serveCommand(args)
+	case "migrate":
+		err = migrateCommand(args)
+	case "help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		log.Fatalf("Unknown command %q", command)
 	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// serveCommand 設定を読み込んでサーバーを起動
+func serveCommand(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	// 設定ファイルはCONFIG_FILEでも指定できる。--storageは設定ファイルと環境変数より優先する
+	configPath := flags.String("config", os.Getenv("CONFIG_FILE"), "path to YAML config file")
+	storage := flags.String("storage", "", "storage backend: postgres or memory (overrides STORAGE)")
+	flags.Parse(args)
 
-	// データベース接続プールを確立
-	pool, err := pgxpool.New(context.Background(), databaseURL)
+	cfg, err := loadConfig(*configPath, config.WithStorage(*storage))
 	if err != nil {
-		log.Fatalf("Failed to create connection pool: %v", err)
+		return err
 	}
-	defer pool.Close()
+	return run(cfg)
+}
 
-	// 接続プールの設定
-	pool.Config().MaxConns = 25
-	pool.Config().MinConns = 5
-	pool.Config().MaxConnLifetime = time.Hour
-	pool.Config().MaxConnIdleTime = time.Minute * 30
+// loadConfig 設定を読み込み、伏せた値をログに出力してログレベルを設定
+func loadConfig(path string, opts ...config.Option) (config.Config, error) {
+	cfg, err := config.Load(path, opts...)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to load config: %w", err)
+	}
+	log.Printf("Configuration:\n%s", cfg)
 
-	// 接続をテスト
-	if err := pool.Ping(context.Background()); err != nil {
-		log.Fatalf("Failed to ping database: %v", err)
+	logLevel, err := cfg.Log.SlogLevel()
+	if err != nil {
+		return cfg, fmt.Errorf("failed to load config: %w", err)
 	}
+	slog.SetLogLoggerLevel(logLevel)
+	return cfg, nil
+}
+
+// run サーバーを起動し、SIGINTまたはSIGTERMを受け取るまで実行する
+// 停止時は新しいリクエストの受け付けを止め、処理中のリクエストの完了を待ってから（最大でShutdownTimeout）、
+// バックグラウンドの処理を止めてデータベースの接続を閉じる
+func run(cfg config.Config) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	log.Println("Database connection pool established successfully")
+	// 指標を作成（クエリの計測は接続プールの作成時に設定する）
+	appMetrics := metrics.New()
 
-	// リポジトリを作成
-	taskRepo := db.NewTaskRepository(pool)
-	accountRepo := db.NewAccountRepository(pool)
+	repos, closeRepos, err := openRepositories(cfg, appMetrics.QueryTracer())
+	if err != nil {
+		return fmt.Errorf("failed to open storage: %w", err)
+	}
+	// 接続を使用する処理がすべて終わってから閉じる（deferは登録と逆順に実行される）
+	defer closeRepos()
+	if repos.pool != nil {
+		if err := appMetrics.RegisterPool(repos.pool); err != nil {
+			return fmt.Errorf("failed to register pool metrics: %w", err)
+		}
+	}
+
+	// 認証設定を読み込み
+	authConfig, err := loadAuthConfig(cfg.Auth)
+	if err != nil {
+		return fmt.Errorf("failed to load auth config: %w", err)
+	}
+	tokenVerifier, err := middleware.NewTokenVerifier(authConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create token verifier: %w", err)
+	}
 
 	// ユースケースを作成
-	taskUsecase := usecase.NewTaskUsecase(taskRepo, accountRepo)
-	accountUsecase := usecase.NewAccountUsecase(accountRepo)
+	taskUsecase := usecase.NewTaskUsecase(repos.txManager, repos.tasks, repos.accounts, repos.categories, repos.outputTemplates, usecase.WithTaskEventRecorder(appMetrics))
+	accountUsecase := usecase.NewAccountUsecase(repos.accounts)
+	categoryUsecase := usecase.NewCategoryUsecase(repos.categories)
+	outputTemplateUsecase := usecase.NewOutputTemplateUsecase(repos.outputTemplates)
+	reportUsecase := usecase.NewReportUsecase(repos.reports)
+	recurringTemplateUsecase := usecase.NewRecurringTemplateUsecase(repos.txManager, repos.recurringTemplates, repos.tasks, repos.accounts, repos.categories, repos.outputTemplates, usecase.WithRecurringTaskEventRecorder(appMetrics))
 
 	// コントローラーを作成
 	taskController := controller.NewTaskController(taskUsecase)
 	accountController := controller.NewAccountController(accountUsecase)
+	categoryController := controller.NewCategoryController(categoryUsecase)
+	outputTemplateController := controller.NewOutputTemplateController(outputTemplateUsecase)
+	reportController := controller.NewReportController(reportUsecase)
+	recurringTemplateController := controller.NewRecurringTemplateController(recurringTemplateUsecase)
 
 	// ハンドラーを作成
-	server := handler.NewServer(taskController, accountController)
+	server := handler.NewServer(taskController, accountController, categoryController, outputTemplateController, reportController, recurringTemplateController)
+	healthHandler, err := newHealthHandler(cfg, repos)
+	if err != nil {
+		return err
+	}
 
 	// Echoインスタンスを作成
 	e := echo.New()
 
+	// ドメインエラーを共通エラーレスポンスに変換するエラーハンドラーを登録
+	e.HTTPErrorHandler = controller.NewHTTPErrorHandler(e.DefaultHTTPErrorHandler)
+
+	// リクエストの件数と処理時間をオペレーションIDごとに計測（CORSや認証で拒否したリクエストも含めるため、最初に登録する）
+	spec, err := openapi.GetSwagger()
+	if err != nil {
+		return fmt.Errorf("failed to load OpenAPI spec: %w", err)
+	}
+	e.Use(appMetrics.Middleware(metrics.OperationIDs(spec)))
+
+	// 許可したオリジンからのリクエストを受け付ける（プリフライトリクエストには認証が不要なため、認証より前に登録する）
+	if len(cfg.Server.CORSAllowedOrigins) > 0 {
+		e.Use(echomiddleware.CORSWithConfig(echomiddleware.CORSConfig{
+			AllowOrigins:  cfg.Server.CORSAllowedOrigins,
+			AllowHeaders:  []string{echo.HeaderAuthorization, echo.HeaderContentType, "If-Match"},
+			ExposeHeaders: []string{"ETag"},
+		}))
+	}
+
+	// リクエストの処理に期限を設定（期限はユースケースとリポジトリに伝わる）
+	e.Use(middleware.RequestTimeout(cfg.Server.RequestTimeout))
+
+	// 認証ミドルウェアを登録（内部APIはアカウントのトークンの代わりにサービスの資格情報で認証する）
+	if cfg.Auth.ServiceToken == "" {
+		log.Println("Warning: AUTH_SERVICE_TOKEN is not set, internal account APIs used by the frontend during sign-in will reject all requests")
+	}
+	e.Use(middleware.ServiceAuth(cfg.Auth.ServiceToken, isServiceRoute))
+	e.Use(middleware.Auth(tokenVerifier, func(ctx echo.Context) bool {
+		return isPublicRoute(ctx) || isServiceRoute(ctx)
+	}))
+
 	// ルーティングを登録
 	openapi.RegisterHandlers(e, server)
+	healthHandler.Register(e)
+	appMetrics.Register(e)
+
+	// 遅いクライアントが接続を占有し続けないようにタイムアウトを設定
+	e.Server.ReadTimeout = cfg.Server.ReadTimeout
+	e.Server.WriteTimeout = cfg.Server.WriteTimeout
+	e.Server.IdleTimeout = cfg.Server.IdleTimeout
+
+	// 保持期間を過ぎたゴミ箱のタスクを定期的に完全に削除（停止時は完了を待ってから接続を閉じる）
+	purgeDone := make(chan struct{})
+	go func() {
+		defer close(purgeDone)
+		runTrashPurge(ctx, taskUsecase, cfg.Trash)
+	}()
+	defer func() {
+		stop()
+		<-purgeDone
+	}()
 
 	// サーバーを起動
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Printf("Server starting on port %s", cfg.Server.Port)
+		serverErr <- e.Start(":" + cfg.Server.Port)
+	}()
+
+	select {
+	case err := <-serverErr:
+		return fmt.Errorf("server stopped unexpectedly: %w", err)
+	case <-ctx.Done():
 	}
+	// 以降のシグナルではデフォルトの動作（即時終了）に戻す
+	stop()
 
-	log.Printf("Server starting on port %s", port)
-	if err := e.Start(":" + port); err != nil {
-		log.Fatal(err)
+	log.Printf("Shutting down server (waiting up to %s for in-flight requests)", cfg.Server.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shut down server gracefully: %w", err)
 	}
+	log.Println("Server stopped")
+	return nil
 }
 
-// runMigrations データベースマイグレーションを実行
-func runMigrations(databaseURL string) error {
-	// pgxの接続をstdlibに変換
-	config, err := pgx.ParseConfig(databaseURL)
+// newHealthHandler 運用向けのエンドポイントのハンドラーを作成
+// PostgreSQLの場合は接続プールとマイグレーションの状態を確認する
+func newHealthHandler(cfg config.Config, repos repositories) (*health.Handler, error) {
+	if repos.pool == nil {
+		return health.NewHandler(nil, nil, cfg.Server.ReadinessTimeout, cfg.Auth.AdminAccountIDs), nil
+	}
+
+	files, err := migration.ListFiles(migrations.FS)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	inspector := migration.NewInspector(repos.pool, files)
+	return health.NewHandler(repos.pool, inspector, cfg.Server.ReadinessTimeout, cfg.Auth.AdminAccountIDs), nil
+}
 
-	db := stdlib.OpenDB(*config)
-	defer db.Close()
+// loadAuthConfig 設定からトークン検証の設定を作成（RS256の公開鍵を読み込む）
+func loadAuthConfig(cfg config.AuthConfig) (middleware.AuthConfig, error) {
+	authConfig := middleware.AuthConfig{
+		Algorithm: cfg.Algorithm,
+		Issuer:    cfg.Issuer,
+		Audience:  cfg.Audience,
+	}
 
-	// データベースドライバーを取得
-	driver, err := postgres.WithInstance(db, &postgres.Config{})
-	if err != nil {
-		return err
+	switch authConfig.Algorithm {
+	case middleware.AlgorithmHS256:
+		authConfig.Secret = []byte(cfg.Secret)
+	case middleware.AlgorithmRS256:
+		// 公開鍵はPEM文字列またはファイルパスで指定する
+		publicKeyPEM := []byte(cfg.PublicKey)
+		if cfg.PublicKeyFile != "" {
+			data, err := os.ReadFile(cfg.PublicKeyFile)
+			if err != nil {
+				return authConfig, fmt.Errorf("failed to read public key file: %w", err)
+			}
+			publicKeyPEM = data
+		}
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM(publicKeyPEM)
+		if err != nil {
+			return authConfig, fmt.Errorf("failed to parse public key: %w", err)
+		}
+		authConfig.PublicKey = publicKey
 	}
 
-	// マイグレーションインスタンスを作成
-	m, err := migrate.NewWithDatabaseInstance(
-		"file://migrations",
-		"postgres",
-		driver,
-	)
-	if err != nil {
-		return err
+	return authConfig, nil
+}
+
+// runTrashPurge 起動時と一定間隔ごとに、保持期間を過ぎたゴミ箱のタスクを完全に削除
+// ctxがキャンセルされるまで実行を続ける
+func runTrashPurge(ctx context.Context, taskUsecase *usecase.TaskUsecase, trash config.TrashConfig) {
+	ticker := time.NewTicker(trash.PurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := taskUsecase.PurgeExpiredTasks(ctx, time.Now(), trash.Retention())
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Failed to purge expired trashed tasks: %v", err)
+		} else if purged > 0 {
+			log.Printf("Purged %d expired trashed tasks", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
 	}
+}
 
-	// マイグレーションを実行
-	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
-		return err
+// isServiceRoute サービスの資格情報で認証する内部APIかどうかを判定
+// better-authがログイン処理中（トークン発行前）に呼び出すAPIのため、アカウントのトークンでは認証できない
+func isServiceRoute(ctx echo.Context) bool {
+	switch ctx.Path() {
+	case "/api/accounts/auth", "/api/accounts/by-email":
+		return true
 	}
+	return false
+}
 
-	log.Println("Database migrations completed successfully")
-	return nil
+// isPublicRoute 認証不要のルートかどうかを判定
+// オーケストレーターが呼び出す死活監視・指標のみ認証を免除する
+func isPublicRoute(ctx echo.Context) bool {
+	return health.IsPublicRoute(ctx.Path()) || metrics.IsPublicRoute(ctx.Path())
 }
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"task-management-system/backend/internal/driver/config"
+	"task-management-system/backend/internal/driver/migration"
+	"task-management-system/backend/migrations"
+)
+
+// migrateCommand バイナリに埋め込んだマイグレーションを適用・取り消し・確認する
+//
+//	migrate up             適用していないマイグレーションをすべて適用
+//	migrate down [N]       適用済みのマイグレーションを新しい順にN件（デフォルト: 1）取り消す
+//	migrate to VERSION     指定したバージョンまで適用または取り消す
+//	migrate force VERSION  dirtyを解除して適用済みのバージョンを書き換える（-1は未適用）
+//	migrate status         適用済みのバージョンとマイグレーションの一覧を表示
+func migrateCommand(args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	configPath := flags.String("config", os.Getenv("CONFIG_FILE"), "path to YAML config file")
+	flags.Parse(args)
+	if flags.NArg() == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("migrate requires an action")
+	}
+	action, actionArgs := flags.Arg(0), flags.Args()[1:]
+
+	// マイグレーションはPostgreSQLのみが対象のため、設定した保存先にかかわらずDATABASE_URLを必須にする
+	cfg, err := loadConfig(*configPath, config.WithStorage(config.StoragePostgres))
+	if err != nil {
+		return err
+	}
+
+	migrator, err := migration.NewMigrator(cfg.Database.URL, migrations.FS)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	switch action {
+	case "up":
+		if err := requireArgs(action, actionArgs, 0); err != nil {
+			return err
+		}
+		err = migrator.Up()
+	case "down":
+		if len(actionArgs) > 1 {
+			return fmt.Errorf("migrate down accepts at most 1 argument, got %d", len(actionArgs))
+		}
+		steps := 1
+		if len(actionArgs) == 1 {
+			var parseErr error
+			if steps, parseErr = strconv.Atoi(actionArgs[0]); parseErr != nil {
+				return fmt.Errorf("migrate down: N must be an integer: %q", actionArgs[0])
+			}
+		}
+		err = migrator.Down(steps)
+	case "to":
+		if err := requireArgs(action, actionArgs, 1); err != nil {
+			return err
+		}
+		version, parseErr := strconv.ParseUint(actionArgs[0], 10, 0)
+		if parseErr != nil {
+			return fmt.Errorf("migrate to: VERSION must be a non-negative integer: %q", actionArgs[0])
+		}
+		err = migrator.To(uint(version))
+	case "force":
+		if err := requireArgs(action, actionArgs, 1); err != nil {
+			return err
+		}
+		version, parseErr := strconv.Atoi(actionArgs[0])
+		if parseErr != nil {
+			return fmt.Errorf("migrate force: VERSION must be an integer: %q", actionArgs[0])
+		}
+		err = migrator.Force(version)
+	case "status":
+		if err := requireArgs(action, actionArgs, 0); err != nil {
+			return err
+		}
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown migrate action %q", action)
+	}
+	if err != nil {
+		return fmt.Errorf("migrate %s failed: %w", action, err)
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+	printStatus(status)
+	return nil
+}
+
+// requireArgs 操作の引数の数を確認
+func requireArgs(action string, args []string, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("migrate %s requires %d argument(s), got %d", action, n, len(args))
+	}
+	return nil
+}
+
+// printStatus 適用済みのバージョンとマイグレーションの一覧を標準出力に表示
+func printStatus(status migration.Status) {
+	current := "none"
+	if status.Version != nil {
+		current = strconv.FormatUint(uint64(*status.Version), 10)
+	}
+	fmt.Printf("Current version: %s (dirty: %t)\n", current, status.Dirty)
+	fmt.Printf("Latest version:  %d\n", status.Latest())
+	for _, file := range status.Files {
+		mark := " "
+		if status.Applied(file) {
+			mark = "x"
+		}
+		fmt.Printf("  [%s] %06d %s\n", mark, file.Version, file.Name)
+	}
+	if err := status.Check(); err != nil {
+		fmt.Printf("Schema is not up to date: %v\n", err)
+	}
+}
+
+// prepareSchema サーバーの起動前にスキーマを確認（設定した場合は適用していないマイグレーションを先に適用する）
+// スキーマが最新でない場合やdirtyの場合は、AllowOutdatedSchemaを設定しない限りエラーを返して起動を中止する
+func prepareSchema(databaseURL string, cfg config.MigrationConfig) error {
+	migrator, err := migration.NewMigrator(databaseURL, migrations.FS)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	if cfg.OnStart {
+		if err := migrator.Up(); err != nil {
+			return fmt.Errorf("failed to run migrations: %w", err)
+		}
+		log.Println("Database migrations completed successfully")
+	}
+
+	status, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+	if err := status.Check(); err != nil {
+		if !cfg.AllowOutdatedSchema {
+			return fmt.Errorf("database schema is not up to date (run `api migrate up`, or `api migrate force` after fixing a dirty migration): %w", err)
+		}
+		log.Printf("Warning: database schema is not up to date, starting anyway because MIGRATE_ALLOW_OUTDATED_SCHEMA is set: %v", err)
+	}
+	return nil
+}
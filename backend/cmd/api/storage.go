@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"task-management-system/backend/internal/adapter/gateway/db"
+	"task-management-system/backend/internal/adapter/gateway/memory"
+	"task-management-system/backend/internal/driver/config"
+	"task-management-system/backend/internal/port/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// repositories ユースケースに渡すリポジトリとトランザクション管理
+type repositories struct {
+	txManager          repository.TxManager
+	tasks              repository.TaskRepository
+	accounts           repository.AccountRepository
+	categories         repository.CategoryRepository
+	outputTemplates    repository.OutputTemplateRepository
+	reports            repository.ReportRepository
+	recurringTemplates repository.RecurringTemplateRepository
+	// pool PostgreSQLの接続プール（インメモリの場合はnil）
+	pool *pgxpool.Pool
+}
+
+// openRepositories 設定した保存先に応じたリポジトリを作成
+// PostgreSQLの場合はtracerでクエリを計測する。返した関数で保存先への接続を閉じる
+func openRepositories(cfg config.Config, tracer pgx.QueryTracer) (repositories, func(), error) {
+	switch cfg.Storage {
+	case config.StoragePostgres:
+		return openPostgresRepositories(cfg.Database, cfg.Migration, tracer)
+	case config.StorageMemory:
+		log.Println("Using in-memory storage: all data will be lost when the server stops")
+		return newMemoryRepositories(), func() {}, nil
+	default:
+		return repositories{}, nil, fmt.Errorf("unknown storage %q (must be %s or %s)", cfg.Storage, config.StoragePostgres, config.StorageMemory)
+	}
+}
+
+// openPostgresRepositories スキーマを確認し、PostgreSQLのリポジトリを作成
+func openPostgresRepositories(database config.DatabaseConfig, migration config.MigrationConfig, tracer pgx.QueryTracer) (repositories, func(), error) {
+	// スキーマが最新でない場合は起動しない（単一接続を使用）
+	if err := prepareSchema(database.URL, migration); err != nil {
+		return repositories{}, nil, err
+	}
+
+	// 接続プールの設定はプールの作成前に適用する
+	poolConfig, err := database.PoolConfig()
+	if err != nil {
+		return repositories{}, nil, err
+	}
+	poolConfig.ConnConfig.Tracer = tracer
+
+	// データベース接続プールを確立
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
+	if err != nil {
+		return repositories{}, nil, fmt.Errorf("failed to create connection pool: %w", err)
+	}
+
+	// 接続をテスト
+	if err := pool.Ping(context.Background()); err != nil {
+		pool.Close()
+		return repositories{}, nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	log.Println("Database connection pool established successfully")
+
+	return repositories{
+		txManager:          db.NewTxManager(pool),
+		tasks:              db.NewTaskRepository(pool),
+		accounts:           db.NewAccountRepository(pool),
+		categories:         db.NewCategoryRepository(pool),
+		outputTemplates:    db.NewOutputTemplateRepository(pool),
+		reports:            db.NewReportRepository(pool),
+		recurringTemplates: db.NewRecurringTemplateRepository(pool),
+		pool:               pool,
+	}, func() {
+		// 取得中の接続が返却されるのを待ってから閉じる
+		pool.Close()
+		log.Println("Database connection pool closed")
+	}, nil
+}
+
+// newMemoryRepositories 1つのストアを共有するインメモリのリポジトリを作成
+func newMemoryRepositories() repositories {
+	store := memory.NewStore()
+	return repositories{
+		txManager:          memory.NewTxManager(store),
+		tasks:              memory.NewTaskRepository(store),
+		accounts:           memory.NewAccountRepository(store),
+		categories:         memory.NewCategoryRepository(store),
+		outputTemplates:    memory.NewOutputTemplateRepository(store),
+		reports:            memory.NewReportRepository(store),
+		recurringTemplates: memory.NewRecurringTemplateRepository(store),
+	}
+}
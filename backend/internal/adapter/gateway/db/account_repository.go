@@ -8,6 +8,7 @@ import (
 
 	dbgen "task-management-system/backend/internal/adapter/gateway/db/sqlc/generated"
 	"task-management-system/backend/internal/domain/account"
+	domainerrors "task-management-system/backend/internal/domain/errors"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -37,7 +38,7 @@ func (r *AccountRepository) GetAccountsByIDs(ctx context.Context, accountIDs []s
 	for _, id := range accountIDs {
 		uuid, err := uuid.Parse(id)
 		if err != nil {
-			return nil, fmt.Errorf("invalid account_id: %w", err)
+			return nil, domainerrors.Validation("invalid account_id").Wrap(err)
 		}
 		var pgUUID pgtype.UUID
 		if err := pgUUID.Scan(uuid.String()); err != nil {
@@ -47,7 +48,7 @@ func (r *AccountRepository) GetAccountsByIDs(ctx context.Context, accountIDs []s
 	}
 
 	// アカウントを取得
-	accounts, err := r.queries.GetAccountsByIDs(ctx, pgUUIDs)
+	accounts, err := queriesFor(ctx, r.queries).GetAccountsByIDs(ctx, pgUUIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +83,7 @@ func (r *AccountRepository) GetAccountByID(ctx context.Context, accountID string
 	// accountIDをUUIDに変換
 	accountUUID, err := uuid.Parse(accountID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid account_id: %w", err)
+		return nil, domainerrors.Validation("invalid account_id").Wrap(err)
 	}
 	var accountPgUUID pgtype.UUID
 	if err := accountPgUUID.Scan(accountUUID.String()); err != nil {
@@ -90,10 +91,10 @@ func (r *AccountRepository) GetAccountByID(ctx context.Context, accountID string
 	}
 
 	// アカウントを取得
-	acc, err := r.queries.GetAccountByID(ctx, accountPgUUID)
+	acc, err := queriesFor(ctx, r.queries).GetAccountByID(ctx, accountPgUUID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, nil
+			return nil, domainerrors.NotFound("Account not found")
 		}
 		return nil, fmt.Errorf("failed to get account: %w", err)
 	}
@@ -124,10 +125,10 @@ func (r *AccountRepository) GetAccountByID(ctx context.Context, accountID string
 // GetAccountByEmail メールアドレスでアカウントを取得
 func (r *AccountRepository) GetAccountByEmail(ctx context.Context, email string) (*account.Account, error) {
 	// アカウントを取得
-	acc, err := r.queries.GetAccountByEmail(ctx, email)
+	acc, err := queriesFor(ctx, r.queries).GetAccountByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, nil
+			return nil, domainerrors.NotFound("Account not found")
 		}
 		return nil, fmt.Errorf("failed to get account by email: %w", err)
 	}
@@ -167,7 +168,7 @@ func (r *AccountRepository) CreateAccount(ctx context.Context, email string, fir
 	}
 
 	// アカウントを作成
-	acc, err := r.queries.CreateAccount(ctx, dbgen.CreateAccountParams{
+	acc, err := queriesFor(ctx, r.queries).CreateAccount(ctx, dbgen.CreateAccountParams{
 		Email:             email,
 		FirstName:         firstName,
 		LastName:          lastName,
@@ -176,6 +177,9 @@ func (r *AccountRepository) CreateAccount(ctx context.Context, email string, fir
 		Thumbnail:         thumbnailPg.String,
 	})
 	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, domainerrors.Conflict("Account already exists").Wrap(err)
+		}
 		return nil, fmt.Errorf("failed to create account: %w", err)
 	}
 
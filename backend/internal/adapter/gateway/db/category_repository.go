@@ -0,0 +1,166 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	dbgen "task-management-system/backend/internal/adapter/gateway/db/sqlc/generated"
+	"task-management-system/backend/internal/domain/category"
+	domainerrors "task-management-system/backend/internal/domain/errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CategoryRepository カテゴリリポジトリ
+type CategoryRepository struct {
+	queries *dbgen.Queries
+}
+
+// NewCategoryRepository カテゴリリポジトリを作成
+func NewCategoryRepository(db dbgen.DBTX) *CategoryRepository {
+	return &CategoryRepository{
+		queries: dbgen.New(db),
+	}
+}
+
+// ListCategories オーナーのカテゴリ一覧を取得（名前順）
+func (r *CategoryRepository) ListCategories(ctx context.Context, ownerID string) ([]*category.Category, error) {
+	ownerPgUUID, err := pgUUIDFromString(ownerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+
+	categories, err := queriesFor(ctx, r.queries).ListCategoriesByOwnerID(ctx, ownerPgUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+
+	result := make([]*category.Category, 0, len(categories))
+	for _, c := range categories {
+		result = append(result, toCategoryEntity(c))
+	}
+
+	return result, nil
+}
+
+// GetCategoryByID カテゴリIDでカテゴリを取得
+func (r *CategoryRepository) GetCategoryByID(ctx context.Context, categoryID string) (*category.Category, error) {
+	categoryPgUUID, err := pgUUIDFromString(categoryID, "category_id")
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := queriesFor(ctx, r.queries).GetCategoryByID(ctx, categoryPgUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainerrors.NotFound("Category not found")
+		}
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	return toCategoryEntity(c), nil
+}
+
+// GetCategoriesByIDs カテゴリIDのリストでカテゴリを取得（存在しないIDは無視する）
+func (r *CategoryRepository) GetCategoriesByIDs(ctx context.Context, categoryIDs []string) ([]*category.Category, error) {
+	if len(categoryIDs) == 0 {
+		return []*category.Category{}, nil
+	}
+
+	pgUUIDs := make([]pgtype.UUID, 0, len(categoryIDs))
+	for _, id := range categoryIDs {
+		pgUUID, err := pgUUIDFromString(id, "category_id")
+		if err != nil {
+			return nil, err
+		}
+		pgUUIDs = append(pgUUIDs, pgUUID)
+	}
+
+	categories, err := queriesFor(ctx, r.queries).GetCategoriesByIDs(ctx, pgUUIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	result := make([]*category.Category, 0, len(categories))
+	for _, c := range categories {
+		result = append(result, toCategoryEntity(c))
+	}
+
+	return result, nil
+}
+
+// CreateCategory カテゴリを作成
+func (r *CategoryRepository) CreateCategory(ctx context.Context, ownerID string, name string, color string) (*category.Category, error) {
+	ownerPgUUID, err := pgUUIDFromString(ownerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := queriesFor(ctx, r.queries).CreateCategory(ctx, dbgen.CreateCategoryParams{
+		OwnerID: ownerPgUUID,
+		Name:    name,
+		Color:   color,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, domainerrors.Conflict("Category with the same name already exists").Wrap(err)
+		}
+		return nil, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	return toCategoryEntity(c), nil
+}
+
+// UpdateCategory カテゴリを更新
+func (r *CategoryRepository) UpdateCategory(ctx context.Context, categoryID string, name string, color string) (*category.Category, error) {
+	categoryPgUUID, err := pgUUIDFromString(categoryID, "category_id")
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := queriesFor(ctx, r.queries).UpdateCategory(ctx, dbgen.UpdateCategoryParams{
+		CategoryID: categoryPgUUID,
+		Name:       name,
+		Color:      color,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainerrors.NotFound("Category not found")
+		}
+		if isUniqueViolation(err) {
+			return nil, domainerrors.Conflict("Category with the same name already exists").Wrap(err)
+		}
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+
+	return toCategoryEntity(c), nil
+}
+
+// DeleteCategory カテゴリを削除
+func (r *CategoryRepository) DeleteCategory(ctx context.Context, categoryID string) error {
+	categoryPgUUID, err := pgUUIDFromString(categoryID, "category_id")
+	if err != nil {
+		return err
+	}
+
+	// カテゴリを削除（ON DELETE SET NULLにより、タスクアイテムは未分類になる）
+	if err := queriesFor(ctx, r.queries).DeleteCategory(ctx, categoryPgUUID); err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	return nil
+}
+
+// toCategoryEntity DBのカテゴリをドメインエンティティに変換
+func toCategoryEntity(c dbgen.Category) *category.Category {
+	return &category.Category{
+		ID:        UUIDFromPgtype(c.ID),
+		OwnerID:   UUIDFromPgtype(c.OwnerID),
+		Name:      c.Name,
+		Color:     c.Color,
+		CreatedAt: c.CreatedAt.Time,
+		UpdatedAt: c.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"task-management-system/backend/internal/port/repository/repositorytest"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// newRepositoriesFactory TEST_DATABASE_URLのデータベースを使用するリポジトリを作成
+// データベースにはマイグレーションを適用しておく。テストごとにすべてのデータを削除するため、開発用のデータベースは指定しない
+func newRepositoriesFactory(t *testing.T) repositorytest.Factory {
+	t.Helper()
+
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	pool, err := pgxpool.New(context.Background(), databaseURL)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	return func(t *testing.T) repositorytest.Repositories {
+		// アカウントから参照するすべてのデータを削除
+		if _, err := pool.Exec(context.Background(), "TRUNCATE accounts CASCADE"); err != nil {
+			t.Fatalf("failed to truncate tables: %v", err)
+		}
+		return repositorytest.Repositories{
+			TxManager:  NewTxManager(pool),
+			Tasks:      NewTaskRepository(pool),
+			Accounts:   NewAccountRepository(pool),
+			Categories: NewCategoryRepository(pool),
+		}
+	}
+}
+
+func TestTaskRepository_Contract(t *testing.T) {
+	repositorytest.RunTaskRepositoryTests(t, newRepositoriesFactory(t))
+}
+
+func TestAccountRepository_Contract(t *testing.T) {
+	repositorytest.RunAccountRepositoryTests(t, newRepositoriesFactory(t))
+}
@@ -1,10 +1,18 @@
 package db
 
 import (
+	"errors"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+// uniqueViolationCode 一意制約違反のSQLSTATE
+const uniqueViolationCode = "23505"
+
 // UUIDFromPgtype pgtype.UUIDをstringに変換
 func UUIDFromPgtype(pgUUID pgtype.UUID) string {
 	if !pgUUID.Valid {
@@ -16,3 +24,36 @@ func UUIDFromPgtype(pgUUID pgtype.UUID) string {
 	uuidValue := uuid.UUID(uuidBytes)
 	return uuidValue.String()
 }
+
+// pgUUIDFromString stringのUUIDをpgtype.UUIDに変換
+// 形式が不正な場合は「invalid <field>」のValidationエラーを返す
+func pgUUIDFromString(value string, field string) (pgtype.UUID, error) {
+	parsed, err := uuid.Parse(value)
+	if err != nil {
+		return pgtype.UUID{}, domainerrors.Validation("invalid " + field).Wrap(err)
+	}
+	return pgtype.UUID{Bytes: [16]byte(parsed), Valid: true}, nil
+}
+
+// nullablePgUUIDFromString nilを許容してstringのUUIDをpgtype.UUIDに変換（nilの場合はNULL）
+func nullablePgUUIDFromString(value *string, field string) (pgtype.UUID, error) {
+	if value == nil {
+		return pgtype.UUID{}, nil
+	}
+	return pgUUIDFromString(*value, field)
+}
+
+// nullableUUIDFromPgtype pgtype.UUIDをstringのポインタに変換（NULLの場合はnil）
+func nullableUUIDFromPgtype(pgUUID pgtype.UUID) *string {
+	if !pgUUID.Valid {
+		return nil
+	}
+	value := UUIDFromPgtype(pgUUID)
+	return &value
+}
+
+// isUniqueViolation 一意制約違反のエラーかどうかを判定
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
@@ -0,0 +1,212 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	dbgen "task-management-system/backend/internal/adapter/gateway/db/sqlc/generated"
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/outputtemplate"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// templateSectionRecord output_templates.sectionsに保存するセクション
+type templateSectionRecord struct {
+	Key      string `json:"key"`
+	Label    string `json:"label"`
+	Required bool   `json:"required"`
+}
+
+// OutputTemplateRepository アウトプットテンプレートリポジトリ
+type OutputTemplateRepository struct {
+	queries *dbgen.Queries
+}
+
+// NewOutputTemplateRepository アウトプットテンプレートリポジトリを作成
+func NewOutputTemplateRepository(db dbgen.DBTX) *OutputTemplateRepository {
+	return &OutputTemplateRepository{
+		queries: dbgen.New(db),
+	}
+}
+
+// ListOutputTemplates オーナーのテンプレート一覧を取得（名前順）
+func (r *OutputTemplateRepository) ListOutputTemplates(ctx context.Context, ownerID string) ([]*outputtemplate.OutputTemplate, error) {
+	ownerPgUUID, err := pgUUIDFromString(ownerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := queriesFor(ctx, r.queries).ListOutputTemplatesByOwnerID(ctx, ownerPgUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list output templates: %w", err)
+	}
+
+	return toOutputTemplateEntities(templates)
+}
+
+// GetOutputTemplateByID テンプレートIDでテンプレートを取得
+func (r *OutputTemplateRepository) GetOutputTemplateByID(ctx context.Context, outputTemplateID string) (*outputtemplate.OutputTemplate, error) {
+	templatePgUUID, err := pgUUIDFromString(outputTemplateID, "output_template_id")
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := queriesFor(ctx, r.queries).GetOutputTemplateByID(ctx, templatePgUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainerrors.NotFound("Output template not found")
+		}
+		return nil, fmt.Errorf("failed to get output template: %w", err)
+	}
+
+	return toOutputTemplateEntity(t)
+}
+
+// GetOutputTemplatesByIDs テンプレートIDのリストでテンプレートを取得（存在しないIDは無視する）
+func (r *OutputTemplateRepository) GetOutputTemplatesByIDs(ctx context.Context, outputTemplateIDs []string) ([]*outputtemplate.OutputTemplate, error) {
+	if len(outputTemplateIDs) == 0 {
+		return []*outputtemplate.OutputTemplate{}, nil
+	}
+
+	pgUUIDs := make([]pgtype.UUID, 0, len(outputTemplateIDs))
+	for _, id := range outputTemplateIDs {
+		pgUUID, err := pgUUIDFromString(id, "output_template_id")
+		if err != nil {
+			return nil, err
+		}
+		pgUUIDs = append(pgUUIDs, pgUUID)
+	}
+
+	templates, err := queriesFor(ctx, r.queries).GetOutputTemplatesByIDs(ctx, pgUUIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get output templates: %w", err)
+	}
+
+	return toOutputTemplateEntities(templates)
+}
+
+// CreateOutputTemplate テンプレートを作成
+func (r *OutputTemplateRepository) CreateOutputTemplate(ctx context.Context, ownerID string, name string, sections []outputtemplate.Section) (*outputtemplate.OutputTemplate, error) {
+	ownerPgUUID, err := pgUUIDFromString(ownerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+
+	sectionsJSON, err := marshalTemplateSections(sections)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := queriesFor(ctx, r.queries).CreateOutputTemplate(ctx, dbgen.CreateOutputTemplateParams{
+		OwnerID:  ownerPgUUID,
+		Name:     name,
+		Sections: sectionsJSON,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, domainerrors.Conflict("Output template with the same name already exists").Wrap(err)
+		}
+		return nil, fmt.Errorf("failed to create output template: %w", err)
+	}
+
+	return toOutputTemplateEntity(t)
+}
+
+// UpdateOutputTemplate テンプレートを更新
+// 既に入力されたアウトプットは入力時のセクションを保持しているため、更新の影響を受けない
+func (r *OutputTemplateRepository) UpdateOutputTemplate(ctx context.Context, outputTemplateID string, name string, sections []outputtemplate.Section) (*outputtemplate.OutputTemplate, error) {
+	templatePgUUID, err := pgUUIDFromString(outputTemplateID, "output_template_id")
+	if err != nil {
+		return nil, err
+	}
+
+	sectionsJSON, err := marshalTemplateSections(sections)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := queriesFor(ctx, r.queries).UpdateOutputTemplate(ctx, dbgen.UpdateOutputTemplateParams{
+		OutputTemplateID: templatePgUUID,
+		Name:             name,
+		Sections:         sectionsJSON,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainerrors.NotFound("Output template not found")
+		}
+		if isUniqueViolation(err) {
+			return nil, domainerrors.Conflict("Output template with the same name already exists").Wrap(err)
+		}
+		return nil, fmt.Errorf("failed to update output template: %w", err)
+	}
+
+	return toOutputTemplateEntity(t)
+}
+
+// DeleteOutputTemplate テンプレートを削除
+func (r *OutputTemplateRepository) DeleteOutputTemplate(ctx context.Context, outputTemplateID string) error {
+	templatePgUUID, err := pgUUIDFromString(outputTemplateID, "output_template_id")
+	if err != nil {
+		return err
+	}
+
+	// テンプレートを削除（ON DELETE SET NULLにより、タスクアイテムは自由形式に戻る）
+	if err := queriesFor(ctx, r.queries).DeleteOutputTemplate(ctx, templatePgUUID); err != nil {
+		return fmt.Errorf("failed to delete output template: %w", err)
+	}
+
+	return nil
+}
+
+// marshalTemplateSections セクションをJSONに変換
+func marshalTemplateSections(sections []outputtemplate.Section) ([]byte, error) {
+	records := make([]templateSectionRecord, 0, len(sections))
+	for _, s := range sections {
+		records = append(records, templateSectionRecord{Key: s.Key, Label: s.Label, Required: s.Required})
+	}
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal output template sections: %w", err)
+	}
+	return data, nil
+}
+
+// toOutputTemplateEntities DBのテンプレートのリストをドメインエンティティに変換
+func toOutputTemplateEntities(templates []dbgen.OutputTemplate) ([]*outputtemplate.OutputTemplate, error) {
+	result := make([]*outputtemplate.OutputTemplate, 0, len(templates))
+	for _, t := range templates {
+		entity, err := toOutputTemplateEntity(t)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, entity)
+	}
+	return result, nil
+}
+
+// toOutputTemplateEntity DBのテンプレートをドメインエンティティに変換
+func toOutputTemplateEntity(t dbgen.OutputTemplate) (*outputtemplate.OutputTemplate, error) {
+	var records []templateSectionRecord
+	if err := json.Unmarshal(t.Sections, &records); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal output template sections: %w", err)
+	}
+
+	sections := make([]outputtemplate.Section, 0, len(records))
+	for _, record := range records {
+		sections = append(sections, outputtemplate.Section{Key: record.Key, Label: record.Label, Required: record.Required})
+	}
+
+	return &outputtemplate.OutputTemplate{
+		ID:        UUIDFromPgtype(t.ID),
+		OwnerID:   UUIDFromPgtype(t.OwnerID),
+		Name:      t.Name,
+		Sections:  sections,
+		CreatedAt: t.CreatedAt.Time,
+		UpdatedAt: t.UpdatedAt.Time,
+	}, nil
+}
@@ -0,0 +1,243 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	dbgen "task-management-system/backend/internal/adapter/gateway/db/sqlc/generated"
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/recurringtemplate"
+	"task-management-system/backend/internal/domain/task"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// recurringItemRecord recurring_templates.task_itemsに保存する子タスク
+type recurringItemRecord struct {
+	Priority         string  `json:"priority"`
+	Density          string  `json:"density"`
+	DurationTime     int32   `json:"durationTime"`
+	Content          string  `json:"content"`
+	IsRequired       bool    `json:"isRequired"`
+	Order            int32   `json:"order"`
+	CategoryID       *string `json:"categoryId,omitempty"`
+	OutputTemplateID *string `json:"outputTemplateId,omitempty"`
+}
+
+// RecurringTemplateRepository 繰り返しテンプレートリポジトリ
+type RecurringTemplateRepository struct {
+	queries *dbgen.Queries
+}
+
+// NewRecurringTemplateRepository 繰り返しテンプレートリポジトリを作成
+func NewRecurringTemplateRepository(db dbgen.DBTX) *RecurringTemplateRepository {
+	return &RecurringTemplateRepository{
+		queries: dbgen.New(db),
+	}
+}
+
+// ListRecurringTemplates オーナーのテンプレート一覧を取得（作成順）
+func (r *RecurringTemplateRepository) ListRecurringTemplates(ctx context.Context, ownerID string) ([]*recurringtemplate.RecurringTemplate, error) {
+	ownerPgUUID, err := pgUUIDFromString(ownerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := queriesFor(ctx, r.queries).ListRecurringTemplatesByOwnerID(ctx, ownerPgUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recurring templates: %w", err)
+	}
+
+	result := make([]*recurringtemplate.RecurringTemplate, 0, len(templates))
+	for _, t := range templates {
+		entity, err := toRecurringTemplateEntity(t)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, entity)
+	}
+	return result, nil
+}
+
+// GetRecurringTemplateByID テンプレートIDでテンプレートを取得
+func (r *RecurringTemplateRepository) GetRecurringTemplateByID(ctx context.Context, recurringTemplateID string) (*recurringtemplate.RecurringTemplate, error) {
+	templatePgUUID, err := pgUUIDFromString(recurringTemplateID, "recurring_template_id")
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := queriesFor(ctx, r.queries).GetRecurringTemplateByID(ctx, templatePgUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainerrors.NotFound("Recurring template not found")
+		}
+		return nil, fmt.Errorf("failed to get recurring template: %w", err)
+	}
+
+	return toRecurringTemplateEntity(t)
+}
+
+// CreateRecurringTemplate テンプレートを作成
+func (r *RecurringTemplateRepository) CreateRecurringTemplate(ctx context.Context, ownerID string, title string, rule recurringtemplate.Rule, taskItems []recurringtemplate.Item) (*recurringtemplate.RecurringTemplate, error) {
+	ownerPgUUID, err := pgUUIDFromString(ownerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+
+	taskItemsJSON, err := marshalRecurringItems(taskItems)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := queriesFor(ctx, r.queries).CreateRecurringTemplate(ctx, dbgen.CreateRecurringTemplateParams{
+		OwnerID:   ownerPgUUID,
+		Title:     title,
+		Frequency: string(rule.Frequency),
+		ByDay:     toByDayStrings(rule.ByDay),
+		StartsOn:  pgtype.Date{Time: rule.StartsOn, Valid: true},
+		EndsOn:    nullablePgDate(rule),
+		TaskItems: taskItemsJSON,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recurring template: %w", err)
+	}
+
+	return toRecurringTemplateEntity(t)
+}
+
+// UpdateRecurringTemplate テンプレートを更新
+// 既に作成されたタスクは更新の影響を受けない
+func (r *RecurringTemplateRepository) UpdateRecurringTemplate(ctx context.Context, recurringTemplateID string, title string, rule recurringtemplate.Rule, taskItems []recurringtemplate.Item) (*recurringtemplate.RecurringTemplate, error) {
+	templatePgUUID, err := pgUUIDFromString(recurringTemplateID, "recurring_template_id")
+	if err != nil {
+		return nil, err
+	}
+
+	taskItemsJSON, err := marshalRecurringItems(taskItems)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := queriesFor(ctx, r.queries).UpdateRecurringTemplate(ctx, dbgen.UpdateRecurringTemplateParams{
+		RecurringTemplateID: templatePgUUID,
+		Title:               title,
+		Frequency:           string(rule.Frequency),
+		ByDay:               toByDayStrings(rule.ByDay),
+		StartsOn:            pgtype.Date{Time: rule.StartsOn, Valid: true},
+		EndsOn:              nullablePgDate(rule),
+		TaskItems:           taskItemsJSON,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainerrors.NotFound("Recurring template not found")
+		}
+		return nil, fmt.Errorf("failed to update recurring template: %w", err)
+	}
+
+	return toRecurringTemplateEntity(t)
+}
+
+// DeleteRecurringTemplate テンプレートを削除
+func (r *RecurringTemplateRepository) DeleteRecurringTemplate(ctx context.Context, recurringTemplateID string) error {
+	templatePgUUID, err := pgUUIDFromString(recurringTemplateID, "recurring_template_id")
+	if err != nil {
+		return err
+	}
+
+	// テンプレートを削除（ON DELETE SET NULLにより、作成済みのタスクは通常のタスクとして残る）
+	if err := queriesFor(ctx, r.queries).DeleteRecurringTemplate(ctx, templatePgUUID); err != nil {
+		return fmt.Errorf("failed to delete recurring template: %w", err)
+	}
+
+	return nil
+}
+
+// nullablePgDate ルールの終了日をpgtype.Dateに変換（無期限の場合はNULL）
+func nullablePgDate(rule recurringtemplate.Rule) pgtype.Date {
+	if rule.EndsOn == nil {
+		return pgtype.Date{}
+	}
+	return pgtype.Date{Time: *rule.EndsOn, Valid: true}
+}
+
+// toByDayStrings 曜日を文字列の配列に変換（NOT NULLの列に保存するため空の場合も空配列を返す）
+func toByDayStrings(byDay []recurringtemplate.Weekday) []string {
+	result := make([]string, 0, len(byDay))
+	for _, day := range byDay {
+		result = append(result, string(day))
+	}
+	return result
+}
+
+// marshalRecurringItems 子タスクをJSONに変換
+func marshalRecurringItems(items []recurringtemplate.Item) ([]byte, error) {
+	records := make([]recurringItemRecord, 0, len(items))
+	for _, item := range items {
+		records = append(records, recurringItemRecord{
+			Priority:         string(item.Priority),
+			Density:          string(item.Density),
+			DurationTime:     int32(item.DurationTime),
+			Content:          item.Content,
+			IsRequired:       item.IsRequired,
+			Order:            item.Order,
+			CategoryID:       item.CategoryID,
+			OutputTemplateID: item.OutputTemplateID,
+		})
+	}
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal recurring template task items: %w", err)
+	}
+	return data, nil
+}
+
+// toRecurringTemplateEntity DBのテンプレートをドメインエンティティに変換
+func toRecurringTemplateEntity(t dbgen.RecurringTemplate) (*recurringtemplate.RecurringTemplate, error) {
+	var records []recurringItemRecord
+	if err := json.Unmarshal(t.TaskItems, &records); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal recurring template task items: %w", err)
+	}
+
+	items := make([]recurringtemplate.Item, 0, len(records))
+	for _, record := range records {
+		items = append(items, recurringtemplate.Item{
+			Priority:         task.Priority(record.Priority),
+			Density:          task.Density(record.Density),
+			DurationTime:     task.DurationTime(record.DurationTime),
+			Content:          record.Content,
+			IsRequired:       record.IsRequired,
+			Order:            record.Order,
+			CategoryID:       record.CategoryID,
+			OutputTemplateID: record.OutputTemplateID,
+		})
+	}
+
+	byDay := make([]recurringtemplate.Weekday, 0, len(t.ByDay))
+	for _, day := range t.ByDay {
+		byDay = append(byDay, recurringtemplate.Weekday(day))
+	}
+
+	rule := recurringtemplate.Rule{
+		Frequency: recurringtemplate.Frequency(t.Frequency),
+		ByDay:     byDay,
+		StartsOn:  t.StartsOn.Time,
+	}
+	if t.EndsOn.Valid {
+		endsOn := t.EndsOn.Time
+		rule.EndsOn = &endsOn
+	}
+
+	return &recurringtemplate.RecurringTemplate{
+		ID:        UUIDFromPgtype(t.ID),
+		OwnerID:   UUIDFromPgtype(t.OwnerID),
+		Title:     t.Title,
+		Rule:      rule,
+		TaskItems: items,
+		CreatedAt: t.CreatedAt.Time,
+		UpdatedAt: t.UpdatedAt.Time,
+	}, nil
+}
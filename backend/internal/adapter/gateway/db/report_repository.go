@@ -0,0 +1,101 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	dbgen "task-management-system/backend/internal/adapter/gateway/db/sqlc/generated"
+	"task-management-system/backend/internal/domain/report"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ReportRepository レポートリポジトリ
+type ReportRepository struct {
+	queries *dbgen.Queries
+}
+
+// NewReportRepository レポートリポジトリを作成
+func NewReportRepository(db dbgen.DBTX) *ReportRepository {
+	return &ReportRepository{
+		queries: dbgen.New(db),
+	}
+}
+
+// GetReport オーナーの期間内のタスクアイテムを日・密度・優先度ごとに集計
+func (r *ReportRepository) GetReport(ctx context.Context, ownerID string, period report.Period) (*report.Report, error) {
+	ownerPgUUID, err := pgUUIDFromString(ownerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+	fromDate := pgtype.Date{Time: period.From, Valid: true}
+	toDate := pgtype.Date{Time: period.To, Valid: true}
+
+	// 日ごとに集計
+	dailyRows, err := queriesFor(ctx, r.queries).SummarizeTaskItemsByDate(ctx, dbgen.SummarizeTaskItemsByDateParams{
+		OwnerID:  ownerPgUUID,
+		FromDate: fromDate,
+		ToDate:   toDate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize task items by date: %w", err)
+	}
+	daily := make([]report.DailyTotals, 0, len(dailyRows))
+	for _, row := range dailyRows {
+		daily = append(daily, report.DailyTotals{
+			Date: row.Date.Time,
+			Totals: report.Totals{
+				PlannedCount:     row.PlannedCount,
+				PlannedMinutes:   row.PlannedMinutes,
+				CompletedCount:   row.CompletedCount,
+				CompletedMinutes: row.CompletedMinutes,
+			},
+		})
+	}
+
+	// 密度ごとに集計
+	densityRows, err := queriesFor(ctx, r.queries).SummarizeTaskItemsByDensity(ctx, dbgen.SummarizeTaskItemsByDensityParams{
+		OwnerID:  ownerPgUUID,
+		FromDate: fromDate,
+		ToDate:   toDate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize task items by density: %w", err)
+	}
+	byDensity := make([]report.Breakdown, 0, len(densityRows))
+	for _, row := range densityRows {
+		byDensity = append(byDensity, report.Breakdown{
+			Key: row.Density,
+			Totals: report.Totals{
+				PlannedCount:     row.PlannedCount,
+				PlannedMinutes:   row.PlannedMinutes,
+				CompletedCount:   row.CompletedCount,
+				CompletedMinutes: row.CompletedMinutes,
+			},
+		})
+	}
+
+	// 優先度ごとに集計
+	priorityRows, err := queriesFor(ctx, r.queries).SummarizeTaskItemsByPriority(ctx, dbgen.SummarizeTaskItemsByPriorityParams{
+		OwnerID:  ownerPgUUID,
+		FromDate: fromDate,
+		ToDate:   toDate,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize task items by priority: %w", err)
+	}
+	byPriority := make([]report.Breakdown, 0, len(priorityRows))
+	for _, row := range priorityRows {
+		byPriority = append(byPriority, report.Breakdown{
+			Key: row.Priority,
+			Totals: report.Totals{
+				PlannedCount:     row.PlannedCount,
+				PlannedMinutes:   row.PlannedMinutes,
+				CompletedCount:   row.CompletedCount,
+				CompletedMinutes: row.CompletedMinutes,
+			},
+		})
+	}
+
+	return report.NewReport(ownerID, period, daily, byDensity, byPriority), nil
+}
@@ -0,0 +1,64 @@
+-- name: ListCategoriesByOwnerID :many
+SELECT 
+    id,
+    owner_id,
+    name,
+    color,
+    created_at,
+    updated_at
+FROM categories
+WHERE owner_id = @owner_id::uuid
+ORDER BY name ASC, id ASC;
+
+-- name: GetCategoryByID :one
+SELECT 
+    id,
+    owner_id,
+    name,
+    color,
+    created_at,
+    updated_at
+FROM categories
+WHERE id = @category_id::uuid;
+
+-- name: GetCategoriesByIDs :many
+SELECT 
+    id,
+    owner_id,
+    name,
+    color,
+    created_at,
+    updated_at
+FROM categories
+WHERE id = ANY(@category_ids::uuid[]);
+
+-- name: CreateCategory :one
+INSERT INTO categories (
+    id,
+    owner_id,
+    name,
+    color,
+    created_at,
+    updated_at
+) VALUES (
+    gen_random_uuid(),
+    @owner_id::uuid,
+    @name::text,
+    @color::text,
+    NOW(),
+    NOW()
+)
+RETURNING id, owner_id, name, color, created_at, updated_at;
+
+-- name: UpdateCategory :one
+UPDATE categories
+SET
+    name = @name::text,
+    color = @color::text,
+    updated_at = NOW()
+WHERE id = @category_id::uuid
+RETURNING id, owner_id, name, color, created_at, updated_at;
+
+-- name: DeleteCategory :exec
+DELETE FROM categories
+WHERE id = @category_id::uuid;
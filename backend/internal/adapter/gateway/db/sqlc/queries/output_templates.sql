@@ -0,0 +1,64 @@
+-- name: ListOutputTemplatesByOwnerID :many
+SELECT 
+    id,
+    owner_id,
+    name,
+    sections,
+    created_at,
+    updated_at
+FROM output_templates
+WHERE owner_id = @owner_id::uuid
+ORDER BY name ASC, id ASC;
+
+-- name: GetOutputTemplateByID :one
+SELECT 
+    id,
+    owner_id,
+    name,
+    sections,
+    created_at,
+    updated_at
+FROM output_templates
+WHERE id = @output_template_id::uuid;
+
+-- name: GetOutputTemplatesByIDs :many
+SELECT 
+    id,
+    owner_id,
+    name,
+    sections,
+    created_at,
+    updated_at
+FROM output_templates
+WHERE id = ANY(@output_template_ids::uuid[]);
+
+-- name: CreateOutputTemplate :one
+INSERT INTO output_templates (
+    id,
+    owner_id,
+    name,
+    sections,
+    created_at,
+    updated_at
+) VALUES (
+    gen_random_uuid(),
+    @owner_id::uuid,
+    @name::text,
+    @sections::jsonb,
+    NOW(),
+    NOW()
+)
+RETURNING id, owner_id, name, sections, created_at, updated_at;
+
+-- name: UpdateOutputTemplate :one
+UPDATE output_templates
+SET
+    name = @name::text,
+    sections = @sections::jsonb,
+    updated_at = NOW()
+WHERE id = @output_template_id::uuid
+RETURNING id, owner_id, name, sections, created_at, updated_at;
+
+-- name: DeleteOutputTemplate :exec
+DELETE FROM output_templates
+WHERE id = @output_template_id::uuid;
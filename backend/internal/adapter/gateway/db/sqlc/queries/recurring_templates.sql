@@ -0,0 +1,73 @@
+-- name: ListRecurringTemplatesByOwnerID :many
+SELECT 
+    id,
+    owner_id,
+    title,
+    frequency,
+    by_day,
+    starts_on,
+    ends_on,
+    task_items,
+    created_at,
+    updated_at
+FROM recurring_templates
+WHERE owner_id = @owner_id::uuid
+ORDER BY created_at ASC, id ASC;
+
+-- name: GetRecurringTemplateByID :one
+SELECT 
+    id,
+    owner_id,
+    title,
+    frequency,
+    by_day,
+    starts_on,
+    ends_on,
+    task_items,
+    created_at,
+    updated_at
+FROM recurring_templates
+WHERE id = @recurring_template_id::uuid;
+
+-- name: CreateRecurringTemplate :one
+INSERT INTO recurring_templates (
+    id,
+    owner_id,
+    title,
+    frequency,
+    by_day,
+    starts_on,
+    ends_on,
+    task_items,
+    created_at,
+    updated_at
+) VALUES (
+    gen_random_uuid(),
+    @owner_id::uuid,
+    @title::text,
+    @frequency::text,
+    @by_day::text[],
+    @starts_on::date,
+    sqlc.narg(ends_on)::date,
+    @task_items::jsonb,
+    NOW(),
+    NOW()
+)
+RETURNING id, owner_id, title, frequency, by_day, starts_on, ends_on, task_items, created_at, updated_at;
+
+-- name: UpdateRecurringTemplate :one
+UPDATE recurring_templates
+SET
+    title = @title::text,
+    frequency = @frequency::text,
+    by_day = @by_day::text[],
+    starts_on = @starts_on::date,
+    ends_on = sqlc.narg(ends_on)::date,
+    task_items = @task_items::jsonb,
+    updated_at = NOW()
+WHERE id = @recurring_template_id::uuid
+RETURNING id, owner_id, title, frequency, by_day, starts_on, ends_on, task_items, created_at, updated_at;
+
+-- name: DeleteRecurringTemplate :exec
+DELETE FROM recurring_templates
+WHERE id = @recurring_template_id::uuid;
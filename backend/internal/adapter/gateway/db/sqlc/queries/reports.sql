@@ -0,0 +1,42 @@
+-- name: SummarizeTaskItemsByDate :many
+-- 期間内のすべての日を返す（タスクがない日は0件）
+-- ゴミ箱に移動したタスクは集計しない
+SELECT
+    d.day::date AS date,
+    COUNT(ti.id)::int4 AS planned_count,
+    COALESCE(SUM(ti.duration_time), 0)::int4 AS planned_minutes,
+    COUNT(ti.id) FILTER (WHERE ti.status = 'Completed')::int4 AS completed_count,
+    COALESCE(SUM(ti.duration_time) FILTER (WHERE ti.status = 'Completed'), 0)::int4 AS completed_minutes
+FROM generate_series(@from_date::date, @to_date::date, INTERVAL '1 day') AS d(day)
+LEFT JOIN tasks t ON t.date = d.day::date AND t.owner_id = @owner_id::uuid AND t.deleted_at IS NULL
+LEFT JOIN task_items ti ON ti.task_id = t.id
+GROUP BY d.day
+ORDER BY d.day ASC;
+
+-- name: SummarizeTaskItemsByDensity :many
+SELECT
+    ti.density,
+    COUNT(*)::int4 AS planned_count,
+    SUM(ti.duration_time)::int4 AS planned_minutes,
+    COUNT(*) FILTER (WHERE ti.status = 'Completed')::int4 AS completed_count,
+    COALESCE(SUM(ti.duration_time) FILTER (WHERE ti.status = 'Completed'), 0)::int4 AS completed_minutes
+FROM task_items ti
+INNER JOIN tasks t ON t.id = ti.task_id
+WHERE t.owner_id = @owner_id::uuid
+    AND t.date BETWEEN @from_date::date AND @to_date::date
+    AND t.deleted_at IS NULL
+GROUP BY ti.density;
+
+-- name: SummarizeTaskItemsByPriority :many
+SELECT
+    ti.priority,
+    COUNT(*)::int4 AS planned_count,
+    SUM(ti.duration_time)::int4 AS planned_minutes,
+    COUNT(*) FILTER (WHERE ti.status = 'Completed')::int4 AS completed_count,
+    COALESCE(SUM(ti.duration_time) FILTER (WHERE ti.status = 'Completed'), 0)::int4 AS completed_minutes
+FROM task_items ti
+INNER JOIN tasks t ON t.id = ti.task_id
+WHERE t.owner_id = @owner_id::uuid
+    AND t.date BETWEEN @from_date::date AND @to_date::date
+    AND t.deleted_at IS NULL
+GROUP BY ti.priority;
@@ -0,0 +1,31 @@
+-- name: CreateTaskEvent :exec
+INSERT INTO task_events (
+    task_id,
+    task_item_id,
+    actor_id,
+    event_type,
+    diff
+) VALUES (
+    @task_id::uuid,
+    sqlc.narg(task_item_id)::uuid,
+    @actor_id::uuid,
+    @event_type::text,
+    @diff::jsonb
+);
+
+-- name: ListTaskEvents :many
+-- ゴミ箱にあるタスクと完全に削除したタスクの履歴は取得しない（完全に削除したタスクの履歴は監査のために残すのみで、APIからは参照しない）
+-- 同じトランザクションで記録した履歴は作成日時が同じため、記録した順番で並べる
+SELECT
+    e.id,
+    e.task_id,
+    e.task_item_id,
+    e.actor_id,
+    e.event_type,
+    e.diff,
+    e.created_at
+FROM task_events e
+INNER JOIN tasks t ON t.id = e.task_id
+WHERE e.task_id = @task_id::uuid
+  AND t.deleted_at IS NULL
+ORDER BY e.seq ASC;
@@ -0,0 +1,46 @@
+-- name: GetTaskItemSessionsByTaskItemIDs :many
+SELECT
+    id,
+    task_item_id,
+    started_at,
+    ended_at,
+    end_reason,
+    created_at
+FROM task_item_sessions
+WHERE task_item_id = ANY($1::uuid[])
+ORDER BY task_item_id, started_at ASC;
+
+-- name: CreateTaskItemSession :exec
+INSERT INTO task_item_sessions (
+    task_item_id,
+    started_at
+) VALUES (
+    @task_item_id::uuid,
+    @started_at::timestamptz
+);
+
+-- name: EndLatestTaskItemSession :exec
+-- 実行中のセッションは終了日時を設定し、一時停止中のセッションは終了理由のみを更新する
+UPDATE task_item_sessions
+SET
+    ended_at = COALESCE(ended_at, @ended_at::timestamptz),
+    end_reason = @end_reason::text
+WHERE id = (
+    SELECT s.id FROM task_item_sessions s
+    WHERE s.task_item_id = @task_item_id::uuid
+    ORDER BY s.started_at DESC
+    LIMIT 1
+);
+
+-- name: StopRunningTaskItemSession :exec
+-- 完了したタスクアイテムの実行中のセッションを完了日時で停止する
+UPDATE task_item_sessions s
+SET
+    ended_at = GREATEST(i.completed_at, s.started_at),
+    end_reason = 'Stopped'
+FROM task_items i
+WHERE s.task_item_id = i.id
+  AND i.id = @task_item_id::uuid
+  AND i.status = 'Completed'
+  AND i.completed_at IS NOT NULL
+  AND s.ended_at IS NULL;
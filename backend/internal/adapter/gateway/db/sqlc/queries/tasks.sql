@@ -1,4 +1,8 @@
 -- name: ListTasks :many
+-- 並び順ごとに (並び替えキー, created_at, id) でキーセットページネーションを行う
+-- カーソルが指定された場合は、カーソルの位置より後ろのタスクのみを取得する
+-- キーワードは全文検索（search_vector）で照合し、単語に分かれない日本語などは部分一致（keyword_pattern）で照合する
+-- ゴミ箱に移動したタスクは含めない
 SELECT 
     t.id,
     t.owner_id,
@@ -6,33 +10,78 @@ SELECT
     t.date,
     t.review,
     t.created_at,
-    t.updated_at
+    t.updated_at,
+    t.version,
+    r.rank::real AS rank
 FROM tasks t
+CROSS JOIN LATERAL (
+    -- キーワードとの関連度（タスクと最も一致する子タスクの全文検索のスコアに、タイトルとの類似度を加える）
+    SELECT CASE WHEN sqlc.narg(keyword)::text IS NULL THEN 0::real ELSE
+        ts_rank(t.search_vector, websearch_to_tsquery('simple', sqlc.narg(keyword)::text))
+        + COALESCE((
+            SELECT MAX(ts_rank(ti.search_vector, websearch_to_tsquery('simple', sqlc.narg(keyword)::text)))
+            FROM task_items ti
+            WHERE ti.task_id = t.id
+        ), 0)
+        + word_similarity(sqlc.narg(keyword)::text, t.title)
+    END AS rank
+) r
 WHERE 
-    (@owner_id::uuid IS NULL OR t.owner_id = @owner_id::uuid)
-    AND (@year_month::text IS NULL OR (
-        t.date >= DATE_TRUNC('month', (@year_month::text || '-01')::date)::date
-        AND t.date < (DATE_TRUNC('month', (@year_month::text || '-01')::date) + INTERVAL '1 month')::date
+    t.deleted_at IS NULL
+    AND (sqlc.narg(owner_id)::uuid IS NULL OR t.owner_id = sqlc.narg(owner_id)::uuid)
+    AND (sqlc.narg(year_month)::text IS NULL OR (
+        t.date >= DATE_TRUNC('month', (sqlc.narg(year_month)::text || '-01')::date)::date
+        AND t.date < (DATE_TRUNC('month', (sqlc.narg(year_month)::text || '-01')::date) + INTERVAL '1 month')::date
     ))
-    AND (@keyword::text IS NULL OR t.title ILIKE '%' || @keyword::text || '%' OR EXISTS (
+    AND (sqlc.narg(keyword)::text IS NULL
+        OR t.search_vector @@ websearch_to_tsquery('simple', sqlc.narg(keyword)::text)
+        OR t.title ILIKE sqlc.narg(keyword_pattern)::text
+        OR t.review ILIKE sqlc.narg(keyword_pattern)::text
+        OR EXISTS (
+            SELECT 1 FROM task_items ti
+            WHERE ti.task_id = t.id
+            AND (
+                ti.search_vector @@ websearch_to_tsquery('simple', sqlc.narg(keyword)::text)
+                OR ti.content ILIKE sqlc.narg(keyword_pattern)::text
+                OR ti.output ILIKE sqlc.narg(keyword_pattern)::text
+            )
+        ))
+    AND (sqlc.narg(category_id)::uuid IS NULL OR EXISTS (
         SELECT 1 FROM task_items ti
         WHERE ti.task_id = t.id
-        AND ti.content ILIKE '%' || @keyword::text || '%'
+        AND ti.category_id = sqlc.narg(category_id)::uuid
     ))
+    AND (sqlc.narg(cursor_id)::uuid IS NULL OR CASE @sort::text
+        WHEN 'oldest' THEN
+            (t.created_at, t.id) > (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid)
+        WHEN 'date-asc' THEN
+            (t.date, t.created_at, t.id) > (sqlc.narg(cursor_date)::date, sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid)
+        WHEN 'date-desc' THEN
+            (t.date, t.created_at, t.id) < (sqlc.narg(cursor_date)::date, sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid)
+        WHEN 'relevance' THEN
+            (r.rank, t.created_at, t.id) < (sqlc.narg(cursor_rank)::real, sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid)
+        ELSE
+            (t.created_at, t.id) < (sqlc.narg(cursor_created_at)::timestamptz, sqlc.narg(cursor_id)::uuid)
+    END)
 ORDER BY 
     CASE 
-        WHEN @sort::text = 'newest' THEN t.created_at
-    END DESC NULLS LAST,
-    CASE 
-        WHEN @sort::text = 'oldest' THEN t.created_at
-    END ASC NULLS LAST,
+        WHEN @sort::text = 'relevance' THEN r.rank
+    END DESC,
     CASE 
         WHEN @sort::text = 'date-asc' THEN t.date
-    END ASC NULLS LAST,
+    END ASC,
     CASE 
         WHEN @sort::text = 'date-desc' THEN t.date
-    END DESC NULLS LAST,
-    t.created_at DESC;
+    END DESC,
+    CASE 
+        WHEN @sort::text IN ('oldest', 'date-asc') THEN t.created_at
+    END ASC,
+    CASE 
+        WHEN @sort::text IN ('oldest', 'date-asc') THEN t.id
+    END ASC,
+    t.created_at DESC,
+    t.id DESC
+LIMIT sqlc.narg(page_limit);
 
 -- name: GetTaskItemsByTaskIDs :many
 SELECT 
@@ -47,7 +96,13 @@ SELECT
     "order",
     status,
     created_at,
-    updated_at
+    updated_at,
+    category_id,
+    output_template_id,
+    output_sections,
+    carried_over_from_task_id,
+    started_at,
+    completed_at
 FROM task_items
 WHERE task_id = ANY($1::uuid[])
 ORDER BY task_id, "order" ASC;
@@ -60,9 +115,11 @@ SELECT
     t.date,
     t.review,
     t.created_at,
-    t.updated_at
+    t.updated_at,
+    t.version
 FROM tasks t
-WHERE t.id = @task_id::uuid;
+WHERE t.id = @task_id::uuid
+  AND t.deleted_at IS NULL;
 
 -- name: GetAccountsByIDs :many
 SELECT 
@@ -146,7 +203,31 @@ INSERT INTO tasks (
     NOW(),
     NOW()
 )
-RETURNING id, owner_id, title, date, review, created_at, updated_at;
+RETURNING id, owner_id, title, date, review, created_at, updated_at, version;
+
+-- name: CreateRecurringTask :one
+-- 繰り返しテンプレートから日付ごとにタスクを作成する（作成済みの日付の場合は何もせず、行を返さない）
+INSERT INTO tasks (
+    id,
+    owner_id,
+    title,
+    date,
+    review,
+    recurring_template_id,
+    created_at,
+    updated_at
+) VALUES (
+    gen_random_uuid(),
+    @owner_id::uuid,
+    @title::text,
+    @date::date,
+    NULL,
+    @recurring_template_id::uuid,
+    NOW(),
+    NOW()
+)
+ON CONFLICT (owner_id, recurring_template_id, date) DO NOTHING
+RETURNING id, owner_id, title, date, review, created_at, updated_at, version;
 
 -- name: CreateTaskItem :one
 INSERT INTO task_items (
@@ -161,7 +242,13 @@ INSERT INTO task_items (
     "order",
     status,
     created_at,
-    updated_at
+    updated_at,
+    category_id,
+    output_template_id,
+    output_sections,
+    carried_over_from_task_id,
+    started_at,
+    completed_at
 ) VALUES (
     gen_random_uuid(),
     @task_id::uuid,
@@ -169,23 +256,31 @@ INSERT INTO task_items (
     @density::text,
     @duration_time::int4,
     @content::text,
-    NULL,
+    sqlc.narg(output)::text,
     @is_required::boolean,
     @order_value::int4,
     @status::text,
     NOW(),
-    NOW()
+    NOW(),
+    sqlc.narg(category_id)::uuid,
+    sqlc.narg(output_template_id)::uuid,
+    sqlc.narg(output_sections)::jsonb,
+    sqlc.narg(carried_over_from_task_id)::uuid,
+    sqlc.narg(started_at)::timestamptz,
+    sqlc.narg(completed_at)::timestamptz
 )
-RETURNING id, task_id, priority, density, duration_time, content, output, is_required, "order", status, created_at, updated_at;
+RETURNING id, task_id, priority, density, duration_time, content, output, is_required, "order", status, created_at, updated_at, category_id, output_template_id, output_sections;
 
 -- name: UpdateTask :one
 UPDATE tasks
 SET
     title = @title::text,
     date = @date::date,
+    version = version + 1,
     updated_at = NOW()
 WHERE id = @task_id::uuid
-RETURNING id, owner_id, title, date, review, created_at, updated_at;
+  AND deleted_at IS NULL
+RETURNING id, owner_id, title, date, review, created_at, updated_at, version;
 
 -- name: UpdateTaskItem :one
 UPDATE task_items
@@ -197,17 +292,158 @@ SET
     is_required = @is_required::boolean,
     "order" = @order_value::int4,
     status = @status::text,
+    category_id = sqlc.narg(category_id)::uuid,
+    output_template_id = sqlc.narg(output_template_id)::uuid,
+    started_at = sqlc.narg(started_at)::timestamptz,
+    completed_at = sqlc.narg(completed_at)::timestamptz,
     updated_at = NOW()
 WHERE id = @task_item_id::uuid
-RETURNING id, task_id, priority, density, duration_time, content, output, is_required, "order", status, created_at, updated_at;
+RETURNING id, task_id, priority, density, duration_time, content, output, is_required, "order", status, created_at, updated_at, category_id, output_template_id, output_sections;
 
--- name: DeleteTaskItemsByTaskID :exec
-DELETE FROM task_items
+-- name: LockTaskDate :exec
+SELECT pg_advisory_xact_lock(hashtextextended(@lock_key::text, 0));
+
+-- name: GetTaskByOwnerAndDate :one
+SELECT id, owner_id, title, date, review, created_at, updated_at, version
+FROM tasks
+WHERE owner_id = @owner_id::uuid
+  AND date = @date::date
+  AND deleted_at IS NULL
+ORDER BY created_at ASC, id ASC
+LIMIT 1;
+
+-- name: GetMaxTaskItemOrder :one
+SELECT COALESCE(MAX("order"), 0)::int4 AS max_order
+FROM task_items
+WHERE task_id = @task_id::uuid;
+
+-- name: MoveTaskItem :exec
+UPDATE task_items
+SET
+    task_id = @target_task_id::uuid,
+    "order" = @order_value::int4,
+    carried_over_from_task_id = @carried_over_from_task_id::uuid,
+    updated_at = NOW()
+WHERE id = @task_item_id::uuid;
+
+-- name: TouchTask :execrows
+-- 子タスクの変更もタスクの変更として扱い、バージョンを上げる
+UPDATE tasks
+SET
+    version = version + 1,
+    updated_at = NOW()
+WHERE id = @task_id::uuid
+  AND deleted_at IS NULL;
+
+-- name: LockTask :one
+-- 楽観的排他制御のため、タスクの行をロックして現在のバージョンを取得する
+SELECT version
+FROM tasks
+WHERE id = @task_id::uuid
+  AND deleted_at IS NULL
+FOR UPDATE;
+
+-- name: LockTaskByTaskItemID :one
+SELECT t.id, t.version
+FROM tasks t
+INNER JOIN task_items ti ON ti.task_id = t.id
+WHERE ti.id = @task_item_id::uuid
+  AND t.deleted_at IS NULL
+FOR UPDATE OF t;
+
+-- name: GetTaskItemIDsByTaskID :many
+SELECT id
+FROM task_items
 WHERE task_id = @task_id::uuid;
 
--- name: DeleteTask :exec
+-- name: SetTaskItemOrders :exec
+UPDATE task_items
+SET
+    "order" = o.order_value,
+    updated_at = NOW()
+FROM (
+    SELECT
+        unnest(@task_item_ids::uuid[]) AS task_item_id,
+        unnest(@order_values::int4[]) AS order_value
+) AS o
+WHERE task_items.id = o.task_item_id
+  AND task_items.task_id = @task_id::uuid;
+
+-- name: DeleteTaskItemsNotIn :exec
+DELETE FROM task_items
+WHERE task_id = @task_id::uuid
+  AND NOT (id = ANY(@keep_ids::uuid[]));
+
+-- name: DeleteTaskItem :execrows
+DELETE FROM task_items
+WHERE id = @task_item_id::uuid
+  AND task_id = @task_id::uuid;
+
+-- name: TrashTask :execrows
+-- タスクをゴミ箱に移動する（子タスクやアウトプットは復元できるように残す）
+UPDATE tasks
+SET
+    deleted_at = NOW(),
+    version = version + 1,
+    updated_at = NOW()
+WHERE id = @task_id::uuid
+  AND deleted_at IS NULL;
+
+-- name: ListTrashedTasks :many
+-- ゴミ箱のタスクを移動した日時の新しい順に取得する
+SELECT
+    t.id,
+    t.owner_id,
+    t.title,
+    t.date,
+    t.review,
+    t.created_at,
+    t.updated_at,
+    t.version,
+    t.deleted_at
+FROM tasks t
+WHERE t.owner_id = @owner_id::uuid
+  AND t.deleted_at IS NOT NULL
+ORDER BY t.deleted_at DESC, t.id DESC;
+
+-- name: GetTrashedTaskByID :one
+SELECT
+    t.id,
+    t.owner_id,
+    t.title,
+    t.date,
+    t.review,
+    t.created_at,
+    t.updated_at,
+    t.version,
+    t.deleted_at
+FROM tasks t
+WHERE t.id = @task_id::uuid
+  AND t.deleted_at IS NOT NULL;
+
+-- name: RestoreTask :execrows
+UPDATE tasks
+SET
+    deleted_at = NULL,
+    version = version + 1,
+    updated_at = NOW()
+WHERE id = @task_id::uuid
+  AND deleted_at IS NOT NULL;
+
+-- name: PurgeTask :execrows
+-- ゴミ箱のタスクを完全に削除する（ON DELETE CASCADEにより、子タスクも削除される）
 DELETE FROM tasks
-WHERE id = @task_id::uuid;
+WHERE id = @task_id::uuid
+  AND deleted_at IS NOT NULL;
+
+-- name: ListExpiredTrashedTasks :many
+-- 保持期間を過ぎたゴミ箱のタスクを削除するためにロックして取得する
+SELECT id, owner_id, title, date
+FROM tasks
+WHERE deleted_at IS NOT NULL
+  AND deleted_at < @deleted_before::timestamptz
+ORDER BY deleted_at ASC, id ASC
+FOR UPDATE;
 
 -- name: GetTaskByTaskItemID :one
 SELECT 
@@ -217,25 +453,40 @@ SELECT
     t.date,
     t.review,
     t.created_at,
-    t.updated_at
+    t.updated_at,
+    t.version
 FROM tasks t
 INNER JOIN task_items ti ON ti.task_id = t.id
-WHERE ti.id = @task_item_id::uuid;
+WHERE ti.id = @task_item_id::uuid
+  AND t.deleted_at IS NULL;
 
 -- name: UpdateTaskItemOutput :one
 UPDATE task_items
 SET
     output = @output::text,
-    status = 'Completed',
+    output_sections = sqlc.narg(output_sections)::jsonb,
+    status = @status::text,
+    started_at = sqlc.narg(started_at)::timestamptz,
+    completed_at = sqlc.narg(completed_at)::timestamptz,
     updated_at = NOW()
 WHERE id = @task_item_id::uuid
-RETURNING id, task_id, priority, density, duration_time, content, output, is_required, "order", status, created_at, updated_at;
+RETURNING id, task_id, priority, density, duration_time, content, output, is_required, "order", status, created_at, updated_at, category_id, output_template_id, output_sections;
 
 -- name: UpdateTaskReview :one
 UPDATE tasks
 SET
     review = NULLIF(@review::text, ''),
+    version = version + 1,
     updated_at = NOW()
 WHERE id = @task_id::uuid
-RETURNING id, owner_id, title, date, review, created_at, updated_at;
+  AND deleted_at IS NULL
+RETURNING id, owner_id, title, date, review, created_at, updated_at, version;
 
+-- name: UpdateTaskItemStatus :exec
+-- タイマーの開始で着手した場合のみ着手日時を設定する（既に着手している場合は変更しない）
+UPDATE task_items
+SET
+    status = @status::text,
+    started_at = CASE WHEN @status::text = 'NotStarted' THEN started_at ELSE COALESCE(started_at, @changed_at::timestamptz) END,
+    updated_at = NOW()
+WHERE id = @task_item_id::uuid;
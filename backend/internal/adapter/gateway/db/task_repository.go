@@ -2,17 +2,20 @@ package db
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	dbgen "task-management-system/backend/internal/adapter/gateway/db/sqlc/generated"
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/outputtemplate"
 	"task-management-system/backend/internal/domain/task"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 // TaskRepository タスクリポジトリ
@@ -37,7 +40,7 @@ func (r *TaskRepository) ListTasks(ctx context.Context, condition task.ListTasks
 	if condition.OwnerID != nil {
 		ownerUUID, err := uuid.Parse(*condition.OwnerID)
 		if err != nil {
-			return nil, fmt.Errorf("invalid owner_id: %w", err)
+			return nil, domainerrors.Validation("invalid owner_id").Wrap(err)
 		}
 		var pgUUID pgtype.UUID
 		if err := pgUUID.Scan(ownerUUID.String()); err != nil {
@@ -48,21 +51,41 @@ func (r *TaskRepository) ListTasks(ctx context.Context, condition task.ListTasks
 
 	// year-monthを設定
 	if condition.YearMonth != nil {
-		params.YearMonth = *condition.YearMonth
+		params.YearMonth = pgtype.Text{String: *condition.YearMonth, Valid: true}
 	}
 
-	// keywordを設定
+	// keywordを設定（全文検索のクエリと、部分一致用のパターン）
 	if condition.Keyword != nil {
-		params.Keyword = *condition.Keyword
+		params.Keyword = pgtype.Text{String: *condition.Keyword, Valid: true}
+		params.KeywordPattern = pgtype.Text{String: "%" + escapeLikePattern(*condition.Keyword) + "%", Valid: true}
 	}
 
+	// categoryIdを設定
+	categoryPgUUID, err := nullablePgUUIDFromString(condition.CategoryID, "category_id")
+	if err != nil {
+		return nil, err
+	}
+	params.CategoryID = categoryPgUUID
+
 	// sortを設定
 	if condition.Sort != nil {
 		params.Sort = *condition.Sort
 	}
 
+	// 取得件数を設定
+	if condition.Limit > 0 {
+		params.PageLimit = pgtype.Int4{Int32: condition.Limit, Valid: true}
+	}
+
+	// カーソルを設定
+	if condition.Cursor != nil {
+		if err := setListTasksCursor(&params, condition.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
 	// タスクを取得
-	tasks, err := r.queries.ListTasks(ctx, params)
+	tasks, err := queriesFor(ctx, r.queries).ListTasks(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -77,51 +100,21 @@ func (r *TaskRepository) ListTasks(ctx context.Context, condition task.ListTasks
 		taskIDs = append(taskIDs, t.ID)
 	}
 
-	// タスクアイテムを取得
-	taskItems, err := r.queries.GetTaskItemsByTaskIDs(ctx, taskIDs)
+	// タスクアイテムを取得（タスクIDでグループ化）
+	taskItemsMap, err := r.getTaskItemsByTaskIDs(ctx, taskIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	// タスクアイテムをタスクIDでグループ化
-	taskItemsMap := make(map[string][]dbgen.TaskItem)
-	for _, item := range taskItems {
-		taskID := UUIDFromPgtype(item.TaskID)
-		taskItemsMap[taskID] = append(taskItemsMap[taskID], item)
-	}
-
 	// ドメインエンティティに変換
 	result := make([]*task.Task, 0, len(tasks))
 	for _, t := range tasks {
 		taskID := UUIDFromPgtype(t.ID)
 		ownerID := UUIDFromPgtype(t.OwnerID)
 
-		// タスクアイテムを変換
-		items := taskItemsMap[taskID]
-		taskItemEntities := make([]task.TaskItem, 0, len(items))
-		for _, item := range items {
-			itemID := UUIDFromPgtype(item.ID)
-			itemTaskID := UUIDFromPgtype(item.TaskID)
-
-			var output *string
-			if item.Output.Valid {
-				output = &item.Output.String
-			}
-
-			taskItemEntities = append(taskItemEntities, task.TaskItem{
-				ID:           itemID,
-				TaskID:       itemTaskID,
-				Priority:     task.Priority(item.Priority),
-				Density:      task.Density(item.Density),
-				DurationTime: task.DurationTime(item.DurationTime),
-				Content:      item.Content,
-				Output:       output,
-				IsRequired:   item.IsRequired,
-				Order:        item.Order,
-				Status:       task.Status(item.Status),
-				CreatedAt:    item.CreatedAt.Time,
-				UpdatedAt:    item.UpdatedAt.Time,
-			})
+		taskItemEntities := taskItemsMap[taskID]
+		if taskItemEntities == nil {
+			taskItemEntities = []task.TaskItem{}
 		}
 
 		var review *string
@@ -130,14 +123,16 @@ func (r *TaskRepository) ListTasks(ctx context.Context, condition task.ListTasks
 		}
 
 		result = append(result, &task.Task{
-			ID:        taskID,
-			OwnerID:   ownerID,
-			Title:     t.Title,
-			Date:      t.Date.Time,
-			Review:    review,
-			TaskItems: taskItemEntities,
-			CreatedAt: t.CreatedAt.Time,
-			UpdatedAt: t.UpdatedAt.Time,
+			ID:         taskID,
+			OwnerID:    ownerID,
+			Title:      t.Title,
+			Date:       t.Date.Time,
+			Review:     review,
+			TaskItems:  taskItemEntities,
+			Version:    t.Version,
+			CreatedAt:  t.CreatedAt.Time,
+			UpdatedAt:  t.UpdatedAt.Time,
+			SearchRank: t.Rank,
 		})
 	}
 
@@ -149,7 +144,7 @@ func (r *TaskRepository) GetTaskByID(ctx context.Context, taskID string) (*task.
 	// taskIDをUUIDに変換
 	taskUUID, err := uuid.Parse(taskID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid task_id: %w", err)
+		return nil, domainerrors.Validation("invalid task_id").Wrap(err)
 	}
 	var pgUUID pgtype.UUID
 	if err := pgUUID.Scan(taskUUID.String()); err != nil {
@@ -157,46 +152,23 @@ func (r *TaskRepository) GetTaskByID(ctx context.Context, taskID string) (*task.
 	}
 
 	// タスクを取得
-	t, err := r.queries.GetTaskByID(ctx, pgUUID)
+	t, err := queriesFor(ctx, r.queries).GetTaskByID(ctx, pgUUID)
 	if err != nil {
-		// pgx.ErrNoRowsの場合はnilを返す（タスクが見つからない）
+		// pgx.ErrNoRowsの場合はNotFoundエラーを返す（タスクが見つからない）
 		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, nil
+			return nil, domainerrors.NotFound("Task not found")
 		}
 		return nil, err
 	}
 
 	// タスクアイテムを取得
-	taskItems, err := r.queries.GetTaskItemsByTaskIDs(ctx, []pgtype.UUID{t.ID})
+	taskItemsMap, err := r.getTaskItemsByTaskIDs(ctx, []pgtype.UUID{t.ID})
 	if err != nil {
 		return nil, err
 	}
-
-	// タスクアイテムを変換
-	taskItemEntities := make([]task.TaskItem, 0, len(taskItems))
-	for _, item := range taskItems {
-		itemID := UUIDFromPgtype(item.ID)
-		itemTaskID := UUIDFromPgtype(item.TaskID)
-
-		var output *string
-		if item.Output.Valid {
-			output = &item.Output.String
-		}
-
-		taskItemEntities = append(taskItemEntities, task.TaskItem{
-			ID:           itemID,
-			TaskID:       itemTaskID,
-			Priority:     task.Priority(item.Priority),
-			Density:      task.Density(item.Density),
-			DurationTime: task.DurationTime(item.DurationTime),
-			Content:      item.Content,
-			Output:       output,
-			IsRequired:   item.IsRequired,
-			Order:        item.Order,
-			Status:       task.Status(item.Status),
-			CreatedAt:    item.CreatedAt.Time,
-			UpdatedAt:    item.UpdatedAt.Time,
-		})
+	taskItemEntities := taskItemsMap[UUIDFromPgtype(t.ID)]
+	if taskItemEntities == nil {
+		taskItemEntities = []task.TaskItem{}
 	}
 
 	var review *string
@@ -211,6 +183,7 @@ func (r *TaskRepository) GetTaskByID(ctx context.Context, taskID string) (*task.
 		Date:      t.Date.Time,
 		Review:    review,
 		TaskItems: taskItemEntities,
+		Version:   t.Version,
 		CreatedAt: t.CreatedAt.Time,
 		UpdatedAt: t.UpdatedAt.Time,
 	}, nil
@@ -218,68 +191,28 @@ func (r *TaskRepository) GetTaskByID(ctx context.Context, taskID string) (*task.
 
 // CreateTask タスクを作成
 func (r *TaskRepository) CreateTask(ctx context.Context, ownerID string, title string, date string, taskItems []task.CreateTaskItemInput) (*task.Task, error) {
-	// DBTXからpgx.Conn、pgxpool.Pool、またはpgx.Txを取得
-	switch v := r.db.(type) {
-	case *pgx.Conn:
-		// 単一接続からトランザクションを開始
-		tx, err := v.Begin(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to begin transaction: %w", err)
-		}
-		defer func() {
-			if err != nil {
-				tx.Rollback(ctx)
-			}
-		}()
-
-		result, err := r.createTaskInTx(ctx, tx, ownerID, title, date, taskItems)
-		if err != nil {
-			return nil, err
-		}
-
-		if err := tx.Commit(ctx); err != nil {
-			return nil, fmt.Errorf("failed to commit transaction: %w", err)
-		}
-
-		return result, nil
-	case *pgxpool.Pool:
-		// 接続プールからトランザクションを開始
-		tx, err := v.Begin(ctx)
+	var result *task.Task
+	err := r.runInTx(ctx, func(qtx *dbgen.Queries) error {
+		created, err := createTaskInTx(ctx, qtx, ownerID, title, date, taskItems)
 		if err != nil {
-			return nil, fmt.Errorf("failed to begin transaction: %w", err)
-		}
-		defer func() {
-			if err != nil {
-				tx.Rollback(ctx)
-			}
-		}()
-
-		result, err := r.createTaskInTx(ctx, tx, ownerID, title, date, taskItems)
-		if err != nil {
-			return nil, err
-		}
-
-		if err := tx.Commit(ctx); err != nil {
-			return nil, fmt.Errorf("failed to commit transaction: %w", err)
+			return err
 		}
-
-		return result, nil
-	case pgx.Tx:
-		// 既にトランザクション内の場合は、そのトランザクションを使用
-		return r.createTaskInTx(ctx, v, ownerID, title, date, taskItems)
-	default:
-		return nil, fmt.Errorf("unsupported database connection type for transaction: %T", r.db)
+		result = created
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+
+	return result, nil
 }
 
 // createTaskInTx トランザクション内でタスクを作成
-func (r *TaskRepository) createTaskInTx(ctx context.Context, tx pgx.Tx, ownerID string, title string, date string, taskItems []task.CreateTaskItemInput) (*task.Task, error) {
-	qtx := r.queries.WithTx(tx)
-
+func createTaskInTx(ctx context.Context, qtx *dbgen.Queries, ownerID string, title string, date string, taskItems []task.CreateTaskItemInput) (*task.Task, error) {
 	// ownerIDをUUIDに変換
 	ownerUUID, err := uuid.Parse(ownerID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid owner_id: %w", err)
+		return nil, domainerrors.Validation("invalid owner_id").Wrap(err)
 	}
 	var ownerPgUUID pgtype.UUID
 	if err := ownerPgUUID.Scan(ownerUUID.String()); err != nil {
@@ -289,7 +222,7 @@ func (r *TaskRepository) createTaskInTx(ctx context.Context, tx pgx.Tx, ownerID
 	// 日付をパース
 	dateTime, err := time.Parse("2006-01-02", date)
 	if err != nil {
-		return nil, fmt.Errorf("invalid date format: %w", err)
+		return nil, domainerrors.Validation("invalid date format").Wrap(err)
 	}
 	var datePg pgtype.Date
 	if err := datePg.Scan(dateTime); err != nil {
@@ -309,138 +242,342 @@ func (r *TaskRepository) createTaskInTx(ctx context.Context, tx pgx.Tx, ownerID
 	taskID := UUIDFromPgtype(createdTask.ID)
 
 	// タスクアイテムを作成
-	taskItemEntities := make([]task.TaskItem, 0, len(taskItems))
-	for _, itemInput := range taskItems {
-		// createdTask.IDは既にpgtype.UUID型なので、そのまま使用
-		createdItem, err := qtx.CreateTaskItem(ctx, dbgen.CreateTaskItemParams{
-			TaskID:       createdTask.ID,
-			Priority:     string(itemInput.Priority),
-			Density:      string(itemInput.Density),
-			DurationTime: int32(itemInput.DurationTime),
-			Content:      itemInput.Content,
-			IsRequired:   itemInput.IsRequired,
-			OrderValue:   itemInput.Order,
-			Status:       string(itemInput.Status),
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to create task item: %w", err)
-		}
-
-		itemID := UUIDFromPgtype(createdItem.ID)
-		itemTaskID := UUIDFromPgtype(createdItem.TaskID)
-
-		taskItemEntities = append(taskItemEntities, task.TaskItem{
-			ID:           itemID,
-			TaskID:       itemTaskID,
-			Priority:     itemInput.Priority,
-			Density:      itemInput.Density,
-			DurationTime: itemInput.DurationTime,
-			Content:      itemInput.Content,
-			Output:       nil,
-			IsRequired:   itemInput.IsRequired,
-			Order:        itemInput.Order,
-			Status:       itemInput.Status,
-			CreatedAt:    createdItem.CreatedAt.Time,
-			UpdatedAt:    createdItem.UpdatedAt.Time,
-		})
+	taskItemEntities, err := createTaskItems(ctx, qtx, createdTask.ID, taskItems)
+	if err != nil {
+		return nil, err
 	}
 
-	// トランザクションをコミット（createTaskInTxは呼び出し元でコミットを制御するため、ここではコミットしない）
-	// コミットはCreateTaskメソッドで行う
-
 	var review *string
 	if createdTask.Review.Valid {
 		review = &createdTask.Review.String
 	}
 
-	return &task.Task{
+	result := &task.Task{
 		ID:        taskID,
 		OwnerID:   ownerID,
 		Title:     title,
 		Date:      dateTime,
 		Review:    review,
 		TaskItems: taskItemEntities,
+		Version:   createdTask.Version,
 		CreatedAt: createdTask.CreatedAt.Time,
 		UpdatedAt: createdTask.UpdatedAt.Time,
-	}, nil
+	}
+
+	// 作成を変更履歴に記録
+	if err := recordTaskEvents(ctx, qtx, nil, result, ownerID); err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
 
-// UpdateTask タスクを更新
-func (r *TaskRepository) UpdateTask(ctx context.Context, taskID string, ownerID string, title string, date string, taskItems []task.UpdateTaskItemInput) (*task.Task, error) {
-	// 既存のタスクを取得してオーナーチェック
-	existingTask, err := r.GetTaskByID(ctx, taskID)
+// CreateRecurringTask 繰り返しテンプレートから指定した日付のタスクを作成
+// 同じテンプレートと日付のタスクが既に作成されている場合はConflictエラーを返す
+func (r *TaskRepository) CreateRecurringTask(ctx context.Context, recurringTemplateID string, ownerID string, title string, date time.Time, taskItems []task.CreateTaskItemInput) (*task.Task, error) {
+	ownerPgUUID, err := pgUUIDFromString(ownerID, "owner_id")
 	if err != nil {
 		return nil, err
 	}
-	if existingTask == nil {
-		return nil, fmt.Errorf("task not found: %s", taskID)
-	}
-	if existingTask.OwnerID != ownerID {
-		return nil, fmt.Errorf("you do not have permission to update this task")
+	templatePgUUID, err := pgUUIDFromString(recurringTemplateID, "recurring_template_id")
+	if err != nil {
+		return nil, err
 	}
 
-	// DBTXからpgx.Conn、pgxpool.Pool、またはpgx.Txを取得
-	switch v := r.db.(type) {
-	case *pgx.Conn:
-		// 単一接続からトランザクションを開始
-		tx, err := v.Begin(ctx)
+	var result *task.Task
+	err = r.runInTx(ctx, func(qtx *dbgen.Queries) error {
+		// タスクを作成（作成済みの場合は行が返らない）
+		createdTask, err := qtx.CreateRecurringTask(ctx, dbgen.CreateRecurringTaskParams{
+			OwnerID:             ownerPgUUID,
+			Title:               title,
+			Date:                pgtype.Date{Time: date, Valid: true},
+			RecurringTemplateID: templatePgUUID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domainerrors.Conflict("Task for this date has already been generated")
+			}
+			return fmt.Errorf("failed to create recurring task: %w", err)
+		}
+
+		// タスクアイテムを作成
+		taskItemEntities, err := createTaskItems(ctx, qtx, createdTask.ID, taskItems)
 		if err != nil {
-			return nil, fmt.Errorf("failed to begin transaction: %w", err)
+			return err
+		}
+
+		result = &task.Task{
+			ID:        UUIDFromPgtype(createdTask.ID),
+			OwnerID:   ownerID,
+			Title:     createdTask.Title,
+			Date:      createdTask.Date.Time,
+			TaskItems: taskItemEntities,
+			Version:   createdTask.Version,
+			CreatedAt: createdTask.CreatedAt.Time,
+			UpdatedAt: createdTask.UpdatedAt.Time,
+		}
+
+		// 作成を変更履歴に記録
+		return recordTaskEvents(ctx, qtx, nil, result, ownerID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CarryOverTaskItems 子タスクをオーナーの指定した日付のタスクに持ち越し、持ち越し先のタスクを返す
+// 持ち越し先のタスクがない場合は作成し、子タスクは既存の子タスクの後ろに順番を振り直して並べる
+func (r *TaskRepository) CarryOverTaskItems(ctx context.Context, input task.CarryOverInput) (*task.CarryOverResult, error) {
+	sourcePgUUID, err := pgUUIDFromString(input.SourceTaskID, "task_id")
+	if err != nil {
+		return nil, err
+	}
+	ownerPgUUID, err := pgUUIDFromString(input.OwnerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+	datePg := pgtype.Date{Time: input.Date, Valid: true}
+
+	var targetTaskID string
+	var targetBefore *task.Task
+	err = r.runInTx(ctx, func(qtx *dbgen.Queries) error {
+		// 同じ日付への持ち越しが同時に実行されてもタスクが重複しないようにロック
+		lockKey := "task-date:" + input.OwnerID + ":" + input.Date.Format(time.DateOnly)
+		if err := qtx.LockTaskDate(ctx, lockKey); err != nil {
+			return fmt.Errorf("failed to lock owner date: %w", err)
+		}
+
+		// 変更履歴のため、変更前の持ち越し元のタスクを取得（移動の場合のみ変わる）
+		var sourceBefore *task.Task
+		if input.Mode == task.CarryOverModeMove {
+			before, err := loadTaskInTx(ctx, qtx, sourcePgUUID)
+			if err != nil {
+				return err
+			}
+			sourceBefore = before
 		}
-		defer func() {
+
+		// 持ち越し先のタスクを取得（ない場合は作成）
+		// 変更履歴のため、既存のタスクは変更前のタスクを取得する（作成した場合はnil）
+		target, err := qtx.GetTaskByOwnerAndDate(ctx, dbgen.GetTaskByOwnerAndDateParams{OwnerID: ownerPgUUID, Date: datePg})
+		if err == nil {
+			if targetBefore, err = loadTaskInTx(ctx, qtx, target.ID); err != nil {
+				return err
+			}
+		} else {
+			if !errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("failed to get task by date: %w", err)
+			}
+			created, err := qtx.CreateTask(ctx, dbgen.CreateTaskParams{
+				OwnerID: ownerPgUUID,
+				Title:   input.Title,
+				Date:    datePg,
+			})
 			if err != nil {
-				tx.Rollback(ctx)
+				return fmt.Errorf("failed to create task: %w", err)
 			}
-		}()
+			target = dbgen.GetTaskByOwnerAndDateRow(created)
+		}
+		targetTaskID = UUIDFromPgtype(target.ID)
 
-		result, err := r.updateTaskInTx(ctx, tx, taskID, title, date, taskItems)
+		// 既存の子タスクの後ろに並べる
+		maxOrder, err := qtx.GetMaxTaskItemOrder(ctx, target.ID)
 		if err != nil {
-			return nil, err
+			return fmt.Errorf("failed to get max task item order: %w", err)
 		}
 
-		if err := tx.Commit(ctx); err != nil {
-			return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		for i, item := range input.TaskItems {
+			order := maxOrder + int32(i) + 1
+			if err := carryOverTaskItem(ctx, qtx, item, order, sourcePgUUID, target.ID, input.Mode); err != nil {
+				return err
+			}
 		}
 
-		return result, nil
-	case *pgxpool.Pool:
-		// 接続プールからトランザクションを開始
-		tx, err := v.Begin(ctx)
+		// 子タスクが変わったタスクのバージョンを更新し、変更履歴を記録（移動の場合は持ち越し元も変わる）
+		if err := touchTask(ctx, qtx, target.ID); err != nil {
+			return err
+		}
+		targetAfter, err := loadTaskInTx(ctx, qtx, target.ID)
 		if err != nil {
-			return nil, fmt.Errorf("failed to begin transaction: %w", err)
+			return err
 		}
-		defer func() {
-			if err != nil {
-				tx.Rollback(ctx)
+		if err := recordTaskEvents(ctx, qtx, targetBefore, targetAfter, input.OwnerID); err != nil {
+			return err
+		}
+		if sourceBefore == nil {
+			return nil
+		}
+		if err := touchTask(ctx, qtx, sourcePgUUID); err != nil {
+			return err
+		}
+		return recordTaskEventsSince(ctx, qtx, sourceBefore, input.OwnerID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	targetAfter, err := r.GetTaskByID(ctx, targetTaskID)
+	if err != nil {
+		return nil, err
+	}
+	return &task.CarryOverResult{Target: targetAfter, TargetBefore: targetBefore}, nil
+}
+
+// carryOverTaskItem トランザクション内で子タスクを持ち越し先のタスクに移動または複製
+func carryOverTaskItem(ctx context.Context, qtx *dbgen.Queries, item task.TaskItem, order int32, sourceTaskID pgtype.UUID, targetTaskID pgtype.UUID, mode task.CarryOverMode) error {
+	if mode == task.CarryOverModeMove {
+		itemPgUUID, err := pgUUIDFromString(item.ID, "task_item_id")
+		if err != nil {
+			return err
+		}
+		if err := qtx.MoveTaskItem(ctx, dbgen.MoveTaskItemParams{
+			TargetTaskID:          targetTaskID,
+			OrderValue:            order,
+			CarriedOverFromTaskID: sourceTaskID,
+			TaskItemID:            itemPgUUID,
+		}); err != nil {
+			if isUniqueViolation(err) {
+				return domainerrors.Conflict("Task item order must be unique within a task").Wrap(err)
 			}
-		}()
+			return fmt.Errorf("failed to move task item: %w", err)
+		}
+		return nil
+	}
+
+	categoryPgUUID, err := nullablePgUUIDFromString(item.CategoryID, "category_id")
+	if err != nil {
+		return err
+	}
+	outputTemplatePgUUID, err := nullablePgUUIDFromString(item.OutputTemplateID, "output_template_id")
+	if err != nil {
+		return err
+	}
+	if _, err := qtx.CreateTaskItem(ctx, dbgen.CreateTaskItemParams{
+		TaskID:                targetTaskID,
+		Priority:              string(item.Priority),
+		Density:               string(item.Density),
+		DurationTime:          int32(item.DurationTime),
+		Content:               item.Content,
+		IsRequired:            item.IsRequired,
+		OrderValue:            order,
+		Status:                string(item.Status),
+		CategoryID:            categoryPgUUID,
+		OutputTemplateID:      outputTemplatePgUUID,
+		CarriedOverFromTaskID: sourceTaskID,
+		StartedAt:             nullablePgTimestamptz(item.StartedAt),
+	}); err != nil {
+		if isUniqueViolation(err) {
+			return domainerrors.Conflict("Task item order must be unique within a task").Wrap(err)
+		}
+		return fmt.Errorf("failed to copy task item: %w", err)
+	}
+	return nil
+}
+
+// createTaskItems トランザクション内でタスクにタスクアイテムを作成
+func createTaskItems(ctx context.Context, qtx *dbgen.Queries, taskID pgtype.UUID, taskItems []task.CreateTaskItemInput) ([]task.TaskItem, error) {
+	// ステータスに合わせて着手日時と完了日時を設定（引き継いだ日時は保持する）
+	taskItems, err := task.PlanNewTaskItems(taskItems, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	taskItemEntities := make([]task.TaskItem, 0, len(taskItems))
+	for _, itemInput := range taskItems {
+		categoryPgUUID, err := nullablePgUUIDFromString(itemInput.CategoryID, "category_id")
+		if err != nil {
+			return nil, err
+		}
+		outputTemplatePgUUID, err := nullablePgUUIDFromString(itemInput.OutputTemplateID, "output_template_id")
+		if err != nil {
+			return nil, err
+		}
 
-		result, err := r.updateTaskInTx(ctx, tx, taskID, title, date, taskItems)
+		outputSections, err := marshalOutputSections(itemInput.OutputSections)
 		if err != nil {
 			return nil, err
 		}
+		var output pgtype.Text
+		if itemInput.Output != nil {
+			output = pgtype.Text{String: *itemInput.Output, Valid: true}
+		}
 
-		if err := tx.Commit(ctx); err != nil {
-			return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		createdItem, err := qtx.CreateTaskItem(ctx, dbgen.CreateTaskItemParams{
+			TaskID:           taskID,
+			Priority:         string(itemInput.Priority),
+			Density:          string(itemInput.Density),
+			DurationTime:     int32(itemInput.DurationTime),
+			Content:          itemInput.Content,
+			Output:           output,
+			IsRequired:       itemInput.IsRequired,
+			OrderValue:       itemInput.Order,
+			Status:           string(itemInput.Status),
+			CategoryID:       categoryPgUUID,
+			OutputTemplateID: outputTemplatePgUUID,
+			OutputSections:   outputSections,
+			StartedAt:        nullablePgTimestamptz(itemInput.StartedAt),
+			CompletedAt:      nullablePgTimestamptz(itemInput.CompletedAt),
+		})
+		if err != nil {
+			if isUniqueViolation(err) {
+				return nil, domainerrors.Conflict("Task item order must be unique within a task").Wrap(err)
+			}
+			return nil, fmt.Errorf("failed to create task item: %w", err)
 		}
 
-		return result, nil
-	case pgx.Tx:
-		// 既にトランザクション内の場合は、そのトランザクションを使用
-		return r.updateTaskInTx(ctx, v, taskID, title, date, taskItems)
-	default:
-		return nil, fmt.Errorf("unsupported database connection type for transaction: %T", r.db)
+		itemID := UUIDFromPgtype(createdItem.ID)
+		itemTaskID := UUIDFromPgtype(createdItem.TaskID)
+
+		taskItemEntities = append(taskItemEntities, task.TaskItem{
+			ID:               itemID,
+			TaskID:           itemTaskID,
+			Priority:         itemInput.Priority,
+			Density:          itemInput.Density,
+			DurationTime:     itemInput.DurationTime,
+			Content:          itemInput.Content,
+			Output:           itemInput.Output,
+			IsRequired:       itemInput.IsRequired,
+			Order:            itemInput.Order,
+			Status:           itemInput.Status,
+			CategoryID:       nullableUUIDFromPgtype(createdItem.CategoryID),
+			OutputTemplateID: nullableUUIDFromPgtype(createdItem.OutputTemplateID),
+			OutputSections:   itemInput.OutputSections,
+			StartedAt:        itemInput.StartedAt,
+			CompletedAt:      itemInput.CompletedAt,
+			CreatedAt:        createdItem.CreatedAt.Time,
+			UpdatedAt:        createdItem.UpdatedAt.Time,
+		})
 	}
+
+	return taskItemEntities, nil
 }
 
-// updateTaskInTx トランザクション内でタスクを更新
-func (r *TaskRepository) updateTaskInTx(ctx context.Context, tx pgx.Tx, taskID string, title string, date string, taskItems []task.UpdateTaskItemInput) (*task.Task, error) {
-	qtx := r.queries.WithTx(tx)
+// UpdateTask タスクを更新
+func (r *TaskRepository) UpdateTask(ctx context.Context, taskID string, actorID string, title string, date string, taskItems []task.UpdateTaskItemInput, expectedVersion *int64) (*task.Task, error) {
+	var result *task.Task
+	err := r.runInTx(ctx, func(qtx *dbgen.Queries) error {
+		updated, err := updateTaskInTx(ctx, qtx, taskID, actorID, title, date, taskItems, expectedVersion)
+		if err != nil {
+			return err
+		}
+		result = updated
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
 
+// updateTaskInTx トランザクション内でタスクを更新し、変更履歴を記録
+func updateTaskInTx(ctx context.Context, qtx *dbgen.Queries, taskID string, actorID string, title string, date string, taskItems []task.UpdateTaskItemInput, expectedVersion *int64) (*task.Task, error) {
 	// taskIDをUUIDに変換
 	taskUUID, err := uuid.Parse(taskID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid task_id: %w", err)
+		return nil, domainerrors.Validation("invalid task_id").Wrap(err)
 	}
 	// pgtype.UUIDを直接構築（Bytesフィールドを設定）
 	taskPgUUID := pgtype.UUID{
@@ -451,13 +588,24 @@ func (r *TaskRepository) updateTaskInTx(ctx context.Context, tx pgx.Tx, taskID s
 	// 日付をパース
 	dateTime, err := time.Parse("2006-01-02", date)
 	if err != nil {
-		return nil, fmt.Errorf("invalid date format: %w", err)
+		return nil, domainerrors.Validation("invalid date format").Wrap(err)
 	}
 	var datePg pgtype.Date
 	if err := datePg.Scan(dateTime); err != nil {
 		return nil, fmt.Errorf("failed to convert date to pgtype.Date: %w", err)
 	}
 
+	// タスクの行をロックしてバージョンを確認
+	if err := checkTaskVersion(ctx, qtx, taskPgUUID, expectedVersion); err != nil {
+		return nil, err
+	}
+
+	// 変更履歴のため、ロック後の変更前のタスクを取得
+	before, err := loadTaskInTx(ctx, qtx, taskPgUUID)
+	if err != nil {
+		return nil, err
+	}
+
 	// タスクを更新
 	updatedTask, err := qtx.UpdateTask(ctx, dbgen.UpdateTaskParams{
 		Title:  title,
@@ -468,82 +616,120 @@ func (r *TaskRepository) updateTaskInTx(ctx context.Context, tx pgx.Tx, taskID s
 		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
 
-	// 既存のタスクアイテムを全て削除
-	if err := qtx.DeleteTaskItemsByTaskID(ctx, taskPgUUID); err != nil {
-		return nil, fmt.Errorf("failed to delete task items: %w", err)
+	// 既存のタスクアイテムを取得（タイマーのセッションやアウトプットを保つため、削除せずに更新する）
+	existingItemIDs, err := qtx.GetTaskItemIDsByTaskID(ctx, taskPgUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task item ids: %w", err)
+	}
+	existing := make(map[string]bool, len(existingItemIDs))
+	for _, id := range existingItemIDs {
+		existing[UUIDFromPgtype(id)] = true
 	}
 
-	// タスクアイテムを作成（既存のIDがある場合は更新、ない場合は新規作成）
-	taskItemEntities := make([]task.TaskItem, 0, len(taskItems))
+	keepIDs := make([]pgtype.UUID, 0, len(taskItems))
+	keepOrders := make([]int32, 0, len(taskItems))
 	for _, itemInput := range taskItems {
-		// タスクアイテムIDをUUIDに変換
-		itemUUID, err := uuid.Parse(itemInput.ID)
-		if err != nil {
-			return nil, fmt.Errorf("invalid task_item_id: %w", err)
-		}
-		// pgtype.UUIDを直接構築（Bytesフィールドを設定）
-		itemPgUUID := pgtype.UUID{
-			Bytes: [16]byte(itemUUID),
-			Valid: true,
-		}
-
-		// 既存のタスクアイテムを更新
-		updatedItem, err := qtx.UpdateTaskItem(ctx, dbgen.UpdateTaskItemParams{
-			TaskItemID:   itemPgUUID,
-			Priority:     string(itemInput.Priority),
-			Density:      string(itemInput.Density),
-			DurationTime: int32(itemInput.DurationTime),
-			Content:      itemInput.Content,
-			IsRequired:   itemInput.IsRequired,
-			OrderValue:   itemInput.Order,
-			Status:       string(itemInput.Status),
-		})
+		if !existing[itemInput.ID] {
+			continue
+		}
+		itemPgUUID, err := pgUUIDFromString(itemInput.ID, "task_item_id")
 		if err != nil {
-			// 更新に失敗した場合（存在しない場合）は新規作成
-			if errors.Is(err, pgx.ErrNoRows) {
-				createdItem, createErr := qtx.CreateTaskItem(ctx, dbgen.CreateTaskItemParams{
-					TaskID:       taskPgUUID,
-					Priority:     string(itemInput.Priority),
-					Density:      string(itemInput.Density),
-					DurationTime: int32(itemInput.DurationTime),
-					Content:      itemInput.Content,
-					IsRequired:   itemInput.IsRequired,
-					OrderValue:   itemInput.Order,
-					Status:       string(itemInput.Status),
-				})
-				if createErr != nil {
-					return nil, fmt.Errorf("failed to create task item: %w", createErr)
-				}
-				updatedItem = createdItem
-			} else {
-				return nil, fmt.Errorf("failed to update task item: %w", err)
-			}
+			return nil, err
 		}
+		keepIDs = append(keepIDs, itemPgUUID)
+		keepOrders = append(keepOrders, itemInput.Order)
+	}
 
-		createdItem := updatedItem
+	// リクエストに含まれない既存のタスクアイテムを削除
+	if err := qtx.DeleteTaskItemsNotIn(ctx, dbgen.DeleteTaskItemsNotInParams{
+		TaskID:  taskPgUUID,
+		KeepIds: keepIDs,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to delete task items: %w", err)
+	}
 
-		itemID := UUIDFromPgtype(createdItem.ID)
-		itemTaskID := UUIDFromPgtype(createdItem.TaskID)
+	// 既存のタスクアイテムの順番を1文でまとめて更新（順番の入れ替えでも一意制約に違反しない）
+	if err := qtx.SetTaskItemOrders(ctx, dbgen.SetTaskItemOrdersParams{
+		TaskID:      taskPgUUID,
+		TaskItemIds: keepIDs,
+		OrderValues: keepOrders,
+	}); err != nil {
+		if isUniqueViolation(err) {
+			return nil, domainerrors.Conflict("Task item order must be unique within a task").Wrap(err)
+		}
+		return nil, fmt.Errorf("failed to update task item orders: %w", err)
+	}
 
-		var output *string
-		if createdItem.Output.Valid {
-			output = &createdItem.Output.String
+	// タスクアイテムを更新（既存のIDがある場合は更新、ない場合は新規作成）
+	newItems := make([]task.CreateTaskItemInput, 0, len(taskItems))
+	for _, itemInput := range taskItems {
+		if !existing[itemInput.ID] {
+			newItems = append(newItems, task.CreateTaskItemInput{
+				Priority:         itemInput.Priority,
+				Density:          itemInput.Density,
+				DurationTime:     itemInput.DurationTime,
+				Content:          itemInput.Content,
+				IsRequired:       itemInput.IsRequired,
+				Order:            itemInput.Order,
+				Status:           itemInput.Status,
+				CategoryID:       itemInput.CategoryID,
+				OutputTemplateID: itemInput.OutputTemplateID,
+				StartedAt:        itemInput.StartedAt,
+				CompletedAt:      itemInput.CompletedAt,
+			})
+			continue
 		}
 
-		taskItemEntities = append(taskItemEntities, task.TaskItem{
-			ID:           itemID,
-			TaskID:       itemTaskID,
-			Priority:     itemInput.Priority,
-			Density:      itemInput.Density,
-			DurationTime: itemInput.DurationTime,
-			Content:      itemInput.Content,
-			Output:       output,
-			IsRequired:   itemInput.IsRequired,
-			Order:        itemInput.Order,
-			Status:       itemInput.Status,
-			CreatedAt:    createdItem.CreatedAt.Time,
-			UpdatedAt:    createdItem.UpdatedAt.Time,
-		})
+		itemPgUUID, err := pgUUIDFromString(itemInput.ID, "task_item_id")
+		if err != nil {
+			return nil, err
+		}
+		categoryPgUUID, err := nullablePgUUIDFromString(itemInput.CategoryID, "category_id")
+		if err != nil {
+			return nil, err
+		}
+		outputTemplatePgUUID, err := nullablePgUUIDFromString(itemInput.OutputTemplateID, "output_template_id")
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := qtx.UpdateTaskItem(ctx, dbgen.UpdateTaskItemParams{
+			TaskItemID:       itemPgUUID,
+			Priority:         string(itemInput.Priority),
+			Density:          string(itemInput.Density),
+			DurationTime:     int32(itemInput.DurationTime),
+			Content:          itemInput.Content,
+			IsRequired:       itemInput.IsRequired,
+			OrderValue:       itemInput.Order,
+			Status:           string(itemInput.Status),
+			CategoryID:       categoryPgUUID,
+			OutputTemplateID: outputTemplatePgUUID,
+			StartedAt:        nullablePgTimestamptz(itemInput.StartedAt),
+			CompletedAt:      nullablePgTimestamptz(itemInput.CompletedAt),
+		}); err != nil {
+			if isUniqueViolation(err) {
+				return nil, domainerrors.Conflict("Task item order must be unique within a task").Wrap(err)
+			}
+			return nil, fmt.Errorf("failed to update task item: %w", err)
+		}
+		if err := stopTimerIfCompleted(ctx, qtx, itemPgUUID); err != nil {
+			return nil, err
+		}
+	}
+
+	// 新しいタスクアイテムを作成
+	if _, err := createTaskItems(ctx, qtx, taskPgUUID, newItems); err != nil {
+		return nil, err
+	}
+
+	// 更新後のタスクアイテムを取得（タイマーのセッションを含む）
+	taskItemsMap, err := loadTaskItems(ctx, qtx, []pgtype.UUID{taskPgUUID})
+	if err != nil {
+		return nil, err
+	}
+	taskItemEntities := taskItemsMap[taskID]
+	if taskItemEntities == nil {
+		taskItemEntities = []task.TaskItem{}
 	}
 
 	var review *string
@@ -551,72 +737,390 @@ func (r *TaskRepository) updateTaskInTx(ctx context.Context, tx pgx.Tx, taskID s
 		review = &updatedTask.Review.String
 	}
 
-	return &task.Task{
+	result := &task.Task{
 		ID:        UUIDFromPgtype(updatedTask.ID),
 		OwnerID:   UUIDFromPgtype(updatedTask.OwnerID),
 		Title:     title,
 		Date:      dateTime,
 		Review:    review,
 		TaskItems: taskItemEntities,
+		Version:   updatedTask.Version,
 		CreatedAt: updatedTask.CreatedAt.Time,
 		UpdatedAt: updatedTask.UpdatedAt.Time,
-	}, nil
-}
-
-// GetTaskByTaskItemID タスクアイテムIDからタスクを取得
-func (r *TaskRepository) GetTaskByTaskItemID(ctx context.Context, taskItemID string) (*task.Task, error) {
-	// taskItemIDをUUIDに変換
-	taskItemUUID, err := uuid.Parse(taskItemID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid task_item_id: %w", err)
-	}
-	var taskItemPgUUID pgtype.UUID
-	if err := taskItemPgUUID.Scan(taskItemUUID.String()); err != nil {
-		return nil, fmt.Errorf("failed to convert task_item_id to pgtype.UUID: %w", err)
 	}
 
-	// タスクを取得
-	t, err := r.queries.GetTaskByTaskItemID(ctx, taskItemPgUUID)
-	if err != nil {
-		// pgx.ErrNoRowsの場合はnilを返す（タスクが見つからない）
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, nil
-		}
+	// 変更された項目を変更履歴に記録
+	if err := recordTaskEvents(ctx, qtx, before, result, actorID); err != nil {
 		return nil, err
 	}
 
-	// タスクアイテムを取得
-	taskItems, err := r.queries.GetTaskItemsByTaskIDs(ctx, []pgtype.UUID{t.ID})
+	return result, nil
+}
+
+// AddTaskItem タスクにタスクアイテムを1件追加
+func (r *TaskRepository) AddTaskItem(ctx context.Context, taskID string, actorID string, input task.CreateTaskItemInput) error {
+	taskPgUUID, err := pgUUIDFromString(taskID, "task_id")
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// タスクアイテムを変換
-	taskItemEntities := make([]task.TaskItem, 0, len(taskItems))
-	for _, item := range taskItems {
-		itemID := UUIDFromPgtype(item.ID)
-		itemTaskID := UUIDFromPgtype(item.TaskID)
+	return r.runInTx(ctx, func(qtx *dbgen.Queries) error {
+		return recordTaskChange(ctx, qtx, taskPgUUID, actorID, func() error {
+			_, err := createTaskItems(ctx, qtx, taskPgUUID, []task.CreateTaskItemInput{input})
+			return err
+		})
+	})
+}
+
+// SaveTaskItem タスクアイテムの項目（内容・順番・ステータスなど）を保存
+// アウトプットとタイマーのセッションは変更しない
+func (r *TaskRepository) SaveTaskItem(ctx context.Context, taskItem task.TaskItem, actorID string) error {
+	taskPgUUID, err := pgUUIDFromString(taskItem.TaskID, "task_id")
+	if err != nil {
+		return err
+	}
+	itemPgUUID, err := pgUUIDFromString(taskItem.ID, "task_item_id")
+	if err != nil {
+		return err
+	}
+	categoryPgUUID, err := nullablePgUUIDFromString(taskItem.CategoryID, "category_id")
+	if err != nil {
+		return err
+	}
+	outputTemplatePgUUID, err := nullablePgUUIDFromString(taskItem.OutputTemplateID, "output_template_id")
+	if err != nil {
+		return err
+	}
+
+	return r.runInTx(ctx, func(qtx *dbgen.Queries) error {
+		return recordTaskChange(ctx, qtx, taskPgUUID, actorID, func() error {
+			if _, err := qtx.UpdateTaskItem(ctx, dbgen.UpdateTaskItemParams{
+				TaskItemID:       itemPgUUID,
+				Priority:         string(taskItem.Priority),
+				Density:          string(taskItem.Density),
+				DurationTime:     int32(taskItem.DurationTime),
+				Content:          taskItem.Content,
+				IsRequired:       taskItem.IsRequired,
+				OrderValue:       taskItem.Order,
+				Status:           string(taskItem.Status),
+				CategoryID:       categoryPgUUID,
+				OutputTemplateID: outputTemplatePgUUID,
+				StartedAt:        nullablePgTimestamptz(taskItem.StartedAt),
+				CompletedAt:      nullablePgTimestamptz(taskItem.CompletedAt),
+			}); err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return domainerrors.NotFound("Task item not found")
+				}
+				if isUniqueViolation(err) {
+					return domainerrors.Conflict("Task item order must be unique within a task").Wrap(err)
+				}
+				return fmt.Errorf("failed to update task item: %w", err)
+			}
+			return stopTimerIfCompleted(ctx, qtx, itemPgUUID)
+		})
+	})
+}
+
+// ReorderTaskItems タスクアイテムの順番をまとめて更新
+// 順番は1文で更新するため、入れ替えでも一意制約に違反しない
+func (r *TaskRepository) ReorderTaskItems(ctx context.Context, taskID string, actorID string, orders []task.TaskItemOrder) error {
+	taskPgUUID, err := pgUUIDFromString(taskID, "task_id")
+	if err != nil {
+		return err
+	}
 
-		var output *string
-		if item.Output.Valid {
-			output = &item.Output.String
+	itemIDs := make([]pgtype.UUID, 0, len(orders))
+	orderValues := make([]int32, 0, len(orders))
+	for _, o := range orders {
+		itemPgUUID, err := pgUUIDFromString(o.TaskItemID, "task_item_id")
+		if err != nil {
+			return err
 		}
+		itemIDs = append(itemIDs, itemPgUUID)
+		orderValues = append(orderValues, o.Order)
+	}
 
-		taskItemEntities = append(taskItemEntities, task.TaskItem{
-			ID:           itemID,
-			TaskID:       itemTaskID,
-			Priority:     task.Priority(item.Priority),
-			Density:      task.Density(item.Density),
-			DurationTime: task.DurationTime(item.DurationTime),
-			Content:      item.Content,
-			Output:       output,
-			IsRequired:   item.IsRequired,
-			Order:        item.Order,
-			Status:       task.Status(item.Status),
-			CreatedAt:    item.CreatedAt.Time,
-			UpdatedAt:    item.UpdatedAt.Time,
+	return r.runInTx(ctx, func(qtx *dbgen.Queries) error {
+		return recordTaskChange(ctx, qtx, taskPgUUID, actorID, func() error {
+			// 確認後にタスクアイテムが追加・削除されていないか確認
+			existingItemIDs, err := qtx.GetTaskItemIDsByTaskID(ctx, taskPgUUID)
+			if err != nil {
+				return fmt.Errorf("failed to get task item ids: %w", err)
+			}
+			requested := make(map[string]bool, len(orders))
+			for _, o := range orders {
+				requested[o.TaskItemID] = true
+			}
+			if len(existingItemIDs) != len(orders) {
+				return domainerrors.Conflict("Task items have been changed")
+			}
+			for _, id := range existingItemIDs {
+				if !requested[UUIDFromPgtype(id)] {
+					return domainerrors.Conflict("Task items have been changed")
+				}
+			}
+
+			if err := qtx.SetTaskItemOrders(ctx, dbgen.SetTaskItemOrdersParams{
+				TaskID:      taskPgUUID,
+				TaskItemIds: itemIDs,
+				OrderValues: orderValues,
+			}); err != nil {
+				if isUniqueViolation(err) {
+					return domainerrors.Conflict("Task item order must be unique within a task").Wrap(err)
+				}
+				return fmt.Errorf("failed to update task item orders: %w", err)
+			}
+			return nil
+		})
+	})
+}
+
+// DeleteTaskItem タスクアイテムを1件削除（タイマーのセッションも一緒に削除される）
+func (r *TaskRepository) DeleteTaskItem(ctx context.Context, taskID string, taskItemID string, actorID string) error {
+	taskPgUUID, err := pgUUIDFromString(taskID, "task_id")
+	if err != nil {
+		return err
+	}
+	itemPgUUID, err := pgUUIDFromString(taskItemID, "task_item_id")
+	if err != nil {
+		return err
+	}
+
+	return r.runInTx(ctx, func(qtx *dbgen.Queries) error {
+		return recordTaskChange(ctx, qtx, taskPgUUID, actorID, func() error {
+			rows, err := qtx.DeleteTaskItem(ctx, dbgen.DeleteTaskItemParams{
+				TaskItemID: itemPgUUID,
+				TaskID:     taskPgUUID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to delete task item: %w", err)
+			}
+			if rows == 0 {
+				return domainerrors.NotFound("Task item not found")
+			}
+			return nil
+		})
+	})
+}
+
+// touchTask トランザクション内でタスクの更新日時を更新（タスクが存在しない場合はNotFoundエラー）
+func touchTask(ctx context.Context, qtx *dbgen.Queries, taskID pgtype.UUID) error {
+	rows, err := qtx.TouchTask(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("failed to touch task: %w", err)
+	}
+	if rows == 0 {
+		return domainerrors.NotFound("Task not found")
+	}
+	return nil
+}
+
+// recordTaskChange トランザクション内でタスクの行をロックして変更を実行し、変更履歴を記録
+// 同じタスクへの更新はこの行ロックで直列化される
+func recordTaskChange(ctx context.Context, qtx *dbgen.Queries, taskID pgtype.UUID, actorID string, change func() error) error {
+	// タスクの更新日時を更新
+	if err := touchTask(ctx, qtx, taskID); err != nil {
+		return err
+	}
+
+	before, err := loadTaskInTx(ctx, qtx, taskID)
+	if err != nil {
+		return err
+	}
+
+	if err := change(); err != nil {
+		return err
+	}
+
+	return recordTaskEventsSince(ctx, qtx, before, actorID)
+}
+
+// recordTaskEventsSince トランザクション内で変更後のタスクを取得し、変更前のタスクとの差分を変更履歴に記録
+func recordTaskEventsSince(ctx context.Context, qtx *dbgen.Queries, before *task.Task, actorID string) error {
+	taskPgUUID, err := pgUUIDFromString(before.ID, "task_id")
+	if err != nil {
+		return err
+	}
+	after, err := loadTaskInTx(ctx, qtx, taskPgUUID)
+	if err != nil {
+		return err
+	}
+	return recordTaskEvents(ctx, qtx, before, after, actorID)
+}
+
+// recordTaskEvents トランザクション内で変更前と変更後のタスクの差分を変更履歴に記録（beforeがnilの場合は作成）
+func recordTaskEvents(ctx context.Context, qtx *dbgen.Queries, before *task.Task, after *task.Task, actorID string) error {
+	for _, event := range task.DiffTask(before, after, actorID) {
+		if err := createTaskEvent(ctx, qtx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createTaskEvent トランザクション内で変更履歴を1件記録
+func createTaskEvent(ctx context.Context, qtx *dbgen.Queries, event task.Event) error {
+	actorPgUUID, err := pgUUIDFromString(event.ActorID, "actor_id")
+	if err != nil {
+		return err
+	}
+	taskPgUUID, err := pgUUIDFromString(event.TaskID, "task_id")
+	if err != nil {
+		return err
+	}
+	taskItemPgUUID, err := nullablePgUUIDFromString(event.TaskItemID, "task_item_id")
+	if err != nil {
+		return err
+	}
+	diff, err := json.Marshal(event.Diff)
+	if err != nil {
+		return fmt.Errorf("failed to marshal task event diff: %w", err)
+	}
+
+	if err := qtx.CreateTaskEvent(ctx, dbgen.CreateTaskEventParams{
+		TaskID:     taskPgUUID,
+		TaskItemID: taskItemPgUUID,
+		ActorID:    actorPgUUID,
+		EventType:  string(event.Type),
+		Diff:       diff,
+	}); err != nil {
+		return fmt.Errorf("failed to create task event: %w", err)
+	}
+	return nil
+}
+
+// loadTaskInTx トランザクション内でタスクを子タスクと合わせて取得
+func loadTaskInTx(ctx context.Context, qtx *dbgen.Queries, taskID pgtype.UUID) (*task.Task, error) {
+	t, err := qtx.GetTaskByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainerrors.NotFound("Task not found")
+		}
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	taskItemsMap, err := loadTaskItems(ctx, qtx, []pgtype.UUID{t.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	var review *string
+	if t.Review.Valid {
+		review = &t.Review.String
+	}
+
+	return &task.Task{
+		ID:        UUIDFromPgtype(t.ID),
+		OwnerID:   UUIDFromPgtype(t.OwnerID),
+		Title:     t.Title,
+		Date:      t.Date.Time,
+		Review:    review,
+		TaskItems: taskItemsMap[UUIDFromPgtype(t.ID)],
+		Version:   t.Version,
+		CreatedAt: t.CreatedAt.Time,
+		UpdatedAt: t.UpdatedAt.Time,
+	}, nil
+}
+
+// ListTaskEvents タスクの変更履歴を古い順に取得（ゴミ箱のタスクと完全に削除したタスクの履歴は取得しない）
+func (r *TaskRepository) ListTaskEvents(ctx context.Context, taskID string) ([]task.Event, error) {
+	taskPgUUID, err := pgUUIDFromString(taskID, "task_id")
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := queriesFor(ctx, r.queries).ListTaskEvents(ctx, taskPgUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task events: %w", err)
+	}
+
+	events := make([]task.Event, 0, len(rows))
+	for _, row := range rows {
+		var diff task.Diff
+		if err := json.Unmarshal(row.Diff, &diff); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal task event diff: %w", err)
+		}
+		events = append(events, task.Event{
+			ID:         UUIDFromPgtype(row.ID),
+			TaskID:     UUIDFromPgtype(row.TaskID),
+			TaskItemID: nullableUUIDFromPgtype(row.TaskItemID),
+			ActorID:    UUIDFromPgtype(row.ActorID),
+			Type:       task.EventType(row.EventType),
+			Diff:       diff,
+			CreatedAt:  row.CreatedAt.Time,
 		})
 	}
+	return events, nil
+}
+
+// checkTaskVersion タスクの行をロックし、バージョンが期待値と一致するか確認
+// expectedVersionがnilの場合はロックのみ行う
+func checkTaskVersion(ctx context.Context, qtx *dbgen.Queries, taskID pgtype.UUID, expectedVersion *int64) error {
+	version, err := qtx.LockTask(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domainerrors.NotFound("Task not found")
+		}
+		return fmt.Errorf("failed to lock task: %w", err)
+	}
+	return task.CheckVersion(version, expectedVersion)
+}
+
+// checkTaskVersionByTaskItemID タスクアイテムが属するタスクの行をロックし、バージョンを確認してタスクIDを返す
+func checkTaskVersionByTaskItemID(ctx context.Context, qtx *dbgen.Queries, taskItemID pgtype.UUID, expectedVersion *int64) (pgtype.UUID, error) {
+	row, err := qtx.LockTaskByTaskItemID(ctx, taskItemID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return pgtype.UUID{}, domainerrors.NotFound("Task item not found")
+		}
+		return pgtype.UUID{}, fmt.Errorf("failed to lock task: %w", err)
+	}
+	if err := task.CheckVersion(row.Version, expectedVersion); err != nil {
+		return pgtype.UUID{}, err
+	}
+	return row.ID, nil
+}
+
+// LockTask タスクの行をロック（TxManagerのトランザクション内で呼び出した場合はトランザクションの終了まで保持される）
+func (r *TaskRepository) LockTask(ctx context.Context, taskID string) error {
+	taskPgUUID, err := pgUUIDFromString(taskID, "task_id")
+	if err != nil {
+		return err
+	}
+	return checkTaskVersion(ctx, queriesFor(ctx, r.queries), taskPgUUID, nil)
+}
+
+// GetTaskByTaskItemID タスクアイテムIDからタスクを取得
+func (r *TaskRepository) GetTaskByTaskItemID(ctx context.Context, taskItemID string) (*task.Task, error) {
+	// taskItemIDをUUIDに変換
+	taskItemUUID, err := uuid.Parse(taskItemID)
+	if err != nil {
+		return nil, domainerrors.Validation("invalid task_item_id").Wrap(err)
+	}
+	var taskItemPgUUID pgtype.UUID
+	if err := taskItemPgUUID.Scan(taskItemUUID.String()); err != nil {
+		return nil, fmt.Errorf("failed to convert task_item_id to pgtype.UUID: %w", err)
+	}
+
+	// タスクを取得
+	t, err := queriesFor(ctx, r.queries).GetTaskByTaskItemID(ctx, taskItemPgUUID)
+	if err != nil {
+		// pgx.ErrNoRowsの場合はNotFoundエラーを返す（タスクが見つからない）
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainerrors.NotFound("Task not found")
+		}
+		return nil, err
+	}
+
+	// タスクアイテムを取得
+	taskItemsMap, err := r.getTaskItemsByTaskIDs(ctx, []pgtype.UUID{t.ID})
+	if err != nil {
+		return nil, err
+	}
+	taskItemEntities := taskItemsMap[UUIDFromPgtype(t.ID)]
+	if taskItemEntities == nil {
+		taskItemEntities = []task.TaskItem{}
+	}
 
 	var review *string
 	if t.Review.Valid {
@@ -630,21 +1134,17 @@ func (r *TaskRepository) GetTaskByTaskItemID(ctx context.Context, taskItemID str
 		Date:      t.Date.Time,
 		Review:    review,
 		TaskItems: taskItemEntities,
+		Version:   t.Version,
 		CreatedAt: t.CreatedAt.Time,
 		UpdatedAt: t.UpdatedAt.Time,
 	}, nil
 }
 
 // UpdateTaskReview タスクの振り返りを更新
-func (r *TaskRepository) UpdateTaskReview(ctx context.Context, taskID string, review *string) error {
-	// taskIDをUUIDに変換
-	taskUUID, err := uuid.Parse(taskID)
+func (r *TaskRepository) UpdateTaskReview(ctx context.Context, taskID string, actorID string, review *string, expectedVersion *int64) error {
+	taskPgUUID, err := pgUUIDFromString(taskID, "task_id")
 	if err != nil {
-		return fmt.Errorf("invalid task_id: %w", err)
-	}
-	var taskPgUUID pgtype.UUID
-	if err := taskPgUUID.Scan(taskUUID.String()); err != nil {
-		return fmt.Errorf("failed to convert task_id to pgtype.UUID: %w", err)
+		return err
 	}
 
 	// reviewをstringに変換（nilの場合は空文字列、NULLIFによりNULLに変換される）
@@ -653,58 +1153,516 @@ func (r *TaskRepository) UpdateTaskReview(ctx context.Context, taskID string, re
 		reviewStr = *review
 	}
 
-	// タスクの振り返りを更新
-	_, err = r.queries.UpdateTaskReview(ctx, dbgen.UpdateTaskReviewParams{
-		TaskID: taskPgUUID,
-		Review: reviewStr,
+	return r.runInTx(ctx, func(qtx *dbgen.Queries) error {
+		// タスクの行をロックしてバージョンを確認
+		if err := checkTaskVersion(ctx, qtx, taskPgUUID, expectedVersion); err != nil {
+			return err
+		}
+
+		before, err := loadTaskInTx(ctx, qtx, taskPgUUID)
+		if err != nil {
+			return err
+		}
+
+		// タスクの振り返りを更新
+		if _, err := qtx.UpdateTaskReview(ctx, dbgen.UpdateTaskReviewParams{
+			TaskID: taskPgUUID,
+			Review: reviewStr,
+		}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domainerrors.NotFound("Task not found")
+			}
+			return fmt.Errorf("failed to update task review: %w", err)
+		}
+
+		return recordTaskEventsSince(ctx, qtx, before, actorID)
 	})
+}
+
+// UpdateTaskItemOutput タスクアイテムのアウトプットとステータスを更新
+// sectionsがnilの場合は自由形式のアウトプットとして保存する
+func (r *TaskRepository) UpdateTaskItemOutput(ctx context.Context, taskItemID string, actorID string, output string, sections []outputtemplate.FilledSection, change task.StatusChange, expectedVersion *int64) error {
+	taskItemPgUUID, err := pgUUIDFromString(taskItemID, "task_item_id")
 	if err != nil {
-		return fmt.Errorf("failed to update task review: %w", err)
+		return err
 	}
 
-	return nil
+	sectionsJSON, err := marshalOutputSections(sections)
+	if err != nil {
+		return err
+	}
+
+	return r.runInTx(ctx, func(qtx *dbgen.Queries) error {
+		// タスクの行をロックしてバージョンを確認
+		taskPgUUID, err := checkTaskVersionByTaskItemID(ctx, qtx, taskItemPgUUID, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		before, err := loadTaskInTx(ctx, qtx, taskPgUUID)
+		if err != nil {
+			return err
+		}
+
+		// タスクアイテムのアウトプットとステータスを更新
+		if _, err := qtx.UpdateTaskItemOutput(ctx, dbgen.UpdateTaskItemOutputParams{
+			TaskItemID:     taskItemPgUUID,
+			Output:         output,
+			OutputSections: sectionsJSON,
+			Status:         string(change.Status),
+			StartedAt:      nullablePgTimestamptz(change.StartedAt),
+			CompletedAt:    nullablePgTimestamptz(change.CompletedAt),
+		}); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domainerrors.NotFound("Task item not found")
+			}
+			return fmt.Errorf("failed to update task item output: %w", err)
+		}
+		if err := stopTimerIfCompleted(ctx, qtx, taskItemPgUUID); err != nil {
+			return err
+		}
+
+		if err := touchTask(ctx, qtx, taskPgUUID); err != nil {
+			return err
+		}
+		return recordTaskEventsSince(ctx, qtx, before, actorID)
+	})
 }
 
-// UpdateTaskItemOutput タスクアイテムのアウトプットを更新
-func (r *TaskRepository) UpdateTaskItemOutput(ctx context.Context, taskItemID string, output string) error {
-	// taskItemIDをUUIDに変換
-	taskItemUUID, err := uuid.Parse(taskItemID)
+// DeleteTask タスクをゴミ箱に移動
+func (r *TaskRepository) DeleteTask(ctx context.Context, taskID string, actorID string, expectedVersion *int64) error {
+	taskPgUUID, err := pgUUIDFromString(taskID, "task_id")
 	if err != nil {
-		return fmt.Errorf("invalid task_item_id: %w", err)
+		return err
 	}
-	var taskItemPgUUID pgtype.UUID
-	if err := taskItemPgUUID.Scan(taskItemUUID.String()); err != nil {
-		return fmt.Errorf("failed to convert task_item_id to pgtype.UUID: %w", err)
+
+	return r.runInTx(ctx, func(qtx *dbgen.Queries) error {
+		// タスクの行をロックしてバージョンを確認
+		if err := checkTaskVersion(ctx, qtx, taskPgUUID, expectedVersion); err != nil {
+			return err
+		}
+
+		// タスクをゴミ箱に移動（子タスクやアウトプットは復元できるように残す）
+		rows, err := qtx.TrashTask(ctx, taskPgUUID)
+		if err != nil {
+			return fmt.Errorf("failed to trash task: %w", err)
+		}
+		if rows == 0 {
+			return domainerrors.NotFound("Task not found")
+		}
+		return createTaskEvent(ctx, qtx, task.TrashEvent(taskID, actorID, false))
+	})
+}
+
+// ListTrashedTasks オーナーのゴミ箱のタスクを移動した日時の新しい順に取得
+func (r *TaskRepository) ListTrashedTasks(ctx context.Context, ownerID string) ([]*task.Task, error) {
+	ownerPgUUID, err := pgUUIDFromString(ownerID, "owner_id")
+	if err != nil {
+		return nil, err
 	}
 
-	// タスクアイテムのアウトプットとステータスを更新（ステータスはCompletedに）
-	_, err = r.queries.UpdateTaskItemOutput(ctx, dbgen.UpdateTaskItemOutputParams{
-		TaskItemID: taskItemPgUUID,
-		Output:     output,
+	rows, err := queriesFor(ctx, r.queries).ListTrashedTasks(ctx, ownerPgUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trashed tasks: %w", err)
+	}
+
+	taskIDs := make([]pgtype.UUID, 0, len(rows))
+	for _, row := range rows {
+		taskIDs = append(taskIDs, row.ID)
+	}
+	taskItemsMap, err := r.getTaskItemsByTaskIDs(ctx, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*task.Task, 0, len(rows))
+	for _, row := range rows {
+		tasks = append(tasks, toTrashedTaskEntity(row, taskItemsMap[UUIDFromPgtype(row.ID)]))
+	}
+	return tasks, nil
+}
+
+// GetTrashedTaskByID ゴミ箱のタスクを取得（ゴミ箱にない場合はNotFound）
+func (r *TaskRepository) GetTrashedTaskByID(ctx context.Context, taskID string) (*task.Task, error) {
+	taskPgUUID, err := pgUUIDFromString(taskID, "task_id")
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := queriesFor(ctx, r.queries).GetTrashedTaskByID(ctx, taskPgUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domainerrors.NotFound("Task not found in trash")
+		}
+		return nil, fmt.Errorf("failed to get trashed task: %w", err)
+	}
+
+	taskItemsMap, err := r.getTaskItemsByTaskIDs(ctx, []pgtype.UUID{row.ID})
+	if err != nil {
+		return nil, err
+	}
+	return toTrashedTaskEntity(dbgen.ListTrashedTasksRow(row), taskItemsMap[UUIDFromPgtype(row.ID)]), nil
+}
+
+// RestoreTask ゴミ箱のタスクを元に戻す
+func (r *TaskRepository) RestoreTask(ctx context.Context, taskID string, actorID string) error {
+	taskPgUUID, err := pgUUIDFromString(taskID, "task_id")
+	if err != nil {
+		return err
+	}
+
+	return r.runInTx(ctx, func(qtx *dbgen.Queries) error {
+		rows, err := qtx.RestoreTask(ctx, taskPgUUID)
+		if err != nil {
+			return fmt.Errorf("failed to restore task: %w", err)
+		}
+		if rows == 0 {
+			return domainerrors.NotFound("Task not found in trash")
+		}
+		return createTaskEvent(ctx, qtx, task.TrashEvent(taskID, actorID, true))
+	})
+}
+
+// PurgeTask ゴミ箱のタスクを完全に削除（変更履歴は残し、削除を記録する）
+func (r *TaskRepository) PurgeTask(ctx context.Context, taskID string, actorID string) error {
+	taskPgUUID, err := pgUUIDFromString(taskID, "task_id")
+	if err != nil {
+		return err
+	}
+
+	return r.runInTx(ctx, func(qtx *dbgen.Queries) error {
+		row, err := qtx.GetTrashedTaskByID(ctx, taskPgUUID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domainerrors.NotFound("Task not found in trash")
+			}
+			return fmt.Errorf("failed to get trashed task: %w", err)
+		}
+		return purgeTaskInTx(ctx, qtx, &task.Task{ID: taskID, Title: row.Title, Date: row.Date.Time}, actorID)
+	})
+}
+
+// PurgeTrashedTasks 指定した日時より前にゴミ箱に移動したタスクを完全に削除し、削除した件数を返す
+// 保持期間による削除は、タスクのオーナーの操作として変更履歴に記録する
+func (r *TaskRepository) PurgeTrashedTasks(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	var purged int64
+	err := r.runInTx(ctx, func(qtx *dbgen.Queries) error {
+		rows, err := qtx.ListExpiredTrashedTasks(ctx, pgtype.Timestamptz{Time: deletedBefore, Valid: true})
+		if err != nil {
+			return fmt.Errorf("failed to list expired trashed tasks: %w", err)
+		}
+		for _, row := range rows {
+			t := &task.Task{ID: UUIDFromPgtype(row.ID), Title: row.Title, Date: row.Date.Time}
+			if err := purgeTaskInTx(ctx, qtx, t, UUIDFromPgtype(row.OwnerID)); err != nil {
+				return err
+			}
+		}
+		purged = int64(len(rows))
+		return nil
 	})
 	if err != nil {
-		return fmt.Errorf("failed to update task item output: %w", err)
+		return 0, err
 	}
+	return purged, nil
+}
 
+// purgeTaskInTx トランザクション内でゴミ箱のタスクを完全に削除し、変更履歴に記録
+// ON DELETE CASCADEにより、子タスクも自動的に削除される
+func purgeTaskInTx(ctx context.Context, qtx *dbgen.Queries, t *task.Task, actorID string) error {
+	taskPgUUID, err := pgUUIDFromString(t.ID, "task_id")
+	if err != nil {
+		return err
+	}
+	rows, err := qtx.PurgeTask(ctx, taskPgUUID)
+	if err != nil {
+		return fmt.Errorf("failed to purge task: %w", err)
+	}
+	if rows == 0 {
+		return domainerrors.NotFound("Task not found in trash")
+	}
+	return createTaskEvent(ctx, qtx, task.PurgeEvent(t, actorID))
+}
+
+// toTrashedTaskEntity ゴミ箱のタスクをドメインエンティティに変換
+func toTrashedTaskEntity(row dbgen.ListTrashedTasksRow, taskItems []task.TaskItem) *task.Task {
+	if taskItems == nil {
+		taskItems = []task.TaskItem{}
+	}
+
+	var review *string
+	if row.Review.Valid {
+		review = &row.Review.String
+	}
+
+	return &task.Task{
+		ID:        UUIDFromPgtype(row.ID),
+		OwnerID:   UUIDFromPgtype(row.OwnerID),
+		Title:     row.Title,
+		Date:      row.Date.Time,
+		Review:    review,
+		TaskItems: taskItems,
+		Version:   row.Version,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+		DeletedAt: nullableTimeFromPgtype(row.DeletedAt),
+	}
+}
+
+// getTaskItemsByTaskIDs タスクアイテムをタイマーのセッションと合わせて取得し、タスクIDでグループ化
+func (r *TaskRepository) getTaskItemsByTaskIDs(ctx context.Context, taskIDs []pgtype.UUID) (map[string][]task.TaskItem, error) {
+	return loadTaskItems(ctx, queriesFor(ctx, r.queries), taskIDs)
+}
+
+// loadTaskItems タスクIDのリストからタスクアイテムを取得してタスクIDでグループ化
+// トランザクション内で使用する場合はトランザクションのクエリを渡す
+func loadTaskItems(ctx context.Context, queries *dbgen.Queries, taskIDs []pgtype.UUID) (map[string][]task.TaskItem, error) {
+	taskItems, err := queries.GetTaskItemsByTaskIDs(ctx, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(taskItems) == 0 {
+		return map[string][]task.TaskItem{}, nil
+	}
+
+	// タイマーのセッションを取得してタスクアイテムIDでグループ化
+	taskItemIDs := make([]pgtype.UUID, 0, len(taskItems))
+	for _, item := range taskItems {
+		taskItemIDs = append(taskItemIDs, item.ID)
+	}
+	sessions, err := queries.GetTaskItemSessionsByTaskItemIDs(ctx, taskItemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task item sessions: %w", err)
+	}
+	sessionsMap := make(map[string][]task.TimerSession)
+	for _, session := range sessions {
+		taskItemID := UUIDFromPgtype(session.TaskItemID)
+		sessionsMap[taskItemID] = append(sessionsMap[taskItemID], toTimerSessionEntity(session))
+	}
+
+	result := make(map[string][]task.TaskItem)
+	for _, item := range taskItems {
+		entity, err := toTaskItemEntity(item)
+		if err != nil {
+			return nil, err
+		}
+		entity.TimerSessions = sessionsMap[entity.ID]
+
+		taskID := UUIDFromPgtype(item.TaskID)
+		result[taskID] = append(result[taskID], entity)
+	}
+	return result, nil
+}
+
+// ApplyTimerTransition タイマーの操作による変更をトランザクション内で永続化
+func (r *TaskRepository) ApplyTimerTransition(ctx context.Context, taskItemID string, actorID string, transition task.TimerTransition, at time.Time) error {
+	taskItemPgUUID, err := pgUUIDFromString(taskItemID, "task_item_id")
+	if err != nil {
+		return err
+	}
+	atPg := pgtype.Timestamptz{Time: at, Valid: true}
+
+	return r.runInTx(ctx, func(qtx *dbgen.Queries) error {
+		// タスクの行をロック（タイマーの操作はバージョンを確認しない）
+		taskPgUUID, err := checkTaskVersionByTaskItemID(ctx, qtx, taskItemPgUUID, nil)
+		if err != nil {
+			return err
+		}
+
+		// 変更履歴のため、ロック後の変更前のタスクを取得（記録するのはステータスの変更のみ）
+		before, err := loadTaskInTx(ctx, qtx, taskPgUUID)
+		if err != nil {
+			return err
+		}
+
+		// 最新のセッションを終了
+		if transition.EndReason != nil {
+			if err := qtx.EndLatestTaskItemSession(ctx, dbgen.EndLatestTaskItemSessionParams{
+				TaskItemID: taskItemPgUUID,
+				EndedAt:    atPg,
+				EndReason:  string(*transition.EndReason),
+			}); err != nil {
+				return fmt.Errorf("failed to end task item session: %w", err)
+			}
+		}
+
+		// 新しいセッションを開始（同時に開始された場合は実行中のセッションの一意制約に違反する）
+		if transition.StartSession {
+			if err := qtx.CreateTaskItemSession(ctx, dbgen.CreateTaskItemSessionParams{
+				TaskItemID: taskItemPgUUID,
+				StartedAt:  atPg,
+			}); err != nil {
+				if isUniqueViolation(err) {
+					return domainerrors.Conflict("Timer is already running").Wrap(err)
+				}
+				return fmt.Errorf("failed to create task item session: %w", err)
+			}
+		}
+
+		// ステータスを更新
+		if err := qtx.UpdateTaskItemStatus(ctx, dbgen.UpdateTaskItemStatusParams{
+			TaskItemID: taskItemPgUUID,
+			Status:     string(transition.Status),
+			ChangedAt:  atPg,
+		}); err != nil {
+			return fmt.Errorf("failed to update task item status: %w", err)
+		}
+
+		if err := touchTask(ctx, qtx, taskPgUUID); err != nil {
+			return err
+		}
+		return recordTaskEventsSince(ctx, qtx, before, actorID)
+	})
+}
+
+// stopTimerIfCompleted 完了したタスクアイテムの実行中のセッションを完了日時で停止
+func stopTimerIfCompleted(ctx context.Context, qtx *dbgen.Queries, taskItemID pgtype.UUID) error {
+	if err := qtx.StopRunningTaskItemSession(ctx, taskItemID); err != nil {
+		return fmt.Errorf("failed to stop task item session: %w", err)
+	}
 	return nil
 }
 
-// DeleteTask タスクを削除
-func (r *TaskRepository) DeleteTask(ctx context.Context, taskID string) error {
-	// taskIDをUUIDに変換
-	taskUUID, err := uuid.Parse(taskID)
+// runInTx トランザクション内で処理を実行（エラーの場合はロールバック）
+// コンテキストに実行中のトランザクションがある場合は、セーブポイントを作らずにそのトランザクションに参加する
+// そのためエラーの場合は一部だけを取り消さず、外側のトランザクション全体がロールバックされる
+func (r *TaskRepository) runInTx(ctx context.Context, fn func(qtx *dbgen.Queries) error) error {
+	return withinTx(ctx, r.db, func(ctx context.Context, tx pgx.Tx) error {
+		return fn(r.queries.WithTx(tx))
+	})
+}
+
+// toTimerSessionEntity DBのセッションをドメインのVOに変換
+func toTimerSessionEntity(s dbgen.TaskItemSession) task.TimerSession {
+	session := task.TimerSession{
+		ID:        UUIDFromPgtype(s.ID),
+		StartedAt: s.StartedAt.Time,
+	}
+	if s.EndedAt.Valid {
+		endedAt := s.EndedAt.Time
+		session.EndedAt = &endedAt
+	}
+	if s.EndReason.Valid {
+		reason := task.TimerEndReason(s.EndReason.String)
+		session.EndReason = &reason
+	}
+	return session
+}
+
+// nullablePgTimestamptz 日時をpgtype.Timestamptzに変換（nilの場合はNULL）
+func nullablePgTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
+
+// nullableTimeFromPgtype pgtype.Timestamptzを日時に変換（NULLの場合はnil）
+func nullableTimeFromPgtype(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	v := t.Time
+	return &v
+}
+
+// toTaskItemEntity DBのタスクアイテムをドメインエンティティに変換
+func toTaskItemEntity(item dbgen.GetTaskItemsByTaskIDsRow) (task.TaskItem, error) {
+	var output *string
+	if item.Output.Valid {
+		output = &item.Output.String
+	}
+
+	outputSections, err := unmarshalOutputSections(item.OutputSections)
+	if err != nil {
+		return task.TaskItem{}, err
+	}
+
+	return task.TaskItem{
+		ID:                    UUIDFromPgtype(item.ID),
+		TaskID:                UUIDFromPgtype(item.TaskID),
+		Priority:              task.Priority(item.Priority),
+		Density:               task.Density(item.Density),
+		DurationTime:          task.DurationTime(item.DurationTime),
+		Content:               item.Content,
+		Output:                output,
+		IsRequired:            item.IsRequired,
+		Order:                 item.Order,
+		Status:                task.Status(item.Status),
+		CategoryID:            nullableUUIDFromPgtype(item.CategoryID),
+		OutputTemplateID:      nullableUUIDFromPgtype(item.OutputTemplateID),
+		OutputSections:        outputSections,
+		CarriedOverFromTaskID: nullableUUIDFromPgtype(item.CarriedOverFromTaskID),
+		StartedAt:             nullableTimeFromPgtype(item.StartedAt),
+		CompletedAt:           nullableTimeFromPgtype(item.CompletedAt),
+		CreatedAt:             item.CreatedAt.Time,
+		UpdatedAt:             item.UpdatedAt.Time,
+	}, nil
+}
+
+// outputSectionRecord task_items.output_sectionsに保存するセクション
+type outputSectionRecord struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// marshalOutputSections 入力されたセクションをJSONに変換（nilの場合はNULLとして保存するためnilを返す）
+func marshalOutputSections(sections []outputtemplate.FilledSection) ([]byte, error) {
+	if sections == nil {
+		return nil, nil
+	}
+
+	records := make([]outputSectionRecord, 0, len(sections))
+	for _, s := range sections {
+		records = append(records, outputSectionRecord{Key: s.Key, Label: s.Label, Value: s.Value})
+	}
+
+	data, err := json.Marshal(records)
 	if err != nil {
-		return fmt.Errorf("invalid task_id: %w", err)
+		return nil, fmt.Errorf("failed to marshal output sections: %w", err)
 	}
-	var taskPgUUID pgtype.UUID
-	if err := taskPgUUID.Scan(taskUUID.String()); err != nil {
-		return fmt.Errorf("failed to convert task_id to pgtype.UUID: %w", err)
+	return data, nil
+}
+
+// unmarshalOutputSections JSONから入力されたセクションに変換（NULLの場合はnilを返す）
+func unmarshalOutputSections(data []byte) ([]outputtemplate.FilledSection, error) {
+	if data == nil {
+		return nil, nil
 	}
 
-	// タスクを削除（ON DELETE CASCADEにより、子タスクも自動的に削除される）
-	if err := r.queries.DeleteTask(ctx, taskPgUUID); err != nil {
-		return fmt.Errorf("failed to delete task: %w", err)
+	var records []outputSectionRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal output sections: %w", err)
 	}
 
+	sections := make([]outputtemplate.FilledSection, 0, len(records))
+	for _, record := range records {
+		sections = append(sections, outputtemplate.FilledSection{Key: record.Key, Label: record.Label, Value: record.Value})
+	}
+	return sections, nil
+}
+
+// setListTasksCursor カーソルの位置をクエリパラメータに設定
+func setListTasksCursor(params *dbgen.ListTasksParams, cursor *task.ListTasksCursor) error {
+	if err := params.CursorID.Scan(cursor.ID); err != nil {
+		return domainerrors.Validation("invalid cursor").Wrap(err)
+	}
+
+	date, err := time.Parse(time.DateOnly, cursor.Date)
+	if err != nil {
+		return domainerrors.Validation("invalid cursor").Wrap(err)
+	}
+	params.CursorDate = pgtype.Date{Time: date, Valid: true}
+	params.CursorCreatedAt = pgtype.Timestamptz{Time: cursor.CreatedAt, Valid: true}
+	params.CursorRank = pgtype.Float4{Float32: cursor.Rank, Valid: true}
+
 	return nil
 }
+
+// likePatternEscaper LIKEのワイルドカードをエスケープする
+var likePatternEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLikePattern キーワードをLIKEのパターン内で文字どおりに一致させるためにエスケープ
+func escapeLikePattern(keyword string) string {
+	return likePatternEscaper.Replace(keyword)
+}
@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	dbgen "task-management-system/backend/internal/adapter/gateway/db/sqlc/generated"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// txContextKey コンテキストに実行中のトランザクションを保持するキー
+type txContextKey struct{}
+
+// txBeginner トランザクションを開始できる接続（pgxpool.Pool、pgx.Conn、pgx.Tx）
+type txBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// TxManager pgxを使用したトランザクション管理
+type TxManager struct {
+	db dbgen.DBTX
+}
+
+// NewTxManager トランザクション管理を作成
+func NewTxManager(db dbgen.DBTX) *TxManager {
+	return &TxManager{db: db}
+}
+
+// WithinTransaction fnを1つのトランザクションで実行
+// fnに渡すコンテキストを使用したリポジトリの操作は、すべて同じトランザクションで実行される
+func (m *TxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return withinTx(ctx, m.db, func(ctx context.Context, tx pgx.Tx) error {
+		return fn(ctx)
+	})
+}
+
+// withinTx トランザクション内でfnを実行
+// コンテキストに実行中のトランザクションがある場合はそのトランザクションに参加し、コミットは開始した側で行う
+func withinTx(ctx context.Context, db dbgen.DBTX, fn func(ctx context.Context, tx pgx.Tx) error) error {
+	if tx, ok := txFromContext(ctx); ok {
+		return fn(ctx, tx)
+	}
+
+	beginner, ok := db.(txBeginner)
+	if !ok {
+		return fmt.Errorf("unsupported database connection type for transaction: %T", db)
+	}
+
+	tx, err := beginner.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(context.WithValue(ctx, txContextKey{}, tx), tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// txFromContext コンテキストから実行中のトランザクションを取得
+func txFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(pgx.Tx)
+	return tx, ok
+}
+
+// queriesFor コンテキストに実行中のトランザクションがある場合は、そのトランザクションでクエリを実行する
+func queriesFor(ctx context.Context, queries *dbgen.Queries) *dbgen.Queries {
+	if tx, ok := txFromContext(ctx); ok {
+		return queries.WithTx(tx)
+	}
+	return queries
+}
@@ -0,0 +1,145 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+
+	"task-management-system/backend/internal/domain/account"
+	domainerrors "task-management-system/backend/internal/domain/errors"
+)
+
+// accountRecord アカウントの行（認証プロバイダーのアカウントを含む）
+type accountRecord struct {
+	account           account.Account
+	provider          string
+	providerAccountID string
+}
+
+// AccountRepository アカウントリポジトリ
+type AccountRepository struct {
+	store *Store
+}
+
+// NewAccountRepository アカウントリポジトリを作成
+func NewAccountRepository(store *Store) *AccountRepository {
+	return &AccountRepository{store: store}
+}
+
+// GetAccountsByIDs アカウントIDのリストでアカウントを取得（存在しないIDは無視する）
+func (r *AccountRepository) GetAccountsByIDs(ctx context.Context, accountIDs []string) ([]*account.Account, error) {
+	ids := make([]string, 0, len(accountIDs))
+	for _, id := range accountIDs {
+		parsed, err := parseID(id, "account_id")
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, parsed)
+	}
+
+	result := make([]*account.Account, 0, len(ids))
+	err := r.store.read(ctx, func(t *tables) error {
+		seen := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			rec, ok := t.accounts[id]
+			if !ok || seen[id] {
+				continue
+			}
+			seen[id] = true
+			a := cloneAccount(rec.account)
+			result = append(result, &a)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetAccountByID アカウントIDでアカウントを取得
+func (r *AccountRepository) GetAccountByID(ctx context.Context, accountID string) (*account.Account, error) {
+	id, err := parseID(accountID, "account_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var result *account.Account
+	err = r.store.read(ctx, func(t *tables) error {
+		rec, ok := t.accounts[id]
+		if !ok {
+			return domainerrors.NotFound("Account not found")
+		}
+		a := cloneAccount(rec.account)
+		result = &a
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetAccountByEmail メールアドレスでアカウントを取得
+func (r *AccountRepository) GetAccountByEmail(ctx context.Context, email string) (*account.Account, error) {
+	var result *account.Account
+	err := r.store.read(ctx, func(t *tables) error {
+		for _, rec := range t.accounts {
+			if rec.account.Email == email {
+				a := cloneAccount(rec.account)
+				result = &a
+				return nil
+			}
+		}
+		return domainerrors.NotFound("Account not found")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateAccount アカウントを作成
+// メールアドレス、または認証プロバイダーのアカウントが既に登録されている場合はConflictエラーを返す
+func (r *AccountRepository) CreateAccount(ctx context.Context, email string, firstName string, lastName string, provider string, providerAccountID string, thumbnail *string) (*account.Account, error) {
+	var result *account.Account
+	err := r.store.write(ctx, func(t *tables) error {
+		for _, rec := range t.accounts {
+			if rec.account.Email == email || (rec.provider == provider && rec.providerAccountID == providerAccountID) {
+				return domainerrors.Conflict("Account already exists")
+			}
+		}
+
+		now := r.store.now()
+		rec := &accountRecord{
+			account: account.Account{
+				ID:          newID(),
+				Email:       email,
+				FirstName:   firstName,
+				LastName:    lastName,
+				Thumbnail:   cloneString(thumbnail),
+				LastLoginAt: &now,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			},
+			provider:          provider,
+			providerAccountID: providerAccountID,
+		}
+		t.accounts[rec.account.ID] = rec
+
+		a := cloneAccount(rec.account)
+		result = &a
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// requireAccount アカウントが存在することを確認（存在しない場合は外部キー制約違反のエラー）
+func (t *tables) requireAccount(accountID string, field string) error {
+	if _, ok := t.accounts[accountID]; !ok {
+		return fmt.Errorf("%s %s does not exist: %w", field, accountID, errForeignKeyViolation)
+	}
+	return nil
+}
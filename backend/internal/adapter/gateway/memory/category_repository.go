@@ -0,0 +1,203 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"task-management-system/backend/internal/domain/category"
+	domainerrors "task-management-system/backend/internal/domain/errors"
+)
+
+// CategoryRepository カテゴリリポジトリ
+type CategoryRepository struct {
+	store *Store
+}
+
+// NewCategoryRepository カテゴリリポジトリを作成
+func NewCategoryRepository(store *Store) *CategoryRepository {
+	return &CategoryRepository{store: store}
+}
+
+// ListCategories オーナーのカテゴリ一覧を取得（名前順）
+func (r *CategoryRepository) ListCategories(ctx context.Context, ownerID string) ([]*category.Category, error) {
+	owner, err := parseID(ownerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*category.Category{}
+	err = r.store.read(ctx, func(t *tables) error {
+		for _, c := range t.categories {
+			if c.OwnerID == owner {
+				copied := *c
+				result = append(result, &copied)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(result, func(a, b *category.Category) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return result, nil
+}
+
+// GetCategoryByID カテゴリIDでカテゴリを取得
+func (r *CategoryRepository) GetCategoryByID(ctx context.Context, categoryID string) (*category.Category, error) {
+	id, err := parseID(categoryID, "category_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var result *category.Category
+	err = r.store.read(ctx, func(t *tables) error {
+		c, ok := t.categories[id]
+		if !ok {
+			return domainerrors.NotFound("Category not found")
+		}
+		copied := *c
+		result = &copied
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetCategoriesByIDs カテゴリIDのリストでカテゴリを取得（存在しないIDは無視する）
+func (r *CategoryRepository) GetCategoriesByIDs(ctx context.Context, categoryIDs []string) ([]*category.Category, error) {
+	ids := make([]string, 0, len(categoryIDs))
+	for _, id := range categoryIDs {
+		parsed, err := parseID(id, "category_id")
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, parsed)
+	}
+
+	result := make([]*category.Category, 0, len(ids))
+	err := r.store.read(ctx, func(t *tables) error {
+		seen := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			c, ok := t.categories[id]
+			if !ok || seen[id] {
+				continue
+			}
+			seen[id] = true
+			copied := *c
+			result = append(result, &copied)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateCategory カテゴリを作成
+func (r *CategoryRepository) CreateCategory(ctx context.Context, ownerID string, name string, color string) (*category.Category, error) {
+	owner, err := parseID(ownerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var result *category.Category
+	err = r.store.write(ctx, func(t *tables) error {
+		if err := t.requireAccount(owner, "owner_id"); err != nil {
+			return err
+		}
+		if t.categoryNameExists(owner, name, "") {
+			return domainerrors.Conflict("Category with the same name already exists")
+		}
+
+		now := r.store.now()
+		c := &category.Category{
+			ID:        newID(),
+			OwnerID:   owner,
+			Name:      name,
+			Color:     color,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		t.categories[c.ID] = c
+
+		copied := *c
+		result = &copied
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateCategory カテゴリを更新
+func (r *CategoryRepository) UpdateCategory(ctx context.Context, categoryID string, name string, color string) (*category.Category, error) {
+	id, err := parseID(categoryID, "category_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var result *category.Category
+	err = r.store.write(ctx, func(t *tables) error {
+		c, ok := t.categories[id]
+		if !ok {
+			return domainerrors.NotFound("Category not found")
+		}
+		if t.categoryNameExists(c.OwnerID, name, id) {
+			return domainerrors.Conflict("Category with the same name already exists")
+		}
+
+		c.Name = name
+		c.Color = color
+		c.UpdatedAt = r.store.now()
+
+		copied := *c
+		result = &copied
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteCategory カテゴリを削除（このカテゴリのタスクアイテムは未分類になる）
+func (r *CategoryRepository) DeleteCategory(ctx context.Context, categoryID string) error {
+	id, err := parseID(categoryID, "category_id")
+	if err != nil {
+		return err
+	}
+
+	return r.store.write(ctx, func(t *tables) error {
+		delete(t.categories, id)
+		for _, rec := range t.tasks {
+			for i := range rec.task.TaskItems {
+				item := &rec.task.TaskItems[i]
+				if item.CategoryID != nil && *item.CategoryID == id {
+					item.CategoryID = nil
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// categoryNameExists オーナーに同名のカテゴリが存在するか判定（大文字と小文字は区別しない。excludeIDのカテゴリは除く）
+func (t *tables) categoryNameExists(ownerID string, name string, excludeID string) bool {
+	for _, c := range t.categories {
+		if c.ID != excludeID && c.OwnerID == ownerID && strings.EqualFold(c.Name, name) {
+			return true
+		}
+	}
+	return false
+}
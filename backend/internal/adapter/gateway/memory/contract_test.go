@@ -0,0 +1,25 @@
+package memory
+
+import (
+	"testing"
+
+	"task-management-system/backend/internal/port/repository/repositorytest"
+)
+
+func newRepositories(t *testing.T) repositorytest.Repositories {
+	store := NewStore()
+	return repositorytest.Repositories{
+		TxManager:  NewTxManager(store),
+		Tasks:      NewTaskRepository(store),
+		Accounts:   NewAccountRepository(store),
+		Categories: NewCategoryRepository(store),
+	}
+}
+
+func TestTaskRepository_Contract(t *testing.T) {
+	repositorytest.RunTaskRepositoryTests(t, newRepositories)
+}
+
+func TestAccountRepository_Contract(t *testing.T) {
+	repositorytest.RunAccountRepositoryTests(t, newRepositories)
+}
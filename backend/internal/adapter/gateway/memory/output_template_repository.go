@@ -0,0 +1,206 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/outputtemplate"
+)
+
+// OutputTemplateRepository アウトプットテンプレートリポジトリ
+type OutputTemplateRepository struct {
+	store *Store
+}
+
+// NewOutputTemplateRepository アウトプットテンプレートリポジトリを作成
+func NewOutputTemplateRepository(store *Store) *OutputTemplateRepository {
+	return &OutputTemplateRepository{store: store}
+}
+
+// ListOutputTemplates オーナーのテンプレート一覧を取得（名前順）
+func (r *OutputTemplateRepository) ListOutputTemplates(ctx context.Context, ownerID string) ([]*outputtemplate.OutputTemplate, error) {
+	owner, err := parseID(ownerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*outputtemplate.OutputTemplate{}
+	err = r.store.read(ctx, func(t *tables) error {
+		for _, tmpl := range t.outputTemplates {
+			if tmpl.OwnerID == owner {
+				result = append(result, cloneOutputTemplate(tmpl))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(result, func(a, b *outputtemplate.OutputTemplate) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return result, nil
+}
+
+// GetOutputTemplateByID テンプレートIDでテンプレートを取得
+func (r *OutputTemplateRepository) GetOutputTemplateByID(ctx context.Context, outputTemplateID string) (*outputtemplate.OutputTemplate, error) {
+	id, err := parseID(outputTemplateID, "output_template_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var result *outputtemplate.OutputTemplate
+	err = r.store.read(ctx, func(t *tables) error {
+		tmpl, ok := t.outputTemplates[id]
+		if !ok {
+			return domainerrors.NotFound("Output template not found")
+		}
+		result = cloneOutputTemplate(tmpl)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetOutputTemplatesByIDs テンプレートIDのリストでテンプレートを取得（存在しないIDは無視する）
+func (r *OutputTemplateRepository) GetOutputTemplatesByIDs(ctx context.Context, outputTemplateIDs []string) ([]*outputtemplate.OutputTemplate, error) {
+	ids := make([]string, 0, len(outputTemplateIDs))
+	for _, id := range outputTemplateIDs {
+		parsed, err := parseID(id, "output_template_id")
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, parsed)
+	}
+
+	result := make([]*outputtemplate.OutputTemplate, 0, len(ids))
+	err := r.store.read(ctx, func(t *tables) error {
+		seen := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			tmpl, ok := t.outputTemplates[id]
+			if !ok || seen[id] {
+				continue
+			}
+			seen[id] = true
+			result = append(result, cloneOutputTemplate(tmpl))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateOutputTemplate テンプレートを作成
+func (r *OutputTemplateRepository) CreateOutputTemplate(ctx context.Context, ownerID string, name string, sections []outputtemplate.Section) (*outputtemplate.OutputTemplate, error) {
+	owner, err := parseID(ownerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var result *outputtemplate.OutputTemplate
+	err = r.store.write(ctx, func(t *tables) error {
+		if err := t.requireAccount(owner, "owner_id"); err != nil {
+			return err
+		}
+		if t.outputTemplateNameExists(owner, name, "") {
+			return domainerrors.Conflict("Output template with the same name already exists")
+		}
+
+		now := r.store.now()
+		tmpl := &outputtemplate.OutputTemplate{
+			ID:        newID(),
+			OwnerID:   owner,
+			Name:      name,
+			Sections:  slices.Clone(sections),
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		t.outputTemplates[tmpl.ID] = tmpl
+
+		result = cloneOutputTemplate(tmpl)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateOutputTemplate テンプレートを更新
+// 既に入力されたアウトプットは入力時のセクションを保持しているため、更新の影響を受けない
+func (r *OutputTemplateRepository) UpdateOutputTemplate(ctx context.Context, outputTemplateID string, name string, sections []outputtemplate.Section) (*outputtemplate.OutputTemplate, error) {
+	id, err := parseID(outputTemplateID, "output_template_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var result *outputtemplate.OutputTemplate
+	err = r.store.write(ctx, func(t *tables) error {
+		tmpl, ok := t.outputTemplates[id]
+		if !ok {
+			return domainerrors.NotFound("Output template not found")
+		}
+		if t.outputTemplateNameExists(tmpl.OwnerID, name, id) {
+			return domainerrors.Conflict("Output template with the same name already exists")
+		}
+
+		tmpl.Name = name
+		tmpl.Sections = slices.Clone(sections)
+		tmpl.UpdatedAt = r.store.now()
+
+		result = cloneOutputTemplate(tmpl)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteOutputTemplate テンプレートを削除（このテンプレートのタスクアイテムは自由形式に戻る）
+func (r *OutputTemplateRepository) DeleteOutputTemplate(ctx context.Context, outputTemplateID string) error {
+	id, err := parseID(outputTemplateID, "output_template_id")
+	if err != nil {
+		return err
+	}
+
+	return r.store.write(ctx, func(t *tables) error {
+		delete(t.outputTemplates, id)
+		for _, rec := range t.tasks {
+			for i := range rec.task.TaskItems {
+				item := &rec.task.TaskItems[i]
+				if item.OutputTemplateID != nil && *item.OutputTemplateID == id {
+					item.OutputTemplateID = nil
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// outputTemplateNameExists オーナーに同名のテンプレートが存在するか判定（大文字と小文字は区別しない。excludeIDのテンプレートは除く）
+func (t *tables) outputTemplateNameExists(ownerID string, name string, excludeID string) bool {
+	for _, tmpl := range t.outputTemplates {
+		if tmpl.ID != excludeID && tmpl.OwnerID == ownerID && strings.EqualFold(tmpl.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// cloneOutputTemplate テンプレートをセクションを含めて複製
+func cloneOutputTemplate(tmpl *outputtemplate.OutputTemplate) *outputtemplate.OutputTemplate {
+	copied := *tmpl
+	copied.Sections = slices.Clone(tmpl.Sections)
+	return &copied
+}
@@ -0,0 +1,176 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/recurringtemplate"
+)
+
+// RecurringTemplateRepository 繰り返しテンプレートリポジトリ
+type RecurringTemplateRepository struct {
+	store *Store
+}
+
+// NewRecurringTemplateRepository 繰り返しテンプレートリポジトリを作成
+func NewRecurringTemplateRepository(store *Store) *RecurringTemplateRepository {
+	return &RecurringTemplateRepository{store: store}
+}
+
+// ListRecurringTemplates オーナーのテンプレート一覧を取得（作成順）
+func (r *RecurringTemplateRepository) ListRecurringTemplates(ctx context.Context, ownerID string) ([]*recurringtemplate.RecurringTemplate, error) {
+	owner, err := parseID(ownerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*recurringtemplate.RecurringTemplate{}
+	err = r.store.read(ctx, func(t *tables) error {
+		for _, tmpl := range t.recurringTemplates {
+			if tmpl.OwnerID == owner {
+				result = append(result, cloneRecurringTemplate(tmpl))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(result, func(a, b *recurringtemplate.RecurringTemplate) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return result, nil
+}
+
+// GetRecurringTemplateByID テンプレートIDでテンプレートを取得
+func (r *RecurringTemplateRepository) GetRecurringTemplateByID(ctx context.Context, recurringTemplateID string) (*recurringtemplate.RecurringTemplate, error) {
+	id, err := parseID(recurringTemplateID, "recurring_template_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var result *recurringtemplate.RecurringTemplate
+	err = r.store.read(ctx, func(t *tables) error {
+		tmpl, ok := t.recurringTemplates[id]
+		if !ok {
+			return domainerrors.NotFound("Recurring template not found")
+		}
+		result = cloneRecurringTemplate(tmpl)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateRecurringTemplate テンプレートを作成
+func (r *RecurringTemplateRepository) CreateRecurringTemplate(ctx context.Context, ownerID string, title string, rule recurringtemplate.Rule, taskItems []recurringtemplate.Item) (*recurringtemplate.RecurringTemplate, error) {
+	owner, err := parseID(ownerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var result *recurringtemplate.RecurringTemplate
+	err = r.store.write(ctx, func(t *tables) error {
+		if err := t.requireAccount(owner, "owner_id"); err != nil {
+			return err
+		}
+
+		now := r.store.now()
+		tmpl := cloneRecurringTemplate(&recurringtemplate.RecurringTemplate{
+			ID:        newID(),
+			OwnerID:   owner,
+			Title:     title,
+			Rule:      rule,
+			TaskItems: taskItems,
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		t.recurringTemplates[tmpl.ID] = tmpl
+
+		result = cloneRecurringTemplate(tmpl)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateRecurringTemplate テンプレートを更新
+// 既に作成されたタスクは更新の影響を受けない
+func (r *RecurringTemplateRepository) UpdateRecurringTemplate(ctx context.Context, recurringTemplateID string, title string, rule recurringtemplate.Rule, taskItems []recurringtemplate.Item) (*recurringtemplate.RecurringTemplate, error) {
+	id, err := parseID(recurringTemplateID, "recurring_template_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var result *recurringtemplate.RecurringTemplate
+	err = r.store.write(ctx, func(t *tables) error {
+		current, ok := t.recurringTemplates[id]
+		if !ok {
+			return domainerrors.NotFound("Recurring template not found")
+		}
+
+		tmpl := cloneRecurringTemplate(&recurringtemplate.RecurringTemplate{
+			ID:        current.ID,
+			OwnerID:   current.OwnerID,
+			Title:     title,
+			Rule:      rule,
+			TaskItems: taskItems,
+			CreatedAt: current.CreatedAt,
+			UpdatedAt: r.store.now(),
+		})
+		t.recurringTemplates[id] = tmpl
+
+		result = cloneRecurringTemplate(tmpl)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteRecurringTemplate テンプレートを削除（作成済みのタスクは通常のタスクとして残る）
+func (r *RecurringTemplateRepository) DeleteRecurringTemplate(ctx context.Context, recurringTemplateID string) error {
+	id, err := parseID(recurringTemplateID, "recurring_template_id")
+	if err != nil {
+		return err
+	}
+
+	return r.store.write(ctx, func(t *tables) error {
+		delete(t.recurringTemplates, id)
+		for _, rec := range t.tasks {
+			if rec.recurringTemplateID != nil && *rec.recurringTemplateID == id {
+				rec.recurringTemplateID = nil
+			}
+		}
+		return nil
+	})
+}
+
+// cloneRecurringTemplate テンプレートをルールと子タスクを含めて複製
+func cloneRecurringTemplate(tmpl *recurringtemplate.RecurringTemplate) *recurringtemplate.RecurringTemplate {
+	copied := *tmpl
+	copied.Rule.ByDay = slices.Clone(tmpl.Rule.ByDay)
+	if copied.Rule.ByDay == nil {
+		copied.Rule.ByDay = []recurringtemplate.Weekday{}
+	}
+	copied.Rule.EndsOn = cloneTime(tmpl.Rule.EndsOn)
+
+	copied.TaskItems = make([]recurringtemplate.Item, 0, len(tmpl.TaskItems))
+	for _, item := range tmpl.TaskItems {
+		item.CategoryID = cloneString(item.CategoryID)
+		item.OutputTemplateID = cloneString(item.OutputTemplateID)
+		copied.TaskItems = append(copied.TaskItems, item)
+	}
+	return &copied
+}
@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+
+	"task-management-system/backend/internal/domain/report"
+	"task-management-system/backend/internal/domain/task"
+)
+
+// ReportRepository レポートリポジトリ
+type ReportRepository struct {
+	store *Store
+}
+
+// NewReportRepository レポートリポジトリを作成
+func NewReportRepository(store *Store) *ReportRepository {
+	return &ReportRepository{store: store}
+}
+
+// GetReport オーナーの期間内のタスクアイテムを日・密度・優先度ごとに集計（ゴミ箱のタスクは集計しない）
+func (r *ReportRepository) GetReport(ctx context.Context, ownerID string, period report.Period) (*report.Report, error) {
+	owner, err := parseID(ownerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+	from := dateOnly(period.From)
+	to := dateOnly(period.To)
+
+	dailyIndex := make(map[string]int)
+	daily := []report.DailyTotals{}
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		dailyIndex[day.Format("2006-01-02")] = len(daily)
+		daily = append(daily, report.DailyTotals{Date: day})
+	}
+	byDensity := make(map[string]*report.Totals)
+	byPriority := make(map[string]*report.Totals)
+
+	err = r.store.read(ctx, func(t *tables) error {
+		for _, rec := range t.tasks {
+			if rec.task.OwnerID != owner || rec.task.DeletedAt != nil {
+				continue
+			}
+			index, ok := dailyIndex[rec.task.Date.Format("2006-01-02")]
+			if !ok {
+				continue
+			}
+			for _, item := range rec.task.TaskItems {
+				totals := itemTotals(item)
+				daily[index].Add(totals)
+				addTotals(byDensity, string(item.Density), totals)
+				addTotals(byPriority, string(item.Priority), totals)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report.NewReport(ownerID, period, daily, toBreakdowns(byDensity), toBreakdowns(byPriority)), nil
+}
+
+// itemTotals タスクアイテム1件の予定と完了の件数・時間
+func itemTotals(item task.TaskItem) report.Totals {
+	totals := report.Totals{PlannedCount: 1, PlannedMinutes: int32(item.DurationTime)}
+	if item.Status == task.StatusCompleted {
+		totals.CompletedCount = 1
+		totals.CompletedMinutes = int32(item.DurationTime)
+	}
+	return totals
+}
+
+func addTotals(totals map[string]*report.Totals, key string, value report.Totals) {
+	if totals[key] == nil {
+		totals[key] = &report.Totals{}
+	}
+	totals[key].Add(value)
+}
+
+func toBreakdowns(totals map[string]*report.Totals) []report.Breakdown {
+	breakdowns := make([]report.Breakdown, 0, len(totals))
+	for key, value := range totals {
+		breakdowns = append(breakdowns, report.Breakdown{Key: key, Totals: *value})
+	}
+	return breakdowns
+}
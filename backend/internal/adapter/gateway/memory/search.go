@@ -0,0 +1,156 @@
+package memory
+
+import (
+	"strings"
+	"unicode"
+
+	"task-management-system/backend/internal/domain/task"
+)
+
+// 関連度の計算に使用する項目の重み（PostgreSQLのts_rankの既定の重みA、B、Cに合わせる）
+const (
+	titleWeight   float32 = 1.0 // タイトル（A）
+	contentWeight float32 = 0.4 // 子タスクの内容（B）
+	reviewWeight  float32 = 0.2 // 振り返りと子タスクのアウトプット（C）
+)
+
+// searchQuery キーワード検索の条件
+// PostgreSQLの全文検索（websearch_to_tsquery）と部分一致（ILIKE）の組み合わせを近似する
+//   - 空白で区切った語はすべて含む必要があり（AND）、ORで区切った場合はいずれかのまとまりを含めばよい
+//   - -で始まる語は含まない必要がある
+//   - 単語に分かれない日本語などのため、キーワード全体がいずれかの項目に部分一致する場合も一致とする
+type searchQuery struct {
+	// pattern 部分一致させるキーワード全体（小文字）
+	pattern string
+	// groups ORで区切った語のまとまり（小文字）
+	groups [][]string
+	// excluded 含んではいけない語（小文字）
+	excluded []string
+}
+
+// newSearchQuery キーワードから検索の条件を作成
+func newSearchQuery(keyword string) *searchQuery {
+	q := &searchQuery{pattern: strings.ToLower(keyword)}
+
+	var group []string
+	for _, field := range strings.Fields(strings.ToLower(keyword)) {
+		if field == "or" {
+			if len(group) > 0 {
+				q.groups = append(q.groups, group)
+			}
+			group = nil
+			continue
+		}
+		if excluded, ok := strings.CutPrefix(field, "-"); ok {
+			q.excluded = append(q.excluded, tokenize(excluded)...)
+			continue
+		}
+		group = append(group, tokenize(field)...)
+	}
+	if len(group) > 0 {
+		q.groups = append(q.groups, group)
+	}
+	return q
+}
+
+// matches タスクがキーワードに一致するか判定
+// 全文検索はタスク（タイトルと振り返り）と子タスク（内容とアウトプット）のそれぞれの中で照合する
+func (q *searchQuery) matches(t *task.Task) bool {
+	if q.matchesWords(t.Title, stringOrEmpty(t.Review)) || q.contains(t.Title) || q.contains(stringOrEmpty(t.Review)) {
+		return true
+	}
+	for _, item := range t.TaskItems {
+		if q.matchesWords(item.Content, stringOrEmpty(item.Output)) || q.contains(item.Content) || q.contains(stringOrEmpty(item.Output)) {
+			return true
+		}
+	}
+	return false
+}
+
+// rank タスクとキーワードの関連度
+// タスクと最も一致する子タスクの語の重みの合計に、タイトルがキーワードを含む場合は1を加える
+func (q *searchQuery) rank(t *task.Task) float32 {
+	rank := q.weight(t.Title, titleWeight) + q.weight(stringOrEmpty(t.Review), reviewWeight)
+
+	var itemRank float32
+	for _, item := range t.TaskItems {
+		itemRank = max(itemRank, q.weight(item.Content, contentWeight)+q.weight(stringOrEmpty(item.Output), reviewWeight))
+	}
+	rank += itemRank
+
+	if q.contains(t.Title) {
+		rank++
+	}
+	return rank
+}
+
+// matchesWords 全文検索の条件に一致するか判定（textsの語をまとめて照合する）
+func (q *searchQuery) matchesWords(texts ...string) bool {
+	words := make(map[string]bool)
+	for _, text := range texts {
+		for _, word := range tokenize(text) {
+			words[word] = true
+		}
+	}
+
+	for _, word := range q.excluded {
+		if words[word] {
+			return false
+		}
+	}
+	if len(q.groups) == 0 {
+		return len(q.excluded) > 0
+	}
+	for _, group := range q.groups {
+		if containsAll(words, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains キーワード全体が部分一致するか判定（大文字と小文字は区別しない）
+func (q *searchQuery) contains(text string) bool {
+	return q.pattern != "" && strings.Contains(strings.ToLower(text), q.pattern)
+}
+
+// weight textに含まれる検索語の数に重みを掛けた値
+func (q *searchQuery) weight(text string, weight float32) float32 {
+	words := make(map[string]bool)
+	for _, word := range tokenize(text) {
+		words[word] = true
+	}
+
+	var matched float32
+	for _, group := range q.groups {
+		for _, term := range group {
+			if words[term] {
+				matched++
+			}
+		}
+	}
+	return matched * weight
+}
+
+// tokenize テキストを小文字の語に分割（文字と数字以外で区切る）
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func containsAll(words map[string]bool, terms []string) bool {
+	for _, term := range terms {
+		if !words[term] {
+			return false
+		}
+	}
+	return true
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
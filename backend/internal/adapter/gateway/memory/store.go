@@ -0,0 +1,236 @@
+// Package memory リポジトリのインメモリ実装
+// PostgreSQLを使用しないテストとデモ用のモード（--storage=memory）で使用する
+// PostgreSQLの実装と同じ振る舞い（並び順、検索、ゴミ箱、カスケード削除、制約違反のエラー）にそろえ、
+// 共通の契約テスト（repositorytest）で確認する。データはプロセスの終了で失われる
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"task-management-system/backend/internal/domain/account"
+	"task-management-system/backend/internal/domain/category"
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/outputtemplate"
+	"task-management-system/backend/internal/domain/recurringtemplate"
+	"task-management-system/backend/internal/domain/task"
+
+	"github.com/google/uuid"
+)
+
+// errForeignKeyViolation 存在しない行を参照した（PostgreSQLの外部キー制約違反に相当）
+var errForeignKeyViolation = errors.New("foreign key violation")
+
+// Store リポジトリが共有するインメモリのデータ
+// 書き込みはデータの複製に対して行い、成功した場合のみ反映するため、途中でエラーになっても変更は残らない
+// 書き込みのたびにデータ全体を複製するため、大量のデータには向かない
+type Store struct {
+	mu   sync.Mutex
+	data *tables
+	// lastNow 最後に発行した日時（同じ日時を発行しないために使用する）
+	lastNow time.Time
+}
+
+// tables ストアのテーブル
+type tables struct {
+	accounts           map[string]*accountRecord
+	tasks              map[string]*taskRecord
+	taskEvents         []task.Event
+	categories         map[string]*category.Category
+	outputTemplates    map[string]*outputtemplate.OutputTemplate
+	recurringTemplates map[string]*recurringtemplate.RecurringTemplate
+}
+
+// NewStore 空のストアを作成
+func NewStore() *Store {
+	return &Store{data: newTables()}
+}
+
+func newTables() *tables {
+	return &tables{
+		accounts:           make(map[string]*accountRecord),
+		tasks:              make(map[string]*taskRecord),
+		taskEvents:         []task.Event{},
+		categories:         make(map[string]*category.Category),
+		outputTemplates:    make(map[string]*outputtemplate.OutputTemplate),
+		recurringTemplates: make(map[string]*recurringtemplate.RecurringTemplate),
+	}
+}
+
+// clone テーブルを複製（トランザクションと書き込みの途中の状態を分けるために使用する）
+func (t *tables) clone() *tables {
+	c := newTables()
+	for id, a := range t.accounts {
+		copied := *a
+		copied.account = cloneAccount(a.account)
+		c.accounts[id] = &copied
+	}
+	for id, rec := range t.tasks {
+		c.tasks[id] = &taskRecord{
+			task:                cloneTask(rec.task),
+			recurringTemplateID: cloneString(rec.recurringTemplateID),
+		}
+	}
+	// 変更履歴は追記のみで更新しないため、要素は共有する
+	c.taskEvents = append(c.taskEvents, t.taskEvents...)
+	for id, cat := range t.categories {
+		copied := *cat
+		c.categories[id] = &copied
+	}
+	for id, tmpl := range t.outputTemplates {
+		c.outputTemplates[id] = cloneOutputTemplate(tmpl)
+	}
+	for id, tmpl := range t.recurringTemplates {
+		c.recurringTemplates[id] = cloneRecurringTemplate(tmpl)
+	}
+	return c
+}
+
+// txContextKey コンテキストに実行中のトランザクションを保持するキー
+type txContextKey struct{}
+
+// transaction 実行中のトランザクション（ストアのロックを保持し、複製したデータに変更を加える）
+type transaction struct {
+	store *Store
+	data  *tables
+}
+
+// TxManager インメモリのトランザクション管理
+// トランザクションの間はストアをロックするため、トランザクションは直列に実行される
+type TxManager struct {
+	store *Store
+}
+
+// NewTxManager トランザクション管理を作成
+func NewTxManager(store *Store) *TxManager {
+	return &TxManager{store: store}
+}
+
+// WithinTransaction fnを1つのトランザクションで実行
+// fnがエラーを返した場合は、fnの中で行った変更をすべて破棄する
+// fnの中では、fnに渡したコンテキストを使用してリポジトリを呼び出す（別のコンテキストで呼び出すとロックを待ち続ける）
+func (m *TxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := m.store.txFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	m.store.mu.Lock()
+	defer m.store.mu.Unlock()
+
+	tx := &transaction{store: m.store, data: m.store.data.clone()}
+	if err := fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		return err
+	}
+	m.store.data = tx.data
+	return nil
+}
+
+// txFromContext コンテキストからこのストアの実行中のトランザクションを取得
+func (s *Store) txFromContext(ctx context.Context) (*transaction, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(*transaction)
+	if !ok || tx.store != s {
+		return nil, false
+	}
+	return tx, true
+}
+
+// read データを読み取る（トランザクション内の場合は、トランザクションの変更を含めて読み取る）
+func (s *Store) read(ctx context.Context, fn func(t *tables) error) error {
+	if tx, ok := s.txFromContext(ctx); ok {
+		return fn(tx.data)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.data)
+}
+
+// write データを変更する（fnがエラーを返した場合は変更を破棄する）
+// トランザクション内の場合は、トランザクションのデータに変更を加える
+func (s *Store) write(ctx context.Context, fn func(t *tables) error) error {
+	if tx, ok := s.txFromContext(ctx); ok {
+		draft := tx.data.clone()
+		if err := fn(draft); err != nil {
+			return err
+		}
+		tx.data = draft
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	draft := s.data.clone()
+	if err := fn(draft); err != nil {
+		return err
+	}
+	s.data = draft
+	return nil
+}
+
+// now 現在日時を取得（readまたはwriteの中で呼び出す）
+// PostgreSQLと同じマイクロ秒の精度にそろえ、同じ日時を2回発行しない
+func (s *Store) now() time.Time {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	if !now.After(s.lastNow) {
+		now = s.lastNow.Add(time.Microsecond)
+	}
+	s.lastNow = now
+	return now
+}
+
+// parseID stringのUUIDを正規化
+// 形式が不正な場合は「invalid <field>」のValidationエラーを返す
+func parseID(value string, field string) (string, error) {
+	parsed, err := uuid.Parse(value)
+	if err != nil {
+		return "", domainerrors.Validation("invalid " + field).Wrap(err)
+	}
+	return parsed.String(), nil
+}
+
+// newID 新しいIDを発行
+func newID() string {
+	return uuid.NewString()
+}
+
+// parseNullableID nilを許容してstringのUUIDを正規化
+func parseNullableID(value *string, field string) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	id, err := parseID(*value, field)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// dateOnly 日時を日付（UTCの0時）に変換
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func cloneString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := *s
+	return &v
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := *t
+	return &v
+}
+
+func cloneAccount(a account.Account) account.Account {
+	a.Thumbnail = cloneString(a.Thumbnail)
+	a.LastLoginAt = cloneTime(a.LastLoginAt)
+	return a
+}
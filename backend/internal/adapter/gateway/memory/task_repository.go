@@ -0,0 +1,1146 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/outputtemplate"
+	"task-management-system/backend/internal/domain/task"
+)
+
+// taskRecord タスクの行（子タスクとタイマーのセッションを含む）
+type taskRecord struct {
+	task task.Task
+	// recurringTemplateID 繰り返しテンプレートから作成したタスクのテンプレート（それ以外、またはテンプレートが削除された場合はnil）
+	recurringTemplateID *string
+}
+
+// TaskRepository タスクリポジトリ
+type TaskRepository struct {
+	store *Store
+}
+
+// NewTaskRepository タスクリポジトリを作成
+func NewTaskRepository(store *Store) *TaskRepository {
+	return &TaskRepository{store: store}
+}
+
+// ListTasks タスク一覧を取得
+// 並び順とカーソルの扱いはPostgreSQLの実装と同じで、(並び替えキー, 作成日時, ID)の順に比較する
+func (r *TaskRepository) ListTasks(ctx context.Context, condition task.ListTasksCondition) ([]*task.Task, error) {
+	ownerID, err := parseNullableID(condition.OwnerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+	categoryID, err := parseNullableID(condition.CategoryID, "category_id")
+	if err != nil {
+		return nil, err
+	}
+
+	// year-monthを月の範囲に変換
+	var monthStart, monthEnd time.Time
+	if condition.YearMonth != nil {
+		monthStart, err = time.Parse("2006-01", *condition.YearMonth)
+		if err != nil {
+			return nil, domainerrors.Validation("invalid year_month").Wrap(err)
+		}
+		monthEnd = monthStart.AddDate(0, 1, 0)
+	}
+
+	var query *searchQuery
+	if condition.Keyword != nil {
+		query = newSearchQuery(*condition.Keyword)
+	}
+
+	sort := task.SortNewest
+	if condition.Sort != nil && *condition.Sort != "" {
+		sort = task.SortOrder(*condition.Sort)
+	}
+
+	var cursor *taskPosition
+	if condition.Cursor != nil {
+		position, err := cursorPosition(condition.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		cursor = &position
+	}
+
+	tasks := []*task.Task{}
+	err = r.store.read(ctx, func(t *tables) error {
+		for _, rec := range t.tasks {
+			if rec.task.DeletedAt != nil {
+				continue
+			}
+			if ownerID != nil && rec.task.OwnerID != *ownerID {
+				continue
+			}
+			if condition.YearMonth != nil && (rec.task.Date.Before(monthStart) || !rec.task.Date.Before(monthEnd)) {
+				continue
+			}
+			if categoryID != nil && !slices.ContainsFunc(rec.task.TaskItems, func(item task.TaskItem) bool {
+				return item.CategoryID != nil && *item.CategoryID == *categoryID
+			}) {
+				continue
+			}
+
+			entity := toTaskEntity(rec)
+			if query != nil {
+				if !query.matches(entity) {
+					continue
+				}
+				entity.SearchRank = query.rank(entity)
+			}
+			if cursor != nil && compareTaskPositions(sort, positionOf(entity), *cursor) <= 0 {
+				continue
+			}
+			tasks = append(tasks, entity)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(tasks, func(a, b *task.Task) int {
+		return compareTaskPositions(sort, positionOf(a), positionOf(b))
+	})
+	if condition.Limit > 0 && len(tasks) > int(condition.Limit) {
+		tasks = tasks[:condition.Limit]
+	}
+	return tasks, nil
+}
+
+// taskPosition タスク一覧での並び替えに使用する値
+type taskPosition struct {
+	rank      float32
+	date      time.Time
+	createdAt time.Time
+	id        string
+}
+
+func positionOf(t *task.Task) taskPosition {
+	return taskPosition{rank: t.SearchRank, date: t.Date, createdAt: t.CreatedAt, id: t.ID}
+}
+
+// cursorPosition カーソルの位置を並び替えの値に変換
+func cursorPosition(cursor *task.ListTasksCursor) (taskPosition, error) {
+	id, err := parseID(cursor.ID, "cursor")
+	if err != nil {
+		return taskPosition{}, domainerrors.Validation("invalid cursor").Wrap(err)
+	}
+	date, err := time.Parse(time.DateOnly, cursor.Date)
+	if err != nil {
+		return taskPosition{}, domainerrors.Validation("invalid cursor").Wrap(err)
+	}
+	return taskPosition{rank: cursor.Rank, date: date, createdAt: cursor.CreatedAt, id: id}, nil
+}
+
+// compareTaskPositions 並び順での2つのタスクの位置を比較（aが前の場合は負の値）
+// 日付の降順と関連度順は、同じ値の場合に作成日時の新しい順に並べる
+func compareTaskPositions(sort task.SortOrder, a taskPosition, b taskPosition) int {
+	newest := func() int {
+		if c := b.createdAt.Compare(a.createdAt); c != 0 {
+			return c
+		}
+		return strings.Compare(b.id, a.id)
+	}
+	oldest := func() int {
+		return -newest()
+	}
+
+	switch sort {
+	case task.SortOldest:
+		return oldest()
+	case task.SortDateAsc:
+		if c := a.date.Compare(b.date); c != 0 {
+			return c
+		}
+		return oldest()
+	case task.SortDateDesc:
+		if c := b.date.Compare(a.date); c != 0 {
+			return c
+		}
+		return newest()
+	case task.SortRelevance:
+		if a.rank != b.rank {
+			if a.rank > b.rank {
+				return -1
+			}
+			return 1
+		}
+		return newest()
+	default:
+		return newest()
+	}
+}
+
+// GetTaskByID タスクIDでタスクを取得
+func (r *TaskRepository) GetTaskByID(ctx context.Context, taskID string) (*task.Task, error) {
+	id, err := parseID(taskID, "task_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var result *task.Task
+	err = r.store.read(ctx, func(t *tables) error {
+		rec, err := t.activeTask(id)
+		if err != nil {
+			return err
+		}
+		result = toTaskEntity(rec)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetTaskByTaskItemID タスクアイテムIDからタスクを取得
+func (r *TaskRepository) GetTaskByTaskItemID(ctx context.Context, taskItemID string) (*task.Task, error) {
+	id, err := parseID(taskItemID, "task_item_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var result *task.Task
+	err = r.store.read(ctx, func(t *tables) error {
+		rec, _, ok := t.findTaskItem(id)
+		if !ok || rec.task.DeletedAt != nil {
+			return domainerrors.NotFound("Task not found")
+		}
+		result = toTaskEntity(rec)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// LockTask タスクが存在することを確認
+// トランザクションの間はストア全体をロックしているため、タスクごとのロックは不要
+func (r *TaskRepository) LockTask(ctx context.Context, taskID string) error {
+	id, err := parseID(taskID, "task_id")
+	if err != nil {
+		return err
+	}
+
+	return r.store.read(ctx, func(t *tables) error {
+		_, err := t.activeTask(id)
+		return err
+	})
+}
+
+// CreateTask タスクを作成し、作成を変更履歴に記録
+func (r *TaskRepository) CreateTask(ctx context.Context, ownerID string, title string, date string, taskItems []task.CreateTaskItemInput) (*task.Task, error) {
+	owner, err := parseID(ownerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+	dateTime, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, domainerrors.Validation("invalid date format").Wrap(err)
+	}
+
+	var result *task.Task
+	err = r.store.write(ctx, func(t *tables) error {
+		rec, err := t.insertTask(r.store.now(), owner, title, dateTime)
+		if err != nil {
+			return err
+		}
+		if err := t.insertTaskItems(r.store.now(), rec, taskItems); err != nil {
+			return err
+		}
+
+		result = toTaskEntity(rec)
+		return t.recordTaskEvents(r.store.now(), nil, result, owner)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateRecurringTask 繰り返しテンプレートから指定した日付のタスクを作成
+// 同じテンプレートと日付のタスクが既に作成されている場合は（ゴミ箱のタスクを含めて）Conflictエラーを返す
+func (r *TaskRepository) CreateRecurringTask(ctx context.Context, recurringTemplateID string, ownerID string, title string, date time.Time, taskItems []task.CreateTaskItemInput) (*task.Task, error) {
+	owner, err := parseID(ownerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+	templateID, err := parseID(recurringTemplateID, "recurring_template_id")
+	if err != nil {
+		return nil, err
+	}
+	day := dateOnly(date)
+
+	var result *task.Task
+	err = r.store.write(ctx, func(t *tables) error {
+		if _, ok := t.recurringTemplates[templateID]; !ok {
+			return fmt.Errorf("recurring_template_id %s does not exist: %w", templateID, errForeignKeyViolation)
+		}
+		for _, rec := range t.tasks {
+			if rec.task.OwnerID == owner && rec.recurringTemplateID != nil && *rec.recurringTemplateID == templateID && rec.task.Date.Equal(day) {
+				return domainerrors.Conflict("Task for this date has already been generated")
+			}
+		}
+
+		rec, err := t.insertTask(r.store.now(), owner, title, day)
+		if err != nil {
+			return err
+		}
+		rec.recurringTemplateID = &templateID
+		if err := t.insertTaskItems(r.store.now(), rec, taskItems); err != nil {
+			return err
+		}
+
+		result = toTaskEntity(rec)
+		return t.recordTaskEvents(r.store.now(), nil, result, owner)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CarryOverTaskItems 子タスクをオーナーの指定した日付のタスクに持ち越し、持ち越し先のタスクを返す
+// 持ち越し先のタスクがない場合は作成し、子タスクは既存の子タスクの後ろに順番を振り直して並べる
+func (r *TaskRepository) CarryOverTaskItems(ctx context.Context, input task.CarryOverInput) (*task.CarryOverResult, error) {
+	sourceID, err := parseID(input.SourceTaskID, "task_id")
+	if err != nil {
+		return nil, err
+	}
+	owner, err := parseID(input.OwnerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+	day := dateOnly(input.Date)
+
+	var result task.CarryOverResult
+	err = r.store.write(ctx, func(t *tables) error {
+		// 持ち越し先のタスクを取得（同じ日付のタスクが複数ある場合は最初に作成したタスク。ない場合は作成）
+		var target *taskRecord
+		for _, rec := range t.tasks {
+			if rec.task.DeletedAt != nil || rec.task.OwnerID != owner || !rec.task.Date.Equal(day) {
+				continue
+			}
+			if target == nil || compareTaskPositions(task.SortOldest, positionOf(&rec.task), positionOf(&target.task)) < 0 {
+				target = rec
+			}
+		}
+		// 変更履歴のため、変更前のタスクを取得（作成した持ち越し先と、複製の場合の持ち越し元はnil）
+		var sourceBefore *task.Task
+		if target == nil {
+			created, err := t.insertTask(r.store.now(), owner, input.Title, day)
+			if err != nil {
+				return err
+			}
+			target = created
+		} else {
+			result.TargetBefore = toTaskEntity(target)
+		}
+		if input.Mode == task.CarryOverModeMove {
+			source, err := t.activeTask(sourceID)
+			if err != nil {
+				return err
+			}
+			sourceBefore = toTaskEntity(source)
+		}
+
+		// 既存の子タスクの後ろに並べる
+		var maxOrder int32
+		for _, item := range target.task.TaskItems {
+			maxOrder = max(maxOrder, item.Order)
+		}
+
+		now := r.store.now()
+		for i, item := range input.TaskItems {
+			order := maxOrder + int32(i) + 1
+			if input.Mode == task.CarryOverModeMove {
+				t.moveTaskItem(now, item.ID, target, order, sourceID)
+				continue
+			}
+			target.task.TaskItems = append(target.task.TaskItems, task.TaskItem{
+				ID:                    newID(),
+				TaskID:                target.task.ID,
+				Priority:              item.Priority,
+				Density:               item.Density,
+				DurationTime:          item.DurationTime,
+				Content:               item.Content,
+				IsRequired:            item.IsRequired,
+				Order:                 order,
+				Status:                item.Status,
+				CategoryID:            cloneString(item.CategoryID),
+				OutputTemplateID:      cloneString(item.OutputTemplateID),
+				CarriedOverFromTaskID: &sourceID,
+				StartedAt:             cloneTime(item.StartedAt),
+				CreatedAt:             now,
+				UpdatedAt:             now,
+			})
+		}
+		if err := checkTaskItemOrders(target); err != nil {
+			return err
+		}
+
+		// 子タスクが変わったタスクのバージョンを更新し、変更履歴を記録（移動の場合は持ち越し元も変わる）
+		t.touchTask(r.store.now(), target)
+		result.Target = toTaskEntity(target)
+		if err := t.recordTaskEvents(r.store.now(), result.TargetBefore, result.Target, owner); err != nil {
+			return err
+		}
+		if sourceBefore == nil {
+			return nil
+		}
+		source, err := t.activeTask(sourceID)
+		if err != nil {
+			return err
+		}
+		t.touchTask(r.store.now(), source)
+		return t.recordTaskEvents(r.store.now(), sourceBefore, toTaskEntity(source), owner)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// moveTaskItem 子タスクを持ち越し先のタスクに移動（タイマーのセッションとアウトプットも一緒に移動する）
+func (t *tables) moveTaskItem(now time.Time, taskItemID string, target *taskRecord, order int32, sourceID string) {
+	rec, index, ok := t.findTaskItem(taskItemID)
+	if !ok {
+		return
+	}
+	item := rec.task.TaskItems[index]
+	rec.task.TaskItems = slices.Delete(rec.task.TaskItems, index, index+1)
+
+	item.TaskID = target.task.ID
+	item.Order = order
+	item.CarriedOverFromTaskID = &sourceID
+	item.UpdatedAt = now
+	target.task.TaskItems = append(target.task.TaskItems, item)
+}
+
+// UpdateTask タスクを更新し、変更された項目を変更履歴に記録
+// 既存の子タスクはタイマーのセッションやアウトプットを保つため、削除せずに更新する
+func (r *TaskRepository) UpdateTask(ctx context.Context, taskID string, actorID string, title string, date string, taskItems []task.UpdateTaskItemInput, expectedVersion *int64) (*task.Task, error) {
+	id, err := parseID(taskID, "task_id")
+	if err != nil {
+		return nil, err
+	}
+	dateTime, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, domainerrors.Validation("invalid date format").Wrap(err)
+	}
+
+	var result *task.Task
+	err = r.store.write(ctx, func(t *tables) error {
+		rec, err := t.activeTask(id)
+		if err != nil {
+			return err
+		}
+		if err := task.CheckVersion(rec.task.Version, expectedVersion); err != nil {
+			return err
+		}
+		before := toTaskEntity(rec)
+
+		now := r.store.now()
+		rec.task.Title = title
+		rec.task.Date = dateTime
+		t.touchTask(now, rec)
+
+		existing := make(map[string]task.TaskItem, len(rec.task.TaskItems))
+		for _, item := range rec.task.TaskItems {
+			existing[item.ID] = item
+		}
+
+		// リクエストに含まれない既存の子タスクは削除し、含まれる子タスクは更新、それ以外は作成する
+		items := make([]task.TaskItem, 0, len(taskItems))
+		for _, input := range taskItems {
+			categoryID, err := parseNullableID(input.CategoryID, "category_id")
+			if err != nil {
+				return err
+			}
+			outputTemplateID, err := parseNullableID(input.OutputTemplateID, "output_template_id")
+			if err != nil {
+				return err
+			}
+
+			item, ok := existing[strings.ToLower(input.ID)]
+			if !ok {
+				item = task.TaskItem{ID: newID(), TaskID: rec.task.ID, CreatedAt: now}
+			}
+			item.Priority = input.Priority
+			item.Density = input.Density
+			item.DurationTime = input.DurationTime
+			item.Content = input.Content
+			item.IsRequired = input.IsRequired
+			item.Order = input.Order
+			item.Status = input.Status
+			item.CategoryID = categoryID
+			item.OutputTemplateID = outputTemplateID
+			item.StartedAt = cloneTime(input.StartedAt)
+			item.CompletedAt = cloneTime(input.CompletedAt)
+			item.UpdatedAt = now
+			item.StopTimerIfCompleted()
+			if err := t.checkTaskItemReferences(item); err != nil {
+				return err
+			}
+			items = append(items, item)
+		}
+		rec.task.TaskItems = items
+		if err := checkTaskItemOrders(rec); err != nil {
+			return err
+		}
+
+		result = toTaskEntity(rec)
+		return t.recordTaskEvents(r.store.now(), before, result, actorID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// AddTaskItem タスクにタスクアイテムを1件追加
+func (r *TaskRepository) AddTaskItem(ctx context.Context, taskID string, actorID string, input task.CreateTaskItemInput) error {
+	id, err := parseID(taskID, "task_id")
+	if err != nil {
+		return err
+	}
+
+	return r.store.write(ctx, func(t *tables) error {
+		return r.recordTaskChange(t, id, actorID, func(rec *taskRecord) error {
+			return t.insertTaskItems(r.store.now(), rec, []task.CreateTaskItemInput{input})
+		})
+	})
+}
+
+// SaveTaskItem タスクアイテムの項目（内容・順番・ステータスなど）を保存
+// アウトプットとタイマーのセッションは変更しない
+func (r *TaskRepository) SaveTaskItem(ctx context.Context, taskItem task.TaskItem, actorID string) error {
+	taskID, err := parseID(taskItem.TaskID, "task_id")
+	if err != nil {
+		return err
+	}
+	itemID, err := parseID(taskItem.ID, "task_item_id")
+	if err != nil {
+		return err
+	}
+	categoryID, err := parseNullableID(taskItem.CategoryID, "category_id")
+	if err != nil {
+		return err
+	}
+	outputTemplateID, err := parseNullableID(taskItem.OutputTemplateID, "output_template_id")
+	if err != nil {
+		return err
+	}
+
+	return r.store.write(ctx, func(t *tables) error {
+		return r.recordTaskChange(t, taskID, actorID, func(rec *taskRecord) error {
+			index := slices.IndexFunc(rec.task.TaskItems, func(item task.TaskItem) bool { return item.ID == itemID })
+			if index < 0 {
+				return domainerrors.NotFound("Task item not found")
+			}
+
+			item := &rec.task.TaskItems[index]
+			item.Priority = taskItem.Priority
+			item.Density = taskItem.Density
+			item.DurationTime = taskItem.DurationTime
+			item.Content = taskItem.Content
+			item.IsRequired = taskItem.IsRequired
+			item.Order = taskItem.Order
+			item.Status = taskItem.Status
+			item.CategoryID = categoryID
+			item.OutputTemplateID = outputTemplateID
+			item.StartedAt = cloneTime(taskItem.StartedAt)
+			item.CompletedAt = cloneTime(taskItem.CompletedAt)
+			item.UpdatedAt = r.store.now()
+			item.StopTimerIfCompleted()
+			return t.checkTaskItemReferences(*item)
+		})
+	})
+}
+
+// DeleteTaskItem タスクアイテムを1件削除（タイマーのセッションも一緒に削除される）
+func (r *TaskRepository) DeleteTaskItem(ctx context.Context, taskID string, taskItemID string, actorID string) error {
+	id, err := parseID(taskID, "task_id")
+	if err != nil {
+		return err
+	}
+	itemID, err := parseID(taskItemID, "task_item_id")
+	if err != nil {
+		return err
+	}
+
+	return r.store.write(ctx, func(t *tables) error {
+		return r.recordTaskChange(t, id, actorID, func(rec *taskRecord) error {
+			index := slices.IndexFunc(rec.task.TaskItems, func(item task.TaskItem) bool { return item.ID == itemID })
+			if index < 0 {
+				return domainerrors.NotFound("Task item not found")
+			}
+			rec.task.TaskItems = slices.Delete(rec.task.TaskItems, index, index+1)
+			return nil
+		})
+	})
+}
+
+// ReorderTaskItems タスクアイテムの順番をまとめて更新
+// 順番はまとめて更新してから重複を確認するため、入れ替えでも重複のエラーにならない
+func (r *TaskRepository) ReorderTaskItems(ctx context.Context, taskID string, actorID string, orders []task.TaskItemOrder) error {
+	id, err := parseID(taskID, "task_id")
+	if err != nil {
+		return err
+	}
+	requested := make(map[string]int32, len(orders))
+	for _, o := range orders {
+		itemID, err := parseID(o.TaskItemID, "task_item_id")
+		if err != nil {
+			return err
+		}
+		requested[itemID] = o.Order
+	}
+
+	return r.store.write(ctx, func(t *tables) error {
+		return r.recordTaskChange(t, id, actorID, func(rec *taskRecord) error {
+			// 確認後にタスクアイテムが追加・削除されていないか確認
+			if len(rec.task.TaskItems) != len(orders) {
+				return domainerrors.Conflict("Task items have been changed")
+			}
+			now := r.store.now()
+			for i := range rec.task.TaskItems {
+				item := &rec.task.TaskItems[i]
+				order, ok := requested[item.ID]
+				if !ok {
+					return domainerrors.Conflict("Task items have been changed")
+				}
+				item.Order = order
+				item.UpdatedAt = now
+			}
+			return nil
+		})
+	})
+}
+
+// UpdateTaskReview タスクの振り返りを更新（空文字列の場合は振り返りを削除する）
+func (r *TaskRepository) UpdateTaskReview(ctx context.Context, taskID string, actorID string, review *string, expectedVersion *int64) error {
+	id, err := parseID(taskID, "task_id")
+	if err != nil {
+		return err
+	}
+
+	return r.store.write(ctx, func(t *tables) error {
+		rec, err := t.activeTask(id)
+		if err != nil {
+			return err
+		}
+		if err := task.CheckVersion(rec.task.Version, expectedVersion); err != nil {
+			return err
+		}
+		before := toTaskEntity(rec)
+
+		rec.task.Review = nil
+		if review != nil && *review != "" {
+			rec.task.Review = cloneString(review)
+		}
+		t.touchTask(r.store.now(), rec)
+
+		return t.recordTaskEvents(r.store.now(), before, toTaskEntity(rec), actorID)
+	})
+}
+
+// UpdateTaskItemOutput タスクアイテムのアウトプットとステータスを更新
+// sectionsがnilの場合は自由形式のアウトプットとして保存する
+func (r *TaskRepository) UpdateTaskItemOutput(ctx context.Context, taskItemID string, actorID string, output string, sections []outputtemplate.FilledSection, change task.StatusChange, expectedVersion *int64) error {
+	itemID, err := parseID(taskItemID, "task_item_id")
+	if err != nil {
+		return err
+	}
+
+	return r.store.write(ctx, func(t *tables) error {
+		rec, index, ok := t.findTaskItem(itemID)
+		if !ok || rec.task.DeletedAt != nil {
+			return domainerrors.NotFound("Task item not found")
+		}
+		if err := task.CheckVersion(rec.task.Version, expectedVersion); err != nil {
+			return err
+		}
+		before := toTaskEntity(rec)
+
+		now := r.store.now()
+		item := &rec.task.TaskItems[index]
+		item.Output = &output
+		item.OutputSections = slices.Clone(sections)
+		item.Status = change.Status
+		item.StartedAt = cloneTime(change.StartedAt)
+		item.CompletedAt = cloneTime(change.CompletedAt)
+		item.UpdatedAt = now
+		item.StopTimerIfCompleted()
+		t.touchTask(now, rec)
+
+		return t.recordTaskEvents(r.store.now(), before, toTaskEntity(rec), actorID)
+	})
+}
+
+// ApplyTimerTransition タイマーの操作による変更を反映（タイマーの操作はバージョンを確認しない）
+func (r *TaskRepository) ApplyTimerTransition(ctx context.Context, taskItemID string, actorID string, transition task.TimerTransition, at time.Time) error {
+	itemID, err := parseID(taskItemID, "task_item_id")
+	if err != nil {
+		return err
+	}
+
+	return r.store.write(ctx, func(t *tables) error {
+		rec, index, ok := t.findTaskItem(itemID)
+		if !ok || rec.task.DeletedAt != nil {
+			return domainerrors.NotFound("Task item not found")
+		}
+		before := toTaskEntity(rec)
+		item := &rec.task.TaskItems[index]
+
+		// 最新のセッションを終了（一時停止中のセッションは終了理由のみを更新する）
+		if transition.EndReason != nil && len(item.TimerSessions) > 0 {
+			latest := &item.TimerSessions[len(item.TimerSessions)-1]
+			if latest.EndedAt == nil {
+				latest.EndedAt = &at
+			}
+			reason := *transition.EndReason
+			latest.EndReason = &reason
+		}
+
+		// 新しいセッションを開始（実行中のセッションは1つまで）
+		if transition.StartSession {
+			if slices.ContainsFunc(item.TimerSessions, func(s task.TimerSession) bool { return s.EndedAt == nil }) {
+				return domainerrors.Conflict("Timer is already running")
+			}
+			item.TimerSessions = append(item.TimerSessions, task.TimerSession{ID: newID(), StartedAt: at})
+			slices.SortStableFunc(item.TimerSessions, func(a, b task.TimerSession) int {
+				return a.StartedAt.Compare(b.StartedAt)
+			})
+		}
+
+		// ステータスを更新（タイマーの開始で着手した場合のみ着手日時を設定する）
+		item.Status = transition.Status
+		if transition.Status != task.StatusNotStarted && item.StartedAt == nil {
+			item.StartedAt = &at
+		}
+		now := r.store.now()
+		item.UpdatedAt = now
+		t.touchTask(now, rec)
+		return t.recordTaskEvents(now, before, toTaskEntity(rec), actorID)
+	})
+}
+
+// DeleteTask タスクをゴミ箱に移動（子タスクやアウトプットは復元できるように残す）
+func (r *TaskRepository) DeleteTask(ctx context.Context, taskID string, actorID string, expectedVersion *int64) error {
+	id, err := parseID(taskID, "task_id")
+	if err != nil {
+		return err
+	}
+
+	return r.store.write(ctx, func(t *tables) error {
+		rec, err := t.activeTask(id)
+		if err != nil {
+			return err
+		}
+		if err := task.CheckVersion(rec.task.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		now := r.store.now()
+		rec.task.DeletedAt = &now
+		t.touchTask(now, rec)
+		return t.appendTaskEvent(now, task.TrashEvent(id, actorID, false))
+	})
+}
+
+// ListTrashedTasks オーナーのゴミ箱のタスクを移動した日時の新しい順に取得
+func (r *TaskRepository) ListTrashedTasks(ctx context.Context, ownerID string) ([]*task.Task, error) {
+	owner, err := parseID(ownerID, "owner_id")
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := []*task.Task{}
+	err = r.store.read(ctx, func(t *tables) error {
+		for _, rec := range t.tasks {
+			if rec.task.OwnerID == owner && rec.task.DeletedAt != nil {
+				tasks = append(tasks, toTaskEntity(rec))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(tasks, func(a, b *task.Task) int {
+		if c := b.DeletedAt.Compare(*a.DeletedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(b.ID, a.ID)
+	})
+	return tasks, nil
+}
+
+// GetTrashedTaskByID ゴミ箱のタスクを取得（ゴミ箱にない場合はNotFound）
+func (r *TaskRepository) GetTrashedTaskByID(ctx context.Context, taskID string) (*task.Task, error) {
+	id, err := parseID(taskID, "task_id")
+	if err != nil {
+		return nil, err
+	}
+
+	var result *task.Task
+	err = r.store.read(ctx, func(t *tables) error {
+		rec, err := t.trashedTask(id)
+		if err != nil {
+			return err
+		}
+		result = toTaskEntity(rec)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RestoreTask ゴミ箱のタスクを元に戻す
+func (r *TaskRepository) RestoreTask(ctx context.Context, taskID string, actorID string) error {
+	id, err := parseID(taskID, "task_id")
+	if err != nil {
+		return err
+	}
+
+	return r.store.write(ctx, func(t *tables) error {
+		rec, err := t.trashedTask(id)
+		if err != nil {
+			return err
+		}
+		rec.task.DeletedAt = nil
+		t.touchTask(r.store.now(), rec)
+		return t.appendTaskEvent(r.store.now(), task.TrashEvent(id, actorID, true))
+	})
+}
+
+// PurgeTask ゴミ箱のタスクを子タスクと合わせて完全に削除（変更履歴は残し、削除を記録する）
+func (r *TaskRepository) PurgeTask(ctx context.Context, taskID string, actorID string) error {
+	id, err := parseID(taskID, "task_id")
+	if err != nil {
+		return err
+	}
+
+	return r.store.write(ctx, func(t *tables) error {
+		rec, err := t.trashedTask(id)
+		if err != nil {
+			return err
+		}
+		return t.purgeTask(r.store.now(), rec, actorID)
+	})
+}
+
+// PurgeTrashedTasks 指定した日時より前にゴミ箱に移動したタスクを完全に削除し、削除した件数を返す
+// 保持期間による削除は、タスクのオーナーの操作として変更履歴に記録する
+func (r *TaskRepository) PurgeTrashedTasks(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	var purged int64
+	err := r.store.write(ctx, func(t *tables) error {
+		expired := []*taskRecord{}
+		for _, rec := range t.tasks {
+			if rec.task.DeletedAt != nil && rec.task.DeletedAt.Before(deletedBefore) {
+				expired = append(expired, rec)
+			}
+		}
+		// PostgreSQLの実装と同じく、ゴミ箱に移動した順に削除する
+		slices.SortFunc(expired, func(a, b *taskRecord) int {
+			if c := a.task.DeletedAt.Compare(*b.task.DeletedAt); c != 0 {
+				return c
+			}
+			return strings.Compare(a.task.ID, b.task.ID)
+		})
+		for _, rec := range expired {
+			if err := t.purgeTask(r.store.now(), rec, rec.task.OwnerID); err != nil {
+				return err
+			}
+			purged++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
+
+// ListTaskEvents タスクの変更履歴を古い順に取得（ゴミ箱のタスクと完全に削除したタスクの履歴は取得しない）
+func (r *TaskRepository) ListTaskEvents(ctx context.Context, taskID string) ([]task.Event, error) {
+	id, err := parseID(taskID, "task_id")
+	if err != nil {
+		return nil, err
+	}
+
+	events := []task.Event{}
+	err = r.store.read(ctx, func(t *tables) error {
+		if _, err := t.activeTask(id); err != nil {
+			return nil
+		}
+		for _, event := range t.taskEvents {
+			if event.TaskID == id {
+				events = append(events, event)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// recordTaskChange タスクを変更し、変更履歴を記録（PostgreSQLの実装と同じく、変更の前にバージョンを更新する）
+func (r *TaskRepository) recordTaskChange(t *tables, taskID string, actorID string, change func(rec *taskRecord) error) error {
+	rec, err := t.activeTask(taskID)
+	if err != nil {
+		return err
+	}
+	t.touchTask(r.store.now(), rec)
+	before := toTaskEntity(rec)
+
+	if err := change(rec); err != nil {
+		return err
+	}
+	if err := checkTaskItemOrders(rec); err != nil {
+		return err
+	}
+
+	return t.recordTaskEvents(r.store.now(), before, toTaskEntity(rec), actorID)
+}
+
+// activeTask ゴミ箱にないタスクを取得（ない場合はNotFound）
+func (t *tables) activeTask(taskID string) (*taskRecord, error) {
+	rec, ok := t.tasks[taskID]
+	if !ok || rec.task.DeletedAt != nil {
+		return nil, domainerrors.NotFound("Task not found")
+	}
+	return rec, nil
+}
+
+// trashedTask ゴミ箱のタスクを取得（ゴミ箱にない場合はNotFound）
+func (t *tables) trashedTask(taskID string) (*taskRecord, error) {
+	rec, ok := t.tasks[taskID]
+	if !ok || rec.task.DeletedAt == nil {
+		return nil, domainerrors.NotFound("Task not found in trash")
+	}
+	return rec, nil
+}
+
+// findTaskItem 子タスクが属するタスクと、タスク内の子タスクの位置を取得（ゴミ箱のタスクも含む）
+func (t *tables) findTaskItem(taskItemID string) (*taskRecord, int, bool) {
+	for _, rec := range t.tasks {
+		for i, item := range rec.task.TaskItems {
+			if item.ID == taskItemID {
+				return rec, i, true
+			}
+		}
+	}
+	return nil, 0, false
+}
+
+// insertTask タスクを作成
+func (t *tables) insertTask(now time.Time, ownerID string, title string, date time.Time) (*taskRecord, error) {
+	if err := t.requireAccount(ownerID, "owner_id"); err != nil {
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
+
+	rec := &taskRecord{
+		task: task.Task{
+			ID:        newID(),
+			OwnerID:   ownerID,
+			Title:     title,
+			Date:      dateOnly(date),
+			TaskItems: []task.TaskItem{},
+			Version:   1,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+	}
+	t.tasks[rec.task.ID] = rec
+	return rec, nil
+}
+
+// insertTaskItems タスクに子タスクを作成（同じタスク内で順番が重複する場合はConflictエラー）
+func (t *tables) insertTaskItems(now time.Time, rec *taskRecord, inputs []task.CreateTaskItemInput) error {
+	// ステータスに合わせて着手日時と完了日時を設定（引き継いだ日時は保持する）
+	inputs, err := task.PlanNewTaskItems(inputs, now)
+	if err != nil {
+		return err
+	}
+	for _, input := range inputs {
+		categoryID, err := parseNullableID(input.CategoryID, "category_id")
+		if err != nil {
+			return err
+		}
+		outputTemplateID, err := parseNullableID(input.OutputTemplateID, "output_template_id")
+		if err != nil {
+			return err
+		}
+
+		item := task.TaskItem{
+			ID:               newID(),
+			TaskID:           rec.task.ID,
+			Priority:         input.Priority,
+			Density:          input.Density,
+			DurationTime:     input.DurationTime,
+			Content:          input.Content,
+			Output:           cloneString(input.Output),
+			IsRequired:       input.IsRequired,
+			Order:            input.Order,
+			Status:           input.Status,
+			CategoryID:       categoryID,
+			OutputTemplateID: outputTemplateID,
+			OutputSections:   slices.Clone(input.OutputSections),
+			StartedAt:        cloneTime(input.StartedAt),
+			CompletedAt:      cloneTime(input.CompletedAt),
+			CreatedAt:        now,
+			UpdatedAt:        now,
+		}
+		if err := t.checkTaskItemReferences(item); err != nil {
+			return err
+		}
+		rec.task.TaskItems = append(rec.task.TaskItems, item)
+	}
+	return checkTaskItemOrders(rec)
+}
+
+// checkTaskItemReferences 子タスクのカテゴリとテンプレートが存在することを確認（存在しない場合は外部キー制約違反のエラー）
+func (t *tables) checkTaskItemReferences(item task.TaskItem) error {
+	if item.CategoryID != nil {
+		if _, ok := t.categories[*item.CategoryID]; !ok {
+			return fmt.Errorf("category_id %s does not exist: %w", *item.CategoryID, errForeignKeyViolation)
+		}
+	}
+	if item.OutputTemplateID != nil {
+		if _, ok := t.outputTemplates[*item.OutputTemplateID]; !ok {
+			return fmt.Errorf("output_template_id %s does not exist: %w", *item.OutputTemplateID, errForeignKeyViolation)
+		}
+	}
+	return nil
+}
+
+// checkTaskItemOrders 同じタスク内で子タスクの順番が重複していないことを確認（一意制約に相当）
+func checkTaskItemOrders(rec *taskRecord) error {
+	seen := make(map[int32]bool, len(rec.task.TaskItems))
+	for _, item := range rec.task.TaskItems {
+		if seen[item.Order] {
+			return domainerrors.Conflict("Task item order must be unique within a task")
+		}
+		seen[item.Order] = true
+	}
+	return nil
+}
+
+// touchTask タスクの更新日時とバージョンを更新
+func (t *tables) touchTask(now time.Time, rec *taskRecord) {
+	rec.task.Version++
+	rec.task.UpdatedAt = now
+}
+
+// recordTaskEvents 変更前と変更後のタスクの差分を変更履歴に記録（beforeがnilの場合は作成）
+func (t *tables) recordTaskEvents(now time.Time, before *task.Task, after *task.Task, actorID string) error {
+	actor, err := parseID(actorID, "actor_id")
+	if err != nil {
+		return err
+	}
+	if err := t.requireAccount(actor, "actor_id"); err != nil {
+		return fmt.Errorf("failed to create task event: %w", err)
+	}
+
+	for _, event := range task.DiffTask(before, after, actor) {
+		if err := t.appendTaskEvent(now, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendTaskEvent 変更履歴を1件記録（記録した順に取得する）
+func (t *tables) appendTaskEvent(now time.Time, event task.Event) error {
+	actor, err := parseID(event.ActorID, "actor_id")
+	if err != nil {
+		return err
+	}
+	if err := t.requireAccount(actor, "actor_id"); err != nil {
+		return fmt.Errorf("failed to create task event: %w", err)
+	}
+
+	event.ID = newID()
+	event.ActorID = actor
+	event.CreatedAt = now
+	t.taskEvents = append(t.taskEvents, event)
+	return nil
+}
+
+// purgeTask ゴミ箱のタスクを完全に削除し、変更履歴に記録
+func (t *tables) purgeTask(now time.Time, rec *taskRecord, actorID string) error {
+	t.deleteTask(rec.task.ID)
+	return t.appendTaskEvent(now, task.PurgeEvent(toTaskEntity(rec), actorID))
+}
+
+// deleteTask タスクを完全に削除
+// 子タスクは一緒に削除し、このタスクから持ち越した子タスクは持ち越し元をnilにする（変更履歴は残す）
+func (t *tables) deleteTask(taskID string) {
+	delete(t.tasks, taskID)
+
+	for _, rec := range t.tasks {
+		for i := range rec.task.TaskItems {
+			item := &rec.task.TaskItems[i]
+			if item.CarriedOverFromTaskID != nil && *item.CarriedOverFromTaskID == taskID {
+				item.CarriedOverFromTaskID = nil
+			}
+		}
+	}
+}
+
+// toTaskEntity タスクの行をドメインエンティティに変換（子タスクは順番の昇順）
+func toTaskEntity(rec *taskRecord) *task.Task {
+	entity := cloneTask(rec.task)
+	slices.SortFunc(entity.TaskItems, func(a, b task.TaskItem) int {
+		return int(a.Order) - int(b.Order)
+	})
+	return &entity
+}
+
+// cloneTask タスクを子タスクとタイマーのセッションを含めて複製
+func cloneTask(t task.Task) task.Task {
+	t.Review = cloneString(t.Review)
+	t.DeletedAt = cloneTime(t.DeletedAt)
+
+	items := make([]task.TaskItem, 0, len(t.TaskItems))
+	for _, item := range t.TaskItems {
+		item.Output = cloneString(item.Output)
+		item.CategoryID = cloneString(item.CategoryID)
+		item.OutputTemplateID = cloneString(item.OutputTemplateID)
+		item.OutputSections = slices.Clone(item.OutputSections)
+		item.CarriedOverFromTaskID = cloneString(item.CarriedOverFromTaskID)
+		item.StartedAt = cloneTime(item.StartedAt)
+		item.CompletedAt = cloneTime(item.CompletedAt)
+		if item.TimerSessions != nil {
+			sessions := make([]task.TimerSession, 0, len(item.TimerSessions))
+			for _, s := range item.TimerSessions {
+				s.EndedAt = cloneTime(s.EndedAt)
+				if s.EndReason != nil {
+					reason := *s.EndReason
+					s.EndReason = &reason
+				}
+				sessions = append(sessions, s)
+			}
+			item.TimerSessions = sessions
+		}
+		items = append(items, item)
+	}
+	t.TaskItems = items
+	return t
+}
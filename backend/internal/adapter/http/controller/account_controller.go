@@ -1,7 +1,6 @@
 package controller
 
 import (
-	"fmt"
 	"net/http"
 	"strings"
 
@@ -26,21 +25,16 @@ func NewAccountController(accountUsecase *usecase.AccountUsecase) *AccountContro
 
 // GetCurrentAccount 現在のアカウントを取得
 func (c *AccountController) GetCurrentAccount(ctx echo.Context) error {
-	// TODO: 認証情報からaccountIdを取得（現在はリクエストヘッダーから取得する必要がある）
-	// 認証実装後は、セッションやJWTからaccountIdを取得する
-	accountID := ctx.Request().Header.Get("x-account-id")
-	if accountID == "" {
-		return HandleBadRequest(ctx, "Account ID is required", nil)
+	// 認証済みのアカウントIDを取得
+	accountID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
 	}
 
 	// ユースケースを実行
 	account, err := c.accountUsecase.GetCurrentAccount(ctx.Request().Context(), accountID)
 	if err != nil {
-		// アカウントが見つからない場合
-		if err.Error() == fmt.Sprintf("account not found: %s", accountID) {
-			return HandleNotFound(ctx, "Account not found")
-		}
-		return HandleInternalServerError(ctx, err)
+		return err
 	}
 
 	// レスポンスに変換
@@ -54,12 +48,7 @@ func (c *AccountController) GetAccountByID(ctx echo.Context, accountId string) e
 	// ユースケースを実行
 	account, err := c.accountUsecase.GetAccountByID(ctx.Request().Context(), accountId)
 	if err != nil {
-		return HandleInternalServerError(ctx, err)
-	}
-
-	// アカウントが見つからない場合
-	if account == nil {
-		return HandleNotFound(ctx, "Account not found")
+		return err
 	}
 
 	// レスポンスに変換
@@ -78,12 +67,7 @@ func (c *AccountController) GetAccountByEmail(ctx echo.Context, params openapi.A
 	// ユースケースを実行
 	account, err := c.accountUsecase.GetAccountByEmail(ctx.Request().Context(), params.Email)
 	if err != nil {
-		return HandleInternalServerError(ctx, err)
-	}
-
-	// アカウントが見つからない場合
-	if account == nil {
-		return HandleNotFound(ctx, "Account not found")
+		return err
 	}
 
 	// レスポンスに変換
@@ -129,7 +113,7 @@ func (c *AccountController) CreateOrGetAccount(ctx echo.Context, request openapi
 		request.Thumbnail,
 	)
 	if err != nil {
-		return HandleInternalServerError(ctx, err)
+		return err
 	}
 
 	// レスポンスに変換
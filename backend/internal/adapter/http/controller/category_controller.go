@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"task-management-system/backend/internal/adapter/http/generated/openapi"
+	"task-management-system/backend/internal/adapter/http/presenter"
+	"task-management-system/backend/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CategoryController カテゴリコントローラー
+type CategoryController struct {
+	categoryUsecase *usecase.CategoryUsecase
+}
+
+// NewCategoryController カテゴリコントローラーを作成
+func NewCategoryController(categoryUsecase *usecase.CategoryUsecase) *CategoryController {
+	return &CategoryController{
+		categoryUsecase: categoryUsecase,
+	}
+}
+
+// ListCategories 自分のカテゴリ一覧を取得
+func (c *CategoryController) ListCategories(ctx echo.Context) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// ユースケースを実行
+	categories, err := c.categoryUsecase.ListCategories(ctx.Request().Context(), ownerID)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換
+	response := presenter.ToCategoryResponseList(categories)
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// GetCategoryByID カテゴリIDでカテゴリを取得
+func (c *CategoryController) GetCategoryByID(ctx echo.Context, categoryId string) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// ユースケースを実行
+	category, err := c.categoryUsecase.GetCategoryByID(ctx.Request().Context(), categoryId, ownerID)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換
+	response := presenter.ToCategoryResponse(category)
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// CreateCategory カテゴリを作成
+func (c *CategoryController) CreateCategory(ctx echo.Context, request openapi.ModelsCategoryCreateCategoryRequest) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// バリデーション
+	validationErrors := ValidateCategoryRequest(request.Name, request.Color)
+	if len(validationErrors) > 0 {
+		return HandleValidationError(ctx, "Validation failed", map[string]interface{}{
+			"errors": ConvertValidationErrorsToMap(validationErrors),
+		})
+	}
+
+	// ユースケースを実行
+	category, err := c.categoryUsecase.CreateCategory(ctx.Request().Context(), ownerID, strings.TrimSpace(request.Name), request.Color)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換
+	response := presenter.ToCategoryResponse(category)
+
+	return ctx.JSON(http.StatusCreated, response)
+}
+
+// UpdateCategory カテゴリを更新
+func (c *CategoryController) UpdateCategory(ctx echo.Context, categoryId string, request openapi.ModelsCategoryUpdateCategoryRequest) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// バリデーション
+	validationErrors := ValidateCategoryRequest(request.Name, request.Color)
+	if len(validationErrors) > 0 {
+		return HandleValidationError(ctx, "Validation failed", map[string]interface{}{
+			"errors": ConvertValidationErrorsToMap(validationErrors),
+		})
+	}
+
+	// ユースケースを実行
+	category, err := c.categoryUsecase.UpdateCategory(ctx.Request().Context(), categoryId, ownerID, strings.TrimSpace(request.Name), request.Color)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換
+	response := presenter.ToCategoryResponse(category)
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// DeleteCategory カテゴリを削除
+func (c *CategoryController) DeleteCategory(ctx echo.Context, categoryId string) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// ユースケースを実行
+	if err := c.categoryUsecase.DeleteCategory(ctx.Request().Context(), categoryId, ownerID); err != nil {
+		return err
+	}
+
+	// レスポンスを返す
+	return ctx.JSON(http.StatusOK, openapi.ModelsCommonSuccessResponse{
+		Success: true,
+	})
+}
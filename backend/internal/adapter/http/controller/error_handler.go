@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"context"
+	"errors"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+// NewHTTPErrorHandler コントローラーが返したエラーをHTTPレスポンスに変換するエラーハンドラーを作成
+// ドメインエラーは種別に応じて共通エラーレスポンスに変換し、echo.HTTPErrorはfallbackに委譲する
+// それ以外のエラーは内部サーバーエラーとして扱う
+func NewHTTPErrorHandler(fallback echo.HTTPErrorHandler) echo.HTTPErrorHandler {
+	return func(err error, ctx echo.Context) {
+		if ctx.Response().Committed {
+			return
+		}
+
+		var httpErr *echo.HTTPError
+		if _, ok := domainerrors.As(err); !ok && errors.As(err, &httpErr) {
+			fallback(err, ctx)
+			return
+		}
+
+		if respErr := HandleError(ctx, err); respErr != nil {
+			ctx.Logger().Errorf("Failed to write error response: %v", respErr)
+		}
+	}
+}
+
+// HandleError エラーの種別に応じたエラーレスポンスを返す
+// リクエストの期限を過ぎて中断された処理のエラーは503として返す
+func HandleError(ctx echo.Context, err error) error {
+	domainErr, ok := domainerrors.As(err)
+	if !ok {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return HandleServiceUnavailable(ctx, err)
+		}
+		return HandleInternalServerError(ctx, err)
+	}
+
+	switch domainErr.Kind {
+	case domainerrors.KindNotFound:
+		return HandleNotFound(ctx, domainErr.Message)
+	case domainerrors.KindForbidden:
+		return HandleForbidden(ctx, domainErr.Message)
+	case domainerrors.KindValidation:
+		return HandleValidationError(ctx, domainErr.Message, domainErr.Details)
+	case domainerrors.KindConflict:
+		return HandleConflict(ctx, domainErr.Message, domainErr.Details)
+	case domainerrors.KindPreconditionFailed:
+		return HandlePreconditionFailed(ctx, domainErr.Message, domainErr.Details)
+	default:
+		return HandleInternalServerError(ctx, err)
+	}
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestHTTPErrorHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{
+			name:       "NotFound",
+			err:        domainerrors.NotFound("Task not found"),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+		{
+			name:       "ラップされたNotFound",
+			err:        fmt.Errorf("failed to load task: %w", domainerrors.NotFound("Task not found")),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "NOT_FOUND",
+		},
+		{
+			name:       "Forbidden",
+			err:        domainerrors.Forbidden("You do not have permission to update this task"),
+			wantStatus: http.StatusForbidden,
+			wantCode:   "FORBIDDEN",
+		},
+		{
+			name:       "Validation",
+			err:        domainerrors.Validation("invalid task_id").Wrap(errors.New("invalid UUID length")),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "Conflict",
+			err:        domainerrors.Conflict("Task item order must be unique within a task"),
+			wantStatus: http.StatusConflict,
+			wantCode:   "CONFLICT",
+		},
+		{
+			name:       "PreconditionFailed",
+			err:        domainerrors.PreconditionFailed("Task has been modified"),
+			wantStatus: http.StatusPreconditionFailed,
+			wantCode:   "PRECONDITION_FAILED",
+		},
+		{
+			name:       "ドメインエラー以外",
+			err:        errors.New("connection refused"),
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "リクエストの期限切れ",
+			err:        fmt.Errorf("failed to list tasks: %w", context.DeadlineExceeded),
+			wantStatus: http.StatusServiceUnavailable,
+			wantCode:   "SERVICE_UNAVAILABLE",
+		},
+		{
+			name:       "echo.HTTPError",
+			err:        echo.ErrMethodNotAllowed,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.HTTPErrorHandler = NewHTTPErrorHandler(e.DefaultHTTPErrorHandler)
+
+			recorder := httptest.NewRecorder()
+			ctx := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), recorder)
+			e.HTTPErrorHandler(tt.err, ctx)
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+
+			if tt.wantCode == "" {
+				return
+			}
+			var body struct {
+				Code string `json:"code"`
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+				t.Fatalf("failed to decode body: %v", err)
+			}
+			if body.Code != tt.wantCode {
+				t.Errorf("code = %q, want %q", body.Code, tt.wantCode)
+			}
+		})
+	}
+}
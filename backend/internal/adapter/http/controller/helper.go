@@ -4,15 +4,26 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"task-management-system/backend/internal/adapter/http/generated/openapi"
+	"task-management-system/backend/internal/adapter/http/middleware"
+	domainerrors "task-management-system/backend/internal/domain/errors"
 	"task-management-system/backend/internal/domain/task"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
+// CurrentAccountID 認証ミドルウェアが検証したアカウントIDを取得
+func CurrentAccountID(ctx echo.Context) (string, bool) {
+	return middleware.AccountIDFromContext(ctx.Request().Context())
+}
+
 // HandleInternalServerError 内部サーバーエラーを返す
 func HandleInternalServerError(ctx echo.Context, err error) error {
 	// エラーの詳細をログに出力
@@ -57,6 +68,34 @@ func HandleForbidden(ctx echo.Context, message string) error {
 	})
 }
 
+// HandleConflict 競合エラーを返す
+func HandleConflict(ctx echo.Context, message string, details interface{}) error {
+	return ctx.JSON(http.StatusConflict, openapi.ModelsCommonConflictError{
+		Code:    openapi.CONFLICT,
+		Message: message,
+		Details: details,
+	})
+}
+
+// HandlePreconditionFailed 前提条件エラーを返す
+// タスクの操作は現在のタスクを含めたレスポンスをコントローラーで返すため、それ以外の場合に使用する
+func HandlePreconditionFailed(ctx echo.Context, message string, details interface{}) error {
+	return ctx.JSON(http.StatusPreconditionFailed, openapi.ModelsCommonConflictError{
+		Code:    openapi.ModelsCommonConflictErrorCode(openapi.PRECONDITIONFAILED),
+		Message: message,
+		Details: details,
+	})
+}
+
+// HandleServiceUnavailable リクエストの処理が期限までに終わらなかった場合のエラーを返す
+func HandleServiceUnavailable(ctx echo.Context, err error) error {
+	ctx.Logger().Warnf("Request timed out: %v", err)
+	return ctx.JSON(http.StatusServiceUnavailable, openapi.ModelsCommonServiceUnavailableError{
+		Code:    openapi.SERVICEUNAVAILABLE,
+		Message: "The request timed out",
+	})
+}
+
 // HandleValidationError バリデーションエラーを返す
 func HandleValidationError(ctx echo.Context, message string, details interface{}) error {
 	return HandleBadRequest(ctx, message, details)
@@ -64,14 +103,38 @@ func HandleValidationError(ctx echo.Context, message string, details interface{}
 
 // IsNotFoundError エラーがNotFoundエラーかどうかを判定
 func IsNotFoundError(err error) bool {
-	// TODO: カスタムエラー型を定義して、より詳細な判定を行う
-	return errors.Is(err, echo.ErrNotFound)
+	return domainerrors.IsNotFound(err) || errors.Is(err, echo.ErrNotFound)
 }
 
 // IsBadRequestError エラーがBadRequestエラーかどうかを判定
 func IsBadRequestError(err error) bool {
-	// TODO: カスタムエラー型を定義して、より詳細な判定を行う
-	return errors.Is(err, echo.ErrBadRequest)
+	return domainerrors.IsValidation(err) || errors.Is(err, echo.ErrBadRequest)
+}
+
+// ParseIfMatch If-Matchヘッダーから更新対象のタスクのバージョンを取得
+// 指定がない場合と「*」の場合はバージョンを確認しないためnilを返す（ETagを扱わないクライアントも更新できる）
+// ETagは「"3"」の形式で返すが、弱いETag（W/"3"）と引用符のない値も受け付ける
+func ParseIfMatch(value *string) (*int64, error) {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return nil, nil
+	}
+
+	tag := strings.TrimSpace(*value)
+	if tag == "*" {
+		return nil, nil
+	}
+	tag = strings.TrimPrefix(tag, "W/")
+	if len(tag) >= 2 && strings.HasPrefix(tag, `"`) && strings.HasSuffix(tag, `"`) {
+		tag = tag[1 : len(tag)-1]
+	}
+
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version < 1 {
+		return nil, domainerrors.Validation("Invalid If-Match header").WithDetails(map[string]interface{}{
+			"ifMatch": *value,
+		})
+	}
+	return &version, nil
 }
 
 // ValidationError バリデーションエラー
@@ -169,6 +232,26 @@ func ValidateCreateTaskItem(item openapi.ModelsTaskCreateTaskItemRequest, index
 		})
 	}
 
+	// categoryIdのUUIDバリデーション（未分類の場合は省略可能）
+	if item.CategoryId != nil {
+		if _, err := uuid.Parse(*item.CategoryId); err != nil {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("%s.categoryId", prefix),
+				Message: "categoryIdは有効なUUIDである必要があります",
+			})
+		}
+	}
+
+	// outputTemplateIdのUUIDバリデーション（自由形式の場合は省略可能）
+	if item.OutputTemplateId != nil {
+		if _, err := uuid.Parse(*item.OutputTemplateId); err != nil {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("%s.outputTemplateId", prefix),
+				Message: "outputTemplateIdは有効なUUIDである必要があります",
+			})
+		}
+	}
+
 	// statusのバリデーション（新規作成時はNotStartedのみ許可）
 	status := task.Status(item.Status)
 	if status != task.StatusNotStarted && status != task.StatusInProgress && status != task.StatusCompleted {
@@ -244,6 +327,26 @@ func ValidateUpdateTaskItem(item openapi.ModelsTaskUpdateTaskItemRequest, index
 		})
 	}
 
+	// categoryIdのUUIDバリデーション（未分類の場合は省略可能）
+	if item.CategoryId != nil {
+		if _, err := uuid.Parse(*item.CategoryId); err != nil {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("%s.categoryId", prefix),
+				Message: "categoryIdは有効なUUIDである必要があります",
+			})
+		}
+	}
+
+	// outputTemplateIdのUUIDバリデーション（自由形式の場合は省略可能）
+	if item.OutputTemplateId != nil {
+		if _, err := uuid.Parse(*item.OutputTemplateId); err != nil {
+			errors = append(errors, ValidationError{
+				Field:   fmt.Sprintf("%s.outputTemplateId", prefix),
+				Message: "outputTemplateIdは有効なUUIDである必要があります",
+			})
+		}
+	}
+
 	// statusのバリデーション
 	status := task.Status(item.Status)
 	if status != task.StatusNotStarted && status != task.StatusInProgress && status != task.StatusCompleted {
@@ -256,6 +359,36 @@ func ValidateUpdateTaskItem(item openapi.ModelsTaskUpdateTaskItemRequest, index
 	return errors
 }
 
+// categoryColorPattern カテゴリの色（#RRGGBB形式）
+var categoryColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// categoryNameMaxLength カテゴリ名の最大文字数
+const categoryNameMaxLength = 50
+
+// ValidateCategoryRequest カテゴリリクエストのバリデーション
+func ValidateCategoryRequest(name, color string) []ValidationError {
+	var errors []ValidationError
+
+	// nameのバリデーション
+	nameLength := utf8.RuneCountInString(strings.TrimSpace(name))
+	if nameLength == 0 || nameLength > categoryNameMaxLength {
+		errors = append(errors, ValidationError{
+			Field:   "name",
+			Message: fmt.Sprintf("nameは1文字以上%d文字以下である必要があります", categoryNameMaxLength),
+		})
+	}
+
+	// colorのバリデーション
+	if !categoryColorPattern.MatchString(color) {
+		errors = append(errors, ValidationError{
+			Field:   "color",
+			Message: "colorは#RRGGBB形式である必要があります",
+		})
+	}
+
+	return errors
+}
+
 // ConvertValidationErrorsToMap バリデーションエラーをmap形式に変換
 func ConvertValidationErrorsToMap(errors []ValidationError) []map[string]string {
 	result := make([]map[string]string, 0, len(errors))
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"testing"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	header := func(v string) *string { return &v }
+	version := func(v int64) *int64 { return &v }
+
+	tests := []struct {
+		name    string
+		value   *string
+		want    *int64
+		wantErr func(error) bool
+	}{
+		{name: "ETagの形式", value: header(`"3"`), want: version(3)},
+		{name: "弱いETag", value: header(`W/"3"`), want: version(3)},
+		{name: "引用符なし", value: header("3"), want: version(3)},
+		{name: "ワイルドカードはバージョンを確認しない", value: header("*"), want: nil},
+		{name: "指定なしはバージョンを確認しない", value: nil, want: nil},
+		{name: "空文字はバージョンを確認しない", value: header(" "), want: nil},
+		{name: "数値以外", value: header(`"abc"`), wantErr: domainerrors.IsValidation},
+		{name: "0以下", value: header(`"0"`), wantErr: domainerrors.IsValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseIfMatch(tt.value)
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("version = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
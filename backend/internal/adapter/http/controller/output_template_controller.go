@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"task-management-system/backend/internal/adapter/http/generated/openapi"
+	"task-management-system/backend/internal/adapter/http/presenter"
+	"task-management-system/backend/internal/domain/outputtemplate"
+	"task-management-system/backend/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// OutputTemplateController アウトプットテンプレートコントローラー
+type OutputTemplateController struct {
+	outputTemplateUsecase *usecase.OutputTemplateUsecase
+}
+
+// NewOutputTemplateController アウトプットテンプレートコントローラーを作成
+func NewOutputTemplateController(outputTemplateUsecase *usecase.OutputTemplateUsecase) *OutputTemplateController {
+	return &OutputTemplateController{
+		outputTemplateUsecase: outputTemplateUsecase,
+	}
+}
+
+// ListOutputTemplates 自分のテンプレート一覧を取得
+func (c *OutputTemplateController) ListOutputTemplates(ctx echo.Context) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// ユースケースを実行
+	templates, err := c.outputTemplateUsecase.ListOutputTemplates(ctx.Request().Context(), ownerID)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換
+	response := presenter.ToOutputTemplateResponseList(templates)
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// GetOutputTemplateByID テンプレートIDでテンプレートを取得
+func (c *OutputTemplateController) GetOutputTemplateByID(ctx echo.Context, outputTemplateId string) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// ユースケースを実行
+	template, err := c.outputTemplateUsecase.GetOutputTemplateByID(ctx.Request().Context(), outputTemplateId, ownerID)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換
+	response := presenter.ToOutputTemplateResponse(template)
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// CreateOutputTemplate テンプレートを作成
+func (c *OutputTemplateController) CreateOutputTemplate(ctx echo.Context, request openapi.ModelsOutputTemplateCreateOutputTemplateRequest) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// ユースケースを実行（テンプレートの定義はドメインで検証する）
+	template, err := c.outputTemplateUsecase.CreateOutputTemplate(ctx.Request().Context(), ownerID, strings.TrimSpace(request.Name), toOutputTemplateSections(request.Sections))
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換
+	response := presenter.ToOutputTemplateResponse(template)
+
+	return ctx.JSON(http.StatusCreated, response)
+}
+
+// UpdateOutputTemplate テンプレートを更新
+func (c *OutputTemplateController) UpdateOutputTemplate(ctx echo.Context, outputTemplateId string, request openapi.ModelsOutputTemplateUpdateOutputTemplateRequest) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// ユースケースを実行（テンプレートの定義はドメインで検証する）
+	template, err := c.outputTemplateUsecase.UpdateOutputTemplate(ctx.Request().Context(), outputTemplateId, ownerID, strings.TrimSpace(request.Name), toOutputTemplateSections(request.Sections))
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換
+	response := presenter.ToOutputTemplateResponse(template)
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// DeleteOutputTemplate テンプレートを削除
+func (c *OutputTemplateController) DeleteOutputTemplate(ctx echo.Context, outputTemplateId string) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// ユースケースを実行
+	if err := c.outputTemplateUsecase.DeleteOutputTemplate(ctx.Request().Context(), outputTemplateId, ownerID); err != nil {
+		return err
+	}
+
+	// レスポンスを返す
+	return ctx.JSON(http.StatusOK, openapi.ModelsCommonSuccessResponse{
+		Success: true,
+	})
+}
+
+// toOutputTemplateSections リクエストのセクションをドメインのセクションに変換
+func toOutputTemplateSections(sections []openapi.ModelsOutputTemplateOutputTemplateSection) []outputtemplate.Section {
+	result := make([]outputtemplate.Section, 0, len(sections))
+	for _, s := range sections {
+		result = append(result, outputtemplate.Section{
+			Key:      s.Key,
+			Label:    strings.TrimSpace(s.Label),
+			Required: s.Required,
+		})
+	}
+	return result
+}
@@ -0,0 +1,262 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"task-management-system/backend/internal/adapter/http/generated/openapi"
+	"task-management-system/backend/internal/adapter/http/presenter"
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/recurringtemplate"
+	"task-management-system/backend/internal/domain/task"
+	"task-management-system/backend/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RecurringTemplateController 繰り返しテンプレートコントローラー
+type RecurringTemplateController struct {
+	recurringTemplateUsecase *usecase.RecurringTemplateUsecase
+}
+
+// NewRecurringTemplateController 繰り返しテンプレートコントローラーを作成
+func NewRecurringTemplateController(recurringTemplateUsecase *usecase.RecurringTemplateUsecase) *RecurringTemplateController {
+	return &RecurringTemplateController{
+		recurringTemplateUsecase: recurringTemplateUsecase,
+	}
+}
+
+// ListRecurringTemplates 自分のテンプレート一覧を取得
+func (c *RecurringTemplateController) ListRecurringTemplates(ctx echo.Context) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// ユースケースを実行
+	templates, err := c.recurringTemplateUsecase.ListRecurringTemplates(ctx.Request().Context(), ownerID)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換
+	response := presenter.ToRecurringTemplateResponseList(templates)
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// GetRecurringTemplateByID テンプレートIDでテンプレートを取得
+func (c *RecurringTemplateController) GetRecurringTemplateByID(ctx echo.Context, recurringTemplateId string) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// ユースケースを実行
+	template, err := c.recurringTemplateUsecase.GetRecurringTemplateByID(ctx.Request().Context(), recurringTemplateId, ownerID)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換
+	response := presenter.ToRecurringTemplateResponse(template)
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// CreateRecurringTemplate テンプレートを作成
+func (c *RecurringTemplateController) CreateRecurringTemplate(ctx echo.Context, request openapi.ModelsRecurringTemplateCreateRecurringTemplateRequest) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// リクエストをドメインのルールに変換（テンプレートの定義はドメインで検証する）
+	rule, err := toRecurringRule(request.Frequency, request.ByDay, request.StartsOn, request.EndsOn)
+	if err != nil {
+		return err
+	}
+
+	// ユースケースを実行
+	template, err := c.recurringTemplateUsecase.CreateRecurringTemplate(ctx.Request().Context(), ownerID, strings.TrimSpace(request.Title), rule, toRecurringItems(request.TaskItems))
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換
+	response := presenter.ToRecurringTemplateResponse(template)
+
+	return ctx.JSON(http.StatusCreated, response)
+}
+
+// UpdateRecurringTemplate テンプレートを更新
+func (c *RecurringTemplateController) UpdateRecurringTemplate(ctx echo.Context, recurringTemplateId string, request openapi.ModelsRecurringTemplateUpdateRecurringTemplateRequest) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// リクエストをドメインのルールに変換（テンプレートの定義はドメインで検証する）
+	rule, err := toRecurringRule(request.Frequency, request.ByDay, request.StartsOn, request.EndsOn)
+	if err != nil {
+		return err
+	}
+
+	// ユースケースを実行
+	template, err := c.recurringTemplateUsecase.UpdateRecurringTemplate(ctx.Request().Context(), recurringTemplateId, ownerID, strings.TrimSpace(request.Title), rule, toRecurringItems(request.TaskItems))
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換
+	response := presenter.ToRecurringTemplateResponse(template)
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// DeleteRecurringTemplate テンプレートを削除
+func (c *RecurringTemplateController) DeleteRecurringTemplate(ctx echo.Context, recurringTemplateId string) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// ユースケースを実行
+	if err := c.recurringTemplateUsecase.DeleteRecurringTemplate(ctx.Request().Context(), recurringTemplateId, ownerID); err != nil {
+		return err
+	}
+
+	// レスポンスを返す
+	return ctx.JSON(http.StatusOK, openapi.ModelsCommonSuccessResponse{
+		Success: true,
+	})
+}
+
+// ListOccurrences テンプレートからタスクが作成される日付をプレビュー
+func (c *RecurringTemplateController) ListOccurrences(ctx echo.Context, recurringTemplateId string, params openapi.RecurringTemplatesListRecurringTemplateOccurrencesParams) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	from, err := parseFromDate(params.From)
+	if err != nil {
+		return err
+	}
+	count, err := recurringtemplate.ParsePreviewCount(params.Count)
+	if err != nil {
+		return err
+	}
+
+	// ユースケースを実行
+	dates, err := c.recurringTemplateUsecase.ListOccurrences(ctx.Request().Context(), recurringTemplateId, ownerID, from, count)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換
+	response := presenter.ToRecurringOccurrencesResponse(dates)
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// GenerateTasks 自分のすべてのテンプレートからタスクを作成
+func (c *RecurringTemplateController) GenerateTasks(ctx echo.Context, request openapi.ModelsRecurringTemplateGenerateRecurringTasksRequest) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	from, err := parseFromDate(request.From)
+	if err != nil {
+		return err
+	}
+	days, err := recurringtemplate.ParseGenerateDays(request.Days)
+	if err != nil {
+		return err
+	}
+
+	// ユースケースを実行
+	result, err := c.recurringTemplateUsecase.GenerateTasks(ctx.Request().Context(), ownerID, from, days)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換（今回作成したタスクのみ）
+	response := presenter.ToTaskResponseList(result.Tasks, result.Owner, nil, nil)
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// parseFromDate 開始日を解析（未指定の場合は今日）
+func parseFromDate(value *string) (time.Time, error) {
+	if value == nil {
+		now := time.Now()
+		return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+
+	from, err := time.Parse(time.DateOnly, *value)
+	if err != nil {
+		return time.Time{}, domainerrors.Validation("invalid from").WithDetails(map[string]string{
+			"from": "must be YYYY-MM-DD",
+		})
+	}
+	return from, nil
+}
+
+// toRecurringRule リクエストの繰り返しの設定をドメインのルールに変換
+// 開始日の形式が不正な場合はゼロ値のままにして、ドメインの検証でエラーにする
+func toRecurringRule(frequency openapi.ModelsRecurringTemplateFrequency, byDay *[]openapi.ModelsRecurringTemplateWeekday, startsOn string, endsOn *string) (recurringtemplate.Rule, error) {
+	rule := recurringtemplate.Rule{
+		Frequency: recurringtemplate.Frequency(frequency),
+		ByDay:     []recurringtemplate.Weekday{},
+	}
+
+	if byDay != nil {
+		for _, day := range *byDay {
+			rule.ByDay = append(rule.ByDay, recurringtemplate.Weekday(day))
+		}
+	}
+
+	if d, err := time.Parse(time.DateOnly, startsOn); err == nil {
+		rule.StartsOn = d
+	}
+
+	if endsOn != nil {
+		d, err := time.Parse(time.DateOnly, *endsOn)
+		if err != nil {
+			return recurringtemplate.Rule{}, domainerrors.Validation("Validation failed").WithDetails(map[string]interface{}{
+				"errors": []recurringtemplate.FieldError{{Field: "endsOn", Message: "endsOnは有効な日付形式である必要があります"}},
+			})
+		}
+		rule.EndsOn = &d
+	}
+
+	return rule, nil
+}
+
+// toRecurringItems リクエストの子タスクをドメインの子タスクに変換
+func toRecurringItems(items []openapi.ModelsRecurringTemplateRecurringTaskItem) []recurringtemplate.Item {
+	result := make([]recurringtemplate.Item, 0, len(items))
+	for _, item := range items {
+		result = append(result, recurringtemplate.Item{
+			Priority:         task.Priority(item.Priority),
+			Density:          task.Density(item.Density),
+			DurationTime:     task.DurationTime(item.DurationTime),
+			Content:          item.Content,
+			IsRequired:       item.IsRequired,
+			Order:            item.Order,
+			CategoryID:       item.CategoryId,
+			OutputTemplateID: item.OutputTemplateId,
+		})
+	}
+	return result
+}
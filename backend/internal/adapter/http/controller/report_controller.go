@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"net/http"
+
+	"task-management-system/backend/internal/adapter/http/generated/openapi"
+	"task-management-system/backend/internal/adapter/http/presenter"
+	"task-management-system/backend/internal/domain/report"
+	"task-management-system/backend/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ReportController レポートコントローラー
+type ReportController struct {
+	reportUsecase *usecase.ReportUsecase
+}
+
+// NewReportController レポートコントローラーを作成
+func NewReportController(reportUsecase *usecase.ReportUsecase) *ReportController {
+	return &ReportController{
+		reportUsecase: reportUsecase,
+	}
+}
+
+// GetReport 振り返りレポートを取得
+func (c *ReportController) GetReport(ctx echo.Context, params openapi.ReportsGetReportParams) error {
+	// 認証済みのアカウントIDを閲覧者として使用
+	viewerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// 集計期間を確定（週・月・日付の範囲のいずれか1つ）
+	period, err := report.ParsePeriod(params.Week, params.Month, params.From, params.To)
+	if err != nil {
+		return err
+	}
+
+	// ユースケースを実行
+	r, err := c.reportUsecase.GetReport(ctx.Request().Context(), viewerID, period)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換
+	response := presenter.ToReportResponse(r)
+
+	return ctx.JSON(http.StatusOK, response)
+}
@@ -3,10 +3,13 @@ package controller
 import (
 	"fmt"
 	"net/http"
-	"strings"
+	"time"
 
 	"task-management-system/backend/internal/adapter/http/generated/openapi"
 	"task-management-system/backend/internal/adapter/http/presenter"
+	"task-management-system/backend/internal/domain/account"
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/outputtemplate"
 	"task-management-system/backend/internal/domain/task"
 	"task-management-system/backend/internal/usecase"
 
@@ -27,6 +30,12 @@ func NewTaskController(taskUsecase *usecase.TaskUsecase) *TaskController {
 
 // ListTasks タスク一覧を取得
 func (c *TaskController) ListTasks(ctx echo.Context, params openapi.TasksListTasksParams) error {
+	// 認証済みのアカウントIDを閲覧者として使用
+	viewerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
 	// 検索条件を構築
 	condition := task.ListTasksCondition{}
 
@@ -42,57 +51,94 @@ func (c *TaskController) ListTasks(ctx echo.Context, params openapi.TasksListTas
 		condition.Keyword = params.Q
 	}
 
+	if params.CategoryId != nil {
+		condition.CategoryID = params.CategoryId
+	}
+
 	if params.Sort != nil {
 		condition.Sort = params.Sort
 	}
 
+	limit, err := task.ParseListTasksLimit(params.Limit)
+	if err != nil {
+		return err
+	}
+	condition.Limit = limit
+
+	if params.Cursor != nil {
+		cursor, err := task.DecodeListTasksCursor(*params.Cursor)
+		if err != nil {
+			return err
+		}
+		condition.Cursor = cursor
+	}
+
 	// ユースケースを実行
-	tasks, owner, err := c.taskUsecase.ListTasks(ctx.Request().Context(), condition)
+	result, err := c.taskUsecase.ListTasks(ctx.Request().Context(), viewerID, condition)
 	if err != nil {
-		return HandleInternalServerError(ctx, err)
+		return err
 	}
 
 	// タスクが0件の場合は空配列を返す
-	if len(tasks) == 0 {
-		return ctx.JSON(http.StatusOK, []openapi.ModelsTaskTaskResponse{})
+	if len(result.Tasks) == 0 {
+		return ctx.JSON(http.StatusOK, presenter.ToTaskResponseList(result.Tasks, nil, nil, nil))
 	}
 
 	// ownerがnilの場合はエラーを返す
-	if owner == nil {
+	if result.Owner == nil {
 		return HandleInternalServerError(ctx, fmt.Errorf("owner account not found"))
 	}
 
 	// レスポンスに変換
-	response := presenter.ToTaskResponseList(tasks, owner)
+	response := presenter.ToTaskResponseList(result.Tasks, result.Owner, result.NextCursor, result.SearchMatches)
 
 	return ctx.JSON(http.StatusOK, response)
 }
 
 // GetTaskByID タスクIDでタスクを取得
 func (c *TaskController) GetTaskByID(ctx echo.Context, taskId string) error {
+	// 認証済みのアカウントIDを閲覧者として使用
+	viewerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
 	// ユースケースを実行
-	t, owner, err := c.taskUsecase.GetTaskByID(ctx.Request().Context(), taskId)
+	t, owner, err := c.taskUsecase.GetTaskByID(ctx.Request().Context(), taskId, viewerID)
 	if err != nil {
-		return HandleInternalServerError(ctx, err)
+		return err
+	}
+
+	// レスポンスに変換（ETagヘッダーにバージョンを含める）
+	return respondTask(ctx, http.StatusOK, t, owner)
+}
+
+// GetTaskHistory タスクの変更履歴を取得
+func (c *TaskController) GetTaskHistory(ctx echo.Context, taskId string) error {
+	// 認証済みのアカウントIDを閲覧者として使用
+	viewerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
 	}
 
-	// タスクが見つからない場合
-	if t == nil {
-		return HandleNotFound(ctx, "Task not found")
+	// ユースケースを実行
+	events, err := c.taskUsecase.GetTaskHistory(ctx.Request().Context(), taskId, viewerID)
+	if err != nil {
+		return err
 	}
 
 	// レスポンスに変換
-	response := presenter.ToTaskResponse(t, owner)
+	response := presenter.ToTaskHistoryResponse(events)
 
 	return ctx.JSON(http.StatusOK, response)
 }
 
 // CreateTask タスクを作成
 func (c *TaskController) CreateTask(ctx echo.Context, request openapi.ModelsTaskCreateTaskRequest) error {
-	// リクエストからownerIdを取得
-	ownerID := request.OwnerId
-	if ownerID == "" {
-		return HandleBadRequest(ctx, "Owner ID is required", nil)
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
 	}
 
 	ctx.Logger().Infof("CreateTask: ownerId=%s, title=%s, date=%s, taskItems=%d",
@@ -135,13 +181,15 @@ func (c *TaskController) CreateTask(ctx echo.Context, request openapi.ModelsTask
 		status := task.StatusNotStarted
 
 		taskItems = append(taskItems, task.CreateTaskItemInput{
-			Priority:     task.Priority(item.Priority),
-			Density:      task.Density(item.Density),
-			DurationTime: durationTime,
-			Content:      item.Content,
-			IsRequired:   item.IsRequired,
-			Order:        item.Order,
-			Status:       status,
+			Priority:         task.Priority(item.Priority),
+			Density:          task.Density(item.Density),
+			DurationTime:     durationTime,
+			Content:          item.Content,
+			IsRequired:       item.IsRequired,
+			Order:            item.Order,
+			Status:           status,
+			CategoryID:       item.CategoryId,
+			OutputTemplateID: item.OutputTemplateId,
 		})
 	}
 
@@ -158,7 +206,7 @@ func (c *TaskController) CreateTask(ctx echo.Context, request openapi.ModelsTask
 	createdTask, owner, err := c.taskUsecase.CreateTask(ctx.Request().Context(), ownerID, request.Title, request.Date, taskItems)
 	if err != nil {
 		ctx.Logger().Errorf("taskUsecase.CreateTask failed: %v", err)
-		return HandleInternalServerError(ctx, err)
+		return err
 	}
 
 	if createdTask == nil {
@@ -178,12 +226,141 @@ func (c *TaskController) CreateTask(ctx echo.Context, request openapi.ModelsTask
 	return ctx.JSON(http.StatusCreated, response)
 }
 
+// AddTaskItem タスクに子タスクを1件追加
+func (c *TaskController) AddTaskItem(ctx echo.Context, taskId string, request openapi.ModelsTaskCreateTaskItemRequest) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// リクエストをドメインの入力に変換（項目の検証はタスクの集約で行う）
+	// ビジネスルール: 新規作成時はStatusはNotStartedに固定
+	input := task.CreateTaskItemInput{
+		Priority:         task.Priority(request.Priority),
+		Density:          task.Density(request.Density),
+		DurationTime:     task.DurationTime(request.DurationTime),
+		Content:          request.Content,
+		IsRequired:       request.IsRequired,
+		Order:            request.Order,
+		Status:           task.StatusNotStarted,
+		CategoryID:       request.CategoryId,
+		OutputTemplateID: request.OutputTemplateId,
+	}
+
+	// ユースケースを実行
+	updatedTask, owner, err := c.taskUsecase.AddTaskItem(ctx.Request().Context(), taskId, ownerID, input)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換（ETagヘッダーにバージョンを含める）
+	return respondTask(ctx, http.StatusCreated, updatedTask, owner)
+}
+
+// ReorderTaskItems 子タスクを指定した順に並び替え
+func (c *TaskController) ReorderTaskItems(ctx echo.Context, taskId string, request openapi.ModelsTaskReorderTaskItemsRequest) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// ユースケースを実行
+	updatedTask, owner, err := c.taskUsecase.ReorderTaskItems(ctx.Request().Context(), taskId, ownerID, request.TaskItemIds)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換（ETagヘッダーにバージョンを含める）
+	return respondTask(ctx, http.StatusOK, updatedTask, owner)
+}
+
+// CarryOverTaskItems 完了していない子タスクを指定した日付のタスクに持ち越し
+func (c *TaskController) CarryOverTaskItems(ctx echo.Context, taskId string, request openapi.ModelsTaskCarryOverTaskItemsRequest) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// バリデーション: 持ち越し先の日付
+	date, err := time.Parse("2006-01-02", request.Date)
+	if err != nil {
+		return HandleValidationError(ctx, "Validation failed", map[string]interface{}{
+			"errors": ConvertValidationErrorsToMap([]ValidationError{{
+				Field:   "date",
+				Message: "dateは有効な日付形式である必要があります",
+			}}),
+		})
+	}
+
+	var mode *string
+	if request.Mode != nil {
+		value := string(*request.Mode)
+		mode = &value
+	}
+	carryOverMode, err := task.ParseCarryOverMode(mode)
+	if err != nil {
+		return err
+	}
+
+	// ユースケースを実行
+	targetTask, owner, err := c.taskUsecase.CarryOverTaskItems(ctx.Request().Context(), taskId, ownerID, date, carryOverMode)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換（持ち越し先のタスク。ETagヘッダーにバージョンを含める）
+	return respondTask(ctx, http.StatusOK, targetTask, owner)
+}
+
+// DuplicateTask タスクを子タスクごと指定した日付に複製
+func (c *TaskController) DuplicateTask(ctx echo.Context, taskId string, request openapi.ModelsTaskDuplicateTaskRequest) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// バリデーション: 複製先の日付
+	if _, err := time.Parse("2006-01-02", request.Date); err != nil {
+		return HandleValidationError(ctx, "Validation failed", map[string]interface{}{
+			"errors": ConvertValidationErrorsToMap([]ValidationError{{
+				Field:   "date",
+				Message: "dateは有効な日付形式である必要があります",
+			}}),
+		})
+	}
+
+	options := task.DuplicateOptions{
+		ResetStatus:    request.ResetStatus != nil && *request.ResetStatus,
+		DropOutputs:    request.DropOutputs != nil && *request.DropOutputs,
+		IncompleteOnly: request.IncompleteOnly != nil && *request.IncompleteOnly,
+	}
+
+	// ユースケースを実行
+	createdTask, owner, err := c.taskUsecase.DuplicateTask(ctx.Request().Context(), taskId, ownerID, request.Date, options)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換（ETagヘッダーにバージョンを含める）
+	return respondTask(ctx, http.StatusCreated, createdTask, owner)
+}
+
 // UpdateTask タスクを更新
-func (c *TaskController) UpdateTask(ctx echo.Context, taskId string, request openapi.ModelsTaskUpdateTaskRequest) error {
-	// リクエストからownerIdを取得
-	ownerID := request.OwnerId
-	if ownerID == "" {
-		return HandleBadRequest(ctx, "Owner ID is required", nil)
+func (c *TaskController) UpdateTask(ctx echo.Context, taskId string, ifMatch *string, request openapi.ModelsTaskUpdateTaskRequest) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// If-Matchヘッダーから更新対象のバージョンを取得
+	expectedVersion, err := ParseIfMatch(ifMatch)
+	if err != nil {
+		return err
 	}
 
 	// バリデーション: 基本項目
@@ -219,14 +396,16 @@ func (c *TaskController) UpdateTask(ctx echo.Context, taskId string, request ope
 		}
 
 		taskItems = append(taskItems, task.UpdateTaskItemInput{
-			ID:           item.Id,
-			Priority:     task.Priority(item.Priority),
-			Density:      task.Density(item.Density),
-			DurationTime: durationTime,
-			Content:      item.Content,
-			IsRequired:   item.IsRequired,
-			Order:        item.Order,
-			Status:       task.Status(item.Status),
+			ID:               item.Id,
+			Priority:         task.Priority(item.Priority),
+			Density:          task.Density(item.Density),
+			DurationTime:     durationTime,
+			Content:          item.Content,
+			IsRequired:       item.IsRequired,
+			Order:            item.Order,
+			Status:           task.Status(item.Status),
+			CategoryID:       item.CategoryId,
+			OutputTemplateID: item.OutputTemplateId,
 		})
 	}
 
@@ -238,51 +417,37 @@ func (c *TaskController) UpdateTask(ctx echo.Context, taskId string, request ope
 	}
 
 	// ユースケースを実行
-	updatedTask, owner, err := c.taskUsecase.UpdateTask(ctx.Request().Context(), taskId, ownerID, request.Title, request.Date, taskItems)
+	updatedTask, owner, err := c.taskUsecase.UpdateTask(ctx.Request().Context(), taskId, ownerID, request.Title, request.Date, taskItems, expectedVersion)
 	if err != nil {
-		// タスクが見つからない場合
-		if strings.Contains(err.Error(), "task not found") {
-			return HandleNotFound(ctx, "Task not found")
-		}
-		// 権限がない場合
-		if strings.Contains(err.Error(), "permission") {
-			return HandleForbidden(ctx, "You do not have permission to update this task")
-		}
-		return HandleInternalServerError(ctx, err)
+		return c.handleTaskPreconditionFailed(ctx, err, func() (*task.Task, *account.Account, error) {
+			return c.taskUsecase.GetTaskByID(ctx.Request().Context(), taskId, ownerID)
+		})
 	}
 
-	// レスポンスに変換
-	response := presenter.ToTaskResponse(updatedTask, owner)
-
-	return ctx.JSON(http.StatusOK, response)
+	// レスポンスに変換（ETagヘッダーにバージョンを含める）
+	return respondTask(ctx, http.StatusOK, updatedTask, owner)
 }
 
-// DeleteTask タスクを削除
-func (c *TaskController) DeleteTask(ctx echo.Context, taskId string) error {
-	// リクエストボディをパース
-	var request openapi.ModelsTaskDeleteTaskRequest
-	if err := ctx.Bind(&request); err != nil {
-		return HandleBadRequest(ctx, "Invalid request body", err)
+// DeleteTask タスクをゴミ箱に移動
+func (c *TaskController) DeleteTask(ctx echo.Context, taskId string, ifMatch *string) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
 	}
 
-	// リクエストからownerIdを取得
-	ownerID := request.OwnerId
-	if ownerID == "" {
-		return HandleBadRequest(ctx, "Owner ID is required", nil)
+	// If-Matchヘッダーから削除対象のバージョンを取得
+	expectedVersion, err := ParseIfMatch(ifMatch)
+	if err != nil {
+		return err
 	}
 
 	// ユースケースを実行
-	err := c.taskUsecase.DeleteTask(ctx.Request().Context(), taskId, ownerID)
+	err = c.taskUsecase.DeleteTask(ctx.Request().Context(), taskId, ownerID, expectedVersion)
 	if err != nil {
-		// タスクが見つからない場合
-		if strings.Contains(err.Error(), "task not found") {
-			return HandleNotFound(ctx, "Task not found")
-		}
-		// 権限がない場合
-		if strings.Contains(err.Error(), "permission") {
-			return HandleForbidden(ctx, "You do not have permission to delete this task")
-		}
-		return HandleInternalServerError(ctx, err)
+		return c.handleTaskPreconditionFailed(ctx, err, func() (*task.Task, *account.Account, error) {
+			return c.taskUsecase.GetTaskByID(ctx.Request().Context(), taskId, ownerID)
+		})
 	}
 
 	// レスポンスを返す
@@ -291,77 +456,282 @@ func (c *TaskController) DeleteTask(ctx echo.Context, taskId string) error {
 	})
 }
 
-// UpdateTaskItemOutput タスクアイテムのアウトプットを更新
-func (c *TaskController) UpdateTaskItemOutput(ctx echo.Context, taskItemId string, request openapi.ModelsTaskUpdateTaskItemOutputRequest) error {
-	// リクエストからownerIdを取得
-	ownerID := request.OwnerId
-	if ownerID == "" {
-		return HandleBadRequest(ctx, "Owner ID is required", nil)
+// ListTrashedTasks ゴミ箱のタスク一覧を取得
+func (c *TaskController) ListTrashedTasks(ctx echo.Context) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
 	}
 
-	// バリデーション: outputの最小長チェック
-	if len(request.Output) == 0 {
-		return HandleValidationError(ctx, "Validation failed", map[string]interface{}{
-			"errors": []map[string]string{
-				{"field": "output", "message": "outputは1文字以上である必要があります"},
-			},
-		})
+	// ユースケースを実行
+	tasks, owner, err := c.taskUsecase.ListTrashedTasks(ctx.Request().Context(), ownerID)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換
+	response := presenter.ToTaskResponseList(tasks, owner, nil, nil)
+
+	return ctx.JSON(http.StatusOK, response)
+}
+
+// RestoreTask ゴミ箱のタスクを元に戻す
+func (c *TaskController) RestoreTask(ctx echo.Context, taskId string) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
 	}
 
 	// ユースケースを実行
-	updatedTask, owner, err := c.taskUsecase.UpdateTaskItemOutput(ctx.Request().Context(), taskItemId, ownerID, request.Output)
+	restoredTask, owner, err := c.taskUsecase.RestoreTask(ctx.Request().Context(), taskId, ownerID)
 	if err != nil {
-		// タスクが見つからない場合
-		if strings.Contains(err.Error(), "task not found") {
-			return HandleNotFound(ctx, "Task not found")
+		return err
+	}
+
+	// レスポンスに変換（ETagヘッダーにバージョンを含める）
+	return respondTask(ctx, http.StatusOK, restoredTask, owner)
+}
+
+// PurgeTask ゴミ箱のタスクを完全に削除
+func (c *TaskController) PurgeTask(ctx echo.Context, taskId string) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// ユースケースを実行
+	if err := c.taskUsecase.PurgeTask(ctx.Request().Context(), taskId, ownerID); err != nil {
+		return err
+	}
+
+	// レスポンスを返す
+	return ctx.JSON(http.StatusOK, openapi.ModelsTaskDeleteTaskResponse{
+		Success: true,
+	})
+}
+
+// PatchTaskItem 子タスクの指定した項目のみを更新
+func (c *TaskController) PatchTaskItem(ctx echo.Context, taskItemId string, request openapi.ModelsTaskPatchTaskItemRequest) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// リクエストをドメインの入力に変換（項目の検証はタスクの集約で行う）
+	patch := task.TaskItemPatch{
+		Content:    request.Content,
+		IsRequired: request.IsRequired,
+		Order:      request.Order,
+	}
+	if request.Priority != nil {
+		priority := task.Priority(*request.Priority)
+		patch.Priority = &priority
+	}
+	if request.Density != nil {
+		density := task.Density(*request.Density)
+		patch.Density = &density
+	}
+	if request.DurationTime != nil {
+		durationTime := task.DurationTime(*request.DurationTime)
+		patch.DurationTime = &durationTime
+	}
+	// 空文字の場合は未分類・自由形式にする
+	if request.CategoryId != nil {
+		if *request.CategoryId == "" {
+			patch.ClearCategory = true
+		} else {
+			patch.CategoryID = request.CategoryId
 		}
-		// タスクアイテムが見つからない場合
-		if strings.Contains(err.Error(), "task item not found") {
-			return HandleNotFound(ctx, "Task item not found")
+	}
+	if request.OutputTemplateId != nil {
+		if *request.OutputTemplateId == "" {
+			patch.ClearOutputTemplate = true
+		} else {
+			patch.OutputTemplateID = request.OutputTemplateId
 		}
-		// 権限がない場合
-		if strings.Contains(err.Error(), "permission") {
-			return HandleForbidden(ctx, "You do not have permission to update this task item")
+	}
+
+	// ユースケースを実行
+	updatedTask, owner, err := c.taskUsecase.PatchTaskItem(ctx.Request().Context(), taskItemId, ownerID, patch)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換（ETagヘッダーにバージョンを含める）
+	return respondTask(ctx, http.StatusOK, updatedTask, owner)
+}
+
+// DeleteTaskItem 子タスクを1件削除
+func (c *TaskController) DeleteTaskItem(ctx echo.Context, taskItemId string) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// ユースケースを実行
+	updatedTask, owner, err := c.taskUsecase.DeleteTaskItem(ctx.Request().Context(), taskItemId, ownerID)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換（ETagヘッダーにバージョンを含める）
+	return respondTask(ctx, http.StatusOK, updatedTask, owner)
+}
+
+// ChangeTaskItemStatus 子タスクのステータスを変更
+func (c *TaskController) ChangeTaskItemStatus(ctx echo.Context, taskItemId string, request openapi.ModelsTaskChangeTaskItemStatusRequest) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// ユースケースを実行
+	updatedTask, owner, err := c.taskUsecase.ChangeTaskItemStatus(ctx.Request().Context(), taskItemId, ownerID, task.Status(request.Status))
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換（ETagヘッダーにバージョンを含める）
+	return respondTask(ctx, http.StatusOK, updatedTask, owner)
+}
+
+// UpdateTaskItemOutput タスクアイテムのアウトプットを更新
+func (c *TaskController) UpdateTaskItemOutput(ctx echo.Context, taskItemId string, ifMatch *string, request openapi.ModelsTaskUpdateTaskItemOutputRequest) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// If-Matchヘッダーから子タスクが属するタスクのバージョンを取得
+	expectedVersion, err := ParseIfMatch(ifMatch)
+	if err != nil {
+		return err
+	}
+
+	// リクエストをドメインの入力に変換（テンプレートに沿っているかはユースケースで検証する）
+	input := task.TaskItemOutputInput{Text: request.Output}
+	if request.Sections != nil {
+		input.Sections = make([]outputtemplate.SectionValue, 0, len(*request.Sections))
+		for _, section := range *request.Sections {
+			input.Sections = append(input.Sections, outputtemplate.SectionValue{
+				Key:   section.Key,
+				Value: section.Value,
+			})
 		}
-		return HandleInternalServerError(ctx, err)
 	}
 
-	// レスポンスに変換
-	response := presenter.ToTaskResponse(updatedTask, owner)
+	// ユースケースを実行
+	updatedTask, owner, err := c.taskUsecase.UpdateTaskItemOutput(ctx.Request().Context(), taskItemId, ownerID, input, expectedVersion)
+	if err != nil {
+		return c.handleTaskPreconditionFailed(ctx, err, func() (*task.Task, *account.Account, error) {
+			return c.taskUsecase.GetTaskByTaskItemID(ctx.Request().Context(), taskItemId, ownerID)
+		})
+	}
 
-	return ctx.JSON(http.StatusOK, response)
+	// レスポンスに変換（ETagヘッダーにバージョンを含める）
+	return respondTask(ctx, http.StatusOK, updatedTask, owner)
+}
+
+// StartTaskItemTimer タスクアイテムのタイマーを開始
+func (c *TaskController) StartTaskItemTimer(ctx echo.Context, taskItemId string) error {
+	return c.controlTaskItemTimer(ctx, taskItemId, task.TimerActionStart)
+}
+
+// PauseTaskItemTimer タスクアイテムのタイマーを一時停止
+func (c *TaskController) PauseTaskItemTimer(ctx echo.Context, taskItemId string) error {
+	return c.controlTaskItemTimer(ctx, taskItemId, task.TimerActionPause)
+}
+
+// ResumeTaskItemTimer タスクアイテムのタイマーを再開
+func (c *TaskController) ResumeTaskItemTimer(ctx echo.Context, taskItemId string) error {
+	return c.controlTaskItemTimer(ctx, taskItemId, task.TimerActionResume)
+}
+
+// StopTaskItemTimer タスクアイテムのタイマーを停止
+func (c *TaskController) StopTaskItemTimer(ctx echo.Context, taskItemId string) error {
+	return c.controlTaskItemTimer(ctx, taskItemId, task.TimerActionStop)
+}
+
+// controlTaskItemTimer タスクアイテムのタイマーを操作
+func (c *TaskController) controlTaskItemTimer(ctx echo.Context, taskItemId string, action task.TimerAction) error {
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// ユースケースを実行
+	updatedTask, owner, err := c.taskUsecase.ControlTaskItemTimer(ctx.Request().Context(), taskItemId, ownerID, action)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスに変換（ETagヘッダーにバージョンを含める）
+	return respondTask(ctx, http.StatusOK, updatedTask, owner)
 }
 
 // UpdateTaskReview タスクの振り返りを更新
-func (c *TaskController) UpdateTaskReview(ctx echo.Context, taskId string) error {
+func (c *TaskController) UpdateTaskReview(ctx echo.Context, taskId string, ifMatch *string) error {
 	// リクエストボディをパース
 	var request openapi.ModelsTaskUpdateTaskReviewRequest
 	if err := ctx.Bind(&request); err != nil {
 		return HandleBadRequest(ctx, "Invalid request body", err)
 	}
 
-	// リクエストからownerIdを取得
-	ownerID := request.OwnerId
-	if ownerID == "" {
-		return HandleBadRequest(ctx, "Owner ID is required", nil)
+	// 認証済みのアカウントIDをオーナーとして使用
+	ownerID, ok := CurrentAccountID(ctx)
+	if !ok {
+		return HandleUnauthorized(ctx, "Authentication required")
+	}
+
+	// If-Matchヘッダーから更新対象のバージョンを取得
+	expectedVersion, err := ParseIfMatch(ifMatch)
+	if err != nil {
+		return err
 	}
 
 	// ユースケースを実行
-	updatedTask, owner, err := c.taskUsecase.UpdateTaskReview(ctx.Request().Context(), taskId, ownerID, request.Review)
+	updatedTask, owner, err := c.taskUsecase.UpdateTaskReview(ctx.Request().Context(), taskId, ownerID, request.Review, expectedVersion)
 	if err != nil {
-		// タスクが見つからない場合
-		if strings.Contains(err.Error(), "task not found") {
-			return HandleNotFound(ctx, "Task not found")
-		}
-		// 権限がない場合
-		if strings.Contains(err.Error(), "permission") {
-			return HandleForbidden(ctx, "You do not have permission to update this task review")
-		}
-		return HandleInternalServerError(ctx, err)
+		return c.handleTaskPreconditionFailed(ctx, err, func() (*task.Task, *account.Account, error) {
+			return c.taskUsecase.GetTaskByID(ctx.Request().Context(), taskId, ownerID)
+		})
 	}
 
-	// レスポンスに変換
-	response := presenter.ToTaskResponse(updatedTask, owner)
+	// レスポンスに変換（ETagヘッダーにバージョンを含める）
+	return respondTask(ctx, http.StatusOK, updatedTask, owner)
+}
 
-	return ctx.JSON(http.StatusOK, response)
+// respondTask タスクのレスポンスをETagヘッダーと合わせて返す
+func respondTask(ctx echo.Context, status int, t *task.Task, owner *account.Account) error {
+	ctx.Response().Header().Set("ETag", presenter.TaskETag(t))
+	return ctx.JSON(status, presenter.ToTaskResponse(t, owner))
+}
+
+// handleTaskPreconditionFailed タスクのバージョンが一致しない場合、現在のタスクを含めて412を返す
+// それ以外のエラーはそのまま返し、エラーハンドラーでレスポンスに変換する
+func (c *TaskController) handleTaskPreconditionFailed(ctx echo.Context, err error, loadCurrent func() (*task.Task, *account.Account, error)) error {
+	domainErr, ok := domainerrors.As(err)
+	if !ok || domainErr.Kind != domainerrors.KindPreconditionFailed {
+		return err
+	}
+
+	current, owner, loadErr := loadCurrent()
+	if loadErr != nil {
+		return loadErr
+	}
+
+	ctx.Response().Header().Set("ETag", presenter.TaskETag(current))
+	return ctx.JSON(http.StatusPreconditionFailed, openapi.ModelsTaskTaskPreconditionFailedError{
+		Code:    openapi.PRECONDITIONFAILED,
+		Message: domainErr.Message,
+		Current: presenter.ToTaskResponse(current, owner),
+	})
 }
@@ -9,15 +9,23 @@ import (
 
 // Server ServerInterfaceの実装
 type Server struct {
-	taskController    *controller.TaskController
-	accountController *controller.AccountController
+	taskController              *controller.TaskController
+	accountController           *controller.AccountController
+	categoryController          *controller.CategoryController
+	outputTemplateController    *controller.OutputTemplateController
+	reportController            *controller.ReportController
+	recurringTemplateController *controller.RecurringTemplateController
 }
 
 // NewServer サーバーを作成
-func NewServer(taskController *controller.TaskController, accountController *controller.AccountController) *Server {
+func NewServer(taskController *controller.TaskController, accountController *controller.AccountController, categoryController *controller.CategoryController, outputTemplateController *controller.OutputTemplateController, reportController *controller.ReportController, recurringTemplateController *controller.RecurringTemplateController) *Server {
 	return &Server{
-		taskController:    taskController,
-		accountController: accountController,
+		taskController:              taskController,
+		accountController:           accountController,
+		categoryController:          categoryController,
+		outputTemplateController:    outputTemplateController,
+		reportController:            reportController,
+		recurringTemplateController: recurringTemplateController,
 	}
 }
 
@@ -51,12 +59,32 @@ func (s *Server) AccountsGetAccountById(ctx echo.Context, accountId string) erro
 }
 
 // TaskItemsUpdateTaskItemOutput タスクアイテムのアウトプットを更新
-func (s *Server) TaskItemsUpdateTaskItemOutput(ctx echo.Context, taskItemId string) error {
+func (s *Server) TaskItemsUpdateTaskItemOutput(ctx echo.Context, taskItemId string, params openapi.TaskItemsUpdateTaskItemOutputParams) error {
 	var request openapi.ModelsTaskUpdateTaskItemOutputRequest
 	if err := ctx.Bind(&request); err != nil {
 		return ctx.JSON(400, map[string]string{"error": "Invalid request body"})
 	}
-	return s.taskController.UpdateTaskItemOutput(ctx, taskItemId, request)
+	return s.taskController.UpdateTaskItemOutput(ctx, taskItemId, params.IfMatch, request)
+}
+
+// TaskItemsStartTaskItemTimer タスクアイテムのタイマーを開始
+func (s *Server) TaskItemsStartTaskItemTimer(ctx echo.Context, taskItemId string) error {
+	return s.taskController.StartTaskItemTimer(ctx, taskItemId)
+}
+
+// TaskItemsPauseTaskItemTimer タスクアイテムのタイマーを一時停止
+func (s *Server) TaskItemsPauseTaskItemTimer(ctx echo.Context, taskItemId string) error {
+	return s.taskController.PauseTaskItemTimer(ctx, taskItemId)
+}
+
+// TaskItemsResumeTaskItemTimer タスクアイテムのタイマーを再開
+func (s *Server) TaskItemsResumeTaskItemTimer(ctx echo.Context, taskItemId string) error {
+	return s.taskController.ResumeTaskItemTimer(ctx, taskItemId)
+}
+
+// TaskItemsStopTaskItemTimer タスクアイテムのタイマーを停止
+func (s *Server) TaskItemsStopTaskItemTimer(ctx echo.Context, taskItemId string) error {
+	return s.taskController.StopTaskItemTimer(ctx, taskItemId)
 }
 
 // TasksCreateTask タスクを作成
@@ -73,9 +101,24 @@ func (s *Server) TasksCreateTask(ctx echo.Context) error {
 	return s.taskController.CreateTask(ctx, request)
 }
 
-// TasksDeleteTask タスクを削除
-func (s *Server) TasksDeleteTask(ctx echo.Context, taskId string) error {
-	return s.taskController.DeleteTask(ctx, taskId)
+// TasksDeleteTask タスクをゴミ箱に移動
+func (s *Server) TasksDeleteTask(ctx echo.Context, taskId string, params openapi.TasksDeleteTaskParams) error {
+	return s.taskController.DeleteTask(ctx, taskId, params.IfMatch)
+}
+
+// TasksListTrashedTasks ゴミ箱のタスク一覧を取得
+func (s *Server) TasksListTrashedTasks(ctx echo.Context) error {
+	return s.taskController.ListTrashedTasks(ctx)
+}
+
+// TasksRestoreTask ゴミ箱のタスクを元に戻す
+func (s *Server) TasksRestoreTask(ctx echo.Context, taskId string) error {
+	return s.taskController.RestoreTask(ctx, taskId)
+}
+
+// TasksPurgeTask ゴミ箱のタスクを完全に削除
+func (s *Server) TasksPurgeTask(ctx echo.Context, taskId string) error {
+	return s.taskController.PurgeTask(ctx, taskId)
 }
 
 // TasksGetTaskById タスクIDでタスクを取得
@@ -83,16 +126,250 @@ func (s *Server) TasksGetTaskById(ctx echo.Context, taskId string) error {
 	return s.taskController.GetTaskByID(ctx, taskId)
 }
 
+// TasksGetTaskHistory タスクの変更履歴を取得
+func (s *Server) TasksGetTaskHistory(ctx echo.Context, taskId string) error {
+	return s.taskController.GetTaskHistory(ctx, taskId)
+}
+
 // TasksUpdateTask タスクを更新
-func (s *Server) TasksUpdateTask(ctx echo.Context, taskId string) error {
+func (s *Server) TasksUpdateTask(ctx echo.Context, taskId string, params openapi.TasksUpdateTaskParams) error {
 	var request openapi.ModelsTaskUpdateTaskRequest
 	if err := ctx.Bind(&request); err != nil {
 		return ctx.JSON(400, map[string]string{"error": "Invalid request body"})
 	}
-	return s.taskController.UpdateTask(ctx, taskId, request)
+	return s.taskController.UpdateTask(ctx, taskId, params.IfMatch, request)
+}
+
+// TasksAddTaskItem タスクに子タスクを追加
+func (s *Server) TasksAddTaskItem(ctx echo.Context, taskId string) error {
+	var request openapi.ModelsTaskCreateTaskItemRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(400, openapi.ModelsCommonBadRequestError{
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+	return s.taskController.AddTaskItem(ctx, taskId, request)
+}
+
+// TasksReorderTaskItems 子タスクを並び替え
+func (s *Server) TasksReorderTaskItems(ctx echo.Context, taskId string) error {
+	var request openapi.ModelsTaskReorderTaskItemsRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(400, openapi.ModelsCommonBadRequestError{
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+	return s.taskController.ReorderTaskItems(ctx, taskId, request)
+}
+
+// TasksCarryOverTaskItems 完了していない子タスクを別の日付に持ち越し
+func (s *Server) TasksCarryOverTaskItems(ctx echo.Context, taskId string) error {
+	var request openapi.ModelsTaskCarryOverTaskItemsRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(400, openapi.ModelsCommonBadRequestError{
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+	return s.taskController.CarryOverTaskItems(ctx, taskId, request)
+}
+
+// TasksDuplicateTask タスクを複製
+func (s *Server) TasksDuplicateTask(ctx echo.Context, taskId string) error {
+	var request openapi.ModelsTaskDuplicateTaskRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(400, openapi.ModelsCommonBadRequestError{
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+	return s.taskController.DuplicateTask(ctx, taskId, request)
 }
 
 // TasksUpdateTaskReview タスクの振り返りを更新
-func (s *Server) TasksUpdateTaskReview(ctx echo.Context, taskId string) error {
-	return s.taskController.UpdateTaskReview(ctx, taskId)
+func (s *Server) TasksUpdateTaskReview(ctx echo.Context, taskId string, params openapi.TasksUpdateTaskReviewParams) error {
+	return s.taskController.UpdateTaskReview(ctx, taskId, params.IfMatch)
+}
+
+// CategoriesListCategories カテゴリ一覧を取得
+func (s *Server) CategoriesListCategories(ctx echo.Context) error {
+	return s.categoryController.ListCategories(ctx)
+}
+
+// CategoriesCreateCategory カテゴリを作成
+func (s *Server) CategoriesCreateCategory(ctx echo.Context) error {
+	var request openapi.ModelsCategoryCreateCategoryRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(400, openapi.ModelsCommonBadRequestError{
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+	return s.categoryController.CreateCategory(ctx, request)
+}
+
+// CategoriesGetCategoryById カテゴリIDでカテゴリを取得
+func (s *Server) CategoriesGetCategoryById(ctx echo.Context, categoryId string) error {
+	return s.categoryController.GetCategoryByID(ctx, categoryId)
+}
+
+// CategoriesUpdateCategory カテゴリを更新
+func (s *Server) CategoriesUpdateCategory(ctx echo.Context, categoryId string) error {
+	var request openapi.ModelsCategoryUpdateCategoryRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(400, openapi.ModelsCommonBadRequestError{
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+	return s.categoryController.UpdateCategory(ctx, categoryId, request)
+}
+
+// CategoriesDeleteCategory カテゴリを削除
+func (s *Server) CategoriesDeleteCategory(ctx echo.Context, categoryId string) error {
+	return s.categoryController.DeleteCategory(ctx, categoryId)
+}
+
+// OutputTemplatesListOutputTemplates アウトプットテンプレート一覧を取得
+func (s *Server) OutputTemplatesListOutputTemplates(ctx echo.Context) error {
+	return s.outputTemplateController.ListOutputTemplates(ctx)
+}
+
+// OutputTemplatesCreateOutputTemplate アウトプットテンプレートを作成
+func (s *Server) OutputTemplatesCreateOutputTemplate(ctx echo.Context) error {
+	var request openapi.ModelsOutputTemplateCreateOutputTemplateRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(400, openapi.ModelsCommonBadRequestError{
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+	return s.outputTemplateController.CreateOutputTemplate(ctx, request)
+}
+
+// OutputTemplatesGetOutputTemplateById テンプレートIDでアウトプットテンプレートを取得
+func (s *Server) OutputTemplatesGetOutputTemplateById(ctx echo.Context, outputTemplateId string) error {
+	return s.outputTemplateController.GetOutputTemplateByID(ctx, outputTemplateId)
+}
+
+// OutputTemplatesUpdateOutputTemplate アウトプットテンプレートを更新
+func (s *Server) OutputTemplatesUpdateOutputTemplate(ctx echo.Context, outputTemplateId string) error {
+	var request openapi.ModelsOutputTemplateUpdateOutputTemplateRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(400, openapi.ModelsCommonBadRequestError{
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+	return s.outputTemplateController.UpdateOutputTemplate(ctx, outputTemplateId, request)
+}
+
+// OutputTemplatesDeleteOutputTemplate アウトプットテンプレートを削除
+func (s *Server) OutputTemplatesDeleteOutputTemplate(ctx echo.Context, outputTemplateId string) error {
+	return s.outputTemplateController.DeleteOutputTemplate(ctx, outputTemplateId)
+}
+
+// ReportsGetReport 振り返りレポートを取得
+func (s *Server) ReportsGetReport(ctx echo.Context, params openapi.ReportsGetReportParams) error {
+	return s.reportController.GetReport(ctx, params)
+}
+
+// RecurringTemplatesListRecurringTemplates 繰り返しテンプレート一覧を取得
+func (s *Server) RecurringTemplatesListRecurringTemplates(ctx echo.Context) error {
+	return s.recurringTemplateController.ListRecurringTemplates(ctx)
+}
+
+// RecurringTemplatesCreateRecurringTemplate 繰り返しテンプレートを作成
+func (s *Server) RecurringTemplatesCreateRecurringTemplate(ctx echo.Context) error {
+	var request openapi.ModelsRecurringTemplateCreateRecurringTemplateRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(400, openapi.ModelsCommonBadRequestError{
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+	return s.recurringTemplateController.CreateRecurringTemplate(ctx, request)
+}
+
+// RecurringTemplatesGenerateRecurringTasks 繰り返しテンプレートからタスクを作成
+func (s *Server) RecurringTemplatesGenerateRecurringTasks(ctx echo.Context) error {
+	var request openapi.ModelsRecurringTemplateGenerateRecurringTasksRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(400, openapi.ModelsCommonBadRequestError{
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+	return s.recurringTemplateController.GenerateTasks(ctx, request)
+}
+
+// RecurringTemplatesGetRecurringTemplateById テンプレートIDで繰り返しテンプレートを取得
+func (s *Server) RecurringTemplatesGetRecurringTemplateById(ctx echo.Context, recurringTemplateId string) error {
+	return s.recurringTemplateController.GetRecurringTemplateByID(ctx, recurringTemplateId)
+}
+
+// RecurringTemplatesUpdateRecurringTemplate 繰り返しテンプレートを更新
+func (s *Server) RecurringTemplatesUpdateRecurringTemplate(ctx echo.Context, recurringTemplateId string) error {
+	var request openapi.ModelsRecurringTemplateUpdateRecurringTemplateRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(400, openapi.ModelsCommonBadRequestError{
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+	return s.recurringTemplateController.UpdateRecurringTemplate(ctx, recurringTemplateId, request)
+}
+
+// RecurringTemplatesDeleteRecurringTemplate 繰り返しテンプレートを削除
+func (s *Server) RecurringTemplatesDeleteRecurringTemplate(ctx echo.Context, recurringTemplateId string) error {
+	return s.recurringTemplateController.DeleteRecurringTemplate(ctx, recurringTemplateId)
+}
+
+// RecurringTemplatesListRecurringTemplateOccurrences 繰り返しテンプレートからタスクが作成される日付をプレビュー
+func (s *Server) RecurringTemplatesListRecurringTemplateOccurrences(ctx echo.Context, recurringTemplateId string, params openapi.RecurringTemplatesListRecurringTemplateOccurrencesParams) error {
+	return s.recurringTemplateController.ListOccurrences(ctx, recurringTemplateId, params)
+}
+
+// TaskItemsPatchTaskItem 子タスクの指定した項目のみを更新
+func (s *Server) TaskItemsPatchTaskItem(ctx echo.Context, taskItemId string) error {
+	var request openapi.ModelsTaskPatchTaskItemRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(400, openapi.ModelsCommonBadRequestError{
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+	return s.taskController.PatchTaskItem(ctx, taskItemId, request)
+}
+
+// TaskItemsDeleteTaskItem 子タスクを削除
+func (s *Server) TaskItemsDeleteTaskItem(ctx echo.Context, taskItemId string) error {
+	return s.taskController.DeleteTaskItem(ctx, taskItemId)
+}
+
+// TaskItemsChangeTaskItemStatus 子タスクのステータスを変更
+func (s *Server) TaskItemsChangeTaskItemStatus(ctx echo.Context, taskItemId string) error {
+	var request openapi.ModelsTaskChangeTaskItemStatusRequest
+	if err := ctx.Bind(&request); err != nil {
+		return ctx.JSON(400, openapi.ModelsCommonBadRequestError{
+			Code:    "BAD_REQUEST",
+			Message: "Invalid request body",
+			Details: err.Error(),
+		})
+	}
+	return s.taskController.ChangeTaskItemStatus(ctx, taskItemId, request)
 }
@@ -0,0 +1,188 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"task-management-system/backend/internal/adapter/http/generated/openapi"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+)
+
+// 署名アルゴリズム
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+)
+
+// ServiceTokenHeader 内部APIを呼び出すサービス（フロントエンドのサーバー）の資格情報を送るヘッダー
+const ServiceTokenHeader = "X-Service-Token"
+
+// accountIDContextKey 認証済みアカウントIDを保持するコンテキストキー
+type accountIDContextKey struct{}
+
+// AuthConfig 認証設定
+type AuthConfig struct {
+	// Algorithm 署名アルゴリズム（HS256またはRS256）
+	Algorithm string
+	// Secret HS256で使用する共有シークレット
+	Secret []byte
+	// PublicKey RS256で使用する公開鍵
+	PublicKey *rsa.PublicKey
+	// Issuer 期待するiss（空の場合は検証しない）
+	Issuer string
+	// Audience 期待するaud（空の場合は検証しない）
+	Audience string
+}
+
+// TokenVerifier トークン検証器
+type TokenVerifier struct {
+	key    interface{}
+	parser *jwt.Parser
+}
+
+// NewTokenVerifier トークン検証器を作成
+func NewTokenVerifier(config AuthConfig) (*TokenVerifier, error) {
+	var key interface{}
+	switch config.Algorithm {
+	case AlgorithmHS256:
+		if len(config.Secret) == 0 {
+			return nil, errors.New("secret is required for HS256")
+		}
+		key = config.Secret
+	case AlgorithmRS256:
+		if config.PublicKey == nil {
+			return nil, errors.New("public key is required for RS256")
+		}
+		key = config.PublicKey
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", config.Algorithm)
+	}
+
+	// 設定したアルゴリズム以外で署名されたトークンは受け付けない（alg: none や鍵の取り違えを防ぐ）
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{config.Algorithm}),
+		jwt.WithExpirationRequired(),
+	}
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+
+	return &TokenVerifier{
+		key:    key,
+		parser: jwt.NewParser(options...),
+	}, nil
+}
+
+// Verify トークンを検証してアカウントID（subクレーム）を返す
+func (v *TokenVerifier) Verify(tokenString string) (string, error) {
+	claims := &jwt.RegisteredClaims{}
+	_, err := v.parser.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return v.key, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if claims.Subject == "" {
+		return "", errors.New("token has no subject")
+	}
+
+	return claims.Subject, nil
+}
+
+// Auth Authorizationヘッダーのベアラートークンを検証し、アカウントIDをリクエストコンテキストに格納するミドルウェア
+// skipperがtrueを返すリクエストは検証しない
+func Auth(verifier *TokenVerifier, skipper func(ctx echo.Context) bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			if skipper != nil && skipper(ctx) {
+				return next(ctx)
+			}
+
+			tokenString, ok := bearerToken(ctx.Request())
+			if !ok {
+				return unauthorized(ctx, "Authentication required")
+			}
+
+			accountID, err := verifier.Verify(tokenString)
+			if err != nil {
+				ctx.Logger().Warnf("Token verification failed: %v", err)
+				return unauthorized(ctx, "Invalid or expired token")
+			}
+
+			request := ctx.Request()
+			ctx.SetRequest(request.WithContext(WithAccountID(request.Context(), accountID)))
+
+			return next(ctx)
+		}
+	}
+}
+
+// ServiceAuth 内部API（isServiceRouteがtrueを返すルート）のリクエストを、サービスの資格情報で認証するミドルウェア
+// better-authがトークンの発行前に呼び出すAPIを、資格情報を共有したフロントエンドのサーバー以外から呼び出せないようにする
+// tokenが空の場合は内部APIへのリクエストをすべて拒否する
+func ServiceAuth(token string, isServiceRoute func(ctx echo.Context) bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			if !isServiceRoute(ctx) {
+				return next(ctx)
+			}
+
+			given := ctx.Request().Header.Get(ServiceTokenHeader)
+			if token == "" || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+				return unauthorized(ctx, "Service credential required")
+			}
+
+			return next(ctx)
+		}
+	}
+}
+
+// WithAccountID 認証済みアカウントIDをコンテキストに設定
+func WithAccountID(ctx context.Context, accountID string) context.Context {
+	return context.WithValue(ctx, accountIDContextKey{}, accountID)
+}
+
+// AccountIDFromContext コンテキストから認証済みアカウントIDを取得
+func AccountIDFromContext(ctx context.Context) (string, bool) {
+	accountID, ok := ctx.Value(accountIDContextKey{}).(string)
+	if !ok || accountID == "" {
+		return "", false
+	}
+	return accountID, true
+}
+
+// bearerToken Authorizationヘッダーからベアラートークンを取り出す
+func bearerToken(request *http.Request) (string, bool) {
+	header := request.Header.Get(echo.HeaderAuthorization)
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", false
+	}
+
+	return token, true
+}
+
+// unauthorized 認証エラーを返す
+func unauthorized(ctx echo.Context, message string) error {
+	ctx.Response().Header().Set(echo.HeaderWWWAuthenticate, "Bearer")
+	return ctx.JSON(http.StatusUnauthorized, openapi.ModelsCommonUnauthorizedError{
+		Code:    openapi.UNAUTHORIZED,
+		Message: message,
+	})
+}
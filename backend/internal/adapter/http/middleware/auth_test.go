@@ -0,0 +1,286 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+)
+
+const testAccountID = "8f7c2a4e-2b1d-4c55-9a8e-3f0d6b9e1c27"
+
+var testSecret = []byte("test-secret")
+
+func signHS256(t *testing.T, secret []byte, claims jwt.RegisteredClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+func validClaims() jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		Subject:   testAccountID,
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+}
+
+// serve 認証ミドルウェアを通してリクエストを処理し、レスポンスとハンドラーが受け取ったアカウントIDを返す
+func serve(t *testing.T, verifier *TokenVerifier, authorization string) (*httptest.ResponseRecorder, string) {
+	t.Helper()
+
+	e := echo.New()
+	var gotAccountID string
+	e.Use(Auth(verifier, func(ctx echo.Context) bool {
+		return ctx.Path() == "/public"
+	}))
+	handler := func(ctx echo.Context) error {
+		gotAccountID, _ = AccountIDFromContext(ctx.Request().Context())
+		return ctx.NoContent(http.StatusOK)
+	}
+	e.GET("/private", handler)
+	e.GET("/public", handler)
+
+	request := httptest.NewRequest(http.MethodGet, "/private", nil)
+	if authorization != "" {
+		request.Header.Set(echo.HeaderAuthorization, authorization)
+	}
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, request)
+
+	return recorder, gotAccountID
+}
+
+func TestAuth_HS256(t *testing.T) {
+	verifier, err := NewTokenVerifier(AuthConfig{Algorithm: AlgorithmHS256, Secret: testSecret})
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+
+	expired := validClaims()
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+
+	withoutExpiry := validClaims()
+	withoutExpiry.ExpiresAt = nil
+
+	withoutSubject := validClaims()
+	withoutSubject.Subject = ""
+
+	unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims()).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatalf("failed to build unsigned token: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantAccountID string
+	}{
+		{
+			name:          "有効なトークン",
+			authorization: "Bearer " + signHS256(t, testSecret, validClaims()),
+			wantStatus:    http.StatusOK,
+			wantAccountID: testAccountID,
+		},
+		{
+			name:       "トークンなし",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "Bearer以外のスキーム",
+			authorization: "Basic dXNlcjpwYXNz",
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "別のシークレットで署名された偽造トークン",
+			authorization: "Bearer " + signHS256(t, []byte("forged-secret"), validClaims()),
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "署名なし（alg: none）のトークン",
+			authorization: "Bearer " + unsigned,
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "期限切れのトークン",
+			authorization: "Bearer " + signHS256(t, testSecret, expired),
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "有効期限のないトークン",
+			authorization: "Bearer " + signHS256(t, testSecret, withoutExpiry),
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "subのないトークン",
+			authorization: "Bearer " + signHS256(t, testSecret, withoutSubject),
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "不正な形式のトークン",
+			authorization: "Bearer not-a-jwt",
+			wantStatus:    http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder, accountID := serve(t, verifier, tt.authorization)
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if accountID != tt.wantAccountID {
+				t.Errorf("accountID = %q, want %q", accountID, tt.wantAccountID)
+			}
+		})
+	}
+}
+
+func TestAuth_RS256(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	verifier, err := NewTokenVerifier(AuthConfig{Algorithm: AlgorithmRS256, PublicKey: &privateKey.PublicKey})
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+
+	signRS256 := func(key *rsa.PrivateKey) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, validClaims()).SignedString(key)
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantAccountID string
+	}{
+		{
+			name:          "有効なトークン",
+			authorization: "Bearer " + signRS256(privateKey),
+			wantStatus:    http.StatusOK,
+			wantAccountID: testAccountID,
+		},
+		{
+			name:          "別の秘密鍵で署名された偽造トークン",
+			authorization: "Bearer " + signRS256(otherKey),
+			wantStatus:    http.StatusUnauthorized,
+		},
+		{
+			name:          "HS256にすり替えられたトークン",
+			authorization: "Bearer " + signHS256(t, testSecret, validClaims()),
+			wantStatus:    http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder, accountID := serve(t, verifier, tt.authorization)
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if accountID != tt.wantAccountID {
+				t.Errorf("accountID = %q, want %q", accountID, tt.wantAccountID)
+			}
+		})
+	}
+}
+
+func TestAuth_Skipper(t *testing.T) {
+	verifier, err := NewTokenVerifier(AuthConfig{Algorithm: AlgorithmHS256, Secret: testSecret})
+	if err != nil {
+		t.Fatalf("failed to create verifier: %v", err)
+	}
+
+	e := echo.New()
+	e.Use(Auth(verifier, func(ctx echo.Context) bool {
+		return ctx.Path() == "/public"
+	}))
+	e.GET("/public", func(ctx echo.Context) error {
+		return ctx.NoContent(http.StatusOK)
+	})
+
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/public", nil))
+	if recorder.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+}
+
+func TestServiceAuth(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		path       string
+		header     string
+		wantStatus int
+	}{
+		{name: "資格情報が一致する", token: "service-token", path: "/internal", header: "service-token", wantStatus: http.StatusOK},
+		{name: "資格情報がない", token: "service-token", path: "/internal", wantStatus: http.StatusUnauthorized},
+		{name: "資格情報が一致しない", token: "service-token", path: "/internal", header: "other-token", wantStatus: http.StatusUnauthorized},
+		{name: "資格情報を設定していない場合は拒否する", token: "", path: "/internal", header: "", wantStatus: http.StatusUnauthorized},
+		{name: "内部API以外は確認しない", token: "service-token", path: "/other", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			e.Use(ServiceAuth(tt.token, func(ctx echo.Context) bool {
+				return ctx.Path() == "/internal"
+			}))
+			handler := func(ctx echo.Context) error {
+				return ctx.NoContent(http.StatusOK)
+			}
+			e.GET("/internal", handler)
+			e.GET("/other", handler)
+
+			request := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				request.Header.Set(ServiceTokenHeader, tt.header)
+			}
+			recorder := httptest.NewRecorder()
+			e.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestNewTokenVerifier_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config AuthConfig
+	}{
+		{name: "HS256でシークレットなし", config: AuthConfig{Algorithm: AlgorithmHS256}},
+		{name: "RS256で公開鍵なし", config: AuthConfig{Algorithm: AlgorithmRS256}},
+		{name: "未対応のアルゴリズム", config: AuthConfig{Algorithm: "none", Secret: testSecret}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTokenVerifier(tt.config); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
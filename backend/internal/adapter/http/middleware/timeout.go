@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequestTimeout リクエストのコンテキストに処理の期限を設定するミドルウェア
+// 期限はユースケースとリポジトリに伝わり、期限を過ぎたデータベースの処理は中断される
+func RequestTimeout(timeout time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			reqCtx, cancel := context.WithTimeout(ctx.Request().Context(), timeout)
+			defer cancel()
+
+			ctx.SetRequest(ctx.Request().WithContext(reqCtx))
+			return next(ctx)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	e := echo.New()
+	e.Use(RequestTimeout(time.Minute))
+
+	var deadline time.Time
+	var hasDeadline bool
+	e.GET("/", func(ctx echo.Context) error {
+		deadline, hasDeadline = ctx.Request().Context().Deadline()
+		return ctx.NoContent(http.StatusOK)
+	})
+
+	recorder := httptest.NewRecorder()
+	started := time.Now()
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+	if !hasDeadline {
+		t.Fatal("request context has no deadline")
+	}
+	if deadline.Before(started.Add(time.Minute)) || deadline.After(time.Now().Add(time.Minute)) {
+		t.Errorf("deadline = %s after the request, want 1m", deadline.Sub(started))
+	}
+}
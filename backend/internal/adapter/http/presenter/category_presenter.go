@@ -0,0 +1,28 @@
+package presenter
+
+import (
+	"task-management-system/backend/internal/adapter/http/generated/openapi"
+	"task-management-system/backend/internal/domain/category"
+)
+
+// ToCategoryResponse カテゴリドメインエンティティをAPIレスポンスに変換
+func ToCategoryResponse(c *category.Category) openapi.ModelsCategoryCategoryResponse {
+	return openapi.ModelsCategoryCategoryResponse{
+		Id:        c.ID,
+		OwnerId:   c.OwnerID,
+		Name:      c.Name,
+		Color:     c.Color,
+		CreatedAt: c.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: c.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// ToCategoryResponseList カテゴリのリストをAPIレスポンスのリストに変換
+func ToCategoryResponseList(categories []*category.Category) []openapi.ModelsCategoryCategoryResponse {
+	result := make([]openapi.ModelsCategoryCategoryResponse, 0, len(categories))
+	for _, c := range categories {
+		result = append(result, ToCategoryResponse(c))
+	}
+
+	return result
+}
@@ -0,0 +1,37 @@
+package presenter
+
+import (
+	"task-management-system/backend/internal/adapter/http/generated/openapi"
+	"task-management-system/backend/internal/domain/outputtemplate"
+)
+
+// ToOutputTemplateResponse アウトプットテンプレートドメインエンティティをAPIレスポンスに変換
+func ToOutputTemplateResponse(t *outputtemplate.OutputTemplate) openapi.ModelsOutputTemplateOutputTemplateResponse {
+	sections := make([]openapi.ModelsOutputTemplateOutputTemplateSection, 0, len(t.Sections))
+	for _, s := range t.Sections {
+		sections = append(sections, openapi.ModelsOutputTemplateOutputTemplateSection{
+			Key:      s.Key,
+			Label:    s.Label,
+			Required: s.Required,
+		})
+	}
+
+	return openapi.ModelsOutputTemplateOutputTemplateResponse{
+		Id:        t.ID,
+		OwnerId:   t.OwnerID,
+		Name:      t.Name,
+		Sections:  sections,
+		CreatedAt: t.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: t.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// ToOutputTemplateResponseList アウトプットテンプレートのリストをAPIレスポンスのリストに変換
+func ToOutputTemplateResponseList(templates []*outputtemplate.OutputTemplate) []openapi.ModelsOutputTemplateOutputTemplateResponse {
+	result := make([]openapi.ModelsOutputTemplateOutputTemplateResponse, 0, len(templates))
+	for _, t := range templates {
+		result = append(result, ToOutputTemplateResponse(t))
+	}
+
+	return result
+}
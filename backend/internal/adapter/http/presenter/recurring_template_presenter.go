@@ -0,0 +1,71 @@
+package presenter
+
+import (
+	"time"
+
+	"task-management-system/backend/internal/adapter/http/generated/openapi"
+	"task-management-system/backend/internal/domain/recurringtemplate"
+)
+
+// ToRecurringTemplateResponse 繰り返しテンプレートドメインエンティティをAPIレスポンスに変換
+func ToRecurringTemplateResponse(t *recurringtemplate.RecurringTemplate) openapi.ModelsRecurringTemplateRecurringTemplateResponse {
+	byDay := make([]openapi.ModelsRecurringTemplateWeekday, 0, len(t.Rule.ByDay))
+	for _, day := range t.Rule.ByDay {
+		byDay = append(byDay, openapi.ModelsRecurringTemplateWeekday(day))
+	}
+
+	var endsOn *string
+	if t.Rule.EndsOn != nil {
+		formatted := t.Rule.EndsOn.Format(time.DateOnly)
+		endsOn = &formatted
+	}
+
+	taskItems := make([]openapi.ModelsRecurringTemplateRecurringTaskItem, 0, len(t.TaskItems))
+	for _, item := range t.TaskItems {
+		taskItems = append(taskItems, openapi.ModelsRecurringTemplateRecurringTaskItem{
+			Priority:         openapi.ModelsTaskPriority(item.Priority),
+			Density:          openapi.ModelsTaskDensity(item.Density),
+			DurationTime:     int32(item.DurationTime),
+			Content:          item.Content,
+			IsRequired:       item.IsRequired,
+			Order:            item.Order,
+			CategoryId:       item.CategoryID,
+			OutputTemplateId: item.OutputTemplateID,
+		})
+	}
+
+	return openapi.ModelsRecurringTemplateRecurringTemplateResponse{
+		Id:        t.ID,
+		OwnerId:   t.OwnerID,
+		Title:     t.Title,
+		Frequency: openapi.ModelsRecurringTemplateFrequency(t.Rule.Frequency),
+		ByDay:     byDay,
+		StartsOn:  t.Rule.StartsOn.Format(time.DateOnly),
+		EndsOn:    endsOn,
+		TaskItems: taskItems,
+		CreatedAt: t.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt: t.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
+
+// ToRecurringTemplateResponseList 繰り返しテンプレートのリストをAPIレスポンスのリストに変換
+func ToRecurringTemplateResponseList(templates []*recurringtemplate.RecurringTemplate) []openapi.ModelsRecurringTemplateRecurringTemplateResponse {
+	result := make([]openapi.ModelsRecurringTemplateRecurringTemplateResponse, 0, len(templates))
+	for _, t := range templates {
+		result = append(result, ToRecurringTemplateResponse(t))
+	}
+
+	return result
+}
+
+// ToRecurringOccurrencesResponse タスクが作成される日付をAPIレスポンスに変換
+func ToRecurringOccurrencesResponse(dates []time.Time) openapi.ModelsRecurringTemplateRecurringOccurrencesResponse {
+	result := make([]string, 0, len(dates))
+	for _, d := range dates {
+		result = append(result, d.Format(time.DateOnly))
+	}
+
+	return openapi.ModelsRecurringTemplateRecurringOccurrencesResponse{
+		Dates: result,
+	}
+}
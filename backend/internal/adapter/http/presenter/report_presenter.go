@@ -0,0 +1,50 @@
+package presenter
+
+import (
+	"task-management-system/backend/internal/adapter/http/generated/openapi"
+	"task-management-system/backend/internal/domain/report"
+)
+
+// ToReportResponse レポートをAPIレスポンスに変換
+func ToReportResponse(r *report.Report) openapi.ModelsReportReportResponse {
+	daily := make([]openapi.ModelsReportDailyReport, 0, len(r.Daily))
+	for _, d := range r.Daily {
+		daily = append(daily, openapi.ModelsReportDailyReport{
+			Date:                         d.Date.Format("2006-01-02"),
+			PlannedTaskCount:             d.PlannedCount,
+			PlannedTaskDurationMinutes:   d.PlannedMinutes,
+			CompletedTaskCount:           d.CompletedCount,
+			CompletedTaskDurationMinutes: d.CompletedMinutes,
+			CompletionRate:               d.CompletionRate(),
+		})
+	}
+
+	return openapi.ModelsReportReportResponse{
+		From:                         r.Period.From.Format("2006-01-02"),
+		To:                           r.Period.To.Format("2006-01-02"),
+		PlannedTaskCount:             r.Totals.PlannedCount,
+		PlannedTaskDurationMinutes:   r.Totals.PlannedMinutes,
+		CompletedTaskCount:           r.Totals.CompletedCount,
+		CompletedTaskDurationMinutes: r.Totals.CompletedMinutes,
+		CompletionRate:               r.Totals.CompletionRate(),
+		DensityBreakdown:             toReportBreakdownResponses(r.ByDensity),
+		PriorityBreakdown:            toReportBreakdownResponses(r.ByPriority),
+		Daily:                        daily,
+	}
+}
+
+// toReportBreakdownResponses 密度・優先度ごとの集計をAPIレスポンスに変換
+func toReportBreakdownResponses(breakdowns []report.Breakdown) []openapi.ModelsReportReportBreakdown {
+	result := make([]openapi.ModelsReportReportBreakdown, 0, len(breakdowns))
+	for _, b := range breakdowns {
+		result = append(result, openapi.ModelsReportReportBreakdown{
+			Key:                          openapi.ModelsReportReportBreakdownKey(b.Key),
+			PlannedTaskCount:             b.PlannedCount,
+			PlannedTaskDurationMinutes:   b.PlannedMinutes,
+			CompletedTaskCount:           b.CompletedCount,
+			CompletedTaskDurationMinutes: b.CompletedMinutes,
+			CompletionRate:               b.CompletionRate(),
+		})
+	}
+	return result
+}
@@ -1,13 +1,21 @@
 package presenter
 
 import (
+	"strconv"
+	"time"
+
 	"task-management-system/backend/internal/adapter/http/generated/openapi"
 	"task-management-system/backend/internal/domain/account"
+	"task-management-system/backend/internal/domain/outputtemplate"
+	"task-management-system/backend/internal/domain/report"
 	"task-management-system/backend/internal/domain/task"
 )
 
 // ToTaskResponse タスクドメインエンティティをAPIレスポンスに変換
 func ToTaskResponse(t *task.Task, owner *account.Account) openapi.ModelsTaskTaskResponse {
+	// 計測中のタイマーはレスポンス作成時点までの時間を実績に含める
+	now := time.Now()
+
 	// タスクアイテムを変換
 	taskItemResponses := make([]openapi.ModelsTaskTaskItemResponse, 0, len(t.TaskItems))
 	for _, item := range t.TaskItems {
@@ -30,6 +38,8 @@ func ToTaskResponse(t *task.Task, owner *account.Account) openapi.ModelsTaskTask
 			durationTime = openapi.ModelsTaskTaskItemResponseDurationTimeN15
 		}
 
+		timerStartedAt := formatNullableDateTime(item.RunningSince())
+
 		taskItemResponses = append(taskItemResponses, openapi.ModelsTaskTaskItemResponse{
 			Id:           item.ID,
 			TaskId:       item.TaskID,
@@ -41,63 +51,25 @@ func ToTaskResponse(t *task.Task, owner *account.Account) openapi.ModelsTaskTask
 			IsRequired:   item.IsRequired,
 			Order:        item.Order,
 			Status:       openapi.ModelsTaskStatus(item.Status),
+			CategoryId:   item.CategoryID,
+			// テンプレートに沿って入力した場合は、構造化したセクションとまとめたテキスト（output）の両方を返す
+			OutputTemplateId: item.OutputTemplateID,
+			OutputSections:   toOutputSectionResponses(item.OutputSections),
+			// 予定時間（durationTime）と比較できるように、タイマーで計測した実績時間を返す
+			ActualDurationMinutes: item.ActualDurationMinutes(now),
+			TimerState:            openapi.ModelsTaskTimerState(item.TimerState()),
+			TimerStartedAt:        timerStartedAt,
+			CarriedOverFromTaskId: item.CarriedOverFromTaskID,
+			StartedAt:             formatNullableDateTime(item.StartedAt),
+			CompletedAt:           formatNullableDateTime(item.CompletedAt),
 		})
 	}
 
 	// 統計情報を計算
-	plannedTaskCount := int32(len(t.TaskItems))
-	var plannedTaskDurationMinutes int32
-	var completedTaskCount int32
-	var completedTaskDurationMinutes int32
-
-	highTaskCount := int32(0)
-	var highTaskDuration int32
-	mediumTaskCount := int32(0)
-	var mediumTaskDuration int32
-	lowTaskCount := int32(0)
-	var lowTaskDuration int32
-
-	for _, item := range t.TaskItems {
-		duration := int32(item.DurationTime)
-		plannedTaskDurationMinutes += duration
-
-		if item.Status == task.StatusCompleted {
-			completedTaskCount++
-			completedTaskDurationMinutes += duration
-		}
-
-		switch item.Density {
-		case task.DensityHigh:
-			highTaskCount++
-			highTaskDuration += duration
-		case task.DensityMedium:
-			mediumTaskCount++
-			mediumTaskDuration += duration
-		case task.DensityLow:
-			lowTaskCount++
-			lowTaskDuration += duration
-		}
-	}
-
-	var completionRate float32
-	if plannedTaskCount > 0 {
-		completionRate = float32(completedTaskCount) / float32(plannedTaskCount) * 100
-	}
-
-	var highTaskRate float32
-	if plannedTaskDurationMinutes > 0 {
-		highTaskRate = float32(highTaskDuration) / float32(plannedTaskDurationMinutes) * 100
-	}
-
-	var mediumTaskRate float32
-	if plannedTaskDurationMinutes > 0 {
-		mediumTaskRate = float32(mediumTaskDuration) / float32(plannedTaskDurationMinutes) * 100
-	}
-
-	var lowTaskRate float32
-	if plannedTaskDurationMinutes > 0 {
-		lowTaskRate = float32(lowTaskDuration) / float32(plannedTaskDurationMinutes) * 100
-	}
+	summary := report.SummarizeTask(t, now)
+	high := summary.Density(task.DensityHigh)
+	medium := summary.Density(task.DensityMedium)
+	low := summary.Density(task.DensityLow)
 
 	// 日付をISO 8601形式（YYYY-MM-DD）に変換
 	dateStr := t.Date.Format("2006-01-02")
@@ -121,32 +93,118 @@ func ToTaskResponse(t *task.Task, owner *account.Account) openapi.ModelsTaskTask
 		Date:                         dateStr,
 		Review:                       t.Review,
 		TaskItems:                    taskItemResponses,
-		PlannedTaskCount:             plannedTaskCount,
-		PlannedTaskDurationMinutes:   plannedTaskDurationMinutes,
-		CompletedTaskCount:           completedTaskCount,
-		CompletedTaskDurationMinutes: completedTaskDurationMinutes,
-		CompletionRate:               completionRate,
-		HighTaskCount:                highTaskCount,
-		HighTaskDuration:             highTaskDuration,
-		HighTaskRate:                 highTaskRate,
-		MediumTaskCount:              mediumTaskCount,
-		MediumTaskDuration:           mediumTaskDuration,
-		MediumTaskRate:               mediumTaskRate,
-		LowTaskCount:                 lowTaskCount,
-		LowTaskDuration:              lowTaskDuration,
-		LowTaskRate:                  lowTaskRate,
+		PlannedTaskCount:             summary.PlannedCount,
+		PlannedTaskDurationMinutes:   summary.PlannedMinutes,
+		CompletedTaskCount:           summary.CompletedCount,
+		CompletedTaskDurationMinutes: summary.CompletedMinutes,
+		ActualTaskDurationMinutes:    summary.ActualMinutes,
+		CompletionRate:               summary.CompletionRate(),
+		HighTaskCount:                high.PlannedCount,
+		HighTaskDuration:             high.PlannedMinutes,
+		HighTaskRate:                 high.DurationRate(summary.Totals),
+		MediumTaskCount:              medium.PlannedCount,
+		MediumTaskDuration:           medium.PlannedMinutes,
+		MediumTaskRate:               medium.DurationRate(summary.Totals),
+		LowTaskCount:                 low.PlannedCount,
+		LowTaskDuration:              low.PlannedMinutes,
+		LowTaskRate:                  low.DurationRate(summary.Totals),
+		Version:                      t.Version,
+		DeletedAt:                    formatNullableDateTime(t.DeletedAt),
 		CreatedAt:                    t.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:                    t.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
 }
 
+// TaskETag タスクのバージョンをETagヘッダーの値に変換
+func TaskETag(t *task.Task) string {
+	return strconv.Quote(strconv.FormatInt(t.Version, 10))
+}
+
+// toOutputSectionResponses 入力されたアウトプットのセクションをAPIレスポンスに変換（自由形式の場合はnil）
+func toOutputSectionResponses(sections []outputtemplate.FilledSection) *[]openapi.ModelsOutputTemplateOutputSection {
+	if sections == nil {
+		return nil
+	}
+
+	result := make([]openapi.ModelsOutputTemplateOutputSection, 0, len(sections))
+	for _, s := range sections {
+		result = append(result, openapi.ModelsOutputTemplateOutputSection{
+			Key:   s.Key,
+			Label: s.Label,
+			Value: s.Value,
+		})
+	}
+	return &result
+}
+
 // ToTaskResponseList タスクのリストをAPIレスポンスのリストに変換
 // 自分のタスクのみを取得するAPIのため、すべてのタスクは同じオーナーを持つ
-func ToTaskResponseList(tasks []*task.Task, owner *account.Account) []openapi.ModelsTaskTaskResponse {
-	result := make([]openapi.ModelsTaskTaskResponse, 0, len(tasks))
+// キーワード検索時は、タスクごとにキーワードが一致した箇所のスニペット（searchMatches）を含める
+func ToTaskResponseList(tasks []*task.Task, owner *account.Account, nextCursor *string, searchMatches map[string][]task.SearchMatch) openapi.ModelsTaskListTaskResponse {
+	items := make([]openapi.ModelsTaskTaskResponse, 0, len(tasks))
 	for _, t := range tasks {
-		result = append(result, ToTaskResponse(t, owner))
+		item := ToTaskResponse(t, owner)
+		if searchMatches != nil {
+			item.SearchMatches = toSearchMatchResponses(searchMatches[t.ID])
+		}
+		items = append(items, item)
+	}
+
+	return openapi.ModelsTaskListTaskResponse{
+		Items:      items,
+		NextCursor: nextCursor,
+	}
+}
+
+// toSearchMatchResponses キーワードが一致した箇所のスニペットをAPIレスポンスに変換
+func toSearchMatchResponses(matches []task.SearchMatch) *[]openapi.ModelsTaskSearchMatch {
+	result := make([]openapi.ModelsTaskSearchMatch, 0, len(matches))
+	for _, m := range matches {
+		fragments := make([]openapi.ModelsTaskHighlightFragment, 0, len(m.Fragments))
+		for _, f := range m.Fragments {
+			fragments = append(fragments, openapi.ModelsTaskHighlightFragment{
+				Text:        f.Text,
+				Highlighted: f.Highlighted,
+			})
+		}
+		result = append(result, openapi.ModelsTaskSearchMatch{
+			Field:      openapi.ModelsTaskSearchField(m.Field),
+			TaskItemId: m.TaskItemID,
+			Fragments:  fragments,
+		})
+	}
+	return &result
+}
+
+// ToTaskHistoryResponse タスクの変更履歴をAPIレスポンスに変換
+func ToTaskHistoryResponse(events []task.Event) openapi.ModelsTaskTaskHistoryResponse {
+	items := make([]openapi.ModelsTaskTaskEventResponse, 0, len(events))
+	for _, e := range events {
+		diff := make(map[string]openapi.ModelsTaskFieldChangeResponse, len(e.Diff))
+		for field, change := range e.Diff {
+			diff[field] = openapi.ModelsTaskFieldChangeResponse{
+				Before: change.Before,
+				After:  change.After,
+			}
+		}
+		items = append(items, openapi.ModelsTaskTaskEventResponse{
+			Id:         e.ID,
+			TaskId:     e.TaskID,
+			TaskItemId: e.TaskItemID,
+			ActorId:    e.ActorID,
+			Type:       openapi.ModelsTaskTaskEventType(e.Type),
+			Diff:       diff,
+			CreatedAt:  e.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		})
 	}
+	return openapi.ModelsTaskTaskHistoryResponse{Items: items}
+}
 
-	return result
+// formatNullableDateTime 日時をISO 8601形式に変換（nilの場合はnil）
+func formatNullableDateTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format("2006-01-02T15:04:05Z07:00")
+	return &formatted
 }
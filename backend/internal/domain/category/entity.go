@@ -0,0 +1,14 @@
+package category
+
+import "time"
+
+// Category カテゴリエンティティ
+// タスクアイテムの分類（思考、執筆、インプットなど）としてアカウントごとに定義する
+type Category struct {
+	ID        string
+	OwnerID   string
+	Name      string
+	Color     string // #RRGGBB形式
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
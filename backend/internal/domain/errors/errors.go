@@ -0,0 +1,128 @@
+// Package errors ドメイン層で発生するエラーの種別を定義する
+// 呼び出し側はエラーメッセージの文字列ではなく種別で判定する
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+)
+
+// Kind エラーの種別
+type Kind string
+
+const (
+	// KindNotFound 対象のリソースが存在しない（または参照権限がない）
+	KindNotFound Kind = "NOT_FOUND"
+	// KindForbidden 対象のリソースを操作する権限がない
+	KindForbidden Kind = "FORBIDDEN"
+	// KindValidation 入力値が不正
+	KindValidation Kind = "VALIDATION"
+	// KindConflict 現在の状態と競合する（一意制約違反など）
+	KindConflict Kind = "CONFLICT"
+	// KindPreconditionFailed クライアントが指定したバージョンが現在のバージョンと一致しない
+	KindPreconditionFailed Kind = "PRECONDITION_FAILED"
+)
+
+// Error ドメインエラー
+type Error struct {
+	// Kind エラーの種別
+	Kind Kind
+	// Message クライアントに返却できるメッセージ
+	Message string
+	// Details エラーの詳細情報（バリデーションエラーの項目など）
+	Details interface{}
+	// Err 原因となったエラー
+	Err error
+}
+
+// Error errorインターフェースの実装
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+// Unwrap 原因となったエラーを返す
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// WithDetails 詳細情報を設定したエラーを返す
+func (e *Error) WithDetails(details interface{}) *Error {
+	copied := *e
+	copied.Details = details
+	return &copied
+}
+
+// Wrap 原因となったエラーを設定したエラーを返す
+func (e *Error) Wrap(err error) *Error {
+	copied := *e
+	copied.Err = err
+	return &copied
+}
+
+// NotFound NotFoundエラーを作成
+func NotFound(message string) *Error {
+	return &Error{Kind: KindNotFound, Message: message}
+}
+
+// Forbidden Forbiddenエラーを作成
+func Forbidden(message string) *Error {
+	return &Error{Kind: KindForbidden, Message: message}
+}
+
+// Validation Validationエラーを作成
+func Validation(message string) *Error {
+	return &Error{Kind: KindValidation, Message: message}
+}
+
+// Conflict Conflictエラーを作成
+func Conflict(message string) *Error {
+	return &Error{Kind: KindConflict, Message: message}
+}
+
+// PreconditionFailed PreconditionFailedエラーを作成
+func PreconditionFailed(message string) *Error {
+	return &Error{Kind: KindPreconditionFailed, Message: message}
+}
+
+// As エラーチェーンからドメインエラーを取り出す
+func As(err error) (*Error, bool) {
+	var domainErr *Error
+	if stderrors.As(err, &domainErr) {
+		return domainErr, true
+	}
+	return nil, false
+}
+
+// Is エラーチェーンに指定した種別のドメインエラーが含まれるかどうかを判定
+func Is(err error, kind Kind) bool {
+	domainErr, ok := As(err)
+	return ok && domainErr.Kind == kind
+}
+
+// IsNotFound NotFoundエラーかどうかを判定
+func IsNotFound(err error) bool {
+	return Is(err, KindNotFound)
+}
+
+// IsForbidden Forbiddenエラーかどうかを判定
+func IsForbidden(err error) bool {
+	return Is(err, KindForbidden)
+}
+
+// IsValidation Validationエラーかどうかを判定
+func IsValidation(err error) bool {
+	return Is(err, KindValidation)
+}
+
+// IsConflict Conflictエラーかどうかを判定
+func IsConflict(err error) bool {
+	return Is(err, KindConflict)
+}
+
+// IsPreconditionFailed PreconditionFailedエラーかどうかを判定
+func IsPreconditionFailed(err error) bool {
+	return Is(err, KindPreconditionFailed)
+}
@@ -0,0 +1,186 @@
+package outputtemplate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+)
+
+const (
+	// NameMaxLength テンプレート名の最大文字数
+	NameMaxLength = 50
+	// MaxSections テンプレートに定義できるセクションの最大数
+	MaxSections = 10
+	// LabelMaxLength セクションの見出しの最大文字数
+	LabelMaxLength = 50
+)
+
+// sectionKeyPattern セクションのキー（英小文字・数字・アンダースコア・ハイフン）
+var sectionKeyPattern = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+// OutputTemplate アウトプットテンプレートエンティティ
+// タスクアイテムのアウトプットの形式（例　学んだこと／次にやること／詰まったこと）をアカウントごとに定義する
+type OutputTemplate struct {
+	ID        string
+	OwnerID   string
+	Name      string
+	Sections  []Section
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Section テンプレートのセクション（VO）
+type Section struct {
+	Key      string // アウトプットの値を対応付けるキー（テンプレート内で一意）
+	Label    string // 見出し
+	Required bool   // 入力必須かどうか
+}
+
+// SectionValue アウトプットとして入力されたセクションの値
+type SectionValue struct {
+	Key   string
+	Value string
+}
+
+// FieldError バリデーションエラーの項目
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Validate テンプレートの定義が正しいことを確認
+func Validate(name string, sections []Section) error {
+	var fieldErrors []FieldError
+
+	nameLength := utf8.RuneCountInString(strings.TrimSpace(name))
+	if nameLength == 0 || nameLength > NameMaxLength {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:   "name",
+			Message: fmt.Sprintf("nameは1文字以上%d文字以下である必要があります", NameMaxLength),
+		})
+	}
+
+	if len(sections) == 0 || len(sections) > MaxSections {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:   "sections",
+			Message: fmt.Sprintf("sectionsは1つ以上%d個以下である必要があります", MaxSections),
+		})
+	}
+
+	keys := make(map[string]bool, len(sections))
+	for i, section := range sections {
+		prefix := fmt.Sprintf("sections[%d]", i)
+
+		if !sectionKeyPattern.MatchString(section.Key) {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:   prefix + ".key",
+				Message: "keyは英小文字・数字・_・-からなる1〜32文字である必要があります",
+			})
+		} else if keys[section.Key] {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:   prefix + ".key",
+				Message: "keyはテンプレート内で一意である必要があります",
+			})
+		}
+		keys[section.Key] = true
+
+		labelLength := utf8.RuneCountInString(strings.TrimSpace(section.Label))
+		if labelLength == 0 || labelLength > LabelMaxLength {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:   prefix + ".label",
+				Message: fmt.Sprintf("labelは1文字以上%d文字以下である必要があります", LabelMaxLength),
+			})
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return domainerrors.Validation("Validation failed").WithDetails(map[string]interface{}{
+			"errors": fieldErrors,
+		})
+	}
+	return nil
+}
+
+// Fill 入力された値をテンプレートのセクションに当てはめる
+// 未定義のキーや必須セクションの未入力はValidationエラーにする。結果はテンプレートのセクション順に並ぶ
+func (t *OutputTemplate) Fill(values []SectionValue) ([]FilledSection, error) {
+	var fieldErrors []FieldError
+
+	valueByKey := make(map[string]string, len(values))
+	for i, v := range values {
+		if _, ok := t.section(v.Key); !ok {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:   fmt.Sprintf("sections[%d].key", i),
+				Message: fmt.Sprintf("%sはテンプレートに定義されていないセクションです", v.Key),
+			})
+			continue
+		}
+		if _, ok := valueByKey[v.Key]; ok {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:   fmt.Sprintf("sections[%d].key", i),
+				Message: fmt.Sprintf("%sが重複しています", v.Key),
+			})
+			continue
+		}
+		valueByKey[v.Key] = strings.TrimSpace(v.Value)
+	}
+
+	filled := make([]FilledSection, 0, len(t.Sections))
+	for _, section := range t.Sections {
+		value := valueByKey[section.Key]
+		if value == "" {
+			if section.Required {
+				fieldErrors = append(fieldErrors, FieldError{
+					Field:   "sections." + section.Key,
+					Message: fmt.Sprintf("%sは入力必須です", section.Label),
+				})
+			}
+			continue
+		}
+		filled = append(filled, FilledSection{Key: section.Key, Label: section.Label, Value: value})
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, domainerrors.Validation("Output does not match the template").WithDetails(map[string]interface{}{
+			"errors": fieldErrors,
+		})
+	}
+	if len(filled) == 0 {
+		return nil, domainerrors.Validation("Output does not match the template").WithDetails(map[string]interface{}{
+			"errors": []FieldError{{Field: "sections", Message: "少なくとも1つのセクションを入力する必要があります"}},
+		})
+	}
+
+	return filled, nil
+}
+
+// section キーでセクションを取得
+func (t *OutputTemplate) section(key string) (Section, bool) {
+	for _, s := range t.Sections {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return Section{}, false
+}
+
+// FilledSection テンプレートに沿って入力されたセクション
+// テンプレートが後から変更・削除されても表示できるように見出しも保持する
+type FilledSection struct {
+	Key   string
+	Label string
+	Value string
+}
+
+// Flatten 入力されたセクションを1つのテキストにまとめる
+func Flatten(sections []FilledSection) string {
+	blocks := make([]string, 0, len(sections))
+	for _, s := range sections {
+		blocks = append(blocks, "■ "+s.Label+"\n"+s.Value)
+	}
+	return strings.Join(blocks, "\n\n")
+}
@@ -0,0 +1,101 @@
+package outputtemplate
+
+import (
+	"slices"
+	"testing"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		tmplName string
+		sections []Section
+		wantErr  bool
+	}{
+		{name: "正常", tmplName: "振り返り", sections: []Section{{Key: "learned", Label: "学んだこと", Required: true}}},
+		{name: "名前が空", tmplName: " ", sections: []Section{{Key: "learned", Label: "学んだこと"}}, wantErr: true},
+		{name: "セクションなし", tmplName: "振り返り", wantErr: true},
+		{name: "キーの形式が不正", tmplName: "振り返り", sections: []Section{{Key: "Learned!", Label: "学んだこと"}}, wantErr: true},
+		{name: "キーが重複", tmplName: "振り返り", sections: []Section{{Key: "a", Label: "A"}, {Key: "a", Label: "B"}}, wantErr: true},
+		{name: "見出しが空", tmplName: "振り返り", sections: []Section{{Key: "a", Label: ""}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.tmplName, tt.sections)
+			if tt.wantErr {
+				if !domainerrors.IsValidation(err) {
+					t.Fatalf("err = %v, want Validation", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestOutputTemplate_Fill(t *testing.T) {
+	tmpl := &OutputTemplate{
+		Sections: []Section{
+			{Key: "learned", Label: "学んだこと", Required: true},
+			{Key: "blockers", Label: "詰まったこと"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		values  []SectionValue
+		want    []FilledSection
+		wantErr bool
+	}{
+		{
+			name:   "任意セクションは省略できる",
+			values: []SectionValue{{Key: "learned", Value: " sqlc "}},
+			want:   []FilledSection{{Key: "learned", Label: "学んだこと", Value: "sqlc"}},
+		},
+		{
+			name:   "テンプレートの順に並ぶ",
+			values: []SectionValue{{Key: "blockers", Value: "CI"}, {Key: "learned", Value: "sqlc"}},
+			want: []FilledSection{
+				{Key: "learned", Label: "学んだこと", Value: "sqlc"},
+				{Key: "blockers", Label: "詰まったこと", Value: "CI"},
+			},
+		},
+		{name: "必須セクションが空白のみ", values: []SectionValue{{Key: "learned", Value: "  "}}, wantErr: true},
+		{name: "未定義のキー", values: []SectionValue{{Key: "learned", Value: "a"}, {Key: "unknown", Value: "b"}}, wantErr: true},
+		{name: "キーが重複", values: []SectionValue{{Key: "learned", Value: "a"}, {Key: "learned", Value: "b"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tmpl.Fill(tt.values)
+			if tt.wantErr {
+				if !domainerrors.IsValidation(err) {
+					t.Fatalf("err = %v, want Validation", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	got := Flatten([]FilledSection{
+		{Key: "learned", Label: "学んだこと", Value: "sqlc"},
+		{Key: "next", Label: "次にやること", Value: "テスト\nレビュー"},
+	})
+	want := "■ 学んだこと\nsqlc\n\n■ 次にやること\nテスト\nレビュー"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,131 @@
+package recurringtemplate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/task"
+)
+
+const (
+	// TitleMaxLength 作成するタスクのタイトルの最大文字数
+	TitleMaxLength = 100
+	// MaxTaskItems テンプレートに定義できる子タスクの最大数
+	MaxTaskItems = 30
+)
+
+// RecurringTemplate 繰り返しテンプレートエンティティ
+// 毎日同じ構成になるタスクを、繰り返しのルールに沿って日付ごとに作成するためにアカウントごとに定義する
+type RecurringTemplate struct {
+	ID        string
+	OwnerID   string
+	Title     string // 作成するタスクのタイトル
+	Rule      Rule
+	TaskItems []Item // 作成するタスクの子タスク
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Item テンプレートの子タスク（VO）
+type Item struct {
+	Priority     task.Priority
+	Density      task.Density
+	DurationTime task.DurationTime
+	Content      string
+	IsRequired   bool
+	Order        int32
+	CategoryID   *string // 未分類の場合はnil
+	// OutputTemplateID アウトプットに使用するテンプレート（自由形式の場合はnil）
+	OutputTemplateID *string
+}
+
+// FieldError バリデーションエラーの項目
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Validate テンプレートの定義が正しいことを確認
+func Validate(title string, rule Rule, items []Item) error {
+	var fieldErrors []FieldError
+
+	titleLength := utf8.RuneCountInString(strings.TrimSpace(title))
+	if titleLength == 0 || titleLength > TitleMaxLength {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:   "title",
+			Message: fmt.Sprintf("titleは1文字以上%d文字以下である必要があります", TitleMaxLength),
+		})
+	}
+
+	fieldErrors = append(fieldErrors, rule.validate()...)
+
+	if len(items) == 0 || len(items) > MaxTaskItems {
+		fieldErrors = append(fieldErrors, FieldError{
+			Field:   "taskItems",
+			Message: fmt.Sprintf("taskItemsは1つ以上%d個以下である必要があります", MaxTaskItems),
+		})
+	}
+
+	orders := make(map[int32]bool, len(items))
+	for i, item := range items {
+		prefix := fmt.Sprintf("taskItems[%d]", i)
+
+		switch item.Priority {
+		case task.PriorityHigh, task.PriorityMedium, task.PriorityLow:
+		default:
+			fieldErrors = append(fieldErrors, FieldError{Field: prefix + ".priority", Message: "priorityはHigh、Medium、Lowのいずれかである必要があります"})
+		}
+
+		switch item.Density {
+		case task.DensityHigh, task.DensityMedium, task.DensityLow:
+		default:
+			fieldErrors = append(fieldErrors, FieldError{Field: prefix + ".density", Message: "densityはHigh、Medium、Lowのいずれかである必要があります"})
+		}
+
+		switch item.DurationTime {
+		case task.DurationTime15, task.DurationTime30, task.DurationTime45, task.DurationTime60:
+		default:
+			fieldErrors = append(fieldErrors, FieldError{Field: prefix + ".durationTime", Message: "durationTimeは15、30、45、60のいずれかである必要があります"})
+		}
+
+		if strings.TrimSpace(item.Content) == "" {
+			fieldErrors = append(fieldErrors, FieldError{Field: prefix + ".content", Message: "contentは1文字以上である必要があります"})
+		}
+
+		if item.Order < 0 {
+			fieldErrors = append(fieldErrors, FieldError{Field: prefix + ".order", Message: "orderは0以上の整数である必要があります"})
+		} else if orders[item.Order] {
+			fieldErrors = append(fieldErrors, FieldError{Field: prefix + ".order", Message: "orderはテンプレート内で一意である必要があります"})
+		}
+		orders[item.Order] = true
+	}
+
+	if len(fieldErrors) > 0 {
+		return domainerrors.Validation("Validation failed").WithDetails(map[string]interface{}{
+			"errors": fieldErrors,
+		})
+	}
+	return nil
+}
+
+// TaskItemInputs テンプレートから作成するタスクの子タスクの入力を作成（ステータスは未着手）
+func (t *RecurringTemplate) TaskItemInputs() []task.CreateTaskItemInput {
+	inputs := make([]task.CreateTaskItemInput, 0, len(t.TaskItems))
+	for _, item := range t.TaskItems {
+		inputs = append(inputs, task.CreateTaskItemInput{
+			Priority:         item.Priority,
+			Density:          item.Density,
+			DurationTime:     item.DurationTime,
+			Content:          item.Content,
+			IsRequired:       item.IsRequired,
+			Order:            item.Order,
+			Status:           task.StatusNotStarted,
+			CategoryID:       item.CategoryID,
+			OutputTemplateID: item.OutputTemplateID,
+		})
+	}
+	return inputs
+}
@@ -0,0 +1,177 @@
+package recurringtemplate
+
+import (
+	"time"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+)
+
+const (
+	// DefaultPreviewCount プレビューする日付の件数が指定されなかった場合の件数
+	DefaultPreviewCount int32 = 7
+	// MaxPreviewCount 一度にプレビューできる日付の最大件数
+	MaxPreviewCount int32 = 60
+	// DefaultGenerateDays タスクを作成する日数が指定されなかった場合の日数
+	DefaultGenerateDays int32 = 7
+	// MaxGenerateDays 一度にタスクを作成できる最大日数
+	MaxGenerateDays int32 = 31
+)
+
+// Frequency 繰り返しの頻度（RRULEのFREQ・BYDAYに相当）
+type Frequency string
+
+const (
+	// FrequencyDaily 毎日
+	FrequencyDaily Frequency = "Daily"
+	// FrequencyWeekdays 平日（月曜日〜金曜日）
+	FrequencyWeekdays Frequency = "Weekdays"
+	// FrequencyWeekly 毎週、指定した曜日
+	FrequencyWeekly Frequency = "Weekly"
+)
+
+// Weekday 曜日（RRULEのBYDAYの表記）
+type Weekday string
+
+const (
+	Monday    Weekday = "MO"
+	Tuesday   Weekday = "TU"
+	Wednesday Weekday = "WE"
+	Thursday  Weekday = "TH"
+	Friday    Weekday = "FR"
+	Saturday  Weekday = "SA"
+	Sunday    Weekday = "SU"
+)
+
+// weekdays 曜日の表記と time.Weekday の対応
+var weekdays = map[Weekday]time.Weekday{
+	Monday:    time.Monday,
+	Tuesday:   time.Tuesday,
+	Wednesday: time.Wednesday,
+	Thursday:  time.Thursday,
+	Friday:    time.Friday,
+	Saturday:  time.Saturday,
+	Sunday:    time.Sunday,
+}
+
+// Rule 繰り返しのルール（VO）
+// 日付はすべて時刻を持たない日付（UTCの0時）として扱う
+type Rule struct {
+	Frequency Frequency
+	ByDay     []Weekday // 毎週の場合に繰り返す曜日
+	StartsOn  time.Time
+	EndsOn    *time.Time // 終了日（無期限の場合はnil）
+}
+
+// validate ルールの定義が正しいことを確認
+func (r Rule) validate() []FieldError {
+	var fieldErrors []FieldError
+
+	switch r.Frequency {
+	case FrequencyDaily, FrequencyWeekdays:
+		if len(r.ByDay) > 0 {
+			fieldErrors = append(fieldErrors, FieldError{Field: "byDay", Message: "byDayはfrequencyがWeeklyの場合のみ指定できます"})
+		}
+	case FrequencyWeekly:
+		if len(r.ByDay) == 0 {
+			fieldErrors = append(fieldErrors, FieldError{Field: "byDay", Message: "frequencyがWeeklyの場合はbyDayを1つ以上指定する必要があります"})
+		}
+		seen := make(map[Weekday]bool, len(r.ByDay))
+		for _, day := range r.ByDay {
+			if _, ok := weekdays[day]; !ok || seen[day] {
+				fieldErrors = append(fieldErrors, FieldError{Field: "byDay", Message: "byDayはMO、TU、WE、TH、FR、SA、SUを重複なく指定する必要があります"})
+				break
+			}
+			seen[day] = true
+		}
+	default:
+		fieldErrors = append(fieldErrors, FieldError{Field: "frequency", Message: "frequencyはDaily、Weekdays、Weeklyのいずれかである必要があります"})
+	}
+
+	if r.StartsOn.IsZero() {
+		fieldErrors = append(fieldErrors, FieldError{Field: "startsOn", Message: "startsOnは有効な日付形式である必要があります"})
+	} else if r.EndsOn != nil && r.EndsOn.Before(r.StartsOn) {
+		fieldErrors = append(fieldErrors, FieldError{Field: "endsOn", Message: "endsOnはstartsOn以降の日付である必要があります"})
+	}
+
+	return fieldErrors
+}
+
+// Occurs 指定した日付がルールに該当するかどうか
+func (r Rule) Occurs(date time.Time) bool {
+	if date.Before(r.StartsOn) || (r.EndsOn != nil && date.After(*r.EndsOn)) {
+		return false
+	}
+
+	switch r.Frequency {
+	case FrequencyDaily:
+		return true
+	case FrequencyWeekdays:
+		return date.Weekday() != time.Saturday && date.Weekday() != time.Sunday
+	case FrequencyWeekly:
+		for _, day := range r.ByDay {
+			if weekdays[day] == date.Weekday() {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// Occurrences 指定した日付以降でルールに該当する日付を、最大count件まで昇順で取得
+func (r Rule) Occurrences(from time.Time, count int32) []time.Time {
+	if from.Before(r.StartsOn) {
+		from = r.StartsOn
+	}
+
+	// 正しいルールは7日ごとに必ず該当する日があるため、count週分を調べれば件数に達する
+	dates := []time.Time{}
+	for i := 0; int32(len(dates)) < count && i < int(count)*7; i++ {
+		date := from.AddDate(0, 0, i)
+		if r.EndsOn != nil && date.After(*r.EndsOn) {
+			break
+		}
+		if r.Occurs(date) {
+			dates = append(dates, date)
+		}
+	}
+	return dates
+}
+
+// OccurrencesWithin 指定した日付からdays日間でルールに該当する日付を昇順で取得
+func (r Rule) OccurrencesWithin(from time.Time, days int32) []time.Time {
+	dates := []time.Time{}
+	for i := int32(0); i < days; i++ {
+		if date := from.AddDate(0, 0, int(i)); r.Occurs(date) {
+			dates = append(dates, date)
+		}
+	}
+	return dates
+}
+
+// ParsePreviewCount プレビューする日付の件数を解析（未指定の場合はデフォルトの件数）
+func ParsePreviewCount(count *int32) (int32, error) {
+	if count == nil {
+		return DefaultPreviewCount, nil
+	}
+	if *count < 1 || *count > MaxPreviewCount {
+		return 0, domainerrors.Validation("invalid count").WithDetails(map[string]string{
+			"count": "must be between 1 and 60",
+		})
+	}
+	return *count, nil
+}
+
+// ParseGenerateDays タスクを作成する日数を解析（未指定の場合はデフォルトの日数）
+func ParseGenerateDays(days *int32) (int32, error) {
+	if days == nil {
+		return DefaultGenerateDays, nil
+	}
+	if *days < 1 || *days > MaxGenerateDays {
+		return 0, domainerrors.Validation("invalid days").WithDetails(map[string]string{
+			"days": "must be between 1 and 31",
+		})
+	}
+	return *days, nil
+}
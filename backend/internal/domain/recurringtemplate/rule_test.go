@@ -0,0 +1,155 @@
+package recurringtemplate
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/task"
+)
+
+func date(value string) time.Time {
+	d, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func formatDates(dates []time.Time) []string {
+	result := make([]string, 0, len(dates))
+	for _, d := range dates {
+		result = append(result, d.Format(time.DateOnly))
+	}
+	return result
+}
+
+func TestRule_Occurrences(t *testing.T) {
+	endsOn := date("2026-10-14")
+
+	tests := []struct {
+		name string
+		rule Rule
+		from time.Time
+		want []string
+	}{
+		{
+			name: "毎日",
+			rule: Rule{Frequency: FrequencyDaily, StartsOn: date("2026-10-01")},
+			from: date("2026-10-09"),
+			want: []string{"2026-10-09", "2026-10-10", "2026-10-11"},
+		},
+		{
+			name: "平日は土日を除く",
+			rule: Rule{Frequency: FrequencyWeekdays, StartsOn: date("2026-10-01")},
+			from: date("2026-10-09"),
+			want: []string{"2026-10-09", "2026-10-12", "2026-10-13"},
+		},
+		{
+			name: "毎週は指定した曜日のみ",
+			rule: Rule{Frequency: FrequencyWeekly, ByDay: []Weekday{Monday, Thursday}, StartsOn: date("2026-10-01")},
+			from: date("2026-10-02"),
+			want: []string{"2026-10-05", "2026-10-08", "2026-10-12"},
+		},
+		{
+			name: "開始日より前からは開始日以降のみ",
+			rule: Rule{Frequency: FrequencyDaily, StartsOn: date("2026-10-10")},
+			from: date("2026-10-01"),
+			want: []string{"2026-10-10", "2026-10-11", "2026-10-12"},
+		},
+		{
+			name: "終了日を過ぎたら打ち切る",
+			rule: Rule{Frequency: FrequencyDaily, StartsOn: date("2026-10-01"), EndsOn: &endsOn},
+			from: date("2026-10-13"),
+			want: []string{"2026-10-13", "2026-10-14"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := formatDates(tt.rule.Occurrences(tt.from, 3))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Occurrences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRule_OccurrencesWithin(t *testing.T) {
+	rule := Rule{Frequency: FrequencyWeekdays, StartsOn: date("2026-10-01")}
+
+	got := formatDates(rule.OccurrencesWithin(date("2026-10-09"), 4))
+	want := []string{"2026-10-09", "2026-10-12"}
+	if !slices.Equal(got, want) {
+		t.Errorf("OccurrencesWithin() = %v, want %v", got, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	validItem := Item{
+		Priority:     task.PriorityHigh,
+		Density:      task.DensityMedium,
+		DurationTime: task.DurationTime30,
+		Content:      "メールを確認する",
+	}
+	endsOn := date("2026-09-30")
+
+	tests := []struct {
+		name    string
+		title   string
+		rule    Rule
+		items   []Item
+		wantErr bool
+	}{
+		{
+			name:  "正しい定義",
+			title: "平日のルーティン",
+			rule:  Rule{Frequency: FrequencyWeekly, ByDay: []Weekday{Monday}, StartsOn: date("2026-10-01")},
+			items: []Item{validItem},
+		},
+		{
+			name:    "毎週で曜日がない",
+			title:   "週次",
+			rule:    Rule{Frequency: FrequencyWeekly, StartsOn: date("2026-10-01")},
+			items:   []Item{validItem},
+			wantErr: true,
+		},
+		{
+			name:    "毎日で曜日を指定",
+			title:   "毎日",
+			rule:    Rule{Frequency: FrequencyDaily, ByDay: []Weekday{Monday}, StartsOn: date("2026-10-01")},
+			items:   []Item{validItem},
+			wantErr: true,
+		},
+		{
+			name:    "終了日が開始日より前",
+			title:   "毎日",
+			rule:    Rule{Frequency: FrequencyDaily, StartsOn: date("2026-10-01"), EndsOn: &endsOn},
+			items:   []Item{validItem},
+			wantErr: true,
+		},
+		{
+			name:    "子タスクの順番が重複",
+			title:   "毎日",
+			rule:    Rule{Frequency: FrequencyDaily, StartsOn: date("2026-10-01")},
+			items:   []Item{validItem, validItem},
+			wantErr: true,
+		},
+		{
+			name:    "子タスクがない",
+			title:   "毎日",
+			rule:    Rule{Frequency: FrequencyDaily, StartsOn: date("2026-10-01")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.title, tt.rule, tt.items)
+			if tt.wantErr != domainerrors.IsValidation(err) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
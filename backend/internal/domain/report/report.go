@@ -0,0 +1,229 @@
+package report
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/task"
+)
+
+// MaxPeriodDays 集計できる期間の最大日数
+const MaxPeriodDays = 366
+
+// isoWeekPattern ISO週（例 2026-W40）
+var isoWeekPattern = regexp.MustCompile(`^(\d{4})-W(\d{2})$`)
+
+// Period 集計期間（From、Toともに含む）
+type Period struct {
+	From time.Time
+	To   time.Time
+}
+
+// Days 期間の日数
+func (p Period) Days() int {
+	return int(p.To.Sub(p.From).Hours()/24) + 1
+}
+
+// ParsePeriod ISO週（YYYY-Www）、月（YYYY-MM）、日付の範囲（YYYY-MM-DD）のいずれか1つから集計期間を作成
+func ParsePeriod(week, month, from, to *string) (Period, error) {
+	specified := 0
+	for _, v := range []*string{week, month} {
+		if v != nil {
+			specified++
+		}
+	}
+	if from != nil || to != nil {
+		specified++
+	}
+	if specified != 1 {
+		return Period{}, domainerrors.Validation("Specify exactly one of week, month or from/to")
+	}
+
+	switch {
+	case week != nil:
+		return parseISOWeek(*week)
+	case month != nil:
+		start, err := time.Parse("2006-01", *month)
+		if err != nil {
+			return Period{}, domainerrors.Validation("month must be in YYYY-MM format").Wrap(err)
+		}
+		return Period{From: start, To: start.AddDate(0, 1, -1)}, nil
+	default:
+		if from == nil || to == nil {
+			return Period{}, domainerrors.Validation("Both from and to are required")
+		}
+		fromDate, err := time.Parse("2006-01-02", *from)
+		if err != nil {
+			return Period{}, domainerrors.Validation("from must be in YYYY-MM-DD format").Wrap(err)
+		}
+		toDate, err := time.Parse("2006-01-02", *to)
+		if err != nil {
+			return Period{}, domainerrors.Validation("to must be in YYYY-MM-DD format").Wrap(err)
+		}
+		if toDate.Before(fromDate) {
+			return Period{}, domainerrors.Validation("from must be on or before to")
+		}
+		period := Period{From: fromDate, To: toDate}
+		if period.Days() > MaxPeriodDays {
+			return Period{}, domainerrors.Validation(fmt.Sprintf("Period must be %d days or less", MaxPeriodDays))
+		}
+		return period, nil
+	}
+}
+
+// parseISOWeek ISO週を月曜日から日曜日までの期間に変換
+func parseISOWeek(value string) (Period, error) {
+	matches := isoWeekPattern.FindStringSubmatch(value)
+	if matches == nil {
+		return Period{}, domainerrors.Validation("week must be in YYYY-Www format")
+	}
+	year, _ := strconv.Atoi(matches[1])
+	week, _ := strconv.Atoi(matches[2])
+
+	// 12月28日は必ずその年の最終週に含まれる
+	_, weeksInYear := time.Date(year, 12, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	if week < 1 || week > weeksInYear {
+		return Period{}, domainerrors.Validation(fmt.Sprintf("week must be between 1 and %d", weeksInYear))
+	}
+
+	// 1月4日は必ず第1週に含まれる
+	jan4 := time.Date(year, 1, 4, 0, 0, 0, 0, time.UTC)
+	firstMonday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+	from := firstMonday.AddDate(0, 0, (week-1)*7)
+	return Period{From: from, To: from.AddDate(0, 0, 6)}, nil
+}
+
+// Totals 予定と完了の件数・時間（分）
+type Totals struct {
+	PlannedCount     int32
+	PlannedMinutes   int32
+	CompletedCount   int32
+	CompletedMinutes int32
+}
+
+// Add 件数・時間を加算
+func (t *Totals) Add(other Totals) {
+	t.PlannedCount += other.PlannedCount
+	t.PlannedMinutes += other.PlannedMinutes
+	t.CompletedCount += other.CompletedCount
+	t.CompletedMinutes += other.CompletedMinutes
+}
+
+// CompletionRate 完了率（件数ベース、%）
+func (t Totals) CompletionRate() float32 {
+	if t.PlannedCount == 0 {
+		return 0
+	}
+	return float32(t.CompletedCount) / float32(t.PlannedCount) * 100
+}
+
+// DurationRate 全体の予定時間に占める予定時間の割合（時間ベース、%）
+func (t Totals) DurationRate(total Totals) float32 {
+	if total.PlannedMinutes == 0 {
+		return 0
+	}
+	return float32(t.PlannedMinutes) / float32(total.PlannedMinutes) * 100
+}
+
+// Breakdown 密度・優先度ごとの集計
+type Breakdown struct {
+	Key string // High、Medium、Low
+	Totals
+}
+
+// DailyTotals 日ごとの集計
+type DailyTotals struct {
+	Date time.Time
+	Totals
+}
+
+// Report 期間の振り返りレポート
+type Report struct {
+	OwnerID    string
+	Period     Period
+	Totals     Totals
+	ByDensity  []Breakdown
+	ByPriority []Breakdown
+	Daily      []DailyTotals // 期間内のすべての日（タスクがない日も含む）
+}
+
+// breakdownKeys 密度・優先度の表示順
+var breakdownKeys = []string{"High", "Medium", "Low"}
+
+// NewReport 集計結果からレポートを作成
+// 全体の集計は日ごとの集計から求め、密度・優先度はHigh、Medium、Lowの順にすべて含める
+func NewReport(ownerID string, period Period, daily []DailyTotals, byDensity []Breakdown, byPriority []Breakdown) *Report {
+	r := &Report{
+		OwnerID:    ownerID,
+		Period:     period,
+		Daily:      daily,
+		ByDensity:  completeBreakdown(byDensity),
+		ByPriority: completeBreakdown(byPriority),
+	}
+	for _, d := range daily {
+		r.Totals.Add(d.Totals)
+	}
+	return r
+}
+
+// completeBreakdown 集計がない区分を0件で補い、High、Medium、Lowの順に並べる
+func completeBreakdown(breakdowns []Breakdown) []Breakdown {
+	byKey := make(map[string]Totals, len(breakdowns))
+	for _, b := range breakdowns {
+		byKey[b.Key] = b.Totals
+	}
+
+	result := make([]Breakdown, 0, len(breakdownKeys))
+	for _, key := range breakdownKeys {
+		result = append(result, Breakdown{Key: key, Totals: byKey[key]})
+	}
+	return result
+}
+
+// TaskSummary 1件のタスクの子タスクの集計
+type TaskSummary struct {
+	Totals
+	ActualMinutes int32 // タイマーで計測した実績時間（分）
+	ByDensity     []Breakdown
+}
+
+// SummarizeTask タスクの子タスクを集計
+// 計測中のタイマーはnowまでの時間を実績に含め、密度はHigh、Medium、Lowの順にすべて含める
+func SummarizeTask(t *task.Task, now time.Time) TaskSummary {
+	var summary TaskSummary
+	byDensity := make(map[string]Totals, len(breakdownKeys))
+	for _, item := range t.TaskItems {
+		itemTotals := Totals{PlannedCount: 1, PlannedMinutes: int32(item.DurationTime)}
+		if item.Status == task.StatusCompleted {
+			itemTotals.CompletedCount = 1
+			itemTotals.CompletedMinutes = int32(item.DurationTime)
+		}
+
+		summary.Add(itemTotals)
+		summary.ActualMinutes += item.ActualDurationMinutes(now)
+
+		density := byDensity[string(item.Density)]
+		density.Add(itemTotals)
+		byDensity[string(item.Density)] = density
+	}
+
+	breakdowns := make([]Breakdown, 0, len(byDensity))
+	for key, totals := range byDensity {
+		breakdowns = append(breakdowns, Breakdown{Key: key, Totals: totals})
+	}
+	summary.ByDensity = completeBreakdown(breakdowns)
+	return summary
+}
+
+// Density 密度の集計（集計がない場合は0件）
+func (s TaskSummary) Density(density task.Density) Totals {
+	for _, b := range s.ByDensity {
+		if b.Key == string(density) {
+			return b.Totals
+		}
+	}
+	return Totals{}
+}
@@ -0,0 +1,131 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/task"
+)
+
+func TestParsePeriod(t *testing.T) {
+	str := func(s string) *string { return &s }
+	date := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+
+	tests := []struct {
+		name     string
+		week     *string
+		month    *string
+		from     *string
+		to       *string
+		wantFrom string
+		wantTo   string
+		wantErr  bool
+	}{
+		{name: "ISO週", week: str("2026-W40"), wantFrom: "2026-09-28", wantTo: "2026-10-04"},
+		{name: "年をまたぐISO週", week: str("2026-W01"), wantFrom: "2025-12-29", wantTo: "2026-01-04"},
+		{name: "53週目がある年", week: str("2026-W53"), wantFrom: "2026-12-28", wantTo: "2027-01-03"},
+		{name: "53週目がない年", week: str("2025-W53"), wantErr: true},
+		{name: "週の形式が不正", week: str("2026-40"), wantErr: true},
+		{name: "月", month: str("2026-02"), wantFrom: "2026-02-01", wantTo: "2026-02-28"},
+		{name: "日付の範囲", from: str("2026-10-01"), to: str("2026-10-15"), wantFrom: "2026-10-01", wantTo: "2026-10-15"},
+		{name: "toがない", from: str("2026-10-01"), wantErr: true},
+		{name: "fromがtoより後", from: str("2026-10-15"), to: str("2026-10-01"), wantErr: true},
+		{name: "366日を超える", from: str("2025-01-01"), to: str("2026-01-02"), wantErr: true},
+		{name: "複数指定", week: str("2026-W40"), month: str("2026-10"), wantErr: true},
+		{name: "指定なし", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePeriod(tt.week, tt.month, tt.from, tt.to)
+			if tt.wantErr {
+				if !domainerrors.IsValidation(err) {
+					t.Fatalf("err = %v, want Validation", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.From.Equal(date(tt.wantFrom)) || !got.To.Equal(date(tt.wantTo)) {
+				t.Errorf("got %s〜%s, want %s〜%s", got.From.Format("2006-01-02"), got.To.Format("2006-01-02"), tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestNewReport(t *testing.T) {
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	r := NewReport("owner", Period{From: day, To: day.AddDate(0, 0, 1)},
+		[]DailyTotals{
+			{Date: day, Totals: Totals{PlannedCount: 3, PlannedMinutes: 90, CompletedCount: 1, CompletedMinutes: 30}},
+			{Date: day.AddDate(0, 0, 1), Totals: Totals{PlannedCount: 1, PlannedMinutes: 60, CompletedCount: 1, CompletedMinutes: 60}},
+		},
+		[]Breakdown{{Key: "Low", Totals: Totals{PlannedCount: 4, PlannedMinutes: 150}}},
+		nil,
+	)
+
+	want := Totals{PlannedCount: 4, PlannedMinutes: 150, CompletedCount: 2, CompletedMinutes: 90}
+	if r.Totals != want {
+		t.Errorf("totals = %+v, want %+v", r.Totals, want)
+	}
+	if got := r.Totals.CompletionRate(); got != 50 {
+		t.Errorf("completion rate = %v, want 50", got)
+	}
+
+	// 集計がない区分は0件で補い、High、Medium、Lowの順に並ぶ
+	for _, breakdowns := range [][]Breakdown{r.ByDensity, r.ByPriority} {
+		if len(breakdowns) != 3 || breakdowns[0].Key != "High" || breakdowns[1].Key != "Medium" || breakdowns[2].Key != "Low" {
+			t.Fatalf("breakdowns = %+v, want High, Medium, Low", breakdowns)
+		}
+	}
+	if r.ByDensity[2].PlannedCount != 4 || r.ByDensity[0].PlannedCount != 0 {
+		t.Errorf("density breakdown = %+v", r.ByDensity)
+	}
+}
+
+func TestSummarizeTask(t *testing.T) {
+	now := time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC)
+	summary := SummarizeTask(&task.Task{
+		TaskItems: []task.TaskItem{
+			{Density: task.DensityHigh, DurationTime: task.DurationTime60, Status: task.StatusCompleted},
+			{Density: task.DensityHigh, DurationTime: task.DurationTime30, Status: task.StatusInProgress},
+			{Density: task.DensityLow, DurationTime: task.DurationTime30, Status: task.StatusNotStarted},
+		},
+	}, now)
+
+	want := Totals{PlannedCount: 3, PlannedMinutes: 120, CompletedCount: 1, CompletedMinutes: 60}
+	if summary.Totals != want {
+		t.Errorf("totals = %+v, want %+v", summary.Totals, want)
+	}
+
+	tests := []struct {
+		name      string
+		density   task.Density
+		wantCount int32
+		wantRate  float32
+	}{
+		{name: "高密度", density: task.DensityHigh, wantCount: 2, wantRate: 75},
+		{name: "子タスクがない密度", density: task.DensityMedium, wantCount: 0, wantRate: 0},
+		{name: "低密度", density: task.DensityLow, wantCount: 1, wantRate: 25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := summary.Density(tt.density)
+			if got.PlannedCount != tt.wantCount {
+				t.Errorf("count = %d, want %d", got.PlannedCount, tt.wantCount)
+			}
+			if rate := got.DurationRate(summary.Totals); rate != tt.wantRate {
+				t.Errorf("rate = %v, want %v", rate, tt.wantRate)
+			}
+		})
+	}
+
+	if got := SummarizeTask(&task.Task{}, now); got.CompletionRate() != 0 || got.Density(task.DensityHigh).DurationRate(got.Totals) != 0 {
+		t.Errorf("empty task summary = %+v, want zero rates", got)
+	}
+}
@@ -0,0 +1,83 @@
+package task
+
+import (
+	"time"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+)
+
+// CarryOverMode 持ち越しの方法
+type CarryOverMode string
+
+const (
+	// CarryOverModeMove 子タスクを移動する（タイマーの記録も一緒に移動し、元のタスクからはなくなる）
+	CarryOverModeMove CarryOverMode = "Move"
+	// CarryOverModeCopy 子タスクを複製する（元のタスクには残る）
+	CarryOverModeCopy CarryOverMode = "Copy"
+)
+
+// ParseCarryOverMode 持ち越しの方法を解析（未指定の場合は移動）
+func ParseCarryOverMode(mode *string) (CarryOverMode, error) {
+	if mode == nil {
+		return CarryOverModeMove, nil
+	}
+	switch m := CarryOverMode(*mode); m {
+	case CarryOverModeMove, CarryOverModeCopy:
+		return m, nil
+	default:
+		return "", domainerrors.Validation("invalid mode").WithDetails(map[string]string{
+			"mode": "must be one of Move, Copy",
+		})
+	}
+}
+
+// CarryOverInput 子タスクの持ち越しの入力
+type CarryOverInput struct {
+	SourceTaskID string
+	OwnerID      string
+	// Title 持ち越し先のタスクがない場合に作成するタスクのタイトル
+	Title string
+	// Date 持ち越し先の日付
+	Date time.Time
+	// TaskItems 持ち越す子タスク（持ち越し先では、この順番で既存の子タスクの後ろに並べる）
+	TaskItems []TaskItem
+	Mode      CarryOverMode
+}
+
+// CarryOverResult 子タスクの持ち越しの結果
+type CarryOverResult struct {
+	// Target 持ち越した後の持ち越し先のタスク
+	Target *Task
+	// TargetBefore 持ち越す前の持ち越し先のタスク（持ち越し先のタスクを作成した場合はnil）
+	TargetBefore *Task
+}
+
+// PlanCarryOver 完了していない子タスクを指定した日付に持ち越す入力を作成
+func (t *Task) PlanCarryOver(date time.Time, mode CarryOverMode) (CarryOverInput, error) {
+	if date.Equal(t.Date) {
+		return CarryOverInput{}, domainerrors.Validation("Cannot carry over to the same date").WithDetails(map[string]string{
+			"date": "must be different from the date of the task",
+		})
+	}
+
+	items := make([]TaskItem, 0, len(t.TaskItems))
+	for _, item := range t.sortedTaskItems() {
+		if item.Status != StatusCompleted {
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		return CarryOverInput{}, domainerrors.Validation("No task items to carry over").WithDetails(map[string]string{
+			"taskItems": "all task items are completed",
+		})
+	}
+
+	return CarryOverInput{
+		SourceTaskID: t.ID,
+		OwnerID:      t.OwnerID,
+		Title:        t.Title,
+		Date:         date,
+		TaskItems:    items,
+		Mode:         mode,
+	}, nil
+}
@@ -0,0 +1,67 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+)
+
+func TestTask_PlanCarryOver(t *testing.T) {
+	today := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	tomorrow := today.AddDate(0, 0, 1)
+	source := &Task{
+		ID:      "task-1",
+		OwnerID: "owner-1",
+		Title:   "今日のタスク",
+		Date:    today,
+		TaskItems: []TaskItem{
+			{ID: "item-3", Order: 3, Status: StatusNotStarted},
+			{ID: "item-1", Order: 1, Status: StatusCompleted},
+			{ID: "item-2", Order: 2, Status: StatusInProgress},
+		},
+	}
+
+	t.Run("完了していない子タスクを順番どおりに持ち越す", func(t *testing.T) {
+		got, err := source.PlanCarryOver(tomorrow, CarryOverModeMove)
+		if err != nil {
+			t.Fatalf("PlanCarryOver() error = %v", err)
+		}
+		if got.SourceTaskID != "task-1" || got.OwnerID != "owner-1" || got.Title != "今日のタスク" || !got.Date.Equal(tomorrow) || got.Mode != CarryOverModeMove {
+			t.Errorf("PlanCarryOver() = %+v", got)
+		}
+		if len(got.TaskItems) != 2 || got.TaskItems[0].ID != "item-2" || got.TaskItems[1].ID != "item-3" {
+			t.Errorf("TaskItems = %+v", got.TaskItems)
+		}
+	})
+
+	t.Run("同じ日付には持ち越せない", func(t *testing.T) {
+		_, err := source.PlanCarryOver(today, CarryOverModeCopy)
+		if !domainerrors.IsValidation(err) {
+			t.Errorf("PlanCarryOver() error = %v, want Validation", err)
+		}
+	})
+
+	t.Run("完了していない子タスクがない", func(t *testing.T) {
+		completed := &Task{Date: today, TaskItems: []TaskItem{{ID: "item-1", Order: 1, Status: StatusCompleted}}}
+		_, err := completed.PlanCarryOver(tomorrow, CarryOverModeMove)
+		if !domainerrors.IsValidation(err) {
+			t.Errorf("PlanCarryOver() error = %v, want Validation", err)
+		}
+	})
+}
+
+func TestParseCarryOverMode(t *testing.T) {
+	copyMode := "Copy"
+	invalid := "Link"
+
+	if got, err := ParseCarryOverMode(nil); err != nil || got != CarryOverModeMove {
+		t.Errorf("ParseCarryOverMode(nil) = %v, %v, want Move", got, err)
+	}
+	if got, err := ParseCarryOverMode(&copyMode); err != nil || got != CarryOverModeCopy {
+		t.Errorf("ParseCarryOverMode(Copy) = %v, %v, want Copy", got, err)
+	}
+	if _, err := ParseCarryOverMode(&invalid); !domainerrors.IsValidation(err) {
+		t.Errorf("ParseCarryOverMode(Link) error = %v, want Validation", err)
+	}
+}
@@ -0,0 +1,75 @@
+package task
+
+import (
+	"cmp"
+	"slices"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+)
+
+// DuplicateOptions タスク複製のオプション
+type DuplicateOptions struct {
+	// ResetStatus 子タスクのステータスをNotStartedに戻す
+	// NotStartedの子タスクはアウトプットを持てないため、アウトプットも引き継がない
+	ResetStatus bool
+	// DropOutputs 子タスクのアウトプットを引き継がない
+	DropOutputs bool
+	// IncompleteOnly 完了していない子タスクのみを引き継ぐ
+	IncompleteOnly bool
+}
+
+// DuplicateTaskItems 複製先のタスクに作成する子タスクを取得
+// 子タスクの順番は元の順番を保ったまま1から振り直す（タイマーのセッションは引き継がない）
+func (t *Task) DuplicateTaskItems(options DuplicateOptions) ([]CreateTaskItemInput, error) {
+	inputs := make([]CreateTaskItemInput, 0, len(t.TaskItems))
+	for _, item := range t.sortedTaskItems() {
+		if options.IncompleteOnly && item.Status == StatusCompleted {
+			continue
+		}
+
+		input := CreateTaskItemInput{
+			Priority:         item.Priority,
+			Density:          item.Density,
+			DurationTime:     item.DurationTime,
+			Content:          item.Content,
+			IsRequired:       item.IsRequired,
+			Order:            int32(len(inputs) + 1),
+			Status:           item.Status,
+			CategoryID:       item.CategoryID,
+			OutputTemplateID: item.OutputTemplateID,
+		}
+		if options.ResetStatus {
+			input.Status = StatusNotStarted
+		} else {
+			input.StartedAt = item.StartedAt
+			input.CompletedAt = item.CompletedAt
+		}
+		if !options.DropOutputs && !options.ResetStatus {
+			input.Output = item.Output
+			input.OutputSections = item.OutputSections
+		}
+		inputs = append(inputs, input)
+	}
+
+	if len(t.TaskItems) == 0 {
+		return nil, domainerrors.Validation("No task items to duplicate").WithDetails(map[string]string{
+			"taskItems": "task has no task items",
+		})
+	}
+	if len(inputs) == 0 {
+		return nil, domainerrors.Validation("No task items to duplicate").WithDetails(map[string]string{
+			"taskItems": "all task items are completed",
+		})
+	}
+	return inputs, nil
+}
+
+// sortedTaskItems 子タスクを順番の昇順で取得
+func (t *Task) sortedTaskItems() []TaskItem {
+	items := make([]TaskItem, len(t.TaskItems))
+	copy(items, t.TaskItems)
+	slices.SortStableFunc(items, func(a, b TaskItem) int {
+		return cmp.Compare(a.Order, b.Order)
+	})
+	return items
+}
@@ -0,0 +1,117 @@
+package task
+
+import (
+	"errors"
+	"testing"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/outputtemplate"
+)
+
+func TestTask_DuplicateTaskItems(t *testing.T) {
+	output := "学んだこと"
+	source := &Task{
+		TaskItems: []TaskItem{
+			{Content: "振り返る", Order: 3, Status: StatusInProgress},
+			{Content: "メールを確認する", Order: 1, Status: StatusCompleted, Output: &output, OutputSections: []outputtemplate.FilledSection{{Key: "learned", Label: "学んだこと", Value: output}}},
+			{Content: "資料を作る", Order: 2, Status: StatusNotStarted},
+		},
+	}
+
+	t.Run("オプションなしはそのまま引き継ぎ、順番を振り直す", func(t *testing.T) {
+		got, err := source.DuplicateTaskItems(DuplicateOptions{})
+		if err != nil {
+			t.Fatalf("DuplicateTaskItems() error = %v", err)
+		}
+		if len(got) != 3 {
+			t.Fatalf("len = %d, want 3", len(got))
+		}
+		if got[0].Content != "メールを確認する" || got[0].Order != 1 || got[0].Status != StatusCompleted {
+			t.Errorf("got[0] = %+v", got[0])
+		}
+		if got[0].Output == nil || *got[0].Output != output || len(got[0].OutputSections) != 1 {
+			t.Errorf("output is not carried over: %+v", got[0])
+		}
+		if got[2].Content != "振り返る" || got[2].Order != 3 {
+			t.Errorf("got[2] = %+v", got[2])
+		}
+	})
+
+	t.Run("ステータスを戻し、アウトプットを引き継がない", func(t *testing.T) {
+		got, err := source.DuplicateTaskItems(DuplicateOptions{ResetStatus: true, DropOutputs: true})
+		if err != nil {
+			t.Fatalf("DuplicateTaskItems() error = %v", err)
+		}
+		for _, item := range got {
+			if item.Status != StatusNotStarted {
+				t.Errorf("%s: status = %s, want NotStarted", item.Content, item.Status)
+			}
+			if item.Output != nil || item.OutputSections != nil {
+				t.Errorf("%s: output is carried over", item.Content)
+			}
+		}
+	})
+
+	t.Run("ステータスを戻す場合はアウトプットも引き継がない", func(t *testing.T) {
+		got, err := source.DuplicateTaskItems(DuplicateOptions{ResetStatus: true})
+		if err != nil {
+			t.Fatalf("DuplicateTaskItems() error = %v", err)
+		}
+		for _, item := range got {
+			if item.Status != StatusNotStarted || item.StartedAt != nil || item.CompletedAt != nil {
+				t.Errorf("%s: status = %s, startedAt = %v, completedAt = %v", item.Content, item.Status, item.StartedAt, item.CompletedAt)
+			}
+			if item.Output != nil || item.OutputSections != nil {
+				t.Errorf("%s: output is carried over to a NotStarted task item", item.Content)
+			}
+		}
+	})
+
+	t.Run("未完了のみ引き継ぐ", func(t *testing.T) {
+		got, err := source.DuplicateTaskItems(DuplicateOptions{IncompleteOnly: true})
+		if err != nil {
+			t.Fatalf("DuplicateTaskItems() error = %v", err)
+		}
+		if len(got) != 2 || got[0].Content != "資料を作る" || got[0].Order != 1 || got[1].Order != 2 {
+			t.Errorf("got = %+v", got)
+		}
+	})
+
+	t.Run("引き継ぐ子タスクがない", func(t *testing.T) {
+		tests := []struct {
+			name       string
+			source     *Task
+			options    DuplicateOptions
+			wantDetail string
+		}{
+			{
+				name:       "すべて完了している",
+				source:     &Task{TaskItems: []TaskItem{{Content: "done", Order: 1, Status: StatusCompleted}}},
+				options:    DuplicateOptions{IncompleteOnly: true},
+				wantDetail: "all task items are completed",
+			},
+			{
+				name:       "子タスクがない",
+				source:     &Task{},
+				options:    DuplicateOptions{IncompleteOnly: true},
+				wantDetail: "task has no task items",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := tt.source.DuplicateTaskItems(tt.options)
+				if !domainerrors.IsValidation(err) {
+					t.Fatalf("DuplicateTaskItems() error = %v, want Validation", err)
+				}
+				var domainErr *domainerrors.Error
+				if !errors.As(err, &domainErr) {
+					t.Fatalf("error is not a domain error: %v", err)
+				}
+				if details, _ := domainErr.Details.(map[string]string); details["taskItems"] != tt.wantDetail {
+					t.Errorf("details = %v, want taskItems = %q", domainErr.Details, tt.wantDetail)
+				}
+			})
+		}
+	})
+}
@@ -2,6 +2,8 @@ package task
 
 import (
 	"time"
+
+	"task-management-system/backend/internal/domain/outputtemplate"
 )
 
 // Task タスクエンティティ（集約ルート）
@@ -12,8 +14,14 @@ type Task struct {
 	Date      time.Time
 	Review    *string
 	TaskItems []TaskItem
+	// Version タスクまたは子タスクが変更されるたびに増えるバージョン（楽観的排他制御に使用する）
+	Version   int64
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	// DeletedAt ゴミ箱に移動した日時（ゴミ箱にない場合はnil）
+	DeletedAt *time.Time
+	// SearchRank キーワードとの関連度（キーワード検索で取得した場合のみ）
+	SearchRank float32
 }
 
 // TaskItem タスクアイテムエンティティ（集約メンバー）
@@ -24,12 +32,23 @@ type TaskItem struct {
 	Density      Density
 	DurationTime DurationTime
 	Content      string
-	Output       *string
+	Output       *string // テンプレートを使用した場合はセクションをまとめたテキスト
 	IsRequired   bool
 	Order        int32
 	Status       Status
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	CategoryID   *string // 未分類の場合はnil
+	// OutputTemplateID アウトプットに使用するテンプレート（自由形式の場合はnil）
+	OutputTemplateID *string
+	// OutputSections テンプレートに沿って入力されたアウトプット（自由形式の場合はnil）
+	OutputSections []outputtemplate.FilledSection
+	// TimerSessions タイマーのセッション（開始日時の昇順）
+	TimerSessions []TimerSession
+	// CarriedOverFromTaskID 持ち越し元のタスク（持ち越していない場合、または持ち越し元が削除された場合はnil）
+	CarriedOverFromTaskID *string
+	StartedAt             *time.Time // 最初に着手した日時（未着手の場合はnil）
+	CompletedAt           *time.Time // 完了した日時（完了していない場合はnil）
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
 }
 
 // Priority 優先度
@@ -68,4 +87,3 @@ const (
 	DurationTime45 DurationTime = 45
 	DurationTime60 DurationTime = 60
 )
-
@@ -0,0 +1,202 @@
+package task
+
+import (
+	"reflect"
+	"time"
+)
+
+// EventType タスクの変更履歴の種類
+type EventType string
+
+const (
+	EventTypeTaskCreated           EventType = "TaskCreated"
+	EventTypeTaskUpdated           EventType = "TaskUpdated"
+	EventTypeTaskReviewUpdated     EventType = "TaskReviewUpdated"
+	EventTypeTaskItemAdded         EventType = "TaskItemAdded"
+	EventTypeTaskItemUpdated       EventType = "TaskItemUpdated"
+	EventTypeTaskItemOutputUpdated EventType = "TaskItemOutputUpdated"
+	EventTypeTaskItemRemoved       EventType = "TaskItemRemoved"
+	EventTypeTaskDeleted           EventType = "TaskDeleted"  // ゴミ箱への移動
+	EventTypeTaskRestored          EventType = "TaskRestored" // ゴミ箱からの復元
+	EventTypeTaskPurged            EventType = "TaskPurged"   // 完全な削除（タスクの削除後も履歴は残る）
+)
+
+// FieldChange 項目の変更前と変更後の値（追加の場合はBefore、削除の場合はAfterがnil）
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Diff 変更された項目（キーはAPIの項目名）
+type Diff map[string]FieldChange
+
+// Event タスクまたは子タスクの変更履歴（追記のみで更新しない）
+type Event struct {
+	ID     string
+	TaskID string
+	// TaskItemID 子タスクの変更の場合のみ設定（子タスクが削除されても残る）
+	TaskItemID *string
+	ActorID    string
+	Type       EventType
+	Diff       Diff
+	CreatedAt  time.Time
+}
+
+// DiffTask 変更前と変更後のタスクを比較して変更履歴を作成
+// beforeがnilの場合は作成として扱う。アウトプットが変わった子タスクはTaskItemOutputUpdatedとして記録する
+func DiffTask(before *Task, after *Task, actorID string) []Event {
+	events := []Event{}
+
+	if before == nil {
+		events = append(events, Event{
+			TaskID:  after.ID,
+			ActorID: actorID,
+			Type:    EventTypeTaskCreated,
+			Diff:    diffFields(nil, taskFields(after)),
+		})
+		for _, item := range after.sortedTaskItems() {
+			events = append(events, newTaskItemEvent(after.ID, item.ID, actorID, EventTypeTaskItemAdded, diffFields(nil, taskItemFields(item))))
+		}
+		return events
+	}
+
+	if diff := diffFields(taskFields(before), taskFields(after)); len(diff) > 0 {
+		events = append(events, Event{TaskID: after.ID, ActorID: actorID, Type: EventTypeTaskUpdated, Diff: diff})
+	}
+	if diff := diffFields(reviewFields(before), reviewFields(after)); len(diff) > 0 {
+		events = append(events, Event{TaskID: after.ID, ActorID: actorID, Type: EventTypeTaskReviewUpdated, Diff: diff})
+	}
+
+	beforeItems := make(map[string]TaskItem, len(before.TaskItems))
+	for _, item := range before.TaskItems {
+		beforeItems[item.ID] = item
+	}
+	afterItems := make(map[string]bool, len(after.TaskItems))
+	for _, item := range after.sortedTaskItems() {
+		afterItems[item.ID] = true
+
+		prev, ok := beforeItems[item.ID]
+		if !ok {
+			events = append(events, newTaskItemEvent(after.ID, item.ID, actorID, EventTypeTaskItemAdded, diffFields(nil, taskItemFields(item))))
+			continue
+		}
+
+		diff := diffFields(taskItemFields(prev), taskItemFields(item))
+		if len(diff) == 0 {
+			continue
+		}
+		eventType := EventTypeTaskItemUpdated
+		if _, ok := diff["output"]; ok {
+			eventType = EventTypeTaskItemOutputUpdated
+		}
+		events = append(events, newTaskItemEvent(after.ID, item.ID, actorID, eventType, diff))
+	}
+
+	for _, item := range before.sortedTaskItems() {
+		if afterItems[item.ID] {
+			continue
+		}
+		events = append(events, newTaskItemEvent(after.ID, item.ID, actorID, EventTypeTaskItemRemoved, diffFields(taskItemFields(item), nil)))
+	}
+
+	return events
+}
+
+// TrashEvent ゴミ箱への移動または復元の変更履歴を作成（変更された項目はない）
+func TrashEvent(taskID string, actorID string, restored bool) Event {
+	eventType := EventTypeTaskDeleted
+	if restored {
+		eventType = EventTypeTaskRestored
+	}
+	return Event{TaskID: taskID, ActorID: actorID, Type: eventType, Diff: Diff{}}
+}
+
+// PurgeEvent 完全に削除したタスクの変更履歴を作成（削除したタスクの項目を変更前として記録する）
+func PurgeEvent(t *Task, actorID string) Event {
+	return Event{TaskID: t.ID, ActorID: actorID, Type: EventTypeTaskPurged, Diff: diffFields(taskFields(t), nil)}
+}
+
+// newTaskItemEvent 子タスクの変更履歴を作成
+func newTaskItemEvent(taskID string, taskItemID string, actorID string, eventType EventType, diff Diff) Event {
+	return Event{
+		TaskID:     taskID,
+		TaskItemID: &taskItemID,
+		ActorID:    actorID,
+		Type:       eventType,
+		Diff:       diff,
+	}
+}
+
+// taskFields 変更履歴に記録するタスクの項目
+func taskFields(t *Task) map[string]interface{} {
+	return map[string]interface{}{
+		"title": t.Title,
+		"date":  t.Date.Format(time.DateOnly),
+	}
+}
+
+// reviewFields 変更履歴に記録するタスクの振り返り
+func reviewFields(t *Task) map[string]interface{} {
+	return map[string]interface{}{
+		"review": stringValue(t.Review),
+	}
+}
+
+// taskItemFields 変更履歴に記録する子タスクの項目（タイマーのセッションは記録しない）
+func taskItemFields(item TaskItem) map[string]interface{} {
+	return map[string]interface{}{
+		"priority":         string(item.Priority),
+		"density":          string(item.Density),
+		"durationTime":     int(item.DurationTime),
+		"content":          item.Content,
+		"output":           stringValue(item.Output),
+		"isRequired":       item.IsRequired,
+		"order":            int(item.Order),
+		"status":           string(item.Status),
+		"categoryId":       stringValue(item.CategoryID),
+		"outputTemplateId": stringValue(item.OutputTemplateID),
+		"startedAt":        timeValue(item.StartedAt),
+		"completedAt":      timeValue(item.CompletedAt),
+	}
+}
+
+// diffFields 変更前と変更後の項目を比較して変更された項目を取得
+// 追加・削除の場合は値のある項目のみを記録する
+func diffFields(before map[string]interface{}, after map[string]interface{}) Diff {
+	diff := Diff{}
+	for key, value := range after {
+		prev := before[key]
+		if before == nil && value == nil {
+			continue
+		}
+		if before != nil && reflect.DeepEqual(prev, value) {
+			continue
+		}
+		diff[key] = FieldChange{Before: prev, After: value}
+	}
+	if after == nil {
+		for key, value := range before {
+			if value == nil {
+				continue
+			}
+			diff[key] = FieldChange{Before: value, After: nil}
+		}
+	}
+	return diff
+}
+
+// stringValue 文字列のポインタを変更履歴の値に変換（nilの場合はnil）
+func stringValue(s *string) interface{} {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+// timeValue 日時のポインタを変更履歴の値に変換（nilの場合はnil）
+func timeValue(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,141 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffTask(t *testing.T) {
+	date := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	output := "学んだこと"
+	review := "よくできた"
+
+	before := &Task{
+		ID:    "task-1",
+		Title: "月曜日",
+		Date:  date,
+		TaskItems: []TaskItem{
+			{ID: "item-1", Content: "メールを確認する", Priority: PriorityLow, Order: 1, Status: StatusNotStarted},
+			{ID: "item-2", Content: "資料を作る", Priority: PriorityMedium, Order: 2, Status: StatusNotStarted},
+			{ID: "item-3", Content: "振り返る", Priority: PriorityLow, Order: 3, Status: StatusNotStarted},
+		},
+	}
+
+	t.Run("作成はタスクと子タスクの追加を記録する", func(t *testing.T) {
+		events := DiffTask(nil, before, "alice")
+		if len(events) != 4 {
+			t.Fatalf("len = %d, want 4", len(events))
+		}
+		if events[0].Type != EventTypeTaskCreated || events[0].TaskItemID != nil || events[0].ActorID != "alice" {
+			t.Errorf("events[0] = %+v", events[0])
+		}
+		if got := events[0].Diff["title"]; got.Before != nil || got.After != "月曜日" {
+			t.Errorf("title change = %+v", got)
+		}
+		if events[1].Type != EventTypeTaskItemAdded || *events[1].TaskItemID != "item-1" {
+			t.Errorf("events[1] = %+v", events[1])
+		}
+		if _, ok := events[1].Diff["output"]; ok {
+			t.Errorf("empty output should not be recorded: %+v", events[1].Diff)
+		}
+	})
+
+	t.Run("変更がない場合は記録しない", func(t *testing.T) {
+		if events := DiffTask(before, before, "alice"); len(events) != 0 {
+			t.Errorf("events = %+v, want none", events)
+		}
+	})
+
+	t.Run("変更された項目のみを記録する", func(t *testing.T) {
+		after := &Task{
+			ID:     "task-1",
+			Title:  "月曜日のタスク",
+			Date:   date,
+			Review: &review,
+			TaskItems: []TaskItem{
+				{ID: "item-1", Content: "メールを確認する", Priority: PriorityHigh, Order: 1, Status: StatusNotStarted},
+				{ID: "item-2", Content: "資料を作る", Priority: PriorityMedium, Order: 2, Status: StatusCompleted, Output: &output},
+				{ID: "item-4", Content: "片付ける", Priority: PriorityLow, Order: 3, Status: StatusNotStarted},
+			},
+		}
+
+		events := DiffTask(before, after, "alice")
+		want := []struct {
+			eventType  EventType
+			taskItemID string
+			fields     []string
+		}{
+			{eventType: EventTypeTaskUpdated, fields: []string{"title"}},
+			{eventType: EventTypeTaskReviewUpdated, fields: []string{"review"}},
+			{eventType: EventTypeTaskItemUpdated, taskItemID: "item-1", fields: []string{"priority"}},
+			{eventType: EventTypeTaskItemOutputUpdated, taskItemID: "item-2", fields: []string{"output", "status"}},
+			{eventType: EventTypeTaskItemAdded, taskItemID: "item-4"},
+			{eventType: EventTypeTaskItemRemoved, taskItemID: "item-3"},
+		}
+		if len(events) != len(want) {
+			t.Fatalf("len = %d, want %d: %+v", len(events), len(want), events)
+		}
+		for i, w := range want {
+			got := events[i]
+			if got.Type != w.eventType {
+				t.Errorf("events[%d].Type = %s, want %s", i, got.Type, w.eventType)
+			}
+			if w.taskItemID == "" && got.TaskItemID != nil {
+				t.Errorf("events[%d].TaskItemID = %s, want nil", i, *got.TaskItemID)
+			}
+			if w.taskItemID != "" && (got.TaskItemID == nil || *got.TaskItemID != w.taskItemID) {
+				t.Errorf("events[%d].TaskItemID = %v, want %s", i, got.TaskItemID, w.taskItemID)
+			}
+			if w.fields != nil && len(got.Diff) != len(w.fields) {
+				t.Errorf("events[%d].Diff = %+v, want fields %v", i, got.Diff, w.fields)
+			}
+			for _, field := range w.fields {
+				if _, ok := got.Diff[field]; !ok {
+					t.Errorf("events[%d].Diff does not contain %s: %+v", i, field, got.Diff)
+				}
+			}
+		}
+
+		if got := events[2].Diff["priority"]; got.Before != "Low" || got.After != "High" {
+			t.Errorf("priority change = %+v", got)
+		}
+		if got := events[5].Diff["content"]; got.Before != "振り返る" || got.After != nil {
+			t.Errorf("removed content = %+v", got)
+		}
+	})
+}
+
+func TestTrashEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		restored bool
+		want     EventType
+	}{
+		{name: "ゴミ箱への移動", restored: false, want: EventTypeTaskDeleted},
+		{name: "ゴミ箱からの復元", restored: true, want: EventTypeTaskRestored},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := TrashEvent("task-1", "alice", tt.restored)
+			if got.Type != tt.want || got.TaskID != "task-1" || got.ActorID != "alice" || got.TaskItemID != nil {
+				t.Errorf("TrashEvent() = %+v, want %s", got, tt.want)
+			}
+			if len(got.Diff) != 0 {
+				t.Errorf("Diff = %+v, want empty", got.Diff)
+			}
+		})
+	}
+}
+
+func TestPurgeEvent(t *testing.T) {
+	deleted := &Task{ID: "task-1", Title: "月曜日", Date: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)}
+
+	got := PurgeEvent(deleted, "alice")
+	if got.Type != EventTypeTaskPurged || got.TaskID != "task-1" || got.ActorID != "alice" {
+		t.Errorf("PurgeEvent() = %+v", got)
+	}
+	// 削除したタスクの内容を変更前の値として残す
+	if change := got.Diff["title"]; change.Before != "月曜日" || change.After != nil {
+		t.Errorf("title change = %+v", change)
+	}
+}
@@ -0,0 +1,286 @@
+package task
+
+import (
+	"strings"
+	"time"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+)
+
+// FieldError バリデーションエラーの項目
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// TaskItemPatch 子タスクの部分更新の入力（nilの項目は変更しない）
+type TaskItemPatch struct {
+	Priority     *Priority
+	Density      *Density
+	DurationTime *DurationTime
+	Content      *string
+	IsRequired   *bool
+	Order        *int32
+	CategoryID   *string
+	// ClearCategory 未分類にする（CategoryIDより優先）
+	ClearCategory    bool
+	OutputTemplateID *string
+	// ClearOutputTemplate 自由形式にする（OutputTemplateIDより優先）
+	ClearOutputTemplate bool
+}
+
+// AddTaskItem 子タスクを追加できることを確認
+// 同じタスク内で順番が重複する場合はConflictエラーを返す
+func (t *Task) AddTaskItem(input CreateTaskItemInput) error {
+	item := TaskItem{
+		Priority:     input.Priority,
+		Density:      input.Density,
+		DurationTime: input.DurationTime,
+		Content:      input.Content,
+		Order:        input.Order,
+		Status:       input.Status,
+	}
+	if err := item.validate(); err != nil {
+		return err
+	}
+	return t.ensureOrderAvailable("", input.Order)
+}
+
+// PatchTaskItem 子タスクに部分更新を適用した結果を取得（集約の子タスクは変更しない）
+// 同じタスク内で順番が重複する場合はConflictエラーを返す
+func (t *Task) PatchTaskItem(taskItemID string, patch TaskItemPatch) (TaskItem, error) {
+	current, err := t.findTaskItem(taskItemID)
+	if err != nil {
+		return TaskItem{}, err
+	}
+
+	item := *current
+	if patch.Priority != nil {
+		item.Priority = *patch.Priority
+	}
+	if patch.Density != nil {
+		item.Density = *patch.Density
+	}
+	if patch.DurationTime != nil {
+		item.DurationTime = *patch.DurationTime
+	}
+	if patch.Content != nil {
+		item.Content = *patch.Content
+	}
+	if patch.IsRequired != nil {
+		item.IsRequired = *patch.IsRequired
+	}
+	if patch.Order != nil {
+		item.Order = *patch.Order
+	}
+	if patch.ClearCategory {
+		item.CategoryID = nil
+	} else if patch.CategoryID != nil {
+		item.CategoryID = patch.CategoryID
+	}
+	if patch.ClearOutputTemplate {
+		item.OutputTemplateID = nil
+	} else if patch.OutputTemplateID != nil {
+		item.OutputTemplateID = patch.OutputTemplateID
+	}
+
+	if err := item.validate(); err != nil {
+		return TaskItem{}, err
+	}
+	if err := t.ensureOrderAvailable(taskItemID, item.Order); err != nil {
+		return TaskItem{}, err
+	}
+	return item, nil
+}
+
+// RemoveTaskItem 子タスクを削除できることを確認
+// タスクには子タスクが少なくとも1つ必要なため、最後の子タスクは削除できない
+func (t *Task) RemoveTaskItem(taskItemID string) error {
+	if _, err := t.findTaskItem(taskItemID); err != nil {
+		return err
+	}
+	if len(t.TaskItems) <= 1 {
+		return domainerrors.Validation("Validation failed").WithDetails(map[string]interface{}{
+			"errors": []FieldError{{Field: "taskItems", Message: "taskItemsは少なくとも1つ必要です"}},
+		})
+	}
+	return nil
+}
+
+// ChangeTaskItemStatus 子タスクのステータスを変更した結果を取得（集約の子タスクは変更しない）
+// 許可されていないステータスの変更はConflictエラーを返す
+func (t *Task) ChangeTaskItemStatus(taskItemID string, status Status, at time.Time) (TaskItem, error) {
+	current, err := t.findTaskItem(taskItemID)
+	if err != nil {
+		return TaskItem{}, err
+	}
+
+	change, err := current.PlanStatusChange(status, at)
+	if err != nil {
+		return TaskItem{}, err
+	}
+	item := *current
+	change.Apply(&item)
+	return item, nil
+}
+
+// PlanTaskItemUpdates 一括更新する子タスクのステータスの変更を確認し、着手日時と完了日時を設定した入力を返す
+// 既存の子タスクは現在のステータスから、新しい子タスクは未着手からの変更として扱う
+func (t *Task) PlanTaskItemUpdates(inputs []UpdateTaskItemInput, at time.Time) ([]UpdateTaskItemInput, error) {
+	result := make([]UpdateTaskItemInput, 0, len(inputs))
+	for _, input := range inputs {
+		var (
+			change StatusChange
+			err    error
+		)
+		if current, findErr := t.findTaskItem(input.ID); findErr == nil {
+			change, err = current.PlanStatusChange(input.Status, at)
+		} else {
+			change, err = newStatusChange(input.Status, at)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		input.StartedAt = change.StartedAt
+		input.CompletedAt = change.CompletedAt
+		result = append(result, input)
+	}
+	return result, nil
+}
+
+// PlanNewTaskItem 作成する子タスクの着手日時と完了日時をステータスから設定した入力を返す
+// 未着手からの変更として扱い、複製や持ち越しで引き継いだ日時は保持する
+func PlanNewTaskItem(input CreateTaskItemInput, at time.Time) (CreateTaskItemInput, error) {
+	change, err := newStatusChange(input.Status, at)
+	if err != nil {
+		return CreateTaskItemInput{}, err
+	}
+	if input.StartedAt == nil {
+		input.StartedAt = change.StartedAt
+	}
+	if input.CompletedAt == nil {
+		input.CompletedAt = change.CompletedAt
+	}
+	return input, nil
+}
+
+// PlanNewTaskItems 作成する子タスクそれぞれにPlanNewTaskItemを適用した入力を返す
+func PlanNewTaskItems(inputs []CreateTaskItemInput, at time.Time) ([]CreateTaskItemInput, error) {
+	result := make([]CreateTaskItemInput, 0, len(inputs))
+	for _, input := range inputs {
+		planned, err := PlanNewTaskItem(input, at)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, planned)
+	}
+	return result, nil
+}
+
+// TaskItemOrder 並び替え後の子タスクの順番
+type TaskItemOrder struct {
+	TaskItemID string
+	Order      int32
+}
+
+// ReorderTaskItems 指定したIDの順に子タスクを並べた順番（1から連番）を取得
+// taskItemIDsはタスクのすべての子タスクを1回ずつ含める必要がある
+func (t *Task) ReorderTaskItems(taskItemIDs []string) ([]TaskItemOrder, error) {
+	var fieldErrors []FieldError
+
+	seen := make(map[string]bool, len(taskItemIDs))
+	for _, id := range taskItemIDs {
+		if seen[id] {
+			fieldErrors = append(fieldErrors, FieldError{Field: "taskItemIds", Message: "子タスクID " + id + " が重複しています"})
+			continue
+		}
+		seen[id] = true
+		if _, err := t.findTaskItem(id); err != nil {
+			fieldErrors = append(fieldErrors, FieldError{Field: "taskItemIds", Message: "子タスクID " + id + " はこのタスクの子タスクではありません"})
+		}
+	}
+	for _, item := range t.sortedTaskItems() {
+		if !seen[item.ID] {
+			fieldErrors = append(fieldErrors, FieldError{Field: "taskItemIds", Message: "子タスクID " + item.ID + " が含まれていません"})
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, domainerrors.Validation("Validation failed").WithDetails(map[string]interface{}{
+			"errors": fieldErrors,
+		})
+	}
+
+	orders := make([]TaskItemOrder, 0, len(taskItemIDs))
+	for i, id := range taskItemIDs {
+		orders = append(orders, TaskItemOrder{TaskItemID: id, Order: int32(i + 1)})
+	}
+	return orders, nil
+}
+
+// findTaskItem 子タスクを取得
+func (t *Task) findTaskItem(taskItemID string) (*TaskItem, error) {
+	for i := range t.TaskItems {
+		if t.TaskItems[i].ID == taskItemID {
+			return &t.TaskItems[i], nil
+		}
+	}
+	return nil, domainerrors.NotFound("Task item not found")
+}
+
+// ensureOrderAvailable 順番が他の子タスクと重複しないことを確認（excludeIDの子タスクは除く）
+func (t *Task) ensureOrderAvailable(excludeID string, order int32) error {
+	for _, item := range t.TaskItems {
+		if item.ID != excludeID && item.Order == order {
+			return domainerrors.Conflict("Task item order must be unique within a task").WithDetails(map[string]interface{}{
+				"order": order,
+			})
+		}
+	}
+	return nil
+}
+
+// validate 子タスクの各項目が正しいことを確認
+func (i TaskItem) validate() error {
+	var fieldErrors []FieldError
+
+	switch i.Priority {
+	case PriorityHigh, PriorityMedium, PriorityLow:
+	default:
+		fieldErrors = append(fieldErrors, FieldError{Field: "priority", Message: "priorityはHigh、Medium、Lowのいずれかである必要があります"})
+	}
+
+	switch i.Density {
+	case DensityHigh, DensityMedium, DensityLow:
+	default:
+		fieldErrors = append(fieldErrors, FieldError{Field: "density", Message: "densityはHigh、Medium、Lowのいずれかである必要があります"})
+	}
+
+	switch i.DurationTime {
+	case DurationTime15, DurationTime30, DurationTime45, DurationTime60:
+	default:
+		fieldErrors = append(fieldErrors, FieldError{Field: "durationTime", Message: "durationTimeは60、45、30、15のいずれかである必要があります"})
+	}
+
+	if strings.TrimSpace(i.Content) == "" {
+		fieldErrors = append(fieldErrors, FieldError{Field: "content", Message: "contentは1文字以上である必要があります"})
+	}
+
+	if i.Order < 0 {
+		fieldErrors = append(fieldErrors, FieldError{Field: "order", Message: "orderは0以上の整数である必要があります"})
+	}
+
+	switch i.Status {
+	case StatusNotStarted, StatusInProgress, StatusCompleted:
+	default:
+		fieldErrors = append(fieldErrors, FieldError{Field: "status", Message: "statusはNotStarted、InProgress、Completedのいずれかである必要があります"})
+	}
+
+	if len(fieldErrors) > 0 {
+		return domainerrors.Validation("Validation failed").WithDetails(map[string]interface{}{
+			"errors": fieldErrors,
+		})
+	}
+	return nil
+}
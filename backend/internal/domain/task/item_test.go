@@ -0,0 +1,152 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+)
+
+func newItemTestTask() *Task {
+	return &Task{
+		TaskItems: []TaskItem{
+			{ID: "item-1", Priority: PriorityHigh, Density: DensityMedium, DurationTime: DurationTime30, Content: "メールを確認する", Order: 1, Status: StatusNotStarted},
+			{ID: "item-2", Priority: PriorityLow, Density: DensityLow, DurationTime: DurationTime15, Content: "資料を作る", Order: 2, Status: StatusInProgress},
+		},
+	}
+}
+
+func TestTask_AddTaskItem(t *testing.T) {
+	input := CreateTaskItemInput{
+		Priority:     PriorityMedium,
+		Density:      DensityHigh,
+		DurationTime: DurationTime60,
+		Content:      "振り返る",
+		Order:        3,
+		Status:       StatusNotStarted,
+	}
+
+	if err := newItemTestTask().AddTaskItem(input); err != nil {
+		t.Errorf("AddTaskItem() error = %v", err)
+	}
+
+	duplicated := input
+	duplicated.Order = 2
+	if err := newItemTestTask().AddTaskItem(duplicated); !domainerrors.IsConflict(err) {
+		t.Errorf("AddTaskItem() error = %v, want Conflict", err)
+	}
+
+	invalid := input
+	invalid.Content = " "
+	if err := newItemTestTask().AddTaskItem(invalid); !domainerrors.IsValidation(err) {
+		t.Errorf("AddTaskItem() error = %v, want Validation", err)
+	}
+}
+
+func TestTask_PatchTaskItem(t *testing.T) {
+	categoryID := "category-1"
+
+	t.Run("指定した項目のみ変更する", func(t *testing.T) {
+		source := newItemTestTask()
+		source.TaskItems[0].CategoryID = &categoryID
+		content := "メールを返信する"
+
+		got, err := source.PatchTaskItem("item-1", TaskItemPatch{Content: &content, ClearCategory: true})
+		if err != nil {
+			t.Fatalf("PatchTaskItem() error = %v", err)
+		}
+		if got.Content != content || got.CategoryID != nil || got.Priority != PriorityHigh || got.Order != 1 {
+			t.Errorf("got = %+v", got)
+		}
+		if source.TaskItems[0].Content != "メールを確認する" {
+			t.Errorf("aggregate is modified: %+v", source.TaskItems[0])
+		}
+	})
+
+	t.Run("順番が重複する", func(t *testing.T) {
+		order := int32(2)
+		_, err := newItemTestTask().PatchTaskItem("item-1", TaskItemPatch{Order: &order})
+		if !domainerrors.IsConflict(err) {
+			t.Errorf("PatchTaskItem() error = %v, want Conflict", err)
+		}
+	})
+
+	t.Run("自分の順番のままは重複としない", func(t *testing.T) {
+		order := int32(1)
+		if _, err := newItemTestTask().PatchTaskItem("item-1", TaskItemPatch{Order: &order}); err != nil {
+			t.Errorf("PatchTaskItem() error = %v", err)
+		}
+	})
+
+	t.Run("不正な値", func(t *testing.T) {
+		duration := DurationTime(20)
+		_, err := newItemTestTask().PatchTaskItem("item-1", TaskItemPatch{DurationTime: &duration})
+		if !domainerrors.IsValidation(err) {
+			t.Errorf("PatchTaskItem() error = %v, want Validation", err)
+		}
+	})
+
+	t.Run("存在しない子タスク", func(t *testing.T) {
+		_, err := newItemTestTask().PatchTaskItem("missing", TaskItemPatch{})
+		if !domainerrors.IsNotFound(err) {
+			t.Errorf("PatchTaskItem() error = %v, want NotFound", err)
+		}
+	})
+}
+
+func TestTask_RemoveTaskItem(t *testing.T) {
+	source := newItemTestTask()
+	if err := source.RemoveTaskItem("item-1"); err != nil {
+		t.Errorf("RemoveTaskItem() error = %v", err)
+	}
+
+	last := &Task{TaskItems: source.TaskItems[:1]}
+	if err := last.RemoveTaskItem("item-1"); !domainerrors.IsValidation(err) {
+		t.Errorf("RemoveTaskItem() error = %v, want Validation", err)
+	}
+}
+
+func TestTask_ChangeTaskItemStatus(t *testing.T) {
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	got, err := newItemTestTask().ChangeTaskItemStatus("item-1", StatusCompleted, at)
+	if err != nil {
+		t.Fatalf("ChangeTaskItemStatus() error = %v", err)
+	}
+	if got.Status != StatusCompleted || got.CompletedAt == nil || !got.CompletedAt.Equal(at) {
+		t.Errorf("got = %+v", got)
+	}
+
+	if _, err := newItemTestTask().ChangeTaskItemStatus("item-1", Status("Done"), at); !domainerrors.IsValidation(err) {
+		t.Errorf("ChangeTaskItemStatus() error = %v, want Validation", err)
+	}
+}
+
+func TestTask_ReorderTaskItems(t *testing.T) {
+	t.Run("指定した順に1から順番を振り直す", func(t *testing.T) {
+		got, err := newItemTestTask().ReorderTaskItems([]string{"item-2", "item-1"})
+		if err != nil {
+			t.Fatalf("ReorderTaskItems() error = %v", err)
+		}
+		want := []TaskItemOrder{{TaskItemID: "item-2", Order: 1}, {TaskItemID: "item-1", Order: 2}}
+		if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+			t.Errorf("got = %+v, want %+v", got, want)
+		}
+	})
+
+	tests := []struct {
+		name string
+		ids  []string
+	}{
+		{name: "不足している", ids: []string{"item-1"}},
+		{name: "重複している", ids: []string{"item-1", "item-1", "item-2"}},
+		{name: "他のタスクの子タスクを含む", ids: []string{"item-1", "item-2", "other"}},
+		{name: "空", ids: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newItemTestTask().ReorderTaskItems(tt.ids); !domainerrors.IsValidation(err) {
+				t.Errorf("ReorderTaskItems() error = %v, want Validation", err)
+			}
+		})
+	}
+}
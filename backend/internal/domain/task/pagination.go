@@ -0,0 +1,163 @@
+package task
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+)
+
+// SortOrder タスク一覧の並び順
+type SortOrder string
+
+const (
+	// SortNewest 作成日時の新しい順
+	SortNewest SortOrder = "newest"
+	// SortOldest 作成日時の古い順
+	SortOldest SortOrder = "oldest"
+	// SortDateAsc 日付の昇順
+	SortDateAsc SortOrder = "date-asc"
+	// SortDateDesc 日付の降順
+	SortDateDesc SortOrder = "date-desc"
+	// SortRelevance キーワードとの関連度の高い順（キーワード検索時のみ）
+	SortRelevance SortOrder = "relevance"
+)
+
+const (
+	// DefaultListTasksLimit 取得件数が指定されなかった場合の件数
+	DefaultListTasksLimit int32 = 50
+	// MaxListTasksLimit 一度に取得できる最大件数
+	MaxListTasksLimit int32 = 100
+)
+
+// ParseSortOrder 並び順を解析
+// 未指定の場合は、キーワード検索時は関連度の高い順、それ以外は新しい順
+func ParseSortOrder(sort *string, keyword *string) (SortOrder, error) {
+	if sort == nil || *sort == "" {
+		if keyword != nil {
+			return SortRelevance, nil
+		}
+		return SortNewest, nil
+	}
+
+	order := SortOrder(*sort)
+	if !order.valid() {
+		return "", domainerrors.Validation("invalid sort").WithDetails(map[string]string{
+			"sort": "must be one of newest, oldest, date-asc, date-desc, relevance",
+		})
+	}
+	if order == SortRelevance && keyword == nil {
+		return "", domainerrors.Validation("invalid sort").WithDetails(map[string]string{
+			"sort": "relevance requires q",
+		})
+	}
+	return order, nil
+}
+
+// valid 対応している並び順かどうか
+func (s SortOrder) valid() bool {
+	switch s {
+	case SortNewest, SortOldest, SortDateAsc, SortDateDesc, SortRelevance:
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseListTasksLimit 取得件数を解析（未指定の場合はデフォルトの件数）
+func ParseListTasksLimit(limit *int32) (int32, error) {
+	if limit == nil {
+		return DefaultListTasksLimit, nil
+	}
+	if *limit < 1 || *limit > MaxListTasksLimit {
+		return 0, domainerrors.Validation("invalid limit").WithDetails(map[string]string{
+			"limit": "must be between 1 and 100",
+		})
+	}
+	return *limit, nil
+}
+
+// ListTasksCursor タスク一覧のページ位置
+// 並び順のキーとタイブレーク用のIDを保持し、クライアントには不透明な文字列として渡す
+type ListTasksCursor struct {
+	Sort      SortOrder `json:"s"`
+	CreatedAt time.Time `json:"c"`
+	Date      string    `json:"d"`
+	// Rank キーワードとの関連度（関連度順の場合のみ）
+	Rank float32 `json:"r,omitempty"`
+	// Keyword 関連度を計算したキーワードのハッシュ（関連度順の場合のみ）
+	Keyword string `json:"k,omitempty"`
+	ID      string `json:"i"`
+}
+
+// NewListTasksCursor タスクの位置を指すカーソルを作成
+// 関連度はキーワードによって変わるため、関連度順の場合はキーワードのハッシュも保持する
+func NewListTasksCursor(sort SortOrder, t *Task, keyword *string) ListTasksCursor {
+	cursor := ListTasksCursor{
+		Sort:      sort,
+		CreatedAt: t.CreatedAt,
+		Date:      t.Date.Format(time.DateOnly),
+		Rank:      t.SearchRank,
+		ID:        t.ID,
+	}
+	if sort == SortRelevance {
+		cursor.Keyword = hashCursorKeyword(keyword)
+	}
+	return cursor
+}
+
+// CheckCondition カーソルを発行したときと同じ並び順とキーワードか確認
+// 関連度順のカーソルは、同じキーワードの検索でのみ使用できる
+func (c ListTasksCursor) CheckCondition(sort SortOrder, keyword *string) error {
+	if c.Sort != sort {
+		return domainerrors.Validation("cursor does not match sort")
+	}
+	if sort == SortRelevance && c.Keyword != hashCursorKeyword(keyword) {
+		return domainerrors.Validation("cursor does not match q")
+	}
+	return nil
+}
+
+// hashCursorKeyword カーソルに保持するキーワードのハッシュ（キーワードがない場合は空文字）
+func hashCursorKeyword(keyword *string) string {
+	if keyword == nil {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(*keyword))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Encode カーソルを不透明な文字列に変換
+func (c ListTasksCursor) Encode() string {
+	// 構造体のエンコードは失敗しないためエラーは無視する
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeListTasksCursor 不透明な文字列からカーソルを復元
+func DecodeListTasksCursor(value string) (*ListTasksCursor, error) {
+	invalid := domainerrors.Validation("invalid cursor")
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, invalid.Wrap(err)
+	}
+
+	var cursor ListTasksCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, invalid.Wrap(err)
+	}
+	if _, err := time.Parse(time.DateOnly, cursor.Date); err != nil {
+		return nil, invalid.Wrap(err)
+	}
+	if cursor.ID == "" || cursor.CreatedAt.IsZero() {
+		return nil, invalid
+	}
+	if !cursor.Sort.valid() {
+		return nil, invalid
+	}
+
+	return &cursor, nil
+}
@@ -0,0 +1,125 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+)
+
+func TestListTasksCursor_EncodeDecode(t *testing.T) {
+	tk := &Task{
+		ID:        "a1f0c3d2-7b6e-4a59-8c1d-3e2f4a5b6c01",
+		Date:      time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt: time.Date(2026, 10, 1, 9, 30, 0, 123456000, time.UTC),
+	}
+
+	got, err := DecodeListTasksCursor(NewListTasksCursor(SortDateDesc, tk, nil).Encode())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Sort != SortDateDesc || got.ID != tk.ID || got.Date != "2026-10-01" || !got.CreatedAt.Equal(tk.CreatedAt) {
+		t.Errorf("decoded cursor = %+v", got)
+	}
+}
+
+func TestListTasksCursor_CheckCondition(t *testing.T) {
+	tk := &Task{
+		ID:         "a1f0c3d2-7b6e-4a59-8c1d-3e2f4a5b6c01",
+		Date:       time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:  time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC),
+		SearchRank: 0.5,
+	}
+	meeting := "会議"
+	report := "日報"
+
+	tests := []struct {
+		name    string
+		cursor  ListTasksCursor
+		sort    SortOrder
+		keyword *string
+		wantErr bool
+	}{
+		{name: "同じ並び順", cursor: NewListTasksCursor(SortNewest, tk, nil), sort: SortNewest},
+		{name: "並び順が異なる", cursor: NewListTasksCursor(SortNewest, tk, nil), sort: SortOldest, wantErr: true},
+		{name: "関連度順で同じキーワード", cursor: NewListTasksCursor(SortRelevance, tk, &meeting), sort: SortRelevance, keyword: &meeting},
+		{name: "関連度順でキーワードが異なる", cursor: NewListTasksCursor(SortRelevance, tk, &meeting), sort: SortRelevance, keyword: &report, wantErr: true},
+		{name: "関連度順以外はキーワードを確認しない", cursor: NewListTasksCursor(SortNewest, tk, &meeting), sort: SortNewest, keyword: &report},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// エンコードしたカーソルを復元しても同じ結果になる
+			cursor, err := DecodeListTasksCursor(tt.cursor.Encode())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			err = cursor.CheckCondition(tt.sort, tt.keyword)
+			if tt.wantErr {
+				if !domainerrors.IsValidation(err) {
+					t.Errorf("err = %v, want Validation", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestDecodeListTasksCursor_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "base64でない", value: "not base64!"},
+		{name: "JSONでない", value: "bm90LWpzb24"},
+		{name: "必須項目がない", value: "e30"},
+		{name: "未対応の並び順", value: ListTasksCursor{Sort: "random", CreatedAt: time.Now(), Date: "2026-10-01", ID: "x"}.Encode()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DecodeListTasksCursor(tt.value); !domainerrors.IsValidation(err) {
+				t.Errorf("err = %v, want Validation", err)
+			}
+		})
+	}
+}
+
+func TestParseSortOrder(t *testing.T) {
+	keyword := "会議"
+	relevance := string(SortRelevance)
+	oldest := string(SortOldest)
+
+	tests := []struct {
+		name    string
+		sort    *string
+		keyword *string
+		want    SortOrder
+		wantErr bool
+	}{
+		{name: "未指定の場合は新しい順", want: SortNewest},
+		{name: "キーワード検索で未指定の場合は関連度順", keyword: &keyword, want: SortRelevance},
+		{name: "キーワード検索でも指定した並び順を優先", sort: &oldest, keyword: &keyword, want: SortOldest},
+		{name: "キーワードがない場合は関連度順を指定できない", sort: &relevance, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSortOrder(tt.sort, tt.keyword)
+			if tt.wantErr {
+				if !domainerrors.IsValidation(err) {
+					t.Errorf("err = %v, want Validation", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ParseSortOrder() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
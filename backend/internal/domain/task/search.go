@@ -0,0 +1,153 @@
+package task
+
+import (
+	"strings"
+	"unicode"
+)
+
+// snippetRadius スニペットに含める一致箇所の前後の文字数
+const snippetRadius = 30
+
+// SearchField キーワードが一致した項目
+type SearchField string
+
+const (
+	SearchFieldTitle   SearchField = "title"
+	SearchFieldReview  SearchField = "review"
+	SearchFieldContent SearchField = "content"
+	SearchFieldOutput  SearchField = "output"
+)
+
+// HighlightFragment スニペットの断片
+type HighlightFragment struct {
+	Text string
+	// Highlighted キーワードに一致した部分かどうか
+	Highlighted bool
+}
+
+// SearchMatch キーワードが一致した箇所のスニペット
+type SearchMatch struct {
+	Field SearchField
+	// TaskItemID 子タスクの項目が一致した場合の子タスクID（タスクの項目の場合はnil）
+	TaskItemID *string
+	Fragments  []HighlightFragment
+}
+
+// SearchTerms 検索キーワードをハイライトする語に分割
+// 全文検索の構文（"フレーズ"、OR、-除外語）に合わせて、引用符とORを取り除き、除外語は含めない
+func SearchTerms(keyword string) []string {
+	var terms []string
+	for _, field := range strings.Fields(keyword) {
+		if strings.HasPrefix(field, "-") || strings.EqualFold(field, "or") {
+			continue
+		}
+		term := strings.Trim(field, `"`)
+		if term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// SearchMatches タスクの中でキーワードが一致した箇所のスニペットを取得
+// タイトル、振り返り、子タスクの内容とアウトプットの順に返す
+func (t *Task) SearchMatches(keyword string) []SearchMatch {
+	terms := SearchTerms(keyword)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var matches []SearchMatch
+	add := func(field SearchField, taskItemID *string, text string) {
+		if fragments := highlight(text, terms); fragments != nil {
+			matches = append(matches, SearchMatch{Field: field, TaskItemID: taskItemID, Fragments: fragments})
+		}
+	}
+
+	add(SearchFieldTitle, nil, t.Title)
+	if t.Review != nil {
+		add(SearchFieldReview, nil, *t.Review)
+	}
+	for i := range t.TaskItems {
+		item := &t.TaskItems[i]
+		add(SearchFieldContent, &item.ID, item.Content)
+		if item.Output != nil {
+			add(SearchFieldOutput, &item.ID, *item.Output)
+		}
+	}
+	return matches
+}
+
+// highlight 最初に一致した箇所の前後を切り出し、一致した部分をハイライトした断片に分割（一致しない場合はnil）
+func highlight(text string, terms []string) []HighlightFragment {
+	runes := []rune(text)
+	lowered := toLowerRunes(runes)
+
+	// 一致した範囲に印を付ける（大文字と小文字は区別しない）
+	marked := make([]bool, len(runes))
+	first := -1
+	for _, term := range terms {
+		needle := toLowerRunes([]rune(term))
+		for i := 0; i+len(needle) <= len(lowered); i++ {
+			if !hasRunePrefix(lowered[i:], needle) {
+				continue
+			}
+			for j := i; j < i+len(needle); j++ {
+				marked[j] = true
+			}
+			if first == -1 || i < first {
+				first = i
+			}
+		}
+	}
+	if first == -1 {
+		return nil
+	}
+
+	// 最初に一致した箇所の前後を切り出す
+	start := max(first-snippetRadius, 0)
+	end := min(first+snippetRadius*2, len(runes))
+
+	var fragments []HighlightFragment
+	if start > 0 {
+		fragments = append(fragments, HighlightFragment{Text: "…"})
+	}
+	for i := start; i < end; {
+		j := i
+		for j < end && marked[j] == marked[i] {
+			j++
+		}
+		fragments = appendFragment(fragments, HighlightFragment{Text: string(runes[i:j]), Highlighted: marked[i]})
+		i = j
+	}
+	if end < len(runes) {
+		fragments = appendFragment(fragments, HighlightFragment{Text: "…"})
+	}
+	return fragments
+}
+
+// appendFragment 断片を追加（ハイライトされていない断片が続く場合は結合する）
+func appendFragment(fragments []HighlightFragment, fragment HighlightFragment) []HighlightFragment {
+	if n := len(fragments); n > 0 && !fragments[n-1].Highlighted && !fragment.Highlighted {
+		fragments[n-1].Text += fragment.Text
+		return fragments
+	}
+	return append(fragments, fragment)
+}
+
+func toLowerRunes(runes []rune) []rune {
+	lowered := make([]rune, len(runes))
+	for i, r := range runes {
+		lowered[i] = unicode.ToLower(r)
+	}
+	return lowered
+}
+
+func hasRunePrefix(runes, prefix []rune) bool {
+	for i, r := range prefix {
+		if runes[i] != r {
+			return false
+		}
+	}
+	return true
+}
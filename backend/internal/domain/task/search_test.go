@@ -0,0 +1,71 @@
+package task
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSearchTerms(t *testing.T) {
+	got := SearchTerms(`"週次 定例" OR 資料 -下書き  `)
+	want := []string{"週次", "定例", "資料"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SearchTerms() = %v, want %v", got, want)
+	}
+}
+
+func TestTask_SearchMatches(t *testing.T) {
+	review := "Go の振り返り"
+	output := "テストを書いた"
+	itemID := "item-1"
+	tk := &Task{
+		Title:  "API設計",
+		Review: &review,
+		TaskItems: []TaskItem{
+			{ID: itemID, Content: "go test を実行", Output: &output},
+		},
+	}
+
+	matches := tk.SearchMatches("GO")
+	if len(matches) != 2 {
+		t.Fatalf("len(matches) = %d, want 2: %+v", len(matches), matches)
+	}
+
+	if matches[0].Field != SearchFieldReview || matches[0].TaskItemID != nil {
+		t.Errorf("matches[0] = %+v, want review", matches[0])
+	}
+	wantFragments := []HighlightFragment{{Text: "Go", Highlighted: true}, {Text: " の振り返り"}}
+	if !reflect.DeepEqual(matches[0].Fragments, wantFragments) {
+		t.Errorf("fragments = %+v, want %+v", matches[0].Fragments, wantFragments)
+	}
+
+	if matches[1].Field != SearchFieldContent || matches[1].TaskItemID == nil || *matches[1].TaskItemID != itemID {
+		t.Errorf("matches[1] = %+v, want content of %s", matches[1], itemID)
+	}
+}
+
+func TestTask_SearchMatches_Snippet(t *testing.T) {
+	tk := &Task{Title: strings.Repeat("あ", 50) + "会議" + strings.Repeat("い", 100)}
+
+	matches := tk.SearchMatches("会議")
+	if len(matches) != 1 {
+		t.Fatalf("len(matches) = %d, want 1", len(matches))
+	}
+
+	// 一致した箇所の前後のみを切り出し、省略した部分は「…」にする
+	want := []HighlightFragment{
+		{Text: "…" + strings.Repeat("あ", snippetRadius)},
+		{Text: "会議", Highlighted: true},
+		{Text: strings.Repeat("い", snippetRadius*2-2) + "…"},
+	}
+	if !reflect.DeepEqual(matches[0].Fragments, want) {
+		t.Errorf("fragments = %+v, want %+v", matches[0].Fragments, want)
+	}
+}
+
+func TestTask_SearchMatches_NoTerms(t *testing.T) {
+	tk := &Task{Title: "除外"}
+	if matches := tk.SearchMatches("-除外"); matches != nil {
+		t.Errorf("matches = %+v, want nil", matches)
+	}
+}
@@ -0,0 +1,109 @@
+package task
+
+import (
+	"time"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+)
+
+// statusTransitions 許可するステータスの遷移（同じステータスへの変更は常に許可する）
+// 完了から未着手へは戻せない。完了したタスクアイテムをやり直す場合は着手中に戻す
+var statusTransitions = map[Status][]Status{
+	StatusNotStarted: {StatusInProgress, StatusCompleted},
+	StatusInProgress: {StatusNotStarted, StatusCompleted},
+	StatusCompleted:  {StatusInProgress},
+}
+
+// StatusChange ステータスの変更によって永続化する変更
+type StatusChange struct {
+	Status      Status
+	StartedAt   *time.Time // 最初に着手した日時（未着手の場合はnil）
+	CompletedAt *time.Time // 完了した日時（完了していない場合はnil）
+}
+
+// IsValid 定義されたステータスかどうかを判定
+func (s Status) IsValid() bool {
+	_, ok := statusTransitions[s]
+	return ok
+}
+
+// CanTransitionTo 指定したステータスに変更できるかどうかを判定
+func (s Status) CanTransitionTo(to Status) bool {
+	if s == to {
+		return to.IsValid()
+	}
+	for _, allowed := range statusTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// PlanStatusChange ステータスの変更が可能か確認し、永続化する変更を返す
+// 着手日時は最初に未着手から変更した日時を保持し、完了日時は完了するたびに設定する
+// 完了から着手中に戻す（やり直す）場合、アウトプットは下書きとして残し、完了日時のみ取り消す
+// 未着手に戻す場合は着手日時を取り消すため、アウトプットやタイマーの記録があるタスクアイテムは戻せない
+// 完了にする場合、実行中のタイマーは完了日時で停止する（リポジトリが同じトランザクションで停止する）
+func (item *TaskItem) PlanStatusChange(to Status, at time.Time) (StatusChange, error) {
+	if !to.IsValid() {
+		return StatusChange{}, domainerrors.Validation("Validation failed").WithDetails(map[string]interface{}{
+			"errors": []FieldError{{Field: "status", Message: "statusはNotStarted、InProgress、Completedのいずれかである必要があります"}},
+		})
+	}
+
+	change := StatusChange{Status: to, StartedAt: item.StartedAt, CompletedAt: item.CompletedAt}
+	if item.Status == to {
+		return change, nil
+	}
+	if !item.Status.CanTransitionTo(to) {
+		return StatusChange{}, statusConflict("Task item status cannot be changed", item.Status, to)
+	}
+
+	switch to {
+	case StatusNotStarted:
+		if item.Output != nil || len(item.TimerSessions) > 0 {
+			return StatusChange{}, statusConflict("Task item with an output or timer sessions cannot be reset to NotStarted", item.Status, to)
+		}
+		change.StartedAt = nil
+		change.CompletedAt = nil
+	case StatusInProgress:
+		if change.StartedAt == nil {
+			change.StartedAt = &at
+		}
+		change.CompletedAt = nil
+	case StatusCompleted:
+		if change.StartedAt == nil {
+			change.StartedAt = &at
+		}
+		change.CompletedAt = &at
+	}
+	return change, nil
+}
+
+// Apply ステータスの変更をタスクアイテムに反映
+func (c StatusChange) Apply(item *TaskItem) {
+	item.Status = c.Status
+	item.StartedAt = c.StartedAt
+	item.CompletedAt = c.CompletedAt
+	item.StopTimerIfCompleted()
+}
+
+// newStatusChange 作成するタスクアイテムのステータスの変更を取得（未着手から変更したものとして扱う）
+func newStatusChange(status Status, at time.Time) (StatusChange, error) {
+	item := TaskItem{Status: StatusNotStarted}
+	return item.PlanStatusChange(status, at)
+}
+
+// statusConflict 許可されていないステータスの変更のエラー
+func statusConflict(message string, from Status, to Status) error {
+	allowed := make([]string, 0, len(statusTransitions[from]))
+	for _, s := range statusTransitions[from] {
+		allowed = append(allowed, string(s))
+	}
+	return domainerrors.Conflict(message).WithDetails(map[string]interface{}{
+		"from":    string(from),
+		"to":      string(to),
+		"allowed": allowed,
+	})
+}
@@ -0,0 +1,91 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+)
+
+func TestTaskItem_PlanStatusChange(t *testing.T) {
+	startedAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	completedAt := startedAt.Add(30 * time.Minute)
+	at := startedAt.Add(time.Hour)
+	output := "学んだこと"
+
+	tests := []struct {
+		name            string
+		item            TaskItem
+		to              Status
+		wantStartedAt   *time.Time
+		wantCompletedAt *time.Time
+		wantErr         func(error) bool
+	}{
+		{name: "未着手から着手中", item: TaskItem{Status: StatusNotStarted}, to: StatusInProgress, wantStartedAt: &at},
+		{name: "未着手から完了", item: TaskItem{Status: StatusNotStarted}, to: StatusCompleted, wantStartedAt: &at, wantCompletedAt: &at},
+		{name: "着手中から完了は着手日時を保持する", item: TaskItem{Status: StatusInProgress, StartedAt: &startedAt}, to: StatusCompleted, wantStartedAt: &startedAt, wantCompletedAt: &at},
+		{name: "完了から着手中に戻すと完了日時を取り消す", item: TaskItem{Status: StatusCompleted, Output: &output, StartedAt: &startedAt, CompletedAt: &completedAt}, to: StatusInProgress, wantStartedAt: &startedAt},
+		{name: "同じステータスは日時を変更しない", item: TaskItem{Status: StatusCompleted, StartedAt: &startedAt, CompletedAt: &completedAt}, to: StatusCompleted, wantStartedAt: &startedAt, wantCompletedAt: &completedAt},
+		{name: "着手中から未着手に戻すと着手日時を取り消す", item: TaskItem{Status: StatusInProgress, StartedAt: &startedAt}, to: StatusNotStarted},
+		{name: "完了から未着手には戻せない", item: TaskItem{Status: StatusCompleted, StartedAt: &startedAt, CompletedAt: &completedAt}, to: StatusNotStarted, wantErr: domainerrors.IsConflict},
+		{name: "アウトプットがある場合は未着手に戻せない", item: TaskItem{Status: StatusInProgress, Output: &output, StartedAt: &startedAt}, to: StatusNotStarted, wantErr: domainerrors.IsConflict},
+		{name: "タイマーの記録がある場合は未着手に戻せない", item: TaskItem{Status: StatusInProgress, StartedAt: &startedAt, TimerSessions: []TimerSession{{StartedAt: startedAt}}}, to: StatusNotStarted, wantErr: domainerrors.IsConflict},
+		{name: "不正なステータス", item: TaskItem{Status: StatusNotStarted}, to: Status("Done"), wantErr: domainerrors.IsValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.item.PlanStatusChange(tt.to, at)
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Fatalf("PlanStatusChange() error = %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PlanStatusChange() error = %v", err)
+			}
+			if got.Status != tt.to {
+				t.Errorf("status = %s, want %s", got.Status, tt.to)
+			}
+			if !equalTime(got.StartedAt, tt.wantStartedAt) {
+				t.Errorf("startedAt = %v, want %v", got.StartedAt, tt.wantStartedAt)
+			}
+			if !equalTime(got.CompletedAt, tt.wantCompletedAt) {
+				t.Errorf("completedAt = %v, want %v", got.CompletedAt, tt.wantCompletedAt)
+			}
+		})
+	}
+}
+
+func TestTask_PlanTaskItemUpdates(t *testing.T) {
+	at := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	source := &Task{TaskItems: []TaskItem{
+		{ID: "item-1", Status: StatusCompleted, StartedAt: &at, CompletedAt: &at},
+		{ID: "item-2", Status: StatusNotStarted},
+	}}
+
+	got, err := source.PlanTaskItemUpdates([]UpdateTaskItemInput{
+		{ID: "item-1", Status: StatusCompleted},
+		{ID: "item-2", Status: StatusInProgress},
+		{Status: StatusCompleted},
+	}, at)
+	if err != nil {
+		t.Fatalf("PlanTaskItemUpdates() error = %v", err)
+	}
+	if got[0].CompletedAt == nil || got[1].StartedAt == nil || got[1].CompletedAt != nil || got[2].CompletedAt == nil {
+		t.Errorf("got = %+v", got)
+	}
+
+	_, err = source.PlanTaskItemUpdates([]UpdateTaskItemInput{{ID: "item-1", Status: StatusNotStarted}}, at)
+	if !domainerrors.IsConflict(err) {
+		t.Errorf("PlanTaskItemUpdates() error = %v, want Conflict", err)
+	}
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
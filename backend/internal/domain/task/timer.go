@@ -0,0 +1,160 @@
+package task
+
+import (
+	"time"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+)
+
+// TimerState タイマーの状態（最新のセッションから導出する）
+type TimerState string
+
+const (
+	TimerStateIdle    TimerState = "Idle"    // 未計測、または停止済み
+	TimerStateRunning TimerState = "Running" // 計測中
+	TimerStatePaused  TimerState = "Paused"  // 一時停止中
+)
+
+// TimerEndReason セッションの終了理由
+type TimerEndReason string
+
+const (
+	TimerEndReasonPaused  TimerEndReason = "Paused"
+	TimerEndReasonStopped TimerEndReason = "Stopped"
+)
+
+// TimerAction タイマーの操作
+type TimerAction string
+
+const (
+	TimerActionStart  TimerAction = "start"
+	TimerActionPause  TimerAction = "pause"
+	TimerActionResume TimerAction = "resume"
+	TimerActionStop   TimerAction = "stop"
+)
+
+// TimerSession タイマーのセッション（VO）
+// 一時停止で実行中のセッションを終了し、再開で新しいセッションを開始する
+type TimerSession struct {
+	ID        string
+	StartedAt time.Time
+	EndedAt   *time.Time      // 実行中の場合はnil
+	EndReason *TimerEndReason // 実行中の場合はnil
+}
+
+// TimerTransition タイマーの操作によって永続化する変更
+type TimerTransition struct {
+	EndReason    *TimerEndReason // 最新のセッションに設定する終了理由（nilの場合は変更しない）
+	StartSession bool            // 新しいセッションを開始するかどうか
+	Status       Status          // 操作後のタスクアイテムのステータス
+}
+
+// TimerState タイマーの状態を取得
+func (item *TaskItem) TimerState() TimerState {
+	if len(item.TimerSessions) == 0 {
+		return TimerStateIdle
+	}
+
+	latest := item.TimerSessions[len(item.TimerSessions)-1]
+	switch {
+	case latest.EndedAt == nil:
+		return TimerStateRunning
+	case latest.EndReason != nil && *latest.EndReason == TimerEndReasonPaused:
+		return TimerStatePaused
+	default:
+		return TimerStateIdle
+	}
+}
+
+// RunningSince 実行中のセッションの開始日時を取得（実行中でない場合はnil）
+func (item *TaskItem) RunningSince() *time.Time {
+	if item.TimerState() != TimerStateRunning {
+		return nil
+	}
+	startedAt := item.TimerSessions[len(item.TimerSessions)-1].StartedAt
+	return &startedAt
+}
+
+// StopTimerIfCompleted 完了したタスクアイテムの実行中のセッションを完了日時で停止する
+// 完了後に実際にかかった時間が増え続けないように、ステータスを完了にするときに呼び出す
+func (item *TaskItem) StopTimerIfCompleted() {
+	if item.Status != StatusCompleted || item.CompletedAt == nil || item.TimerState() != TimerStateRunning {
+		return
+	}
+	latest := &item.TimerSessions[len(item.TimerSessions)-1]
+	endedAt := *item.CompletedAt
+	reason := TimerEndReasonStopped
+	latest.EndedAt = &endedAt
+	latest.EndReason = &reason
+}
+
+// ActualDuration 実際にかかった時間（実行中のセッションはnowまでを含める）
+func (item *TaskItem) ActualDuration(now time.Time) time.Duration {
+	var total time.Duration
+	for _, s := range item.TimerSessions {
+		end := now
+		if s.EndedAt != nil {
+			end = *s.EndedAt
+		}
+		if end.After(s.StartedAt) {
+			total += end.Sub(s.StartedAt)
+		}
+	}
+	return total
+}
+
+// ActualDurationMinutes 実際にかかった時間（分、切り捨て）
+func (item *TaskItem) ActualDurationMinutes(now time.Time) int32 {
+	return int32(item.ActualDuration(now) / time.Minute)
+}
+
+// PlanTimerAction タイマーの操作が可能か確認し、永続化する変更を返す
+// 開始・再開時に未着手のタスクアイテムは着手中になる。状態に合わない操作はConflictエラーにする
+func (item *TaskItem) PlanTimerAction(action TimerAction) (TimerTransition, error) {
+	state := item.TimerState()
+	transition := TimerTransition{Status: item.Status}
+
+	switch action {
+	case TimerActionStart, TimerActionResume:
+		if item.Status == StatusCompleted {
+			return TimerTransition{}, domainerrors.Conflict("Task item is already completed")
+		}
+		want := TimerStateIdle
+		if action == TimerActionResume {
+			want = TimerStatePaused
+		}
+		if state != want {
+			return TimerTransition{}, timerConflict(action, state)
+		}
+		transition.StartSession = true
+		if item.Status == StatusNotStarted {
+			transition.Status = StatusInProgress
+		}
+	case TimerActionPause:
+		if state != TimerStateRunning {
+			return TimerTransition{}, timerConflict(action, state)
+		}
+		reason := TimerEndReasonPaused
+		transition.EndReason = &reason
+	case TimerActionStop:
+		if state == TimerStateIdle {
+			return TimerTransition{}, timerConflict(action, state)
+		}
+		reason := TimerEndReasonStopped
+		transition.EndReason = &reason
+	default:
+		return TimerTransition{}, domainerrors.Validation("invalid timer action").WithDetails(map[string]string{
+			"action": string(action),
+		})
+	}
+
+	return transition, nil
+}
+
+// timerConflict 現在の状態で実行できない操作のエラー
+func timerConflict(action TimerAction, state TimerState) error {
+	return domainerrors.Conflict("Timer cannot be changed in the current state").WithDetails(map[string]string{
+		"action":     string(action),
+		"timerState": string(state),
+	})
+}
@@ -0,0 +1,125 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+)
+
+func TestTaskItem_PlanTimerAction(t *testing.T) {
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(10 * time.Minute)
+	paused := TimerEndReasonPaused
+	stopped := TimerEndReasonStopped
+
+	running := []TimerSession{{StartedAt: start}}
+	pausedSessions := []TimerSession{{StartedAt: start, EndedAt: &end, EndReason: &paused}}
+	stoppedSessions := []TimerSession{{StartedAt: start, EndedAt: &end, EndReason: &stopped}}
+
+	tests := []struct {
+		name         string
+		status       Status
+		sessions     []TimerSession
+		action       TimerAction
+		want         TimerTransition
+		wantConflict bool
+	}{
+		{name: "未着手で開始すると着手中になる", status: StatusNotStarted, action: TimerActionStart, want: TimerTransition{StartSession: true, Status: StatusInProgress}},
+		{name: "停止済みから再び開始できる", status: StatusInProgress, sessions: stoppedSessions, action: TimerActionStart, want: TimerTransition{StartSession: true, Status: StatusInProgress}},
+		{name: "計測中は開始できない", status: StatusInProgress, sessions: running, action: TimerActionStart, wantConflict: true},
+		{name: "完了済みは開始できない", status: StatusCompleted, action: TimerActionStart, wantConflict: true},
+		{name: "計測中を一時停止", status: StatusInProgress, sessions: running, action: TimerActionPause, want: TimerTransition{EndReason: &paused, Status: StatusInProgress}},
+		{name: "一時停止中は一時停止できない", status: StatusInProgress, sessions: pausedSessions, action: TimerActionPause, wantConflict: true},
+		{name: "一時停止中を再開", status: StatusInProgress, sessions: pausedSessions, action: TimerActionResume, want: TimerTransition{StartSession: true, Status: StatusInProgress}},
+		{name: "計測中は再開できない", status: StatusInProgress, sessions: running, action: TimerActionResume, wantConflict: true},
+		{name: "計測中を停止", status: StatusInProgress, sessions: running, action: TimerActionStop, want: TimerTransition{EndReason: &stopped, Status: StatusInProgress}},
+		{name: "一時停止中を停止", status: StatusInProgress, sessions: pausedSessions, action: TimerActionStop, want: TimerTransition{EndReason: &stopped, Status: StatusInProgress}},
+		{name: "完了済みでも計測中なら停止できる", status: StatusCompleted, sessions: running, action: TimerActionStop, want: TimerTransition{EndReason: &stopped, Status: StatusCompleted}},
+		{name: "未計測は停止できない", status: StatusNotStarted, action: TimerActionStop, wantConflict: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &TaskItem{Status: tt.status, TimerSessions: tt.sessions}
+
+			got, err := item.PlanTimerAction(tt.action)
+			if tt.wantConflict {
+				if !domainerrors.IsConflict(err) {
+					t.Fatalf("err = %v, want Conflict", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.StartSession != tt.want.StartSession || got.Status != tt.want.Status {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+			if (got.EndReason == nil) != (tt.want.EndReason == nil) || (got.EndReason != nil && *got.EndReason != *tt.want.EndReason) {
+				t.Errorf("end reason = %v, want %v", got.EndReason, tt.want.EndReason)
+			}
+		})
+	}
+}
+
+func TestTaskItem_ActualDuration(t *testing.T) {
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	pausedAt := start.Add(20*time.Minute + 30*time.Second)
+	paused := TimerEndReasonPaused
+	item := &TaskItem{
+		TimerSessions: []TimerSession{
+			{StartedAt: start, EndedAt: &pausedAt, EndReason: &paused},
+			{StartedAt: start.Add(time.Hour)},
+		},
+	}
+
+	now := start.Add(time.Hour + 15*time.Minute)
+	if got := item.ActualDuration(now); got != 35*time.Minute+30*time.Second {
+		t.Errorf("ActualDuration = %v, want 35m30s", got)
+	}
+	if got := item.ActualDurationMinutes(now); got != 35 {
+		t.Errorf("ActualDurationMinutes = %d, want 35", got)
+	}
+	if got := item.TimerState(); got != TimerStateRunning {
+		t.Errorf("TimerState = %s, want %s", got, TimerStateRunning)
+	}
+	if got := item.RunningSince(); got == nil || !got.Equal(start.Add(time.Hour)) {
+		t.Errorf("RunningSince = %v, want %v", got, start.Add(time.Hour))
+	}
+}
+
+func TestTaskItem_StopTimerIfCompleted(t *testing.T) {
+	start := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	completedAt := start.Add(45 * time.Minute)
+
+	tests := []struct {
+		name      string
+		to        Status
+		wantState TimerState
+		wantAt    time.Time // この日時までに実際にかかった時間を確認する
+		want      time.Duration
+	}{
+		{name: "完了にすると完了日時で停止し、時間が増えない", to: StatusCompleted, wantState: TimerStateIdle, wantAt: start.Add(3 * time.Hour), want: 45 * time.Minute},
+		{name: "完了以外では計測を続ける", to: StatusInProgress, wantState: TimerStateRunning, wantAt: start.Add(3 * time.Hour), want: 3 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := TaskItem{Status: StatusInProgress, StartedAt: &start, TimerSessions: []TimerSession{{StartedAt: start}}}
+
+			change, err := item.PlanStatusChange(tt.to, completedAt)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			change.Apply(&item)
+
+			if got := item.TimerState(); got != tt.wantState {
+				t.Errorf("TimerState = %s, want %s", got, tt.wantState)
+			}
+			if got := item.ActualDuration(tt.wantAt); got != tt.want {
+				t.Errorf("ActualDuration = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,11 +1,23 @@
 package task
 
+import (
+	"time"
+
+	"task-management-system/backend/internal/domain/outputtemplate"
+)
+
 // ListTasksCondition タスク一覧取得の検索条件
 type ListTasksCondition struct {
 	OwnerID   *string
 	YearMonth *string
 	Keyword   *string
-	Sort      *string
+	// CategoryID 指定したカテゴリのタスクアイテムを含むタスクのみを取得
+	CategoryID *string
+	Sort       *string
+	// Limit 取得する最大件数（0の場合は上限なし）
+	Limit int32
+	// Cursor 前のページの最後のタスクの位置（nilの場合は先頭から取得）
+	Cursor *ListTasksCursor
 }
 
 // CreateTaskItemInput タスクアイテム作成の入力
@@ -17,6 +29,17 @@ type CreateTaskItemInput struct {
 	IsRequired   bool
 	Order        int32
 	Status       Status
+	CategoryID   *string
+	// OutputTemplateID アウトプットに使用するテンプレート（自由形式の場合はnil）
+	OutputTemplateID *string
+	// Output 作成時のアウトプット（複製でアウトプットを引き継ぐ場合のみ。それ以外はnil）
+	Output *string
+	// OutputSections 作成時のテンプレートに沿ったアウトプット（複製でアウトプットを引き継ぐ場合のみ。それ以外はnil）
+	OutputSections []outputtemplate.FilledSection
+	// StartedAt 着手日時（引き継がない場合はPlanNewTaskItemでステータスから設定する）
+	StartedAt *time.Time
+	// CompletedAt 完了日時（引き継がない場合はPlanNewTaskItemでステータスから設定する）
+	CompletedAt *time.Time
 }
 
 // UpdateTaskItemInput タスクアイテム更新の入力
@@ -29,4 +52,18 @@ type UpdateTaskItemInput struct {
 	IsRequired   bool
 	Order        int32
 	Status       Status
+	CategoryID   *string
+	// OutputTemplateID アウトプットに使用するテンプレート（自由形式の場合はnil）
+	OutputTemplateID *string
+	// StartedAt 着手日時（Task.PlanTaskItemUpdatesでステータスの変更から設定する）
+	StartedAt *time.Time
+	// CompletedAt 完了日時（Task.PlanTaskItemUpdatesでステータスの変更から設定する）
+	CompletedAt *time.Time
+}
+
+// TaskItemOutputInput タスクアイテムのアウトプット更新の入力
+// テンプレートが選択されているタスクアイテムはSections、そうでなければTextを使用する
+type TaskItemOutputInput struct {
+	Text     *string
+	Sections []outputtemplate.SectionValue
 }
@@ -0,0 +1,16 @@
+package task
+
+import (
+	domainerrors "task-management-system/backend/internal/domain/errors"
+)
+
+// CheckVersion タスクのバージョンが期待値と一致するか確認
+// 一致しない場合はPreconditionFailedのドメインエラーを返す（expectedがnilの場合は確認しない）
+func CheckVersion(current int64, expected *int64) error {
+	if expected != nil && *expected != current {
+		return domainerrors.PreconditionFailed("Task has been modified").WithDetails(map[string]interface{}{
+			"version": current,
+		})
+	}
+	return nil
+}
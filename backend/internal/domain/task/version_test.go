@@ -0,0 +1,38 @@
+package task
+
+import (
+	"testing"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+)
+
+func TestCheckVersion(t *testing.T) {
+	version := func(v int64) *int64 { return &v }
+
+	tests := []struct {
+		name     string
+		current  int64
+		expected *int64
+		wantErr  bool
+	}{
+		{name: "期待値なし", current: 3, expected: nil, wantErr: false},
+		{name: "一致", current: 3, expected: version(3), wantErr: false},
+		{name: "古いバージョン", current: 3, expected: version(2), wantErr: true},
+		{name: "新しいバージョン", current: 3, expected: version(4), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckVersion(tt.current, tt.expected)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !domainerrors.IsPreconditionFailed(err) {
+				t.Fatalf("expected PreconditionFailed error, got %v", err)
+			}
+		})
+	}
+}
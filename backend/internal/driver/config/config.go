@@ -0,0 +1,351 @@
+// Package config アプリケーションの設定
+// デフォルト値、YAMLファイル（任意）、環境変数の順に読み込み、後から読み込んだ値で上書きする
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gopkg.in/yaml.v3"
+)
+
+// データの保存先
+const (
+	// StoragePostgres PostgreSQLに保存する（DATABASE_URLが必要）
+	StoragePostgres = "postgres"
+	// StorageMemory プロセスのメモリに保存する（テストとデモ用。終了するとデータは失われる）
+	StorageMemory = "memory"
+)
+
+// redacted 表示しない秘密の値の代わりに表示する文字列
+const redacted = "REDACTED"
+
+// Config アプリケーションの設定
+type Config struct {
+	// Storage データの保存先（postgresまたはmemory）
+	Storage   string          `yaml:"storage"`
+	Server    ServerConfig    `yaml:"server"`
+	Log       LogConfig       `yaml:"log"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Migration MigrationConfig `yaml:"migration"`
+	Auth      AuthConfig      `yaml:"auth"`
+	Trash     TrashConfig     `yaml:"trash"`
+}
+
+// ServerConfig HTTPサーバーの設定
+type ServerConfig struct {
+	Port string `yaml:"port"`
+	// CORSAllowedOrigins クロスオリジンのリクエストを許可するオリジン（空の場合はCORSを許可しない）
+	CORSAllowedOrigins []string `yaml:"corsAllowedOrigins"`
+	// ReadTimeout リクエストの本文まで読み込む最大時間
+	ReadTimeout time.Duration `yaml:"readTimeout"`
+	// WriteTimeout リクエストの読み込み完了からレスポンスを書き込むまでの最大時間
+	WriteTimeout time.Duration `yaml:"writeTimeout"`
+	// IdleTimeout Keep-Aliveの接続で次のリクエストを待つ最大時間
+	IdleTimeout time.Duration `yaml:"idleTimeout"`
+	// RequestTimeout 1件のリクエストの処理に設定する期限（WriteTimeoutより短くする）
+	RequestTimeout time.Duration `yaml:"requestTimeout"`
+	// ShutdownTimeout 停止時に処理中のリクエストの完了を待つ最大時間
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+	// ReadinessTimeout /readyzでデータベースの応答を待つ最大時間
+	ReadinessTimeout time.Duration `yaml:"readinessTimeout"`
+}
+
+// LogConfig ログの設定
+type LogConfig struct {
+	// Level 出力する最低のログレベル（debug、info、warn、error）
+	Level string `yaml:"level"`
+}
+
+// DatabaseConfig データベースと接続プールの設定
+type DatabaseConfig struct {
+	// URL 接続文字列（パスワードを含むため表示しない）
+	URL             string        `yaml:"url"`
+	MaxConns        int32         `yaml:"maxConns"`
+	MinConns        int32         `yaml:"minConns"`
+	MaxConnLifetime time.Duration `yaml:"maxConnLifetime"`
+	MaxConnIdleTime time.Duration `yaml:"maxConnIdleTime"`
+	// HealthCheckPeriod アイドル状態の接続を確認する間隔
+	HealthCheckPeriod time.Duration `yaml:"healthCheckPeriod"`
+	// ConnectTimeout 新しい接続を確立するまでの待ち時間
+	ConnectTimeout time.Duration `yaml:"connectTimeout"`
+}
+
+// MigrationConfig サーバーの起動時のマイグレーションの設定
+// マイグレーションは通常migrateサブコマンドで適用し、スキーマが最新でない場合はサーバーを起動しない
+type MigrationConfig struct {
+	// OnStart 起動時に適用していないマイグレーションを適用するかどうか（失敗した場合は起動しない）
+	OnStart bool `yaml:"onStart"`
+	// AllowOutdatedSchema スキーマが最新でない場合やdirtyの場合も警告を出力して起動するかどうか
+	AllowOutdatedSchema bool `yaml:"allowOutdatedSchema"`
+}
+
+// AuthConfig JWTの検証の設定
+type AuthConfig struct {
+	// Algorithm 署名アルゴリズム（HS256またはRS256）
+	Algorithm string `yaml:"algorithm"`
+	// Secret HS256の共有シークレット（表示しない）
+	Secret string `yaml:"secret"`
+	// PublicKey RS256の公開鍵（PEM文字列）
+	PublicKey string `yaml:"publicKey"`
+	// PublicKeyFile RS256の公開鍵のファイルパス（PublicKeyより優先する）
+	PublicKeyFile string `yaml:"publicKeyFile"`
+	Issuer        string `yaml:"issuer"`
+	Audience      string `yaml:"audience"`
+	// AdminAccountIDs 管理者のアカウントID（/debug/migrationsを参照できる）
+	AdminAccountIDs []string `yaml:"adminAccountIds"`
+	// ServiceToken トークンの発行前にフロントエンドのサーバーが内部APIを呼び出すための資格情報（表示しない）
+	ServiceToken string `yaml:"serviceToken"`
+}
+
+// TrashConfig ゴミ箱の設定
+type TrashConfig struct {
+	// RetentionDays ゴミ箱に移動してから完全に削除するまでの日数
+	RetentionDays int `yaml:"retentionDays"`
+	// PurgeInterval 保持期間を過ぎたタスクを削除する間隔
+	PurgeInterval time.Duration `yaml:"purgeInterval"`
+}
+
+// Default デフォルトの設定
+func Default() Config {
+	return Config{
+		Storage: StoragePostgres,
+		Server: ServerConfig{
+			Port:             "8080",
+			ReadTimeout:      15 * time.Second,
+			WriteTimeout:     30 * time.Second,
+			IdleTimeout:      time.Minute,
+			RequestTimeout:   20 * time.Second,
+			ShutdownTimeout:  15 * time.Second,
+			ReadinessTimeout: 2 * time.Second,
+		},
+		Log: LogConfig{
+			Level: "info",
+		},
+		Database: DatabaseConfig{
+			MaxConns:          25,
+			MinConns:          5,
+			MaxConnLifetime:   time.Hour,
+			MaxConnIdleTime:   30 * time.Minute,
+			HealthCheckPeriod: time.Minute,
+			ConnectTimeout:    5 * time.Second,
+		},
+		Migration: MigrationConfig{
+			OnStart:             false,
+			AllowOutdatedSchema: false,
+		},
+		Auth: AuthConfig{
+			Algorithm: "HS256",
+		},
+		Trash: TrashConfig{
+			RetentionDays: 30,
+			PurgeInterval: time.Hour,
+		},
+	}
+}
+
+// Option コマンドラインの引数など、環境変数より優先する値で設定を上書きする
+type Option func(c *Config)
+
+// WithStorage データの保存先を上書き（空の場合は上書きしない）
+func WithStorage(storage string) Option {
+	return func(c *Config) {
+		if storage != "" {
+			c.Storage = storage
+		}
+	}
+}
+
+// Load 設定を読み込んで検証
+// pathが空でない場合はYAMLファイルを読み込み、その後に環境変数、optsの順に上書きする
+func Load(path string, opts ...Option) (Config, error) {
+	return load(path, os.LookupEnv, opts...)
+}
+
+func load(path string, lookupEnv func(string) (string, bool), opts ...Option) (Config, error) {
+	config := Default()
+
+	if path != "" {
+		if err := config.loadFile(path); err != nil {
+			return config, err
+		}
+	}
+	if err := config.loadEnv(lookupEnv); err != nil {
+		return config, err
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if err := config.Validate(); err != nil {
+		return config, err
+	}
+	return config, nil
+}
+
+// loadFile YAMLファイルの値で上書き（定義されていない項目はエラーにする）
+func (c *Config) loadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Validate 設定の値を検証（すべての不正な値をまとめて返す）
+func (c Config) Validate() error {
+	var errs []error
+
+	switch c.Storage {
+	case StoragePostgres:
+		if c.Database.URL == "" {
+			errs = append(errs, errors.New("DATABASE_URL is required when storage is postgres"))
+		}
+	case StorageMemory:
+	default:
+		errs = append(errs, fmt.Errorf("storage must be one of %s, %s: %q", StoragePostgres, StorageMemory, c.Storage))
+	}
+
+	if c.Server.Port == "" {
+		errs = append(errs, errors.New("PORT must not be empty"))
+	}
+	if c.Server.RequestTimeout >= c.Server.WriteTimeout {
+		// 期限を過ぎたリクエストのエラーレスポンスを書き込めるように、WriteTimeoutより短くする
+		errs = append(errs, fmt.Errorf("HTTP_REQUEST_TIMEOUT must be shorter than HTTP_WRITE_TIMEOUT: %s >= %s", c.Server.RequestTimeout, c.Server.WriteTimeout))
+	}
+	if _, err := c.Log.SlogLevel(); err != nil {
+		errs = append(errs, err)
+	}
+
+	db := c.Database
+	if db.MaxConns < 1 {
+		errs = append(errs, fmt.Errorf("DB_MAX_CONNS must be at least 1: %d", db.MaxConns))
+	}
+	if db.MinConns < 0 || db.MinConns > db.MaxConns {
+		errs = append(errs, fmt.Errorf("DB_MIN_CONNS must be between 0 and DB_MAX_CONNS: %d", db.MinConns))
+	}
+	for _, d := range []struct {
+		name  string
+		value time.Duration
+	}{
+		{name: "HTTP_READ_TIMEOUT", value: c.Server.ReadTimeout},
+		{name: "HTTP_WRITE_TIMEOUT", value: c.Server.WriteTimeout},
+		{name: "HTTP_IDLE_TIMEOUT", value: c.Server.IdleTimeout},
+		{name: "HTTP_REQUEST_TIMEOUT", value: c.Server.RequestTimeout},
+		{name: "HTTP_SHUTDOWN_TIMEOUT", value: c.Server.ShutdownTimeout},
+		{name: "HTTP_READINESS_TIMEOUT", value: c.Server.ReadinessTimeout},
+		{name: "DB_MAX_CONN_LIFETIME", value: db.MaxConnLifetime},
+		{name: "DB_MAX_CONN_IDLE_TIME", value: db.MaxConnIdleTime},
+		{name: "DB_HEALTH_CHECK_PERIOD", value: db.HealthCheckPeriod},
+		{name: "DB_CONNECT_TIMEOUT", value: db.ConnectTimeout},
+		{name: "TRASH_PURGE_INTERVAL", value: c.Trash.PurgeInterval},
+	} {
+		if d.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be a positive duration: %s", d.name, d.value))
+		}
+	}
+
+	// 署名を検証する鍵がない場合は、起動後にすべてのリクエストが認証エラーになるため起動時に検出する
+	switch c.Auth.Algorithm {
+	case "HS256":
+		if c.Auth.Secret == "" {
+			errs = append(errs, errors.New("AUTH_JWT_SECRET is required when AUTH_JWT_ALGORITHM is HS256"))
+		}
+	case "RS256":
+		if c.Auth.PublicKey == "" && c.Auth.PublicKeyFile == "" {
+			errs = append(errs, errors.New("AUTH_JWT_PUBLIC_KEY or AUTH_JWT_PUBLIC_KEY_FILE is required when AUTH_JWT_ALGORITHM is RS256"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("AUTH_JWT_ALGORITHM must be one of HS256, RS256: %q", c.Auth.Algorithm))
+	}
+
+	if c.Trash.RetentionDays < 1 {
+		errs = append(errs, fmt.Errorf("TRASH_RETENTION_DAYS must be a positive integer: %d", c.Trash.RetentionDays))
+	}
+
+	return errors.Join(errs...)
+}
+
+// SlogLevel ログレベルをslogのレベルに変換
+func (c LogConfig) SlogLevel() (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Level)); err != nil {
+		return 0, fmt.Errorf("LOG_LEVEL must be one of debug, info, warn, error: %q", c.Level)
+	}
+	return level, nil
+}
+
+// PoolConfig 接続文字列を解析し、接続プールの設定を適用したpgxpoolの設定を作成
+// pgxpool.Newの後にpool.Config()を変更しても反映されないため、プールの作成前に設定する
+func (c DatabaseConfig) PoolConfig() (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(c.URL)
+	if err != nil {
+		// 接続文字列を含むエラーメッセージを出力しないように元のエラーは含めない
+		return nil, errors.New("failed to parse DATABASE_URL")
+	}
+
+	poolConfig.MaxConns = c.MaxConns
+	poolConfig.MinConns = c.MinConns
+	poolConfig.MaxConnLifetime = c.MaxConnLifetime
+	poolConfig.MaxConnIdleTime = c.MaxConnIdleTime
+	poolConfig.HealthCheckPeriod = c.HealthCheckPeriod
+	poolConfig.ConnConfig.ConnectTimeout = c.ConnectTimeout
+	return poolConfig, nil
+}
+
+// Retention ゴミ箱の保持期間
+func (c TrashConfig) Retention() time.Duration {
+	return time.Duration(c.RetentionDays) * 24 * time.Hour
+}
+
+// Redacted 秘密の値（データベースのパスワード、JWTのシークレット、サービスの資格情報）を伏せた設定
+func (c Config) Redacted() Config {
+	c.Server.CORSAllowedOrigins = append([]string(nil), c.Server.CORSAllowedOrigins...)
+	c.Database.URL = redactURL(c.Database.URL)
+	if c.Auth.Secret != "" {
+		c.Auth.Secret = redacted
+	}
+	if c.Auth.ServiceToken != "" {
+		c.Auth.ServiceToken = redacted
+	}
+	return c
+}
+
+// String 秘密の値を伏せた設定をYAML形式で表示
+func (c Config) String() string {
+	data, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return fmt.Sprintf("failed to marshal config: %v", err)
+	}
+	return strings.TrimSuffix(string(data), "\n")
+}
+
+// redactURL 接続文字列のパスワードを伏せる
+// URL形式でない接続文字列（key=value形式）はパスワードの位置が分からないため、全体を伏せる
+func redactURL(value string) string {
+	if value == "" {
+		return ""
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return redacted
+	}
+	if password := u.Query().Get("password"); password != "" {
+		query := u.Query()
+		query.Set("password", redacted)
+		u.RawQuery = query.Encode()
+	}
+	return u.Redacted()
+}
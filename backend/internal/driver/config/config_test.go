@@ -0,0 +1,230 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testDatabaseURL = "postgres://app:s3cret@db:5432/task_management?sslmode=disable"
+
+const testJWTSecret = "jwt-s3cret"
+
+// envFrom 環境変数の代わりにmapから値を取得する
+func envFrom(values map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := values[name]
+		return value, ok
+	}
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("環境変数がない項目はデフォルト値を使用する", func(t *testing.T) {
+		got, err := load("", envFrom(map[string]string{"DATABASE_URL": testDatabaseURL, "AUTH_JWT_SECRET": testJWTSecret}))
+		if err != nil {
+			t.Fatalf("load() error = %v", err)
+		}
+
+		want := Default()
+		want.Database.URL = testDatabaseURL
+		want.Auth.Secret = testJWTSecret
+		if got.String() != want.String() {
+			t.Errorf("load() =\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("YAMLファイルの値を環境変数で上書きする", func(t *testing.T) {
+		path := writeFile(t, `
+storage: postgres
+server:
+  port: "9000"
+  corsAllowedOrigins: ["http://localhost:3000"]
+log:
+  level: debug
+database:
+  url: postgres://file@db/task_management
+  maxConns: 10
+  minConns: 2
+  connectTimeout: 3s
+migration:
+  onStart: true
+trash:
+  retentionDays: 7
+`)
+		got, err := load(path, envFrom(map[string]string{
+			"DATABASE_URL":         testDatabaseURL,
+			"AUTH_JWT_SECRET":      testJWTSecret,
+			"DB_MAX_CONNS":         "40",
+			"CORS_ALLOWED_ORIGINS": "https://app.example.com, https://admin.example.com,",
+			"TRASH_PURGE_INTERVAL": "15m",
+		}))
+		if err != nil {
+			t.Fatalf("load() error = %v", err)
+		}
+
+		if got.Server.Port != "9000" || got.Log.Level != "debug" || !got.Migration.OnStart {
+			t.Errorf("values from file = {Port: %s, Level: %s, OnStart: %v}", got.Server.Port, got.Log.Level, got.Migration.OnStart)
+		}
+		if got.Database.URL != testDatabaseURL || got.Database.MaxConns != 40 || got.Database.MinConns != 2 || got.Database.ConnectTimeout != 3*time.Second {
+			t.Errorf("Database = %+v", got.Database)
+		}
+		if origins := got.Server.CORSAllowedOrigins; len(origins) != 2 || origins[0] != "https://app.example.com" || origins[1] != "https://admin.example.com" {
+			t.Errorf("CORSAllowedOrigins = %v", origins)
+		}
+		if got.Trash.RetentionDays != 7 || got.Trash.PurgeInterval != 15*time.Minute || got.Trash.Retention() != 7*24*time.Hour {
+			t.Errorf("Trash = %+v", got.Trash)
+		}
+	})
+
+	t.Run("引数で指定した保存先は環境変数より優先し、上書き後の値で検証する", func(t *testing.T) {
+		got, err := load("", envFrom(map[string]string{"STORAGE": StoragePostgres, "AUTH_JWT_SECRET": testJWTSecret}), WithStorage(StorageMemory))
+		if err != nil {
+			t.Fatalf("load() error = %v", err)
+		}
+		if got.Storage != StorageMemory {
+			t.Errorf("Storage = %s, want %s", got.Storage, StorageMemory)
+		}
+	})
+
+	t.Run("YAMLファイルに定義されていない項目がある場合はエラー", func(t *testing.T) {
+		path := writeFile(t, "database:\n  maxConnections: 10\n")
+		if _, err := load(path, envFrom(map[string]string{"DATABASE_URL": testDatabaseURL})); err == nil {
+			t.Error("load() error = nil, want error for unknown field")
+		}
+	})
+
+	t.Run("解析できない環境変数と不正な値はまとめてエラーにする", func(t *testing.T) {
+		_, err := load("", envFrom(map[string]string{
+			"DB_MAX_CONNS":       "many",
+			"DB_CONNECT_TIMEOUT": "5",
+			"MIGRATE_ON_START":   "sometimes",
+		}))
+		if err == nil {
+			t.Fatal("load() error = nil, want error")
+		}
+		for _, name := range []string{"DB_MAX_CONNS", "DB_CONNECT_TIMEOUT", "MIGRATE_ON_START"} {
+			if !strings.Contains(err.Error(), name) {
+				t.Errorf("load() error = %v, want to mention %s", err, name)
+			}
+		}
+	})
+}
+
+func TestConfig_Validate(t *testing.T) {
+	valid := Default()
+	valid.Database.URL = testDatabaseURL
+	valid.Auth.Secret = testJWTSecret
+
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr string
+	}{
+		{name: "デフォルト値に接続文字列を設定した設定は正しい", modify: func(c *Config) {}},
+		{name: "インメモリの場合は接続文字列が不要", modify: func(c *Config) {
+			c.Storage = StorageMemory
+			c.Database.URL = ""
+		}},
+		{name: "PostgreSQLの場合は接続文字列が必要", modify: func(c *Config) { c.Database.URL = "" }, wantErr: "DATABASE_URL"},
+		{name: "未対応の保存先", modify: func(c *Config) { c.Storage = "sqlite" }, wantErr: "storage"},
+		{name: "未対応のログレベル", modify: func(c *Config) { c.Log.Level = "verbose" }, wantErr: "LOG_LEVEL"},
+		{name: "最小接続数が最大接続数を超える", modify: func(c *Config) { c.Database.MinConns = 30 }, wantErr: "DB_MIN_CONNS"},
+		{name: "最大接続数が0", modify: func(c *Config) { c.Database.MaxConns = 0 }, wantErr: "DB_MAX_CONNS"},
+		{name: "リクエストの期限が書き込みのタイムアウト以上", modify: func(c *Config) { c.Server.RequestTimeout = c.Server.WriteTimeout }, wantErr: "HTTP_REQUEST_TIMEOUT"},
+		{name: "停止のタイムアウトが0", modify: func(c *Config) { c.Server.ShutdownTimeout = 0 }, wantErr: "HTTP_SHUTDOWN_TIMEOUT"},
+		{name: "準備完了の確認のタイムアウトが0", modify: func(c *Config) { c.Server.ReadinessTimeout = 0 }, wantErr: "HTTP_READINESS_TIMEOUT"},
+		{name: "接続のタイムアウトが0", modify: func(c *Config) { c.Database.ConnectTimeout = 0 }, wantErr: "DB_CONNECT_TIMEOUT"},
+		{name: "未対応の署名アルゴリズム", modify: func(c *Config) { c.Auth.Algorithm = "ES256" }, wantErr: "AUTH_JWT_ALGORITHM"},
+		{name: "HS256の場合は共有シークレットが必要", modify: func(c *Config) { c.Auth.Secret = "" }, wantErr: "AUTH_JWT_SECRET"},
+		{name: "RS256の場合は公開鍵が必要", modify: func(c *Config) { c.Auth.Algorithm = "RS256" }, wantErr: "AUTH_JWT_PUBLIC_KEY"},
+		{name: "RS256の場合は公開鍵のファイルでもよい", modify: func(c *Config) {
+			c.Auth.Algorithm = "RS256"
+			c.Auth.PublicKeyFile = "/etc/task-management/jwt.pub"
+		}},
+		{name: "ゴミ箱の保持期間が0日", modify: func(c *Config) { c.Trash.RetentionDays = 0 }, wantErr: "TRASH_RETENTION_DAYS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid
+			tt.modify(&c)
+
+			err := c.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want to mention %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDatabaseConfig_PoolConfig(t *testing.T) {
+	database := Default().Database
+	database.URL = testDatabaseURL
+	database.MaxConns = 12
+	database.MinConns = 3
+
+	got, err := database.PoolConfig()
+	if err != nil {
+		t.Fatalf("PoolConfig() error = %v", err)
+	}
+	if got.MaxConns != 12 || got.MinConns != 3 || got.MaxConnLifetime != time.Hour || got.MaxConnIdleTime != 30*time.Minute {
+		t.Errorf("PoolConfig() = {MaxConns: %d, MinConns: %d, MaxConnLifetime: %s, MaxConnIdleTime: %s}", got.MaxConns, got.MinConns, got.MaxConnLifetime, got.MaxConnIdleTime)
+	}
+	if got.ConnConfig.ConnectTimeout != 5*time.Second || got.ConnConfig.Host != "db" || got.ConnConfig.Database != "task_management" {
+		t.Errorf("ConnConfig = {ConnectTimeout: %s, Host: %s, Database: %s}", got.ConnConfig.ConnectTimeout, got.ConnConfig.Host, got.ConnConfig.Database)
+	}
+
+	database.URL = "postgres://app:s3cret@db:invalid-port/task_management"
+	if _, err := database.PoolConfig(); err == nil || strings.Contains(err.Error(), "s3cret") {
+		t.Errorf("PoolConfig(invalid) error = %v, want error without the password", err)
+	}
+}
+
+func TestConfig_String(t *testing.T) {
+	tests := []struct {
+		name        string
+		databaseURL string
+		want        string
+	}{
+		{name: "URLのパスワードを伏せる", databaseURL: testDatabaseURL, want: "postgres://app:xxxxx@db:5432/task_management?sslmode=disable"},
+		{name: "クエリのパスワードを伏せる", databaseURL: "postgres://app@db/task_management?password=s3cret", want: "postgres://app@db/task_management?password=REDACTED"},
+		{name: "URL形式でない接続文字列は全体を伏せる", databaseURL: "host=db user=app password=s3cret", want: "REDACTED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Default()
+			c.Database.URL = tt.databaseURL
+			c.Auth.Secret = testJWTSecret
+			c.Auth.ServiceToken = "service-s3cret"
+
+			got := c.String()
+			if strings.Contains(got, "s3cret") {
+				t.Errorf("String() contains a secret:\n%s", got)
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("String() =\n%s\nwant to contain %s", got, tt.want)
+			}
+			if c.Database.URL != tt.databaseURL || c.Auth.Secret != testJWTSecret || c.Auth.ServiceToken != "service-s3cret" {
+				t.Error("String() modified the original config")
+			}
+		})
+	}
+}
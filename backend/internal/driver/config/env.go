@@ -0,0 +1,141 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// envReader 環境変数を読み込んで設定を上書きする（解析できない値はまとめてエラーにする）
+type envReader struct {
+	lookupEnv func(string) (string, bool)
+	errs      []error
+}
+
+// loadEnv 設定されている環境変数の値で上書き
+func (c *Config) loadEnv(lookupEnv func(string) (string, bool)) error {
+	r := &envReader{lookupEnv: lookupEnv}
+
+	r.string("STORAGE", &c.Storage)
+
+	r.string("PORT", &c.Server.Port)
+	r.list("CORS_ALLOWED_ORIGINS", &c.Server.CORSAllowedOrigins)
+	r.duration("HTTP_READ_TIMEOUT", &c.Server.ReadTimeout)
+	r.duration("HTTP_WRITE_TIMEOUT", &c.Server.WriteTimeout)
+	r.duration("HTTP_IDLE_TIMEOUT", &c.Server.IdleTimeout)
+	r.duration("HTTP_REQUEST_TIMEOUT", &c.Server.RequestTimeout)
+	r.duration("HTTP_SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout)
+	r.duration("HTTP_READINESS_TIMEOUT", &c.Server.ReadinessTimeout)
+
+	r.string("LOG_LEVEL", &c.Log.Level)
+
+	r.string("DATABASE_URL", &c.Database.URL)
+	r.int32("DB_MAX_CONNS", &c.Database.MaxConns)
+	r.int32("DB_MIN_CONNS", &c.Database.MinConns)
+	r.duration("DB_MAX_CONN_LIFETIME", &c.Database.MaxConnLifetime)
+	r.duration("DB_MAX_CONN_IDLE_TIME", &c.Database.MaxConnIdleTime)
+	r.duration("DB_HEALTH_CHECK_PERIOD", &c.Database.HealthCheckPeriod)
+	r.duration("DB_CONNECT_TIMEOUT", &c.Database.ConnectTimeout)
+
+	r.bool("MIGRATE_ON_START", &c.Migration.OnStart)
+	r.bool("MIGRATE_ALLOW_OUTDATED_SCHEMA", &c.Migration.AllowOutdatedSchema)
+
+	r.string("AUTH_JWT_ALGORITHM", &c.Auth.Algorithm)
+	r.string("AUTH_JWT_SECRET", &c.Auth.Secret)
+	r.string("AUTH_JWT_PUBLIC_KEY", &c.Auth.PublicKey)
+	r.string("AUTH_JWT_PUBLIC_KEY_FILE", &c.Auth.PublicKeyFile)
+	r.string("AUTH_JWT_ISSUER", &c.Auth.Issuer)
+	r.string("AUTH_JWT_AUDIENCE", &c.Auth.Audience)
+	r.list("AUTH_ADMIN_ACCOUNT_IDS", &c.Auth.AdminAccountIDs)
+	r.string("AUTH_SERVICE_TOKEN", &c.Auth.ServiceToken)
+
+	r.int("TRASH_RETENTION_DAYS", &c.Trash.RetentionDays)
+	r.duration("TRASH_PURGE_INTERVAL", &c.Trash.PurgeInterval)
+
+	return errors.Join(r.errs...)
+}
+
+// lookup 空でない環境変数の値を取得（空の場合は設定されていないものとして扱う）
+func (r *envReader) lookup(name string) (string, bool) {
+	value, ok := r.lookupEnv(name)
+	if !ok || value == "" {
+		return "", false
+	}
+	return value, true
+}
+
+func (r *envReader) string(name string, dst *string) {
+	if value, ok := r.lookup(name); ok {
+		*dst = value
+	}
+}
+
+// list カンマ区切りの値を読み込む（前後の空白と空の要素は除く）
+func (r *envReader) list(name string, dst *[]string) {
+	value, ok := r.lookup(name)
+	if !ok {
+		return
+	}
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	*dst = items
+}
+
+func (r *envReader) int(name string, dst *int) {
+	value, ok := r.lookup(name)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		r.errs = append(r.errs, fmt.Errorf("%s must be an integer: %q", name, value))
+		return
+	}
+	*dst = parsed
+}
+
+func (r *envReader) int32(name string, dst *int32) {
+	value, ok := r.lookup(name)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		r.errs = append(r.errs, fmt.Errorf("%s must be an integer: %q", name, value))
+		return
+	}
+	*dst = int32(parsed)
+}
+
+func (r *envReader) bool(name string, dst *bool) {
+	value, ok := r.lookup(name)
+	if !ok {
+		return
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		r.errs = append(r.errs, fmt.Errorf("%s must be true or false: %q", name, value))
+		return
+	}
+	*dst = parsed
+}
+
+// duration 時間を読み込む（例：30s、1h）
+func (r *envReader) duration(name string, dst *time.Duration) {
+	value, ok := r.lookup(name)
+	if !ok {
+		return
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		r.errs = append(r.errs, fmt.Errorf("%s must be a duration such as 30s or 1h: %q", name, value))
+		return
+	}
+	*dst = parsed
+}
@@ -0,0 +1,173 @@
+// Package health 死活監視、準備完了の確認、マイグレーションの状態を返す運用向けのエンドポイント
+// OpenAPIのAPIとは別に、オーケストレーターと管理者が使用する
+package health
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"task-management-system/backend/internal/adapter/http/controller"
+	"task-management-system/backend/internal/adapter/http/middleware"
+	"task-management-system/backend/internal/driver/migration"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 確認の結果
+const (
+	statusOK          = "ok"
+	statusUnavailable = "unavailable"
+)
+
+// Pinger データベースに接続できるか確認する（pgxpool.Pool）
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// MigrationInspector データベースのマイグレーションの状態を取得する
+type MigrationInspector interface {
+	Status(ctx context.Context) (migration.Status, error)
+}
+
+// Handler 運用向けのエンドポイントのハンドラー
+type Handler struct {
+	// db データベース（インメモリの場合はnil）
+	db Pinger
+	// migrations マイグレーションの状態（インメモリの場合はnil）
+	migrations MigrationInspector
+	// timeout 準備完了の確認でデータベースの応答を待つ最大時間
+	timeout time.Duration
+	// admins マイグレーションの状態を参照できるアカウント
+	admins map[string]bool
+}
+
+// NewHandler ハンドラーを作成
+// インメモリの場合はdbとmigrationsにnilを指定する
+func NewHandler(db Pinger, migrations MigrationInspector, timeout time.Duration, adminAccountIDs []string) *Handler {
+	admins := make(map[string]bool, len(adminAccountIDs))
+	for _, id := range adminAccountIDs {
+		admins[id] = true
+	}
+	return &Handler{db: db, migrations: migrations, timeout: timeout, admins: admins}
+}
+
+// Register エンドポイントを登録
+// /healthzと/readyzは認証不要のルートとして扱う（IsPublicRouteを参照）
+func (h *Handler) Register(e *echo.Echo) {
+	e.GET("/healthz", h.Liveness)
+	e.GET("/readyz", h.Readiness)
+	e.GET("/debug/migrations", h.Migrations)
+}
+
+// IsPublicRoute 認証不要の運用向けのルートかどうかを判定
+func IsPublicRoute(path string) bool {
+	return path == "/healthz" || path == "/readyz"
+}
+
+// checkResponse 依存先の確認の結果
+// 認証不要のエンドポイントで返すため、エラーの詳細やバージョンは含めない（ログと/debug/migrationsで確認する）
+type checkResponse struct {
+	Status string `json:"status"`
+}
+
+// readinessResponse 準備完了の確認の結果
+type readinessResponse struct {
+	Status string                   `json:"status"`
+	Checks map[string]checkResponse `json:"checks"`
+}
+
+// migrationResponse マイグレーションファイルと適用状況
+type migrationResponse struct {
+	Version uint   `json:"version"`
+	Name    string `json:"name"`
+	Applied bool   `json:"applied"`
+}
+
+// migrationsResponse マイグレーションの状態
+type migrationsResponse struct {
+	CurrentVersion *uint               `json:"currentVersion"`
+	LatestVersion  uint                `json:"latestVersion"`
+	Dirty          bool                `json:"dirty"`
+	Migrations     []migrationResponse `json:"migrations"`
+}
+
+// Liveness プロセスが応答できることを返す（GET /healthz）
+// 依存先は確認しないため、データベースが停止していても再起動の対象にならない
+func (h *Handler) Liveness(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, map[string]string{"status": statusOK})
+}
+
+// Readiness リクエストを受け付けられるかを返す（GET /readyz）
+// データベースに接続でき、マイグレーションが最新まで適用済みの場合は200、それ以外は503を返す
+func (h *Handler) Readiness(ctx echo.Context) error {
+	checkCtx, cancel := context.WithTimeout(ctx.Request().Context(), h.timeout)
+	defer cancel()
+
+	checks := map[string]func(context.Context) error{}
+	if h.db != nil {
+		checks["database"] = h.checkDatabase
+	}
+	if h.migrations != nil {
+		checks["migrations"] = h.checkMigrations
+	}
+
+	response := readinessResponse{Status: statusOK, Checks: make(map[string]checkResponse, len(checks))}
+	code := http.StatusOK
+	for name, check := range checks {
+		if err := check(checkCtx); err != nil {
+			// エラーの詳細はログにのみ出力する
+			ctx.Logger().Warnf("Readiness check %s failed: %v", name, err)
+			response.Checks[name] = checkResponse{Status: statusUnavailable}
+			response.Status = statusUnavailable
+			code = http.StatusServiceUnavailable
+			continue
+		}
+		response.Checks[name] = checkResponse{Status: statusOK}
+	}
+	return ctx.JSON(code, response)
+}
+
+func (h *Handler) checkDatabase(ctx context.Context) error {
+	return h.db.Ping(ctx)
+}
+
+func (h *Handler) checkMigrations(ctx context.Context) error {
+	status, err := h.migrations.Status(ctx)
+	if err != nil {
+		return err
+	}
+	return status.Check()
+}
+
+// Migrations 適用済みのバージョンとマイグレーションファイルの一覧を返す（GET /debug/migrations）
+// 管理者のアカウントのみ参照できる
+func (h *Handler) Migrations(ctx echo.Context) error {
+	accountID, _ := middleware.AccountIDFromContext(ctx.Request().Context())
+	if !h.admins[accountID] {
+		return controller.HandleForbidden(ctx, "Administrator permission is required")
+	}
+	if h.migrations == nil {
+		return controller.HandleNotFound(ctx, "Migrations are not used with in-memory storage")
+	}
+
+	status, err := h.migrations.Status(ctx.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	migrations := make([]migrationResponse, 0, len(status.Files))
+	for _, file := range status.Files {
+		migrations = append(migrations, migrationResponse{
+			Version: file.Version,
+			Name:    file.Name,
+			Applied: status.Applied(file),
+		})
+	}
+	return ctx.JSON(http.StatusOK, migrationsResponse{
+		CurrentVersion: status.Version,
+		LatestVersion:  status.Latest(),
+		Dirty:          status.Dirty,
+		Migrations:     migrations,
+	})
+}
@@ -0,0 +1,181 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"task-management-system/backend/internal/adapter/http/middleware"
+	"task-management-system/backend/internal/driver/migration"
+
+	"github.com/labstack/echo/v4"
+)
+
+const adminAccountID = "admin-account"
+
+type fakePinger struct {
+	err error
+}
+
+func (p fakePinger) Ping(ctx context.Context) error {
+	return p.err
+}
+
+type fakeInspector struct {
+	status migration.Status
+	err    error
+}
+
+func (i fakeInspector) Status(ctx context.Context) (migration.Status, error) {
+	return i.status, i.err
+}
+
+var testFiles = []migration.File{{Version: 1, Name: "create_accounts"}, {Version: 2, Name: "create_tasks"}}
+
+func version(v uint) *uint {
+	return &v
+}
+
+// serve ハンドラーを登録したEchoにリクエストを送り、レスポンスのステータスと本文を返す
+func serve(t *testing.T, h *Handler, path, accountID string) (int, map[string]any) {
+	t.Helper()
+	e := echo.New()
+	h.Register(e)
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if accountID != "" {
+		req = req.WithContext(middleware.WithAccountID(req.Context(), accountID))
+	}
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, req)
+
+	body := map[string]any{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode response %q: %v", recorder.Body.String(), err)
+	}
+	return recorder.Code, body
+}
+
+func TestHandler_Liveness(t *testing.T) {
+	h := NewHandler(fakePinger{err: errors.New("connection refused")}, nil, time.Second, nil)
+
+	code, body := serve(t, h, "/healthz", "")
+	if code != http.StatusOK || body["status"] != statusOK {
+		t.Errorf("GET /healthz = %d %v, want %d and ok even if the database is down", code, body, http.StatusOK)
+	}
+}
+
+func TestHandler_Readiness(t *testing.T) {
+	tests := []struct {
+		name       string
+		db         Pinger
+		migrations MigrationInspector
+		wantCode   int
+		wantChecks map[string]string
+	}{
+		{
+			name:       "データベースに接続でき最新まで適用済み",
+			db:         fakePinger{},
+			migrations: fakeInspector{status: migration.Status{Version: version(2), Files: testFiles}},
+			wantCode:   http.StatusOK,
+			wantChecks: map[string]string{"database": statusOK, "migrations": statusOK},
+		},
+		{
+			name:       "データベースに接続できない",
+			db:         fakePinger{err: errors.New("connection refused")},
+			migrations: fakeInspector{err: errors.New("connection refused")},
+			wantCode:   http.StatusServiceUnavailable,
+			wantChecks: map[string]string{"database": statusUnavailable, "migrations": statusUnavailable},
+		},
+		{
+			name:       "適用していないマイグレーションがある",
+			db:         fakePinger{},
+			migrations: fakeInspector{status: migration.Status{Version: version(1), Files: testFiles}},
+			wantCode:   http.StatusServiceUnavailable,
+			wantChecks: map[string]string{"database": statusOK, "migrations": statusUnavailable},
+		},
+		{
+			name:       "途中で失敗したマイグレーションがある",
+			db:         fakePinger{},
+			migrations: fakeInspector{status: migration.Status{Version: version(2), Dirty: true, Files: testFiles}},
+			wantCode:   http.StatusServiceUnavailable,
+			wantChecks: map[string]string{"database": statusOK, "migrations": statusUnavailable},
+		},
+		{
+			name:       "インメモリの場合は確認する依存先がない",
+			wantCode:   http.StatusOK,
+			wantChecks: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(tt.db, tt.migrations, time.Second, nil)
+
+			code, body := serve(t, h, "/readyz", "")
+			if code != tt.wantCode {
+				t.Errorf("status = %d, want %d (body: %v)", code, tt.wantCode, body)
+			}
+			checks, _ := body["checks"].(map[string]any)
+			if len(checks) != len(tt.wantChecks) {
+				t.Fatalf("checks = %v, want %v", checks, tt.wantChecks)
+			}
+			for name, want := range tt.wantChecks {
+				check, _ := checks[name].(map[string]any)
+				if check["status"] != want {
+					t.Errorf("checks[%s] = %v, want status %s", name, check, want)
+				}
+				// 認証不要のため、エラーの詳細やバージョンは返さない
+				if len(check) != 1 {
+					t.Errorf("checks[%s] = %v, want only the status", name, check)
+				}
+			}
+		})
+	}
+}
+
+func TestHandler_Migrations(t *testing.T) {
+	inspector := fakeInspector{status: migration.Status{Version: version(1), Files: testFiles}}
+
+	t.Run("管理者には適用状況を返す", func(t *testing.T) {
+		h := NewHandler(fakePinger{}, inspector, time.Second, []string{adminAccountID})
+
+		code, body := serve(t, h, "/debug/migrations", adminAccountID)
+		if code != http.StatusOK {
+			t.Fatalf("status = %d, want %d (body: %v)", code, http.StatusOK, body)
+		}
+		if body["currentVersion"] != 1.0 || body["latestVersion"] != 2.0 || body["dirty"] != false {
+			t.Errorf("body = %v, want currentVersion 1, latestVersion 2, dirty false", body)
+		}
+		migrations, _ := body["migrations"].([]any)
+		if len(migrations) != 2 {
+			t.Fatalf("migrations = %v, want 2 files", migrations)
+		}
+		for i, wantApplied := range []bool{true, false} {
+			file, _ := migrations[i].(map[string]any)
+			if file["applied"] != wantApplied {
+				t.Errorf("migrations[%d] = %v, want applied %v", i, file, wantApplied)
+			}
+		}
+	})
+
+	t.Run("管理者以外は参照できない", func(t *testing.T) {
+		h := NewHandler(fakePinger{}, inspector, time.Second, []string{adminAccountID})
+
+		if code, _ := serve(t, h, "/debug/migrations", "other-account"); code != http.StatusForbidden {
+			t.Errorf("status = %d, want %d", code, http.StatusForbidden)
+		}
+	})
+
+	t.Run("インメモリの場合はマイグレーションがない", func(t *testing.T) {
+		h := NewHandler(nil, nil, time.Second, []string{adminAccountID})
+
+		if code, _ := serve(t, h, "/debug/migrations", adminAccountID); code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", code, http.StatusNotFound)
+		}
+	})
+}
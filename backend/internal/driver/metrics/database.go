@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// sqlcが生成したクエリの先頭のコメント（-- name: GetTaskByID :one）
+const sqlcNamePrefix = "-- name: "
+
+// otherQuery sqlcが生成していないクエリのクエリ名
+const otherQuery = "other"
+
+// QueryTracer クエリの処理時間をsqlcのクエリ名ごとに計測するトレーサー
+// 接続プールの作成前にpgxpool.ConfigのConnConfig.Tracerに設定する
+func (m *Metrics) QueryTracer() pgx.QueryTracer {
+	return queryTracer{duration: m.queryDuration}
+}
+
+type queryTracer struct {
+	duration *prometheus.HistogramVec
+}
+
+// queryStartKey コンテキストにクエリの開始を保持するキー
+type queryStartKey struct{}
+
+type queryStart struct {
+	name string
+	at   time.Time
+}
+
+func (t queryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, queryStart{name: queryName(data.SQL), at: time.Now()})
+}
+
+func (t queryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+	result := "success"
+	if data.Err != nil {
+		result = "error"
+	}
+	t.duration.WithLabelValues(start.name, result).Observe(time.Since(start.at).Seconds())
+}
+
+// queryName sqlcが生成したクエリの先頭のコメントからクエリ名を取得（コメントがない場合はother）
+func queryName(sql string) string {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(sql), sqlcNamePrefix)
+	if !ok {
+		return otherQuery
+	}
+	name, _, _ := strings.Cut(rest, " ")
+	if name == "" {
+		return otherQuery
+	}
+	return name
+}
+
+// RegisterPool 接続プールの統計（取得中・アイドルの接続数、接続の取得の待ち時間など）を登録
+func (m *Metrics) RegisterPool(pool *pgxpool.Pool) error {
+	return m.registry.Register(newPoolCollector(pool.Stat))
+}
+
+// poolCollector 収集のたびに接続プールの統計を取得するコレクター
+type poolCollector struct {
+	stat func() *pgxpool.Stat
+
+	acquiredConns        *prometheus.Desc
+	idleConns            *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+	acquireCount         *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+	acquireWaitSeconds   *prometheus.Desc
+}
+
+func newPoolCollector(stat func() *pgxpool.Stat) *poolCollector {
+	return &poolCollector{
+		stat:                 stat,
+		acquiredConns:        prometheus.NewDesc("pgxpool_acquired_conns", "Number of connections currently acquired from the pool.", nil, nil),
+		idleConns:            prometheus.NewDesc("pgxpool_idle_conns", "Number of idle connections in the pool.", nil, nil),
+		totalConns:           prometheus.NewDesc("pgxpool_total_conns", "Number of connections in the pool, including connections being established.", nil, nil),
+		maxConns:             prometheus.NewDesc("pgxpool_max_conns", "Maximum number of connections in the pool.", nil, nil),
+		acquireCount:         prometheus.NewDesc("pgxpool_acquire_count_total", "Number of successful connection acquisitions.", nil, nil),
+		emptyAcquireCount:    prometheus.NewDesc("pgxpool_empty_acquire_count_total", "Number of acquisitions that had to wait for a connection because the pool was empty.", nil, nil),
+		canceledAcquireCount: prometheus.NewDesc("pgxpool_canceled_acquire_count_total", "Number of acquisitions canceled by the context.", nil, nil),
+		acquireWaitSeconds:   prometheus.NewDesc("pgxpool_acquire_wait_seconds_total", "Total time spent waiting for a connection because the pool was empty.", nil, nil),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.stat()
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquireCount, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireWaitSeconds, prometheus.CounterValue, stat.EmptyAcquireWaitTime().Seconds())
+}
@@ -0,0 +1,21 @@
+package metrics
+
+import "task-management-system/backend/internal/domain/task"
+
+// RecordTaskEvents コミットしたタスクの変更履歴からドメインの指標を記録（metrics.TaskEventRecorderの実装）
+// 完了とアウトプットは既存の子タスクの変更のみを数え、複製で引き継いだ状態は数えない
+func (m *Metrics) RecordTaskEvents(events []task.Event) {
+	for _, event := range events {
+		switch event.Type {
+		case task.EventTypeTaskCreated:
+			m.tasksCreated.Inc()
+		case task.EventTypeTaskItemUpdated, task.EventTypeTaskItemOutputUpdated:
+			if change, ok := event.Diff["status"]; ok && change.After == string(task.StatusCompleted) {
+				m.taskItemsCompleted.Inc()
+			}
+			if change, ok := event.Diff["output"]; ok && change.After != nil {
+				m.outputsSubmitted.Inc()
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// OperationIDs OpenAPIの定義から、Echoのルート（メソッドとパス）ごとのオペレーションIDを作成
+// パスのパラメーターはEchoの形式（{taskId}は:taskId）に変換する
+func OperationIDs(spec *openapi3.T) map[string]string {
+	operationIDs := map[string]string{}
+	for specPath, item := range spec.Paths.Map() {
+		routePath := specPath
+		for _, param := range strings.Split(specPath, "/") {
+			if strings.HasPrefix(param, "{") && strings.HasSuffix(param, "}") {
+				routePath = strings.Replace(routePath, param, ":"+strings.Trim(param, "{}"), 1)
+			}
+		}
+		for method, operation := range item.Operations() {
+			operationIDs[routeKey(method, routePath)] = operation.OperationID
+		}
+	}
+	return operationIDs
+}
+
+// routeKey オペレーションIDを引くためのキー
+func routeKey(method string, routePath string) string {
+	return method + " " + routePath
+}
+
+// Middleware リクエストの件数と処理時間をOpenAPIのオペレーションIDごとに計測するミドルウェア
+// エラーのステータスコードを計測するため、エラーはこのミドルウェアで処理する（すべてのミドルウェアより先に登録する）
+// OpenAPIに定義していないルート（/healthz、/metricsなど）と存在しないパスは計測しない
+func (m *Metrics) Middleware(operationIDs map[string]string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			operationID, ok := operationIDs[routeKey(ctx.Request().Method, ctx.Path())]
+			if !ok {
+				return next(ctx)
+			}
+
+			started := time.Now()
+			if err := next(ctx); err != nil {
+				ctx.Error(err)
+			}
+
+			code := ctx.Response().Status
+			if !ctx.Response().Committed {
+				// レスポンスを書き込まなかった場合はnet/httpが200を返す
+				code = http.StatusOK
+			}
+			m.httpRequests.WithLabelValues(operationID, strconv.Itoa(code)).Inc()
+			m.httpDuration.WithLabelValues(operationID).Observe(time.Since(started).Seconds())
+			return nil
+		}
+	}
+}
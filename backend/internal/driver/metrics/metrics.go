@@ -0,0 +1,82 @@
+// Package metrics Prometheusの形式で公開する指標
+// HTTPのリクエスト（OpenAPIのオペレーションIDごと）、接続プールとクエリ（sqlcのクエリ名ごと）、ドメインの出来事を計測する
+package metrics
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// path 指標を公開するパス
+const path = "/metrics"
+
+// Metrics 指標の登録先と、計測に使用するコレクター
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests  *prometheus.CounterVec
+	httpDuration  *prometheus.HistogramVec
+	queryDuration *prometheus.HistogramVec
+
+	tasksCreated       prometheus.Counter
+	taskItemsCompleted prometheus.Counter
+	outputsSubmitted   prometheus.Counter
+}
+
+// New 指標を作成して登録（Goのランタイムとプロセスの指標も合わせて公開する）
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Number of HTTP requests by OpenAPI operation ID and status code.",
+		}, []string{"operation_id", "code"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Latency of HTTP requests by OpenAPI operation ID.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"operation_id"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Latency of database queries by sqlc query name and result.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"query", "result"}),
+		tasksCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "tasks_created_total",
+			Help: "Number of tasks created, including duplicated tasks and tasks generated from recurring templates.",
+		}),
+		taskItemsCompleted: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "task_items_completed_total",
+			Help: "Number of task items whose status changed to Completed.",
+		}),
+		outputsSubmitted: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "task_item_outputs_submitted_total",
+			Help: "Number of task item outputs submitted or rewritten.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.queryDuration,
+		m.tasksCreated,
+		m.taskItemsCompleted,
+		m.outputsSubmitted,
+	)
+	return m
+}
+
+// Register 指標を公開するエンドポイント（GET /metrics）を登録
+// 認証不要のルートとして扱うため（IsPublicRouteを参照）、外部に公開しないネットワークからのみ到達できるようにする
+func (m *Metrics) Register(e *echo.Echo) {
+	e.GET(path, echo.WrapHandler(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})))
+}
+
+// IsPublicRoute 認証不要の指標のルートかどうかを判定
+func IsPublicRoute(routePath string) bool {
+	return routePath == path
+}
@@ -0,0 +1,207 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"task-management-system/backend/internal/domain/task"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// value 登録した指標から、ラベルが一致する値を取得（カウンターは値、ヒストグラムは件数）
+func value(t *testing.T, m *Metrics, name string, labels map[string]string) float64 {
+	t.Helper()
+	families, err := m.registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			if !hasLabels(metric, labels) {
+				continue
+			}
+			switch {
+			case metric.GetCounter() != nil:
+				return metric.GetCounter().GetValue()
+			case metric.GetGauge() != nil:
+				return metric.GetGauge().GetValue()
+			case metric.GetHistogram() != nil:
+				return float64(metric.GetHistogram().GetSampleCount())
+			}
+		}
+	}
+	return 0
+}
+
+func hasLabels(metric *dto.Metric, labels map[string]string) bool {
+	matched := 0
+	for _, pair := range metric.GetLabel() {
+		if want, ok := labels[pair.GetName()]; ok && want == pair.GetValue() {
+			matched++
+		}
+	}
+	return matched == len(labels)
+}
+
+func TestOperationIDs(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(`
+openapi: 3.0.0
+info: {title: test, version: "1"}
+paths:
+  /api/tasks:
+    get: {operationId: Tasks_listTasks, responses: {"200": {description: ok}}}
+    post: {operationId: Tasks_createTask, responses: {"200": {description: ok}}}
+  /api/task-items/{taskItemId}/status:
+    put: {operationId: TaskItems_changeStatus, responses: {"200": {description: ok}}}
+`))
+	if err != nil {
+		t.Fatalf("failed to load spec: %v", err)
+	}
+
+	got := OperationIDs(spec)
+	want := map[string]string{
+		"GET /api/tasks":                         "Tasks_listTasks",
+		"POST /api/tasks":                        "Tasks_createTask",
+		"PUT /api/task-items/:taskItemId/status": "TaskItems_changeStatus",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("OperationIDs() = %v, want %v", got, want)
+	}
+	for key, operationID := range want {
+		if got[key] != operationID {
+			t.Errorf("OperationIDs()[%q] = %q, want %q", key, got[key], operationID)
+		}
+	}
+}
+
+func TestMetrics_Middleware(t *testing.T) {
+	m := New()
+	e := echo.New()
+	e.Use(m.Middleware(map[string]string{
+		"GET /api/tasks/:taskId":  "Tasks_getTask",
+		"POST /api/tasks/:taskId": "Tasks_updateTask",
+	}))
+	e.GET("/api/tasks/:taskId", func(ctx echo.Context) error {
+		return ctx.JSON(http.StatusOK, map[string]string{"id": ctx.Param("taskId")})
+	})
+	e.POST("/api/tasks/:taskId", func(ctx echo.Context) error {
+		return echo.NewHTTPError(http.StatusConflict, "conflict")
+	})
+	e.GET("/healthz", func(ctx echo.Context) error {
+		return ctx.NoContent(http.StatusOK)
+	})
+
+	requests := []struct {
+		method   string
+		path     string
+		wantCode int
+	}{
+		{method: http.MethodGet, path: "/api/tasks/1", wantCode: http.StatusOK},
+		{method: http.MethodGet, path: "/api/tasks/2", wantCode: http.StatusOK},
+		{method: http.MethodPost, path: "/api/tasks/1", wantCode: http.StatusConflict},
+		{method: http.MethodGet, path: "/healthz", wantCode: http.StatusOK},
+	}
+	for _, r := range requests {
+		recorder := httptest.NewRecorder()
+		e.ServeHTTP(recorder, httptest.NewRequest(r.method, r.path, nil))
+		if recorder.Code != r.wantCode {
+			t.Errorf("%s %s = %d, want %d", r.method, r.path, recorder.Code, r.wantCode)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		metric string
+		labels map[string]string
+		want   float64
+	}{
+		{name: "パスのパラメーターが異なっても同じオペレーションとして数える", metric: "http_requests_total", labels: map[string]string{"operation_id": "Tasks_getTask", "code": "200"}, want: 2},
+		{name: "エラーはエラーハンドラーが返したステータスコードで数える", metric: "http_requests_total", labels: map[string]string{"operation_id": "Tasks_updateTask", "code": "409"}, want: 1},
+		{name: "処理時間をオペレーションごとに記録する", metric: "http_request_duration_seconds", labels: map[string]string{"operation_id": "Tasks_getTask"}, want: 2},
+		{name: "OpenAPIに定義していないルートは数えない", metric: "http_requests_total", labels: map[string]string{"operation_id": ""}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := value(t, m, tt.metric, tt.labels); got != tt.want {
+				t.Errorf("%s%v = %v, want %v", tt.metric, tt.labels, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryName(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		want string
+	}{
+		{name: "sqlcが生成したクエリ", sql: "-- name: GetTaskByID :one\nSELECT * FROM tasks WHERE id = $1", want: "GetTaskByID"},
+		{name: "先頭の空白は無視する", sql: "\n  -- name: ListTasks :many\nSELECT 1", want: "ListTasks"},
+		{name: "sqlcが生成していないクエリ", sql: "SELECT version, dirty FROM schema_migrations LIMIT 1", want: otherQuery},
+		{name: "名前のないコメント", sql: "-- name: ", want: otherQuery},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := queryName(tt.sql); got != tt.want {
+				t.Errorf("queryName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetrics_RecordTaskEvents(t *testing.T) {
+	m := New()
+	itemID := "item-1"
+	m.RecordTaskEvents([]task.Event{
+		{Type: task.EventTypeTaskCreated},
+		// 作成時に引き継いだ状態は完了・提出として数えない
+		{Type: task.EventTypeTaskItemAdded, TaskItemID: &itemID, Diff: task.Diff{"status": {After: "Completed"}, "output": {After: "引き継いだアウトプット"}}},
+	})
+	m.RecordTaskEvents([]task.Event{
+		{Type: task.EventTypeTaskItemUpdated, TaskItemID: &itemID, Diff: task.Diff{"status": {Before: "InProgress", After: "Completed"}}},
+		{Type: task.EventTypeTaskItemOutputUpdated, TaskItemID: &itemID, Diff: task.Diff{"output": {Before: nil, After: "学んだこと"}, "status": {Before: "InProgress", After: "Completed"}}},
+		// 完了から着手中に戻した場合は数えない
+		{Type: task.EventTypeTaskItemUpdated, TaskItemID: &itemID, Diff: task.Diff{"status": {Before: "Completed", After: "InProgress"}}},
+	})
+
+	for name, want := range map[string]float64{
+		"tasks_created_total":               1,
+		"task_items_completed_total":        2,
+		"task_item_outputs_submitted_total": 1,
+	} {
+		if got := value(t, m, name, nil); got != want {
+			t.Errorf("%s = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestMetrics_RegisterPool(t *testing.T) {
+	// 接続しないプール（最小接続数が0のため、取得するまで接続しない）
+	pool, err := pgxpool.New(context.Background(), "postgres://app@127.0.0.1:1/task_management?pool_max_conns=7")
+	if err != nil {
+		t.Fatalf("pgxpool.New() error = %v", err)
+	}
+	defer pool.Close()
+
+	m := New()
+	if err := m.RegisterPool(pool); err != nil {
+		t.Fatalf("RegisterPool() error = %v", err)
+	}
+	if got := value(t, m, "pgxpool_max_conns", nil); got != 7 {
+		t.Errorf("pgxpool_max_conns = %v, want 7", got)
+	}
+	for _, name := range []string{"pgxpool_acquired_conns", "pgxpool_idle_conns", "pgxpool_acquire_wait_seconds_total"} {
+		if got := value(t, m, name, nil); got != 0 {
+			t.Errorf("%s = %v, want 0", name, got)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Package migration データベースのマイグレーション
+// マイグレーションファイルの一覧と、データベースに適用済みのバージョン（golang-migrateが記録する）を扱う
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// versionTable golang-migrateが適用済みのバージョンを記録するテーブル
+const versionTable = "schema_migrations"
+
+// undefinedTableCode テーブルが存在しない場合のPostgreSQLのエラーコード
+const undefinedTableCode = "42P01"
+
+// File マイグレーションファイル（upとdownの組）
+type File struct {
+	Version uint
+	// Name バージョンの後ろの名前（例：000001_create_accounts.up.sqlの場合はcreate_accounts）
+	Name string
+}
+
+// ListFiles fsysの直下にあるマイグレーションファイルをバージョンの昇順に取得
+func ListFiles(fsys fs.FS) ([]File, error) {
+	source, err := iofs.New(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open migrations: %w", err)
+	}
+	defer source.Close()
+
+	files := []File{}
+	version, err := source.First()
+	for err == nil {
+		r, name, readErr := source.ReadUp(version)
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read migration %d: %w", version, readErr)
+		}
+		r.Close()
+		files = append(files, File{Version: version, Name: name})
+
+		version, err = source.Next(version)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	return files, nil
+}
+
+// Status データベースのマイグレーションの状態
+type Status struct {
+	// Version 適用済みのバージョン（1件も適用していない場合はnil）
+	Version *uint
+	// Dirty 最後のマイグレーションが途中で失敗したかどうか（手動で修正するまでスキーマは不完全）
+	Dirty bool
+	// Files マイグレーションファイル（バージョンの昇順）
+	Files []File
+}
+
+// Latest マイグレーションファイルの最新のバージョン（ファイルがない場合は0）
+func (s Status) Latest() uint {
+	if len(s.Files) == 0 {
+		return 0
+	}
+	return s.Files[len(s.Files)-1].Version
+}
+
+// Applied マイグレーションファイルを適用済みかどうか
+func (s Status) Applied(file File) bool {
+	return s.Version != nil && file.Version <= *s.Version
+}
+
+// Pending 適用していないマイグレーションファイル
+func (s Status) Pending() []File {
+	pending := []File{}
+	for _, file := range s.Files {
+		if !s.Applied(file) {
+			pending = append(pending, file)
+		}
+	}
+	return pending
+}
+
+// Check スキーマが最新のマイグレーションファイルまで適用済みか確認
+// 途中で失敗したマイグレーションがある場合と、適用していないマイグレーションファイルがある場合はエラーを返す
+// データベースの方が新しい場合（新しいバージョンのアプリケーションが適用した場合）は互換性があるものとしてエラーにしない
+func (s Status) Check() error {
+	if s.Dirty {
+		return fmt.Errorf("migration %d is dirty and must be fixed manually", *s.Version)
+	}
+	if pending := s.Pending(); len(pending) > 0 {
+		return fmt.Errorf("%d migrations are pending (latest is %d)", len(pending), s.Latest())
+	}
+	return nil
+}
+
+// querier 1行を取得するクエリを実行できる接続（pgxpool.Pool、pgx.Conn、pgx.Tx）
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Inspector データベースのマイグレーションの状態を確認する
+type Inspector struct {
+	db    querier
+	files []File
+}
+
+// NewInspector マイグレーションの状態の確認を作成（filesはListFilesで取得したマイグレーションファイル）
+func NewInspector(db querier, files []File) *Inspector {
+	return &Inspector{db: db, files: files}
+}
+
+// Status データベースに適用済みのバージョンを取得し、マイグレーションファイルと合わせて返す
+func (i *Inspector) Status(ctx context.Context) (Status, error) {
+	status := Status{Files: i.files}
+
+	var version int64
+	err := i.db.QueryRow(ctx, "SELECT version, dirty FROM "+versionTable+" LIMIT 1").Scan(&version, &status.Dirty)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == undefinedTableCode) {
+			// マイグレーションを1件も適用していない
+			return status, nil
+		}
+		return status, fmt.Errorf("failed to get migration version: %w", err)
+	}
+
+	applied := uint(version)
+	status.Version = &applied
+	return status, nil
+}
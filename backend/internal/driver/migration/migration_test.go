@@ -0,0 +1,186 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"task-management-system/backend/migrations"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func TestListFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"000002_create_tasks.up.sql":      {Data: []byte("CREATE TABLE tasks ();")},
+		"000002_create_tasks.down.sql":    {Data: []byte("DROP TABLE tasks;")},
+		"000001_create_accounts.up.sql":   {Data: []byte("CREATE TABLE accounts ();")},
+		"000001_create_accounts.down.sql": {Data: []byte("DROP TABLE accounts;")},
+		"embed.go":                        {Data: []byte("package migrations")},
+	}
+
+	got, err := ListFiles(fsys)
+	if err != nil {
+		t.Fatalf("ListFiles() error = %v", err)
+	}
+
+	want := []File{{Version: 1, Name: "create_accounts"}, {Version: 2, Name: "create_tasks"}}
+	if len(got) != len(want) {
+		t.Fatalf("ListFiles() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ListFiles()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestStatus_Check(t *testing.T) {
+	files := []File{{Version: 1, Name: "create_accounts"}, {Version: 2, Name: "create_tasks"}}
+	version := func(v uint) *uint { return &v }
+
+	tests := []struct {
+		name        string
+		status      Status
+		wantPending int
+		wantErr     string
+	}{
+		{name: "最新まで適用済み", status: Status{Version: version(2), Files: files}},
+		{name: "データベースの方が新しい場合は互換性があるものとする", status: Status{Version: version(3), Files: files}},
+		{name: "1件も適用していない", status: Status{Files: files}, wantPending: 2, wantErr: "2 migrations are pending"},
+		{name: "適用していないファイルがある", status: Status{Version: version(1), Files: files}, wantPending: 1, wantErr: "1 migrations are pending"},
+		{name: "途中で失敗したマイグレーションがある", status: Status{Version: version(2), Dirty: true, Files: files}, wantErr: "dirty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.Latest(); got != 2 {
+				t.Errorf("Latest() = %d, want 2", got)
+			}
+			if got := tt.status.Pending(); len(got) != tt.wantPending {
+				t.Errorf("Pending() = %+v, want %d files", got, tt.wantPending)
+			}
+
+			err := tt.status.Check()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Check() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Check() error = %v, want to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestListFiles_Embedded(t *testing.T) {
+	files, err := ListFiles(migrations.FS)
+	if err != nil {
+		t.Fatalf("ListFiles() error = %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("ListFiles() returned no migrations")
+	}
+
+	for i, file := range files {
+		// バージョンは1からの連番にする
+		if file.Version != uint(i+1) {
+			t.Errorf("files[%d].Version = %d, want %d", i, file.Version, i+1)
+		}
+		// すべてのマイグレーションは取り消せるようにする
+		down := fmt.Sprintf("%06d_%s.down.sql", file.Version, file.Name)
+		if _, err := fs.Stat(migrations.FS, down); err != nil {
+			t.Errorf("%s is missing: %v", down, err)
+		}
+	}
+}
+
+// TestMigrator TEST_DATABASE_URLのデータベースでマイグレーションの適用と取り消しを確認する
+// 最後に最新のバージョンまで適用した状態に戻す
+func TestMigrator(t *testing.T) {
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	migrator, err := NewMigrator(databaseURL, migrations.FS)
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+	defer migrator.Close()
+
+	conn, err := pgx.Connect(context.Background(), databaseURL)
+	if err != nil {
+		t.Fatalf("failed to connect to database: %v", err)
+	}
+	defer conn.Close(context.Background())
+
+	files, err := ListFiles(migrations.FS)
+	if err != nil {
+		t.Fatalf("ListFiles() error = %v", err)
+	}
+	inspector := NewInspector(conn, files)
+
+	// assertStatus MigratorとInspectorが同じ状態を返すことを確認
+	assertStatus := func(t *testing.T, wantPending int) {
+		t.Helper()
+		got, err := migrator.Status()
+		if err != nil {
+			t.Fatalf("Status() error = %v", err)
+		}
+		if pending := got.Pending(); len(pending) != wantPending || got.Dirty {
+			t.Errorf("Status() = {Version: %v, Dirty: %v, Pending: %d}, want %d pending", got.Version, got.Dirty, len(pending), wantPending)
+		}
+
+		inspected, err := inspector.Status(context.Background())
+		if err != nil {
+			t.Fatalf("Inspector.Status() error = %v", err)
+		}
+		if len(inspected.Pending()) != len(got.Pending()) || inspected.Dirty != got.Dirty {
+			t.Errorf("Inspector.Status() = {Version: %v, Dirty: %v}, want {Version: %v, Dirty: %v}", inspected.Version, inspected.Dirty, got.Version, got.Dirty)
+		}
+	}
+
+	if err := migrator.Up(); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	assertStatus(t, 0)
+
+	t.Run("適用済みの場合は何もしない", func(t *testing.T) {
+		if err := migrator.Up(); err != nil {
+			t.Errorf("Up() error = %v", err)
+		}
+		assertStatus(t, 0)
+	})
+
+	t.Run("新しい順に取り消して再び適用する", func(t *testing.T) {
+		if err := migrator.Down(2); err != nil {
+			t.Fatalf("Down(2) error = %v", err)
+		}
+		assertStatus(t, 2)
+
+		if err := migrator.To(files[len(files)-1].Version); err != nil {
+			t.Fatalf("To() error = %v", err)
+		}
+		assertStatus(t, 0)
+	})
+
+	t.Run("強制的にバージョンを書き換える", func(t *testing.T) {
+		latest := int(files[len(files)-1].Version)
+		if err := migrator.Force(latest - 1); err != nil {
+			t.Fatalf("Force() error = %v", err)
+		}
+		assertStatus(t, 1)
+
+		if err := migrator.Force(latest); err != nil {
+			t.Fatalf("Force() error = %v", err)
+		}
+		assertStatus(t, 0)
+	})
+}
@@ -0,0 +1,123 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// Migrator マイグレーションを適用する（golang-migrateを使用し、単一の接続で実行する）
+type Migrator struct {
+	m     *migrate.Migrate
+	files []File
+}
+
+// NewMigrator fsysのマイグレーションファイルをdatabaseURLのデータベースに適用するMigratorを作成
+// 使用後はCloseで接続を閉じる
+func NewMigrator(databaseURL string, fsys fs.FS) (*Migrator, error) {
+	files, err := ListFiles(fsys)
+	if err != nil {
+		return nil, err
+	}
+	source, err := iofs.New(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open migrations: %w", err)
+	}
+
+	// pgxの接続をstdlibに変換
+	config, err := pgx.ParseConfig(databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database URL: %w", err)
+	}
+	db := stdlib.OpenDB(*config)
+
+	driver, err := postgres.WithInstance(db, &postgres.Config{MigrationsTable: versionTable})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", source, "postgres", driver)
+	if err != nil {
+		driver.Close()
+		return nil, fmt.Errorf("failed to create migrator: %w", err)
+	}
+	m.Log = logger{}
+
+	return &Migrator{m: m, files: files}, nil
+}
+
+// Close データベースの接続を閉じる
+func (m *Migrator) Close() error {
+	sourceErr, dbErr := m.m.Close()
+	return errors.Join(sourceErr, dbErr)
+}
+
+// Up 適用していないマイグレーションをすべて適用
+func (m *Migrator) Up() error {
+	return ignoreNoChange(m.m.Up())
+}
+
+// Down 適用済みのマイグレーションを新しい順にsteps件取り消す
+func (m *Migrator) Down(steps int) error {
+	if steps < 1 {
+		return fmt.Errorf("steps must be at least 1: %d", steps)
+	}
+	return ignoreNoChange(m.m.Steps(-steps))
+}
+
+// To 指定したバージョンまで適用または取り消す
+func (m *Migrator) To(version uint) error {
+	return ignoreNoChange(m.m.Migrate(version))
+}
+
+// Force マイグレーションを実行せずに適用済みのバージョンを書き換え、dirtyを解除する
+// 途中で失敗したマイグレーションを手動で修正した後に使用する（-1は1件も適用していない状態）
+func (m *Migrator) Force(version int) error {
+	if version < database.NilVersion {
+		return fmt.Errorf("version must be %d or greater: %d", database.NilVersion, version)
+	}
+	return m.m.Force(version)
+}
+
+// Status 適用済みのバージョンとマイグレーションファイルを取得
+func (m *Migrator) Status() (Status, error) {
+	status := Status{Files: m.files}
+
+	version, dirty, err := m.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return status, nil
+	}
+	if err != nil {
+		return status, fmt.Errorf("failed to get migration version: %w", err)
+	}
+	status.Version = &version
+	status.Dirty = dirty
+	return status, nil
+}
+
+// ignoreNoChange 適用するマイグレーションがない場合は成功として扱う
+func ignoreNoChange(err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
+
+// logger 適用したマイグレーションをログに出力する
+type logger struct{}
+
+func (logger) Printf(format string, v ...any) {
+	log.Printf("Migration: "+format, v...)
+}
+
+func (logger) Verbose() bool {
+	return false
+}
@@ -0,0 +1,16 @@
+// Package metrics ユースケースが記録するドメインの指標のポート
+package metrics
+
+import "task-management-system/backend/internal/domain/task"
+
+// TaskEventRecorder 保存したタスクの変更をドメインの指標（作成したタスク、完了した子タスク、提出したアウトプットなど）として記録する
+// トランザクションのコミット後に呼び出すため、ロールバックした変更は記録されない
+type TaskEventRecorder interface {
+	RecordTaskEvents(events []task.Event)
+}
+
+// NopTaskEventRecorder 何も記録しない（指標を収集しない場合に使用する）
+type NopTaskEventRecorder struct{}
+
+// RecordTaskEvents 何もしない
+func (NopTaskEventRecorder) RecordTaskEvents(events []task.Event) {}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"task-management-system/backend/internal/domain/category"
+)
+
+// CategoryRepository カテゴリリポジトリインターフェース
+// 対象のカテゴリが存在しない場合はNotFound、IDの形式が不正な場合はValidation、
+// 同じオーナーに同名のカテゴリが存在する場合はConflictのドメインエラーを返す
+type CategoryRepository interface {
+	ListCategories(ctx context.Context, ownerID string) ([]*category.Category, error)
+	GetCategoryByID(ctx context.Context, categoryID string) (*category.Category, error)
+	GetCategoriesByIDs(ctx context.Context, categoryIDs []string) ([]*category.Category, error)
+	CreateCategory(ctx context.Context, ownerID string, name string, color string) (*category.Category, error)
+	UpdateCategory(ctx context.Context, categoryID string, name string, color string) (*category.Category, error)
+	DeleteCategory(ctx context.Context, categoryID string) error
+}
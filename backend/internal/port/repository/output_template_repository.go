@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"task-management-system/backend/internal/domain/outputtemplate"
+)
+
+// OutputTemplateRepository アウトプットテンプレートリポジトリインターフェース
+// 対象のテンプレートが存在しない場合はNotFound、IDの形式が不正な場合はValidation、
+// 同じオーナーに同名のテンプレートが存在する場合はConflictのドメインエラーを返す
+type OutputTemplateRepository interface {
+	ListOutputTemplates(ctx context.Context, ownerID string) ([]*outputtemplate.OutputTemplate, error)
+	GetOutputTemplateByID(ctx context.Context, outputTemplateID string) (*outputtemplate.OutputTemplate, error)
+	GetOutputTemplatesByIDs(ctx context.Context, outputTemplateIDs []string) ([]*outputtemplate.OutputTemplate, error)
+	CreateOutputTemplate(ctx context.Context, ownerID string, name string, sections []outputtemplate.Section) (*outputtemplate.OutputTemplate, error)
+	UpdateOutputTemplate(ctx context.Context, outputTemplateID string, name string, sections []outputtemplate.Section) (*outputtemplate.OutputTemplate, error)
+	DeleteOutputTemplate(ctx context.Context, outputTemplateID string) error
+}
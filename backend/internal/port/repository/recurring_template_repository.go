@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"task-management-system/backend/internal/domain/recurringtemplate"
+)
+
+// RecurringTemplateRepository 繰り返しテンプレートリポジトリインターフェース
+// 対象のテンプレートが存在しない場合はNotFound、IDの形式が不正な場合はValidationのドメインエラーを返す
+type RecurringTemplateRepository interface {
+	ListRecurringTemplates(ctx context.Context, ownerID string) ([]*recurringtemplate.RecurringTemplate, error)
+	GetRecurringTemplateByID(ctx context.Context, recurringTemplateID string) (*recurringtemplate.RecurringTemplate, error)
+	CreateRecurringTemplate(ctx context.Context, ownerID string, title string, rule recurringtemplate.Rule, taskItems []recurringtemplate.Item) (*recurringtemplate.RecurringTemplate, error)
+	UpdateRecurringTemplate(ctx context.Context, recurringTemplateID string, title string, rule recurringtemplate.Rule, taskItems []recurringtemplate.Item) (*recurringtemplate.RecurringTemplate, error)
+	DeleteRecurringTemplate(ctx context.Context, recurringTemplateID string) error
+}
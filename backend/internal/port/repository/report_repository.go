@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"task-management-system/backend/internal/domain/report"
+)
+
+// ReportRepository レポートリポジトリインターフェース
+// 集計はDBで行い、オーナーの期間内のタスクアイテムのみを対象にする
+type ReportRepository interface {
+	GetReport(ctx context.Context, ownerID string, period report.Period) (*report.Report, error)
+}
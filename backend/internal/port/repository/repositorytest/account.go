@@ -0,0 +1,85 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+
+	"github.com/google/uuid"
+)
+
+// RunAccountRepositoryTests アカウントリポジトリの契約テストを実行
+func RunAccountRepositoryTests(t *testing.T, newRepositories Factory) {
+	t.Run("作成したアカウントをIDとメールアドレスで取得できる", func(t *testing.T) {
+		repos := newRepositories(t)
+		ctx := context.Background()
+
+		created, err := repos.Accounts.CreateAccount(ctx, "alice@example.com", "Alice", "Smith", "google", "google-alice", ptr("https://example.com/alice.png"))
+		if err != nil {
+			t.Fatalf("CreateAccount() error = %v", err)
+		}
+		if created.LastLoginAt == nil {
+			t.Error("LastLoginAt = nil, want the time of creation")
+		}
+
+		byID, err := repos.Accounts.GetAccountByID(ctx, created.ID)
+		if err != nil {
+			t.Fatalf("GetAccountByID() error = %v", err)
+		}
+		if byID.Email != "alice@example.com" || byID.FirstName != "Alice" || byID.LastName != "Smith" || byID.Thumbnail == nil || *byID.Thumbnail != "https://example.com/alice.png" {
+			t.Errorf("GetAccountByID() = %+v", byID)
+		}
+
+		byEmail, err := repos.Accounts.GetAccountByEmail(ctx, "alice@example.com")
+		if err != nil {
+			t.Fatalf("GetAccountByEmail() error = %v", err)
+		}
+		if byEmail.ID != created.ID {
+			t.Errorf("GetAccountByEmail() = %s, want %s", byEmail.ID, created.ID)
+		}
+	})
+
+	t.Run("登録済みのメールアドレスまたは認証プロバイダーのアカウントはConflictを返す", func(t *testing.T) {
+		repos := newRepositories(t)
+		ctx := context.Background()
+
+		if _, err := repos.Accounts.CreateAccount(ctx, "alice@example.com", "Alice", "Smith", "google", "google-alice", nil); err != nil {
+			t.Fatalf("CreateAccount() error = %v", err)
+		}
+		if _, err := repos.Accounts.CreateAccount(ctx, "alice@example.com", "Alice", "Smith", "google", "google-other", nil); !domainerrors.IsConflict(err) {
+			t.Errorf("CreateAccount(same email) error = %v, want Conflict", err)
+		}
+		if _, err := repos.Accounts.CreateAccount(ctx, "other@example.com", "Alice", "Smith", "google", "google-alice", nil); !domainerrors.IsConflict(err) {
+			t.Errorf("CreateAccount(same provider account) error = %v, want Conflict", err)
+		}
+	})
+
+	t.Run("存在しないアカウントはNotFound、形式が不正なIDはValidationを返す", func(t *testing.T) {
+		repos := newRepositories(t)
+		ctx := context.Background()
+
+		if _, err := repos.Accounts.GetAccountByID(ctx, uuid.NewString()); !domainerrors.IsNotFound(err) {
+			t.Errorf("GetAccountByID(unknown) error = %v, want NotFound", err)
+		}
+		if _, err := repos.Accounts.GetAccountByEmail(ctx, "unknown@example.com"); !domainerrors.IsNotFound(err) {
+			t.Errorf("GetAccountByEmail(unknown) error = %v, want NotFound", err)
+		}
+		if _, err := repos.Accounts.GetAccountByID(ctx, "not-a-uuid"); !domainerrors.IsValidation(err) {
+			t.Errorf("GetAccountByID(invalid) error = %v, want Validation", err)
+		}
+	})
+
+	t.Run("IDのリストで取得し、存在しないIDは無視する", func(t *testing.T) {
+		repos := newRepositories(t)
+		alice := newAccount(t, repos, "alice")
+
+		got, err := repos.Accounts.GetAccountsByIDs(context.Background(), []string{alice.ID, uuid.NewString()})
+		if err != nil {
+			t.Fatalf("GetAccountsByIDs() error = %v", err)
+		}
+		if len(got) != 1 || got[0].ID != alice.ID {
+			t.Errorf("GetAccountsByIDs() = %v, want only %s", got, alice.ID)
+		}
+	})
+}
@@ -0,0 +1,152 @@
+// Package repositorytest リポジトリの実装が満たす振る舞いを確認する契約テスト
+// PostgreSQLの実装とインメモリの実装の両方で同じテストを実行し、実装による振る舞いの違いを防ぐ
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"task-management-system/backend/internal/domain/account"
+	"task-management-system/backend/internal/domain/task"
+	"task-management-system/backend/internal/port/repository"
+
+	"github.com/google/uuid"
+)
+
+// Repositories 契約テストの対象のリポジトリ（同じデータを共有する）
+type Repositories struct {
+	TxManager  repository.TxManager
+	Tasks      repository.TaskRepository
+	Accounts   repository.AccountRepository
+	Categories repository.CategoryRepository
+}
+
+// Factory データが空のリポジトリを作成する（サブテストごとに呼び出す）
+type Factory func(t *testing.T) Repositories
+
+// newAccount テスト用のアカウントを作成
+func newAccount(t *testing.T, repos Repositories, name string) *account.Account {
+	t.Helper()
+
+	providerAccountID := uuid.NewString()
+	a, err := repos.Accounts.CreateAccount(context.Background(), name+"-"+providerAccountID+"@example.com", name, "Test", "test", providerAccountID, nil)
+	if err != nil {
+		t.Fatalf("CreateAccount() error = %v", err)
+	}
+	return a
+}
+
+// newTask テスト用のタスクを作成
+func newTask(t *testing.T, repos Repositories, ownerID string, title string, date string, items ...task.CreateTaskItemInput) *task.Task {
+	t.Helper()
+
+	if len(items) == 0 {
+		items = []task.CreateTaskItemInput{newItem(title+"の子タスク", 1)}
+	}
+	created, err := repos.Tasks.CreateTask(context.Background(), ownerID, title, date, items)
+	if err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+	return created
+}
+
+// newItem テスト用の子タスクの入力を作成
+func newItem(content string, order int32) task.CreateTaskItemInput {
+	return task.CreateTaskItemInput{
+		Priority:     task.PriorityMedium,
+		Density:      task.DensityMedium,
+		DurationTime: task.DurationTime30,
+		Content:      content,
+		Order:        order,
+		Status:       task.StatusNotStarted,
+	}
+}
+
+// getTask タスクを取得（取得できない場合はテストを終了する）
+func getTask(t *testing.T, repos Repositories, taskID string) *task.Task {
+	t.Helper()
+
+	got, err := repos.Tasks.GetTaskByID(context.Background(), taskID)
+	if err != nil {
+		t.Fatalf("GetTaskByID() error = %v", err)
+	}
+	return got
+}
+
+// listTaskIDs 条件に一致するタスクのIDを並び順に取得
+func listTaskIDs(t *testing.T, repos Repositories, condition task.ListTasksCondition) []string {
+	t.Helper()
+
+	tasks, err := repos.Tasks.ListTasks(context.Background(), condition)
+	if err != nil {
+		t.Fatalf("ListTasks() error = %v", err)
+	}
+	return taskIDs(tasks)
+}
+
+func taskIDs(tasks []*task.Task) []string {
+	ids := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+// itemContents 子タスクの内容を並び順に取得
+func itemContents(t *task.Task) []string {
+	contents := make([]string, 0, len(t.TaskItems))
+	for _, item := range t.TaskItems {
+		contents = append(contents, item.Content)
+	}
+	return contents
+}
+
+// findItem 内容が一致する子タスクを取得（ない場合はテストを終了する）
+func findItem(t *testing.T, got *task.Task, content string) task.TaskItem {
+	t.Helper()
+
+	for _, item := range got.TaskItems {
+		if item.Content == content {
+			return item
+		}
+	}
+	t.Fatalf("task item %q not found in %v", content, itemContents(got))
+	return task.TaskItem{}
+}
+
+// listEventTypes タスクの変更履歴の種類を記録した順に取得
+func listEventTypes(t *testing.T, repos Repositories, taskID string) []task.EventType {
+	t.Helper()
+
+	events, err := repos.Tasks.ListTaskEvents(context.Background(), taskID)
+	if err != nil {
+		t.Fatalf("ListTaskEvents() error = %v", err)
+	}
+	types := make([]task.EventType, 0, len(events))
+	for _, e := range events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// at テストで使用する日時（PostgreSQLの精度で丸められないように秒単位にする）
+func at(hour, minute int) time.Time {
+	return time.Date(2024, 1, 15, hour, minute, 0, 0, time.UTC)
+}
@@ -0,0 +1,810 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/task"
+
+	"github.com/google/uuid"
+)
+
+// RunTaskRepositoryTests タスクリポジトリの契約テストを実行
+func RunTaskRepositoryTests(t *testing.T, newRepositories Factory) {
+	t.Run("作成したタスクを子タスクの順番の昇順で取得できる", func(t *testing.T) {
+		repos := newRepositories(t)
+		owner := newAccount(t, repos, "alice")
+
+		created := newTask(t, repos, owner.ID, "朝のタスク", "2024-01-15",
+			newItem("3番目", 3), newItem("1番目", 1), newItem("2番目", 2))
+		if created.Version != 1 {
+			t.Errorf("Version = %d, want 1", created.Version)
+		}
+
+		got := getTask(t, repos, created.ID)
+		if got.OwnerID != owner.ID || got.Title != "朝のタスク" || got.Date.Format(time.DateOnly) != "2024-01-15" {
+			t.Errorf("GetTaskByID() = {OwnerID: %s, Title: %s, Date: %s}", got.OwnerID, got.Title, got.Date.Format(time.DateOnly))
+		}
+		if contents := itemContents(got); !equalStrings(contents, []string{"1番目", "2番目", "3番目"}) {
+			t.Errorf("TaskItems = %v, want sorted by order", contents)
+		}
+
+		byItem, err := repos.Tasks.GetTaskByTaskItemID(context.Background(), got.TaskItems[0].ID)
+		if err != nil {
+			t.Fatalf("GetTaskByTaskItemID() error = %v", err)
+		}
+		if byItem.ID != created.ID {
+			t.Errorf("GetTaskByTaskItemID() = %s, want %s", byItem.ID, created.ID)
+		}
+	})
+
+	t.Run("ステータスを指定して作成した子タスクに着手日時と完了日時を設定する", func(t *testing.T) {
+		repos := newRepositories(t)
+		ctx := context.Background()
+		owner := newAccount(t, repos, "alice")
+
+		inProgress := newItem("着手中", 1)
+		inProgress.Status = task.StatusInProgress
+		completed := newItem("完了", 2)
+		completed.Status = task.StatusCompleted
+		created := newTask(t, repos, owner.ID, "ステータス", "2024-01-15", inProgress, completed)
+
+		added := newItem("追加", 3)
+		added.Status = task.StatusCompleted
+		if err := repos.Tasks.AddTaskItem(ctx, created.ID, owner.ID, added); err != nil {
+			t.Fatalf("AddTaskItem() error = %v", err)
+		}
+
+		got := getTask(t, repos, created.ID)
+		if item := findItem(t, got, "着手中"); item.StartedAt == nil || item.CompletedAt != nil {
+			t.Errorf("着手中: StartedAt = %v, CompletedAt = %v, want started only", item.StartedAt, item.CompletedAt)
+		}
+		for _, content := range []string{"完了", "追加"} {
+			if item := findItem(t, got, content); item.StartedAt == nil || item.CompletedAt == nil {
+				t.Errorf("%s: StartedAt = %v, CompletedAt = %v, want both set", content, item.StartedAt, item.CompletedAt)
+			}
+		}
+	})
+
+	t.Run("存在しないタスクはNotFound、形式が不正なIDはValidationを返す", func(t *testing.T) {
+		repos := newRepositories(t)
+		ctx := context.Background()
+
+		if _, err := repos.Tasks.GetTaskByID(ctx, uuid.NewString()); !domainerrors.IsNotFound(err) {
+			t.Errorf("GetTaskByID(unknown) error = %v, want NotFound", err)
+		}
+		if _, err := repos.Tasks.GetTaskByTaskItemID(ctx, uuid.NewString()); !domainerrors.IsNotFound(err) {
+			t.Errorf("GetTaskByTaskItemID(unknown) error = %v, want NotFound", err)
+		}
+		if _, err := repos.Tasks.GetTaskByID(ctx, "not-a-uuid"); !domainerrors.IsValidation(err) {
+			t.Errorf("GetTaskByID(invalid) error = %v, want Validation", err)
+		}
+	})
+
+	t.Run("順番が重複する子タスクはConflictを返し、タスクも作成しない", func(t *testing.T) {
+		repos := newRepositories(t)
+		owner := newAccount(t, repos, "alice")
+
+		_, err := repos.Tasks.CreateTask(context.Background(), owner.ID, "重複", "2024-01-15",
+			[]task.CreateTaskItemInput{newItem("a", 1), newItem("b", 1)})
+		if !domainerrors.IsConflict(err) {
+			t.Fatalf("CreateTask() error = %v, want Conflict", err)
+		}
+
+		if ids := listTaskIDs(t, repos, task.ListTasksCondition{OwnerID: &owner.ID}); len(ids) != 0 {
+			t.Errorf("ListTasks() = %v, want no tasks", ids)
+		}
+	})
+
+	t.Run("タスク一覧をオーナーと年月で絞り込む", func(t *testing.T) {
+		repos := newRepositories(t)
+		alice := newAccount(t, repos, "alice")
+		bob := newAccount(t, repos, "bob")
+
+		newTask(t, repos, alice.ID, "1月", "2024-01-31")
+		feb1 := newTask(t, repos, alice.ID, "2月1日", "2024-02-01")
+		feb29 := newTask(t, repos, alice.ID, "2月29日", "2024-02-29")
+		newTask(t, repos, alice.ID, "3月", "2024-03-01")
+		newTask(t, repos, bob.ID, "bobの2月", "2024-02-10")
+
+		got := listTaskIDs(t, repos, task.ListTasksCondition{
+			OwnerID:   &alice.ID,
+			YearMonth: ptr("2024-02"),
+			Sort:      ptr(string(task.SortDateAsc)),
+		})
+		if want := []string{feb1.ID, feb29.ID}; !equalStrings(got, want) {
+			t.Errorf("ListTasks() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("並び順に従って取得し、カーソルで続きを取得できる", func(t *testing.T) {
+		repos := newRepositories(t)
+		owner := newAccount(t, repos, "alice")
+
+		// 作成順はa、b、c。日付の順はb、a、c
+		a := newTask(t, repos, owner.ID, "a", "2024-01-02")
+		b := newTask(t, repos, owner.ID, "b", "2024-01-01")
+		c := newTask(t, repos, owner.ID, "c", "2024-01-03")
+
+		tests := []struct {
+			sort task.SortOrder
+			want []string
+		}{
+			{sort: task.SortNewest, want: []string{c.ID, b.ID, a.ID}},
+			{sort: task.SortOldest, want: []string{a.ID, b.ID, c.ID}},
+			{sort: task.SortDateAsc, want: []string{b.ID, a.ID, c.ID}},
+			{sort: task.SortDateDesc, want: []string{c.ID, a.ID, b.ID}},
+		}
+		for _, tt := range tests {
+			condition := task.ListTasksCondition{OwnerID: &owner.ID, Sort: ptr(string(tt.sort))}
+			if got := listTaskIDs(t, repos, condition); !equalStrings(got, tt.want) {
+				t.Errorf("ListTasks(sort=%s) = %v, want %v", tt.sort, got, tt.want)
+			}
+
+			condition.Limit = 2
+			first, err := repos.Tasks.ListTasks(context.Background(), condition)
+			if err != nil {
+				t.Fatalf("ListTasks(sort=%s, limit=2) error = %v", tt.sort, err)
+			}
+			if got := taskIDs(first); !equalStrings(got, tt.want[:2]) {
+				t.Errorf("ListTasks(sort=%s, limit=2) = %v, want %v", tt.sort, got, tt.want[:2])
+				continue
+			}
+
+			cursor := task.NewListTasksCursor(tt.sort, first[len(first)-1], nil)
+			condition.Cursor = &cursor
+			if got := listTaskIDs(t, repos, condition); !equalStrings(got, tt.want[2:]) {
+				t.Errorf("ListTasks(sort=%s, cursor) = %v, want %v", tt.sort, got, tt.want[2:])
+			}
+		}
+	})
+
+	t.Run("キーワードでタイトル、振り返り、子タスクの内容とアウトプットを検索する", func(t *testing.T) {
+		repos := newRepositories(t)
+		ctx := context.Background()
+		owner := newAccount(t, repos, "alice")
+
+		titled := newTask(t, repos, owner.ID, "Weekly report", "2024-01-01")
+		content := newTask(t, repos, owner.ID, "月曜日", "2024-01-02", newItem("資料を作成する", 1))
+		reviewed := newTask(t, repos, owner.ID, "火曜日", "2024-01-03")
+		if err := repos.Tasks.UpdateTaskReview(ctx, reviewed.ID, owner.ID, ptr("振り返りのメモ"), nil); err != nil {
+			t.Fatalf("UpdateTaskReview() error = %v", err)
+		}
+		output := newTask(t, repos, owner.ID, "水曜日", "2024-01-04")
+		if err := repos.Tasks.UpdateTaskItemOutput(ctx, output.TaskItems[0].ID, owner.ID, "REPORT draft", nil, task.StatusChange{Status: task.StatusNotStarted}, nil); err != nil {
+			t.Fatalf("UpdateTaskItemOutput() error = %v", err)
+		}
+		newTask(t, repos, owner.ID, "関係のないタスク", "2024-01-05")
+
+		tests := []struct {
+			keyword string
+			want    []string
+		}{
+			// 大文字と小文字を区別しない
+			{keyword: "report", want: []string{titled.ID, output.ID}},
+			// 単語に分かれない日本語は部分一致で検索する
+			{keyword: "資料", want: []string{content.ID}},
+			{keyword: "振り返り", want: []string{reviewed.ID}},
+			{keyword: "一致しない", want: []string{}},
+		}
+		for _, tt := range tests {
+			got := listTaskIDs(t, repos, task.ListTasksCondition{
+				OwnerID: &owner.ID,
+				Keyword: ptr(tt.keyword),
+				Sort:    ptr(string(task.SortDateAsc)),
+			})
+			if !equalStrings(got, tt.want) {
+				t.Errorf("ListTasks(q=%s) = %v, want %v", tt.keyword, got, tt.want)
+			}
+		}
+
+		// 関連度順ではタイトルに一致するタスクを先に並べる
+		ranked, err := repos.Tasks.ListTasks(ctx, task.ListTasksCondition{
+			OwnerID: &owner.ID,
+			Keyword: ptr("report"),
+			Sort:    ptr(string(task.SortRelevance)),
+		})
+		if err != nil {
+			t.Fatalf("ListTasks(sort=relevance) error = %v", err)
+		}
+		if got, want := taskIDs(ranked), []string{titled.ID, output.ID}; !equalStrings(got, want) {
+			t.Fatalf("ListTasks(sort=relevance) = %v, want %v", got, want)
+		}
+		if ranked[0].SearchRank <= ranked[1].SearchRank {
+			t.Errorf("SearchRank = %v, %v, want descending", ranked[0].SearchRank, ranked[1].SearchRank)
+		}
+	})
+
+	t.Run("カテゴリで絞り込み、カテゴリを削除すると子タスクは未分類になる", func(t *testing.T) {
+		repos := newRepositories(t)
+		ctx := context.Background()
+		owner := newAccount(t, repos, "alice")
+
+		writing, err := repos.Categories.CreateCategory(ctx, owner.ID, "執筆", "#FF0000")
+		if err != nil {
+			t.Fatalf("CreateCategory() error = %v", err)
+		}
+		item := newItem("記事を書く", 1)
+		item.CategoryID = &writing.ID
+		categorized := newTask(t, repos, owner.ID, "分類あり", "2024-01-01", item)
+		newTask(t, repos, owner.ID, "分類なし", "2024-01-02")
+
+		got := listTaskIDs(t, repos, task.ListTasksCondition{OwnerID: &owner.ID, CategoryID: &writing.ID})
+		if want := []string{categorized.ID}; !equalStrings(got, want) {
+			t.Errorf("ListTasks(category) = %v, want %v", got, want)
+		}
+
+		if err := repos.Categories.DeleteCategory(ctx, writing.ID); err != nil {
+			t.Fatalf("DeleteCategory() error = %v", err)
+		}
+		if categoryID := getTask(t, repos, categorized.ID).TaskItems[0].CategoryID; categoryID != nil {
+			t.Errorf("CategoryID = %s, want nil", *categoryID)
+		}
+	})
+
+	t.Run("タスクの更新で子タスクを保ち、バージョンと変更履歴を更新する", func(t *testing.T) {
+		repos := newRepositories(t)
+		ctx := context.Background()
+		owner := newAccount(t, repos, "alice")
+
+		created := newTask(t, repos, owner.ID, "更新前", "2024-01-15",
+			newItem("a", 1), newItem("b", 2), newItem("c", 3))
+		a := findItem(t, created, "a")
+		b := findItem(t, created, "b")
+
+		// aとbの順番を入れ替え、cを削除してdを追加する
+		items := []task.UpdateTaskItemInput{
+			updateInput(a, "a", 2),
+			updateInput(b, "b（変更）", 1),
+			updateInput(task.TaskItem{Status: task.StatusNotStarted}, "d", 3),
+		}
+
+		if _, err := repos.Tasks.UpdateTask(ctx, uuid.NewString(), owner.ID, "更新後", "2024-01-16", items, nil); !domainerrors.IsNotFound(err) {
+			t.Errorf("UpdateTask(missing task) error = %v, want NotFound", err)
+		}
+		if _, err := repos.Tasks.UpdateTask(ctx, created.ID, owner.ID, "更新後", "2024-01-16", items, ptr(int64(2))); !domainerrors.IsPreconditionFailed(err) {
+			t.Errorf("UpdateTask(stale version) error = %v, want PreconditionFailed", err)
+		}
+
+		updated, err := repos.Tasks.UpdateTask(ctx, created.ID, owner.ID, "更新後", "2024-01-16", items, ptr(int64(1)))
+		if err != nil {
+			t.Fatalf("UpdateTask() error = %v", err)
+		}
+		if updated.Version != 2 {
+			t.Errorf("Version = %d, want 2", updated.Version)
+		}
+
+		got := getTask(t, repos, created.ID)
+		if got.Title != "更新後" || got.Date.Format(time.DateOnly) != "2024-01-16" {
+			t.Errorf("GetTaskByID() = {Title: %s, Date: %s}", got.Title, got.Date.Format(time.DateOnly))
+		}
+		if contents := itemContents(got); !equalStrings(contents, []string{"b（変更）", "a", "d"}) {
+			t.Errorf("TaskItems = %v, want [b（変更） a d]", contents)
+		}
+		if findItem(t, got, "a").ID != a.ID || findItem(t, got, "b（変更）").ID != b.ID {
+			t.Error("UpdateTask() did not keep the IDs of the existing task items")
+		}
+
+		events, err := repos.Tasks.ListTaskEvents(ctx, created.ID)
+		if err != nil {
+			t.Fatalf("ListTaskEvents() error = %v", err)
+		}
+		// 作成時の3件の追加の後に、変更した順に記録される
+		want := []task.EventType{
+			task.EventTypeTaskCreated,
+			task.EventTypeTaskItemAdded,
+			task.EventTypeTaskItemAdded,
+			task.EventTypeTaskItemAdded,
+			task.EventTypeTaskUpdated,
+			task.EventTypeTaskItemUpdated,
+			task.EventTypeTaskItemUpdated,
+			task.EventTypeTaskItemAdded,
+			task.EventTypeTaskItemRemoved,
+		}
+		if got := listEventTypes(t, repos, created.ID); !slices.Equal(got, want) {
+			t.Errorf("event types = %v, want %v", got, want)
+		}
+		for _, e := range events {
+			if e.ActorID != owner.ID {
+				t.Errorf("ActorID = %s, want %s", e.ActorID, owner.ID)
+			}
+		}
+	})
+
+	t.Run("子タスクを追加、保存、削除、並び替えできる", func(t *testing.T) {
+		repos := newRepositories(t)
+		ctx := context.Background()
+		owner := newAccount(t, repos, "alice")
+		created := newTask(t, repos, owner.ID, "子タスク", "2024-01-15", newItem("a", 1))
+
+		if err := repos.Tasks.AddTaskItem(ctx, created.ID, owner.ID, newItem("b", 2)); err != nil {
+			t.Fatalf("AddTaskItem() error = %v", err)
+		}
+		// 順番の重複は失敗し、バージョンも変わらない
+		if err := repos.Tasks.AddTaskItem(ctx, created.ID, owner.ID, newItem("重複", 2)); !domainerrors.IsConflict(err) {
+			t.Errorf("AddTaskItem(duplicate order) error = %v, want Conflict", err)
+		}
+		got := getTask(t, repos, created.ID)
+		if got.Version != 2 {
+			t.Errorf("Version = %d, want 2", got.Version)
+		}
+
+		b := findItem(t, got, "b")
+		b.Content = "b（保存）"
+		b.Priority = task.PriorityHigh
+		if err := repos.Tasks.SaveTaskItem(ctx, b, owner.ID); err != nil {
+			t.Fatalf("SaveTaskItem() error = %v", err)
+		}
+		saved := findItem(t, getTask(t, repos, created.ID), "b（保存）")
+		if saved.Priority != task.PriorityHigh {
+			t.Errorf("Priority = %s, want %s", saved.Priority, task.PriorityHigh)
+		}
+
+		a := findItem(t, got, "a")
+		if err := repos.Tasks.ReorderTaskItems(ctx, created.ID, owner.ID, []task.TaskItemOrder{{TaskItemID: a.ID, Order: 1}}); !domainerrors.IsConflict(err) {
+			t.Errorf("ReorderTaskItems(missing item) error = %v, want Conflict", err)
+		}
+		if err := repos.Tasks.ReorderTaskItems(ctx, created.ID, owner.ID, []task.TaskItemOrder{{TaskItemID: b.ID, Order: 1}, {TaskItemID: a.ID, Order: 2}}); err != nil {
+			t.Fatalf("ReorderTaskItems() error = %v", err)
+		}
+		if contents := itemContents(getTask(t, repos, created.ID)); !equalStrings(contents, []string{"b（保存）", "a"}) {
+			t.Errorf("TaskItems = %v, want [b（保存） a]", contents)
+		}
+
+		if err := repos.Tasks.DeleteTaskItem(ctx, created.ID, a.ID, owner.ID); err != nil {
+			t.Fatalf("DeleteTaskItem() error = %v", err)
+		}
+		if err := repos.Tasks.DeleteTaskItem(ctx, created.ID, a.ID, owner.ID); !domainerrors.IsNotFound(err) {
+			t.Errorf("DeleteTaskItem(deleted) error = %v, want NotFound", err)
+		}
+		if contents := itemContents(getTask(t, repos, created.ID)); !equalStrings(contents, []string{"b（保存）"}) {
+			t.Errorf("TaskItems = %v, want [b（保存）]", contents)
+		}
+
+		// 並び替えは順番が変わった子タスクごとに記録される
+		want := []task.EventType{
+			task.EventTypeTaskCreated,
+			task.EventTypeTaskItemAdded,
+			task.EventTypeTaskItemAdded,
+			task.EventTypeTaskItemUpdated,
+			task.EventTypeTaskItemUpdated,
+			task.EventTypeTaskItemUpdated,
+			task.EventTypeTaskItemRemoved,
+		}
+		if got := listEventTypes(t, repos, created.ID); !slices.Equal(got, want) {
+			t.Errorf("event types = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("振り返りとアウトプットを更新できる", func(t *testing.T) {
+		repos := newRepositories(t)
+		ctx := context.Background()
+		owner := newAccount(t, repos, "alice")
+		created := newTask(t, repos, owner.ID, "振り返り", "2024-01-15")
+
+		if err := repos.Tasks.UpdateTaskReview(ctx, created.ID, owner.ID, ptr("よくできた"), ptr(int64(2))); !domainerrors.IsPreconditionFailed(err) {
+			t.Errorf("UpdateTaskReview(stale version) error = %v, want PreconditionFailed", err)
+		}
+		if err := repos.Tasks.UpdateTaskReview(ctx, created.ID, owner.ID, ptr("よくできた"), ptr(int64(1))); err != nil {
+			t.Fatalf("UpdateTaskReview() error = %v", err)
+		}
+		if review := getTask(t, repos, created.ID).Review; review == nil || *review != "よくできた" {
+			t.Errorf("Review = %v, want よくできた", review)
+		}
+
+		completedAt := at(10, 0)
+		change := task.StatusChange{Status: task.StatusCompleted, StartedAt: ptr(at(9, 0)), CompletedAt: &completedAt}
+		if err := repos.Tasks.UpdateTaskItemOutput(ctx, created.TaskItems[0].ID, owner.ID, "成果物", nil, change, nil); err != nil {
+			t.Fatalf("UpdateTaskItemOutput() error = %v", err)
+		}
+		item := getTask(t, repos, created.ID).TaskItems[0]
+		if item.Output == nil || *item.Output != "成果物" {
+			t.Errorf("Output = %v, want 成果物", item.Output)
+		}
+		if item.Status != task.StatusCompleted || item.CompletedAt == nil || !item.CompletedAt.Equal(completedAt) {
+			t.Errorf("Status = %s, CompletedAt = %v, want %s at %v", item.Status, item.CompletedAt, task.StatusCompleted, completedAt)
+		}
+
+		want := []task.EventType{
+			task.EventTypeTaskCreated,
+			task.EventTypeTaskItemAdded,
+			task.EventTypeTaskReviewUpdated,
+			task.EventTypeTaskItemOutputUpdated,
+		}
+		if got := listEventTypes(t, repos, created.ID); !slices.Equal(got, want) {
+			t.Errorf("event types = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("タイマーのセッションを開始、一時停止できる", func(t *testing.T) {
+		repos := newRepositories(t)
+		ctx := context.Background()
+		owner := newAccount(t, repos, "alice")
+		created := newTask(t, repos, owner.ID, "タイマー", "2024-01-15")
+		itemID := created.TaskItems[0].ID
+
+		start := task.TimerTransition{StartSession: true, Status: task.StatusInProgress}
+		if err := repos.Tasks.ApplyTimerTransition(ctx, itemID, owner.ID, start, at(9, 0)); err != nil {
+			t.Fatalf("ApplyTimerTransition(start) error = %v", err)
+		}
+		if err := repos.Tasks.ApplyTimerTransition(ctx, itemID, owner.ID, start, at(9, 5)); !domainerrors.IsConflict(err) {
+			t.Errorf("ApplyTimerTransition(start twice) error = %v, want Conflict", err)
+		}
+
+		paused := task.TimerEndReasonPaused
+		pause := task.TimerTransition{EndReason: &paused, Status: task.StatusInProgress}
+		if err := repos.Tasks.ApplyTimerTransition(ctx, itemID, owner.ID, pause, at(9, 30)); err != nil {
+			t.Fatalf("ApplyTimerTransition(pause) error = %v", err)
+		}
+
+		item := getTask(t, repos, created.ID).TaskItems[0]
+		if item.Status != task.StatusInProgress || item.StartedAt == nil || !item.StartedAt.Equal(at(9, 0)) {
+			t.Errorf("Status = %s, StartedAt = %v, want %s at %v", item.Status, item.StartedAt, task.StatusInProgress, at(9, 0))
+		}
+		if item.TimerState() != task.TimerStatePaused {
+			t.Errorf("TimerState() = %s, want %s", item.TimerState(), task.TimerStatePaused)
+		}
+		if got := item.ActualDuration(at(12, 0)); got != 30*time.Minute {
+			t.Errorf("ActualDuration() = %v, want 30m", got)
+		}
+
+		// 開始による状態の変更だけが記録され、一時停止は状態が変わらないため記録されない
+		want := []task.EventType{task.EventTypeTaskCreated, task.EventTypeTaskItemAdded, task.EventTypeTaskItemUpdated}
+		if got := listEventTypes(t, repos, created.ID); !slices.Equal(got, want) {
+			t.Errorf("event types = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("子タスクを完了にすると実行中のタイマーを完了日時で停止する", func(t *testing.T) {
+		completedAt := at(10, 0)
+		change := task.StatusChange{Status: task.StatusCompleted, StartedAt: ptr(at(9, 0)), CompletedAt: &completedAt}
+
+		tests := []struct {
+			name     string
+			complete func(ctx context.Context, repos Repositories, ownerID string, created *task.Task) error
+		}{
+			{
+				name: "子タスクの保存",
+				complete: func(ctx context.Context, repos Repositories, ownerID string, created *task.Task) error {
+					item := getTask(t, repos, created.ID).TaskItems[0]
+					change.Apply(&item)
+					return repos.Tasks.SaveTaskItem(ctx, item, ownerID)
+				},
+			},
+			{
+				name: "アウトプットの更新",
+				complete: func(ctx context.Context, repos Repositories, ownerID string, created *task.Task) error {
+					return repos.Tasks.UpdateTaskItemOutput(ctx, created.TaskItems[0].ID, ownerID, "成果物", nil, change, nil)
+				},
+			},
+			{
+				name: "タスクの更新",
+				complete: func(ctx context.Context, repos Repositories, ownerID string, created *task.Task) error {
+					input := updateInput(created.TaskItems[0], created.TaskItems[0].Content, 1)
+					input.Status = change.Status
+					input.StartedAt = change.StartedAt
+					input.CompletedAt = change.CompletedAt
+					_, err := repos.Tasks.UpdateTask(ctx, created.ID, ownerID, created.Title, "2024-01-15", []task.UpdateTaskItemInput{input}, nil)
+					return err
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				repos := newRepositories(t)
+				ctx := context.Background()
+				owner := newAccount(t, repos, "alice")
+				created := newTask(t, repos, owner.ID, "完了", "2024-01-15")
+
+				start := task.TimerTransition{StartSession: true, Status: task.StatusInProgress}
+				if err := repos.Tasks.ApplyTimerTransition(ctx, created.TaskItems[0].ID, owner.ID, start, at(9, 0)); err != nil {
+					t.Fatalf("ApplyTimerTransition(start) error = %v", err)
+				}
+
+				if err := tt.complete(ctx, repos, owner.ID, created); err != nil {
+					t.Fatalf("complete error = %v", err)
+				}
+
+				item := getTask(t, repos, created.ID).TaskItems[0]
+				if item.TimerState() != task.TimerStateIdle {
+					t.Errorf("TimerState() = %s, want %s", item.TimerState(), task.TimerStateIdle)
+				}
+				if got := item.ActualDuration(at(12, 0)); got != time.Hour {
+					t.Errorf("ActualDuration() = %v, want 1h", got)
+				}
+			})
+		}
+	})
+
+	t.Run("子タスクを移動または複製して持ち越せる", func(t *testing.T) {
+		repos := newRepositories(t)
+		ctx := context.Background()
+		owner := newAccount(t, repos, "alice")
+
+		source := newTask(t, repos, owner.ID, "持ち越し元", "2024-01-15", newItem("x", 1), newItem("y", 2))
+		target := newTask(t, repos, owner.ID, "持ち越し先", "2024-01-16", newItem("z", 1))
+		x := findItem(t, source, "x")
+		y := findItem(t, source, "y")
+
+		movedResult, err := repos.Tasks.CarryOverTaskItems(ctx, task.CarryOverInput{
+			SourceTaskID: source.ID,
+			OwnerID:      owner.ID,
+			Title:        source.Title,
+			Date:         time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+			TaskItems:    []task.TaskItem{x},
+			Mode:         task.CarryOverModeMove,
+		})
+		if err != nil {
+			t.Fatalf("CarryOverTaskItems(move) error = %v", err)
+		}
+		moved := movedResult.Target
+		if moved.ID != target.ID {
+			t.Errorf("CarryOverTaskItems(move) = %s, want existing task %s", moved.ID, target.ID)
+		}
+		if contents := itemContents(moved); !equalStrings(contents, []string{"z", "x"}) {
+			t.Errorf("TaskItems = %v, want [z x]", contents)
+		}
+		if before := movedResult.TargetBefore; before == nil || before.ID != target.ID || !equalStrings(itemContents(before), []string{"z"}) {
+			t.Errorf("TargetBefore = %+v, want the existing task before carrying over", before)
+		}
+		movedX := findItem(t, moved, "x")
+		if movedX.ID != x.ID || movedX.CarriedOverFromTaskID == nil || *movedX.CarriedOverFromTaskID != source.ID {
+			t.Errorf("moved item = {ID: %s, CarriedOverFromTaskID: %v}, want {ID: %s, CarriedOverFromTaskID: %s}", movedX.ID, movedX.CarriedOverFromTaskID, x.ID, source.ID)
+		}
+		if contents := itemContents(getTask(t, repos, source.ID)); !equalStrings(contents, []string{"y"}) {
+			t.Errorf("source TaskItems = %v, want [y]", contents)
+		}
+
+		copiedResult, err := repos.Tasks.CarryOverTaskItems(ctx, task.CarryOverInput{
+			SourceTaskID: source.ID,
+			OwnerID:      owner.ID,
+			Title:        source.Title,
+			Date:         time.Date(2024, 1, 17, 0, 0, 0, 0, time.UTC),
+			TaskItems:    []task.TaskItem{y},
+			Mode:         task.CarryOverModeCopy,
+		})
+		if err != nil {
+			t.Fatalf("CarryOverTaskItems(copy) error = %v", err)
+		}
+		copied := copiedResult.Target
+		if copiedResult.TargetBefore != nil {
+			t.Errorf("TargetBefore = %+v, want nil for a created task", copiedResult.TargetBefore)
+		}
+		if copied.ID == source.ID || copied.ID == target.ID || copied.Title != source.Title || copied.Date.Format(time.DateOnly) != "2024-01-17" {
+			t.Errorf("CarryOverTaskItems(copy) = {ID: %s, Title: %s, Date: %s}, want a new task", copied.ID, copied.Title, copied.Date.Format(time.DateOnly))
+		}
+		if copiedY := findItem(t, copied, "y"); copiedY.ID == y.ID {
+			t.Error("copied item has the same ID as the source item")
+		}
+		if contents := itemContents(getTask(t, repos, source.ID)); !equalStrings(contents, []string{"y"}) {
+			t.Errorf("source TaskItems = %v, want [y]", contents)
+		}
+
+		// 移動は持ち越し元からの削除と持ち越し先への追加、複製は作成したタスクへの追加として記録される
+		tests := []struct {
+			name   string
+			taskID string
+			want   []task.EventType
+		}{
+			{
+				name:   "持ち越し元",
+				taskID: source.ID,
+				want:   []task.EventType{task.EventTypeTaskCreated, task.EventTypeTaskItemAdded, task.EventTypeTaskItemAdded, task.EventTypeTaskItemRemoved},
+			},
+			{
+				name:   "移動先の既存のタスク",
+				taskID: target.ID,
+				want:   []task.EventType{task.EventTypeTaskCreated, task.EventTypeTaskItemAdded, task.EventTypeTaskItemAdded},
+			},
+			{
+				name:   "複製で作成したタスク",
+				taskID: copied.ID,
+				want:   []task.EventType{task.EventTypeTaskCreated, task.EventTypeTaskItemAdded},
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				if got := listEventTypes(t, repos, tt.taskID); !slices.Equal(got, tt.want) {
+					t.Errorf("event types = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("ゴミ箱に移動、復元、完全に削除できる", func(t *testing.T) {
+		repos := newRepositories(t)
+		ctx := context.Background()
+		owner := newAccount(t, repos, "alice")
+
+		trashed := newTask(t, repos, owner.ID, "削除するタスク", "2024-01-15", newItem("持ち越す", 1), newItem("残す", 2))
+		carried := findItem(t, trashed, "持ち越す")
+		result, err := repos.Tasks.CarryOverTaskItems(ctx, task.CarryOverInput{
+			SourceTaskID: trashed.ID,
+			OwnerID:      owner.ID,
+			Title:        "持ち越し先",
+			Date:         time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+			TaskItems:    []task.TaskItem{carried},
+			Mode:         task.CarryOverModeMove,
+		})
+		if err != nil {
+			t.Fatalf("CarryOverTaskItems() error = %v", err)
+		}
+		target := result.Target
+		remaining := findItem(t, getTask(t, repos, trashed.ID), "残す")
+
+		if err := repos.Tasks.DeleteTask(ctx, trashed.ID, owner.ID, ptr(int64(1))); !domainerrors.IsPreconditionFailed(err) {
+			t.Errorf("DeleteTask(stale version) error = %v, want PreconditionFailed", err)
+		}
+		if err := repos.Tasks.DeleteTask(ctx, trashed.ID, owner.ID, nil); err != nil {
+			t.Fatalf("DeleteTask() error = %v", err)
+		}
+
+		// ゴミ箱のタスクは存在しないものとして扱う
+		if _, err := repos.Tasks.GetTaskByID(ctx, trashed.ID); !domainerrors.IsNotFound(err) {
+			t.Errorf("GetTaskByID(trashed) error = %v, want NotFound", err)
+		}
+		if ids := listTaskIDs(t, repos, task.ListTasksCondition{OwnerID: &owner.ID, Keyword: ptr("削除")}); len(ids) != 0 {
+			t.Errorf("ListTasks(q=削除) = %v, want no tasks", ids)
+		}
+		events, err := repos.Tasks.ListTaskEvents(ctx, trashed.ID)
+		if err != nil {
+			t.Fatalf("ListTaskEvents(trashed) error = %v", err)
+		}
+		if len(events) != 0 {
+			t.Errorf("ListTaskEvents(trashed) = %d events, want none", len(events))
+		}
+
+		trash, err := repos.Tasks.ListTrashedTasks(ctx, owner.ID)
+		if err != nil {
+			t.Fatalf("ListTrashedTasks() error = %v", err)
+		}
+		if got := taskIDs(trash); !equalStrings(got, []string{trashed.ID}) || trash[0].DeletedAt == nil {
+			t.Errorf("ListTrashedTasks() = %v, want [%s] with DeletedAt", got, trashed.ID)
+		}
+
+		if err := repos.Tasks.RestoreTask(ctx, trashed.ID, owner.ID); err != nil {
+			t.Fatalf("RestoreTask() error = %v", err)
+		}
+		if restored := getTask(t, repos, trashed.ID); restored.DeletedAt != nil {
+			t.Errorf("DeletedAt = %v, want nil", restored.DeletedAt)
+		}
+		if _, err := repos.Tasks.GetTrashedTaskByID(ctx, trashed.ID); !domainerrors.IsNotFound(err) {
+			t.Errorf("GetTrashedTaskByID(restored) error = %v, want NotFound", err)
+		}
+		// ゴミ箱にある間の履歴は取得できないが、復元すると移動と復元の履歴も取得できる
+		want := []task.EventType{
+			task.EventTypeTaskCreated,
+			task.EventTypeTaskItemAdded,
+			task.EventTypeTaskItemAdded,
+			task.EventTypeTaskItemRemoved,
+			task.EventTypeTaskDeleted,
+			task.EventTypeTaskRestored,
+		}
+		if got := listEventTypes(t, repos, trashed.ID); !slices.Equal(got, want) {
+			t.Errorf("event types = %v, want %v", got, want)
+		}
+
+		// 完全に削除すると子タスクも削除され、持ち越し先からの参照はなくなる
+		if err := repos.Tasks.DeleteTask(ctx, trashed.ID, owner.ID, nil); err != nil {
+			t.Fatalf("DeleteTask() error = %v", err)
+		}
+		if err := repos.Tasks.PurgeTask(ctx, trashed.ID, owner.ID); err != nil {
+			t.Fatalf("PurgeTask() error = %v", err)
+		}
+		if _, err := repos.Tasks.GetTrashedTaskByID(ctx, trashed.ID); !domainerrors.IsNotFound(err) {
+			t.Errorf("GetTrashedTaskByID(purged) error = %v, want NotFound", err)
+		}
+		if _, err := repos.Tasks.GetTaskByTaskItemID(ctx, remaining.ID); !domainerrors.IsNotFound(err) {
+			t.Errorf("GetTaskByTaskItemID(purged item) error = %v, want NotFound", err)
+		}
+		if err := repos.Tasks.RestoreTask(ctx, trashed.ID, owner.ID); !domainerrors.IsNotFound(err) {
+			t.Errorf("RestoreTask(purged) error = %v, want NotFound", err)
+		}
+		// 完全に削除したタスクの履歴は監査のために残すが、取得はできない
+		if events, err := repos.Tasks.ListTaskEvents(ctx, trashed.ID); err != nil || len(events) != 0 {
+			t.Errorf("ListTaskEvents(purged) = %d events, err = %v, want none", len(events), err)
+		}
+		if from := findItem(t, getTask(t, repos, target.ID), "持ち越す").CarriedOverFromTaskID; from != nil {
+			t.Errorf("CarriedOverFromTaskID = %s, want nil", *from)
+		}
+	})
+
+	t.Run("指定した日時より前にゴミ箱に移動したタスクをまとめて削除する", func(t *testing.T) {
+		repos := newRepositories(t)
+		ctx := context.Background()
+		owner := newAccount(t, repos, "alice")
+
+		for _, date := range []string{"2024-01-01", "2024-01-02"} {
+			trashed := newTask(t, repos, owner.ID, date, date)
+			if err := repos.Tasks.DeleteTask(ctx, trashed.ID, owner.ID, nil); err != nil {
+				t.Fatalf("DeleteTask() error = %v", err)
+			}
+		}
+		kept := newTask(t, repos, owner.ID, "残すタスク", "2024-01-03")
+
+		purged, err := repos.Tasks.PurgeTrashedTasks(ctx, time.Now().Add(-time.Hour))
+		if err != nil {
+			t.Fatalf("PurgeTrashedTasks(past) error = %v", err)
+		}
+		if purged != 0 {
+			t.Errorf("PurgeTrashedTasks(past) = %d, want 0", purged)
+		}
+
+		purged, err = repos.Tasks.PurgeTrashedTasks(ctx, time.Now().Add(time.Hour))
+		if err != nil {
+			t.Fatalf("PurgeTrashedTasks() error = %v", err)
+		}
+		if purged != 2 {
+			t.Errorf("PurgeTrashedTasks() = %d, want 2", purged)
+		}
+		trash, err := repos.Tasks.ListTrashedTasks(ctx, owner.ID)
+		if err != nil {
+			t.Fatalf("ListTrashedTasks() error = %v", err)
+		}
+		if len(trash) != 0 {
+			t.Errorf("ListTrashedTasks() = %v, want no tasks", taskIDs(trash))
+		}
+		getTask(t, repos, kept.ID)
+	})
+
+	t.Run("トランザクション内の変更はエラーで破棄し、成功した場合のみ反映する", func(t *testing.T) {
+		repos := newRepositories(t)
+		ctx := context.Background()
+		owner := newAccount(t, repos, "alice")
+		errRollback := errors.New("rollback")
+
+		err := repos.TxManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			created := newTaskInContext(t, ctx, repos, owner.ID, "破棄するタスク")
+			// トランザクション内では自身の変更を読み取れる
+			if _, err := repos.Tasks.GetTaskByID(ctx, created.ID); err != nil {
+				t.Errorf("GetTaskByID() in transaction error = %v", err)
+			}
+			return errRollback
+		})
+		if !errors.Is(err, errRollback) {
+			t.Fatalf("WithinTransaction() error = %v, want %v", err, errRollback)
+		}
+		if ids := listTaskIDs(t, repos, task.ListTasksCondition{OwnerID: &owner.ID}); len(ids) != 0 {
+			t.Errorf("ListTasks() = %v, want no tasks after rollback", ids)
+		}
+
+		var committed *task.Task
+		err = repos.TxManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			committed = newTaskInContext(t, ctx, repos, owner.ID, "反映するタスク")
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("WithinTransaction() error = %v", err)
+		}
+		getTask(t, repos, committed.ID)
+	})
+}
+
+// updateInput 既存の子タスクを更新する入力を作成（IDが空の場合は新しい子タスクを追加する）
+func updateInput(item task.TaskItem, content string, order int32) task.UpdateTaskItemInput {
+	return task.UpdateTaskItemInput{
+		ID:           item.ID,
+		Priority:     task.PriorityMedium,
+		Density:      task.DensityMedium,
+		DurationTime: task.DurationTime30,
+		Content:      content,
+		Order:        order,
+		Status:       item.Status,
+	}
+}
+
+// newTaskInContext トランザクションのコンテキストでタスクを作成
+func newTaskInContext(t *testing.T, ctx context.Context, repos Repositories, ownerID string, title string) *task.Task {
+	t.Helper()
+
+	created, err := repos.Tasks.CreateTask(ctx, ownerID, title, "2024-01-15", []task.CreateTaskItemInput{newItem(title, 1)})
+	if err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+	return created
+}
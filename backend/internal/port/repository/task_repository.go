@@ -2,24 +2,58 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"task-management-system/backend/internal/domain/account"
+	"task-management-system/backend/internal/domain/outputtemplate"
 	"task-management-system/backend/internal/domain/task"
 )
 
 // TaskRepository タスクリポジトリインターフェース
+// 対象のタスクが存在しない場合はNotFound、IDの形式が不正な場合はValidationのドメインエラーを返す
+// タスクを作成・変更する操作（ownerIDまたはactorIDを受け取る操作）は、同じトランザクションで変更履歴を記録する
+// タスクアイテムを完了として保存する操作は、同じトランザクションで実行中のタイマーを完了日時で停止する
+// オーナーの確認はユースケースで行い、リポジトリでは確認しない
+// expectedVersionを受け取る操作は、タスクのバージョンが一致しない場合にPreconditionFailedのドメインエラーを返す（nilの場合は確認しない）
 type TaskRepository interface {
 	ListTasks(ctx context.Context, condition task.ListTasksCondition) ([]*task.Task, error)
 	GetTaskByID(ctx context.Context, taskID string) (*task.Task, error)
 	GetTaskByTaskItemID(ctx context.Context, taskItemID string) (*task.Task, error)
+	// LockTask トランザクションの終了までタスクの行をロックし、同じタスクへの更新を直列化する（トランザクション外ではロックしない）
+	LockTask(ctx context.Context, taskID string) error
 	CreateTask(ctx context.Context, ownerID string, title string, date string, taskItems []task.CreateTaskItemInput) (*task.Task, error)
-	UpdateTask(ctx context.Context, taskID string, ownerID string, title string, date string, taskItems []task.UpdateTaskItemInput) (*task.Task, error)
-	UpdateTaskReview(ctx context.Context, taskID string, review *string) error
-	UpdateTaskItemOutput(ctx context.Context, taskItemID string, output string) error
-	DeleteTask(ctx context.Context, taskID string) error
+	// CreateRecurringTask 同じ繰り返しテンプレートと日付のタスクが既に作成されている場合はConflictのドメインエラーを返す
+	CreateRecurringTask(ctx context.Context, recurringTemplateID string, ownerID string, title string, date time.Time, taskItems []task.CreateTaskItemInput) (*task.Task, error)
+	// CarryOverTaskItems 持ち越し先のタスクがない場合は作成し、持ち越す前と後の持ち越し先のタスクを返す
+	CarryOverTaskItems(ctx context.Context, input task.CarryOverInput) (*task.CarryOverResult, error)
+	UpdateTask(ctx context.Context, taskID string, actorID string, title string, date string, taskItems []task.UpdateTaskItemInput, expectedVersion *int64) (*task.Task, error)
+	AddTaskItem(ctx context.Context, taskID string, actorID string, input task.CreateTaskItemInput) error
+	// SaveTaskItem アウトプットとタイマーのセッション以外の項目を保存する
+	SaveTaskItem(ctx context.Context, taskItem task.TaskItem, actorID string) error
+	DeleteTaskItem(ctx context.Context, taskID string, taskItemID string, actorID string) error
+	// ReorderTaskItems タスクアイテムが指定した順番以外に追加・削除されている場合はConflictのドメインエラーを返す
+	ReorderTaskItems(ctx context.Context, taskID string, actorID string, orders []task.TaskItemOrder) error
+	UpdateTaskReview(ctx context.Context, taskID string, actorID string, review *string, expectedVersion *int64) error
+	UpdateTaskItemOutput(ctx context.Context, taskItemID string, actorID string, output string, sections []outputtemplate.FilledSection, change task.StatusChange, expectedVersion *int64) error
+	ApplyTimerTransition(ctx context.Context, taskItemID string, actorID string, transition task.TimerTransition, at time.Time) error
+	// DeleteTask タスクをゴミ箱に移動する。ゴミ箱のタスクは他のメソッドでは存在しないものとして扱う
+	DeleteTask(ctx context.Context, taskID string, actorID string, expectedVersion *int64) error
+	ListTrashedTasks(ctx context.Context, ownerID string) ([]*task.Task, error)
+	// GetTrashedTaskByID ゴミ箱にないタスクはNotFoundのドメインエラーを返す
+	GetTrashedTaskByID(ctx context.Context, taskID string) (*task.Task, error)
+	RestoreTask(ctx context.Context, taskID string, actorID string) error
+	// PurgeTask ゴミ箱のタスクを子タスクと合わせて完全に削除する（変更履歴は残す）
+	PurgeTask(ctx context.Context, taskID string, actorID string) error
+	// PurgeTrashedTasks deletedBeforeより前にゴミ箱に移動したタスクを完全に削除し、削除した件数を返す
+	// 保持期間による削除は、タスクのオーナーの操作として変更履歴に記録する
+	PurgeTrashedTasks(ctx context.Context, deletedBefore time.Time) (int64, error)
+	// ListTaskEvents タスクの変更履歴を古い順に取得する
+	// ゴミ箱のタスクと完全に削除したタスクの履歴は返さない（完全に削除したタスクの履歴は監査のために記録のみ行う）
+	ListTaskEvents(ctx context.Context, taskID string) ([]task.Event, error)
 }
 
 // AccountRepository アカウントリポジトリインターフェース
+// 対象のアカウントが存在しない場合はNotFoundのドメインエラーを返す
 type AccountRepository interface {
 	GetAccountsByIDs(ctx context.Context, accountIDs []string) ([]*account.Account, error)
 	GetAccountByID(ctx context.Context, accountID string) (*account.Account, error)
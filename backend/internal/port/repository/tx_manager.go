@@ -0,0 +1,13 @@
+package repository
+
+import "context"
+
+// TxManager トランザクション管理インターフェース
+// 複数のリポジトリにまたがる読み取り・確認・書き込みを1つのトランザクションで実行するために使用する
+type TxManager interface {
+	// WithinTransaction fnを1つのトランザクションで実行する
+	// fnに渡すコンテキストでリポジトリを呼び出すと同じトランザクションで実行され、fnがエラーを返した場合はロールバックする
+	// 既にトランザクション内の場合は、そのトランザクションに参加する
+	// トランザクション内でエラーになったSQLの後は同じトランザクションを使えないため、fnの中でリポジトリのエラーを無視して処理を続けない
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
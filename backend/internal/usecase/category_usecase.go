@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+
+	"task-management-system/backend/internal/domain/category"
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/port/repository"
+)
+
+// CategoryUsecase カテゴリユースケース
+type CategoryUsecase struct {
+	categoryRepo repository.CategoryRepository
+}
+
+// NewCategoryUsecase カテゴリユースケースを作成
+func NewCategoryUsecase(categoryRepo repository.CategoryRepository) *CategoryUsecase {
+	return &CategoryUsecase{
+		categoryRepo: categoryRepo,
+	}
+}
+
+// ListCategories 自分のカテゴリ一覧を取得
+func (u *CategoryUsecase) ListCategories(ctx context.Context, ownerID string) ([]*category.Category, error) {
+	return u.categoryRepo.ListCategories(ctx, ownerID)
+}
+
+// GetCategoryByID カテゴリIDでカテゴリを取得
+// 他のアカウントのカテゴリは存在しないものとして扱う
+func (u *CategoryUsecase) GetCategoryByID(ctx context.Context, categoryID string, ownerID string) (*category.Category, error) {
+	c, err := u.categoryRepo.GetCategoryByID(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if c.OwnerID != ownerID {
+		return nil, domainerrors.NotFound("Category not found")
+	}
+
+	return c, nil
+}
+
+// CreateCategory カテゴリを作成（同じオーナーに同名のカテゴリがある場合はConflictエラー）
+func (u *CategoryUsecase) CreateCategory(ctx context.Context, ownerID string, name string, color string) (*category.Category, error) {
+	return u.categoryRepo.CreateCategory(ctx, ownerID, name, color)
+}
+
+// UpdateCategory カテゴリを更新
+func (u *CategoryUsecase) UpdateCategory(ctx context.Context, categoryID string, ownerID string, name string, color string) (*category.Category, error) {
+	// 既存のカテゴリを取得してオーナーチェック
+	existingCategory, err := u.categoryRepo.GetCategoryByID(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if existingCategory.OwnerID != ownerID {
+		return nil, domainerrors.Forbidden("You do not have permission to update this category")
+	}
+
+	return u.categoryRepo.UpdateCategory(ctx, categoryID, name, color)
+}
+
+// DeleteCategory カテゴリを削除（カテゴリが設定されていたタスクアイテムは未分類になる）
+func (u *CategoryUsecase) DeleteCategory(ctx context.Context, categoryID string, ownerID string) error {
+	// 既存のカテゴリを取得してオーナーチェック
+	existingCategory, err := u.categoryRepo.GetCategoryByID(ctx, categoryID)
+	if err != nil {
+		return err
+	}
+	if existingCategory.OwnerID != ownerID {
+		return domainerrors.Forbidden("You do not have permission to delete this category")
+	}
+
+	return u.categoryRepo.DeleteCategory(ctx, categoryID)
+}
+
+// ensureCategoriesOwnedBy タスクアイテムに設定するカテゴリがすべてオーナーのものであることを確認
+// nilのカテゴリ（未分類）は無視する。存在しないカテゴリや他のアカウントのカテゴリはValidationエラーにする
+func ensureCategoriesOwnedBy(ctx context.Context, categoryRepo repository.CategoryRepository, ownerID string, categoryIDs []*string) error {
+	ids := make([]string, 0, len(categoryIDs))
+	seen := make(map[string]bool, len(categoryIDs))
+	for _, id := range categoryIDs {
+		if id == nil || seen[*id] {
+			continue
+		}
+		seen[*id] = true
+		ids = append(ids, *id)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	categories, err := categoryRepo.GetCategoriesByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	owned := make(map[string]bool, len(categories))
+	for _, c := range categories {
+		if c.OwnerID == ownerID {
+			owned[c.ID] = true
+		}
+	}
+	for _, id := range ids {
+		if !owned[id] {
+			return domainerrors.Validation("Category not found").WithDetails(map[string]string{
+				"categoryId": id,
+			})
+		}
+	}
+
+	return nil
+}
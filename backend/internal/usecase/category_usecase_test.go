@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"task-management-system/backend/internal/domain/category"
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/port/repository"
+)
+
+const (
+	aliceCategoryID = "c1a2b3c4-d5e6-4f70-8a9b-0c1d2e3f4a01"
+	bobCategoryID   = "c2b3c4d5-e6f7-4a81-9b0c-1d2e3f4a5b02"
+)
+
+// fakeCategoryRepository テスト用のカテゴリリポジトリ
+type fakeCategoryRepository struct {
+	repository.CategoryRepository
+	categories []*category.Category
+	deletedIDs []string
+}
+
+func (r *fakeCategoryRepository) ListCategories(ctx context.Context, ownerID string) ([]*category.Category, error) {
+	result := []*category.Category{}
+	for _, c := range r.categories {
+		if c.OwnerID == ownerID {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeCategoryRepository) GetCategoryByID(ctx context.Context, categoryID string) (*category.Category, error) {
+	for _, c := range r.categories {
+		if c.ID == categoryID {
+			return c, nil
+		}
+	}
+	return nil, domainerrors.NotFound("Category not found")
+}
+
+func (r *fakeCategoryRepository) GetCategoriesByIDs(ctx context.Context, categoryIDs []string) ([]*category.Category, error) {
+	result := []*category.Category{}
+	for _, c := range r.categories {
+		for _, id := range categoryIDs {
+			if c.ID == id {
+				result = append(result, c)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeCategoryRepository) UpdateCategory(ctx context.Context, categoryID string, name string, color string) (*category.Category, error) {
+	c, err := r.GetCategoryByID(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	updated := *c
+	updated.Name = name
+	updated.Color = color
+	return &updated, nil
+}
+
+func (r *fakeCategoryRepository) DeleteCategory(ctx context.Context, categoryID string) error {
+	r.deletedIDs = append(r.deletedIDs, categoryID)
+	return nil
+}
+
+func newTestCategoryRepository() *fakeCategoryRepository {
+	return &fakeCategoryRepository{
+		categories: []*category.Category{
+			{ID: aliceCategoryID, OwnerID: aliceID, Name: "思考", Color: "#3366FF"},
+			{ID: bobCategoryID, OwnerID: bobID, Name: "執筆", Color: "#FF6633"},
+		},
+	}
+}
+
+func TestCategoryUsecase_Ownership(t *testing.T) {
+	tests := []struct {
+		name       string
+		categoryID string
+		wantErr    func(error) bool
+	}{
+		{name: "自分のカテゴリ", categoryID: aliceCategoryID},
+		{name: "他のアカウントのカテゴリ", categoryID: bobCategoryID, wantErr: domainerrors.IsForbidden},
+		{name: "存在しないカテゴリ", categoryID: "c3c4d5e6-f7a8-4b92-8c1d-2e3f4a5b6c03", wantErr: domainerrors.IsNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestCategoryRepository()
+			u := NewCategoryUsecase(repo)
+
+			_, updateErr := u.UpdateCategory(context.Background(), tt.categoryID, aliceID, "インプット", "#00AA55")
+			deleteErr := u.DeleteCategory(context.Background(), tt.categoryID, aliceID)
+
+			if tt.wantErr == nil {
+				if updateErr != nil || deleteErr != nil {
+					t.Fatalf("unexpected error: update=%v, delete=%v", updateErr, deleteErr)
+				}
+				if len(repo.deletedIDs) != 1 {
+					t.Errorf("deleted %v, want [%s]", repo.deletedIDs, tt.categoryID)
+				}
+				return
+			}
+
+			if !tt.wantErr(updateErr) || !tt.wantErr(deleteErr) {
+				t.Errorf("unexpected error: update=%v, delete=%v", updateErr, deleteErr)
+			}
+			if len(repo.deletedIDs) != 0 {
+				t.Errorf("category must not be deleted: %v", repo.deletedIDs)
+			}
+		})
+	}
+}
+
+func TestCategoryUsecase_GetCategoryByID_OtherOwner(t *testing.T) {
+	u := NewCategoryUsecase(newTestCategoryRepository())
+
+	if _, err := u.GetCategoryByID(context.Background(), bobCategoryID, aliceID); !domainerrors.IsNotFound(err) {
+		t.Errorf("err = %v, want NotFound", err)
+	}
+}
+
+func TestEnsureCategoriesOwnedBy(t *testing.T) {
+	aliceCategory := aliceCategoryID
+	bobCategory := bobCategoryID
+	missingCategory := "c3c4d5e6-f7a8-4b92-8c1d-2e3f4a5b6c03"
+
+	tests := []struct {
+		name           string
+		categoryIDs    []*string
+		wantValidation bool
+	}{
+		{name: "未分類のみ", categoryIDs: []*string{nil, nil}},
+		{name: "自分のカテゴリ", categoryIDs: []*string{&aliceCategory, nil, &aliceCategory}},
+		{name: "他のアカウントのカテゴリ", categoryIDs: []*string{&aliceCategory, &bobCategory}, wantValidation: true},
+		{name: "存在しないカテゴリ", categoryIDs: []*string{&missingCategory}, wantValidation: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ensureCategoriesOwnedBy(context.Background(), newTestCategoryRepository(), aliceID, tt.categoryIDs)
+			if tt.wantValidation {
+				if !domainerrors.IsValidation(err) {
+					t.Errorf("err = %v, want Validation", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/outputtemplate"
+	"task-management-system/backend/internal/port/repository"
+)
+
+// OutputTemplateUsecase アウトプットテンプレートユースケース
+type OutputTemplateUsecase struct {
+	outputTemplateRepo repository.OutputTemplateRepository
+}
+
+// NewOutputTemplateUsecase アウトプットテンプレートユースケースを作成
+func NewOutputTemplateUsecase(outputTemplateRepo repository.OutputTemplateRepository) *OutputTemplateUsecase {
+	return &OutputTemplateUsecase{
+		outputTemplateRepo: outputTemplateRepo,
+	}
+}
+
+// ListOutputTemplates 自分のテンプレート一覧を取得
+func (u *OutputTemplateUsecase) ListOutputTemplates(ctx context.Context, ownerID string) ([]*outputtemplate.OutputTemplate, error) {
+	return u.outputTemplateRepo.ListOutputTemplates(ctx, ownerID)
+}
+
+// GetOutputTemplateByID テンプレートIDでテンプレートを取得
+// 他のアカウントのテンプレートは存在しないものとして扱う
+func (u *OutputTemplateUsecase) GetOutputTemplateByID(ctx context.Context, outputTemplateID string, ownerID string) (*outputtemplate.OutputTemplate, error) {
+	t, err := u.outputTemplateRepo.GetOutputTemplateByID(ctx, outputTemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if t.OwnerID != ownerID {
+		return nil, domainerrors.NotFound("Output template not found")
+	}
+
+	return t, nil
+}
+
+// CreateOutputTemplate テンプレートを作成（同じオーナーに同名のテンプレートがある場合はConflictエラー）
+func (u *OutputTemplateUsecase) CreateOutputTemplate(ctx context.Context, ownerID string, name string, sections []outputtemplate.Section) (*outputtemplate.OutputTemplate, error) {
+	if err := outputtemplate.Validate(name, sections); err != nil {
+		return nil, err
+	}
+
+	return u.outputTemplateRepo.CreateOutputTemplate(ctx, ownerID, name, sections)
+}
+
+// UpdateOutputTemplate テンプレートを更新
+func (u *OutputTemplateUsecase) UpdateOutputTemplate(ctx context.Context, outputTemplateID string, ownerID string, name string, sections []outputtemplate.Section) (*outputtemplate.OutputTemplate, error) {
+	if err := outputtemplate.Validate(name, sections); err != nil {
+		return nil, err
+	}
+
+	// 既存のテンプレートを取得してオーナーチェック
+	existingTemplate, err := u.outputTemplateRepo.GetOutputTemplateByID(ctx, outputTemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if existingTemplate.OwnerID != ownerID {
+		return nil, domainerrors.Forbidden("You do not have permission to update this output template")
+	}
+
+	return u.outputTemplateRepo.UpdateOutputTemplate(ctx, outputTemplateID, name, sections)
+}
+
+// DeleteOutputTemplate テンプレートを削除（テンプレートが設定されていたタスクアイテムは自由形式になる）
+func (u *OutputTemplateUsecase) DeleteOutputTemplate(ctx context.Context, outputTemplateID string, ownerID string) error {
+	// 既存のテンプレートを取得してオーナーチェック
+	existingTemplate, err := u.outputTemplateRepo.GetOutputTemplateByID(ctx, outputTemplateID)
+	if err != nil {
+		return err
+	}
+	if existingTemplate.OwnerID != ownerID {
+		return domainerrors.Forbidden("You do not have permission to delete this output template")
+	}
+
+	return u.outputTemplateRepo.DeleteOutputTemplate(ctx, outputTemplateID)
+}
+
+// ensureOutputTemplatesOwnedBy タスクアイテムに設定するテンプレートがすべてオーナーのものであることを確認
+// nilのテンプレート（自由形式）は無視する。存在しないテンプレートや他のアカウントのテンプレートはValidationエラーにする
+func ensureOutputTemplatesOwnedBy(ctx context.Context, outputTemplateRepo repository.OutputTemplateRepository, ownerID string, outputTemplateIDs []*string) error {
+	ids := make([]string, 0, len(outputTemplateIDs))
+	seen := make(map[string]bool, len(outputTemplateIDs))
+	for _, id := range outputTemplateIDs {
+		if id == nil || seen[*id] {
+			continue
+		}
+		seen[*id] = true
+		ids = append(ids, *id)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	templates, err := outputTemplateRepo.GetOutputTemplatesByIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	owned := make(map[string]bool, len(templates))
+	for _, t := range templates {
+		if t.OwnerID == ownerID {
+			owned[t.ID] = true
+		}
+	}
+	for _, id := range ids {
+		if !owned[id] {
+			return domainerrors.Validation("Output template not found").WithDetails(map[string]string{
+				"outputTemplateId": id,
+			})
+		}
+	}
+
+	return nil
+}
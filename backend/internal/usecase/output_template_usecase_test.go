@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/outputtemplate"
+	"task-management-system/backend/internal/port/repository"
+)
+
+const (
+	aliceOutputTemplateID = "d1a2b3c4-d5e6-4f70-8a9b-0c1d2e3f4a01"
+	bobOutputTemplateID   = "d2b3c4d5-e6f7-4a81-9b0c-1d2e3f4a5b02"
+)
+
+// fakeOutputTemplateRepository テスト用のアウトプットテンプレートリポジトリ
+type fakeOutputTemplateRepository struct {
+	repository.OutputTemplateRepository
+	templates []*outputtemplate.OutputTemplate
+}
+
+func (r *fakeOutputTemplateRepository) ListOutputTemplates(ctx context.Context, ownerID string) ([]*outputtemplate.OutputTemplate, error) {
+	result := []*outputtemplate.OutputTemplate{}
+	for _, t := range r.templates {
+		if t.OwnerID == ownerID {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeOutputTemplateRepository) GetOutputTemplateByID(ctx context.Context, outputTemplateID string) (*outputtemplate.OutputTemplate, error) {
+	for _, t := range r.templates {
+		if t.ID == outputTemplateID {
+			return t, nil
+		}
+	}
+	return nil, domainerrors.NotFound("Output template not found")
+}
+
+func (r *fakeOutputTemplateRepository) GetOutputTemplatesByIDs(ctx context.Context, outputTemplateIDs []string) ([]*outputtemplate.OutputTemplate, error) {
+	result := []*outputtemplate.OutputTemplate{}
+	for _, t := range r.templates {
+		for _, id := range outputTemplateIDs {
+			if t.ID == id {
+				result = append(result, t)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeOutputTemplateRepository) UpdateOutputTemplate(ctx context.Context, outputTemplateID string, name string, sections []outputtemplate.Section) (*outputtemplate.OutputTemplate, error) {
+	t, err := r.GetOutputTemplateByID(ctx, outputTemplateID)
+	if err != nil {
+		return nil, err
+	}
+	updated := *t
+	updated.Name = name
+	updated.Sections = sections
+	return &updated, nil
+}
+
+func newTestOutputTemplateRepository() *fakeOutputTemplateRepository {
+	return &fakeOutputTemplateRepository{
+		templates: []*outputtemplate.OutputTemplate{
+			{
+				ID:      aliceOutputTemplateID,
+				OwnerID: aliceID,
+				Name:    "振り返り",
+				Sections: []outputtemplate.Section{
+					{Key: "learned", Label: "学んだこと", Required: true},
+					{Key: "next", Label: "次にやること", Required: true},
+					{Key: "blockers", Label: "詰まったこと"},
+				},
+			},
+			{
+				ID:       bobOutputTemplateID,
+				OwnerID:  bobID,
+				Name:     "日報",
+				Sections: []outputtemplate.Section{{Key: "done", Label: "やったこと", Required: true}},
+			},
+		},
+	}
+}
+
+func TestOutputTemplateUsecase_UpdateOutputTemplate(t *testing.T) {
+	validSections := []outputtemplate.Section{{Key: "learned", Label: "学んだこと", Required: true}}
+
+	tests := []struct {
+		name             string
+		outputTemplateID string
+		templateName     string
+		sections         []outputtemplate.Section
+		wantErr          func(error) bool
+	}{
+		{name: "自分のテンプレート", outputTemplateID: aliceOutputTemplateID, templateName: "振り返り", sections: validSections},
+		{name: "他のアカウントのテンプレート", outputTemplateID: bobOutputTemplateID, templateName: "日報", sections: validSections, wantErr: domainerrors.IsForbidden},
+		{name: "存在しないテンプレート", outputTemplateID: "d3c4d5e6-f7a8-4b92-8c1d-2e3f4a5b6c03", templateName: "週報", sections: validSections, wantErr: domainerrors.IsNotFound},
+		{name: "セクションが空", outputTemplateID: aliceOutputTemplateID, templateName: "振り返り", sections: nil, wantErr: domainerrors.IsValidation},
+		{name: "キーが重複", outputTemplateID: aliceOutputTemplateID, templateName: "振り返り", sections: append(validSections, outputtemplate.Section{Key: "learned", Label: "気づき"}), wantErr: domainerrors.IsValidation},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewOutputTemplateUsecase(newTestOutputTemplateRepository())
+
+			got, err := u.UpdateOutputTemplate(context.Background(), tt.outputTemplateID, aliceID, tt.templateName, tt.sections)
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got.Sections) != len(tt.sections) {
+				t.Errorf("got %d sections, want %d", len(got.Sections), len(tt.sections))
+			}
+		})
+	}
+}
+
+func TestEnsureOutputTemplatesOwnedBy(t *testing.T) {
+	alice := aliceOutputTemplateID
+	bob := bobOutputTemplateID
+	unknown := "d3c4d5e6-f7a8-4b92-8c1d-2e3f4a5b6c03"
+
+	tests := []struct {
+		name              string
+		outputTemplateIDs []*string
+		wantErr           bool
+	}{
+		{name: "テンプレートなし", outputTemplateIDs: []*string{nil, nil}},
+		{name: "自分のテンプレート", outputTemplateIDs: []*string{&alice, nil, &alice}},
+		{name: "他のアカウントのテンプレート", outputTemplateIDs: []*string{&alice, &bob}, wantErr: true},
+		{name: "存在しないテンプレート", outputTemplateIDs: []*string{&unknown}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ensureOutputTemplatesOwnedBy(context.Background(), newTestOutputTemplateRepository(), aliceID, tt.outputTemplateIDs)
+			if tt.wantErr {
+				if !domainerrors.IsValidation(err) {
+					t.Fatalf("err = %v, want Validation", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
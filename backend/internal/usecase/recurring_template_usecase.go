@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"task-management-system/backend/internal/domain/account"
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/recurringtemplate"
+	"task-management-system/backend/internal/domain/task"
+	"task-management-system/backend/internal/port/metrics"
+	"task-management-system/backend/internal/port/repository"
+)
+
+// RecurringTemplateUsecase 繰り返しテンプレートユースケース
+type RecurringTemplateUsecase struct {
+	txManager             repository.TxManager
+	recurringTemplateRepo repository.RecurringTemplateRepository
+	taskRepo              repository.TaskRepository
+	accountRepo           repository.AccountRepository
+	categoryRepo          repository.CategoryRepository
+	outputTemplateRepo    repository.OutputTemplateRepository
+	eventRecorder         metrics.TaskEventRecorder
+}
+
+// RecurringTemplateUsecaseOption 繰り返しテンプレートユースケースの任意の設定
+type RecurringTemplateUsecaseOption func(u *RecurringTemplateUsecase)
+
+// WithRecurringTaskEventRecorder テンプレートから作成したタスクをドメインの指標として記録する（指定しない場合は記録しない）
+func WithRecurringTaskEventRecorder(recorder metrics.TaskEventRecorder) RecurringTemplateUsecaseOption {
+	return func(u *RecurringTemplateUsecase) {
+		u.eventRecorder = recorder
+	}
+}
+
+// NewRecurringTemplateUsecase 繰り返しテンプレートユースケースを作成
+func NewRecurringTemplateUsecase(txManager repository.TxManager, recurringTemplateRepo repository.RecurringTemplateRepository, taskRepo repository.TaskRepository, accountRepo repository.AccountRepository, categoryRepo repository.CategoryRepository, outputTemplateRepo repository.OutputTemplateRepository, opts ...RecurringTemplateUsecaseOption) *RecurringTemplateUsecase {
+	u := &RecurringTemplateUsecase{
+		txManager:             txManager,
+		recurringTemplateRepo: recurringTemplateRepo,
+		taskRepo:              taskRepo,
+		accountRepo:           accountRepo,
+		categoryRepo:          categoryRepo,
+		outputTemplateRepo:    outputTemplateRepo,
+		eventRecorder:         metrics.NopTaskEventRecorder{},
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+// ListRecurringTemplates 自分のテンプレート一覧を取得
+func (u *RecurringTemplateUsecase) ListRecurringTemplates(ctx context.Context, ownerID string) ([]*recurringtemplate.RecurringTemplate, error) {
+	return u.recurringTemplateRepo.ListRecurringTemplates(ctx, ownerID)
+}
+
+// GetRecurringTemplateByID テンプレートIDでテンプレートを取得
+// 他のアカウントのテンプレートは存在しないものとして扱う
+func (u *RecurringTemplateUsecase) GetRecurringTemplateByID(ctx context.Context, recurringTemplateID string, ownerID string) (*recurringtemplate.RecurringTemplate, error) {
+	t, err := u.recurringTemplateRepo.GetRecurringTemplateByID(ctx, recurringTemplateID)
+	if err != nil {
+		return nil, err
+	}
+	if t.OwnerID != ownerID {
+		return nil, domainerrors.NotFound("Recurring template not found")
+	}
+
+	return t, nil
+}
+
+// CreateRecurringTemplate テンプレートを作成
+func (u *RecurringTemplateUsecase) CreateRecurringTemplate(ctx context.Context, ownerID string, title string, rule recurringtemplate.Rule, taskItems []recurringtemplate.Item) (*recurringtemplate.RecurringTemplate, error) {
+	if err := u.validate(ctx, ownerID, title, rule, taskItems); err != nil {
+		return nil, err
+	}
+
+	return u.recurringTemplateRepo.CreateRecurringTemplate(ctx, ownerID, title, rule, taskItems)
+}
+
+// UpdateRecurringTemplate テンプレートを更新（作成済みのタスクは変更しない）
+func (u *RecurringTemplateUsecase) UpdateRecurringTemplate(ctx context.Context, recurringTemplateID string, ownerID string, title string, rule recurringtemplate.Rule, taskItems []recurringtemplate.Item) (*recurringtemplate.RecurringTemplate, error) {
+	var updatedTemplate *recurringtemplate.RecurringTemplate
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := u.validate(ctx, ownerID, title, rule, taskItems); err != nil {
+			return err
+		}
+
+		// 既存のテンプレートを取得してオーナーチェック（他のアカウントのテンプレートは存在しないものとして扱う）
+		if _, err := u.GetRecurringTemplateByID(ctx, recurringTemplateID, ownerID); err != nil {
+			return err
+		}
+
+		var err error
+		updatedTemplate, err = u.recurringTemplateRepo.UpdateRecurringTemplate(ctx, recurringTemplateID, title, rule, taskItems)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedTemplate, nil
+}
+
+// DeleteRecurringTemplate テンプレートを削除（作成済みのタスクは通常のタスクとして残る）
+func (u *RecurringTemplateUsecase) DeleteRecurringTemplate(ctx context.Context, recurringTemplateID string, ownerID string) error {
+	return u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// 既存のテンプレートを取得してオーナーチェック（他のアカウントのテンプレートは存在しないものとして扱う）
+		if _, err := u.GetRecurringTemplateByID(ctx, recurringTemplateID, ownerID); err != nil {
+			return err
+		}
+
+		return u.recurringTemplateRepo.DeleteRecurringTemplate(ctx, recurringTemplateID)
+	})
+}
+
+// ListOccurrences 指定した日付以降でテンプレートからタスクが作成される日付を、最大count件まで取得
+func (u *RecurringTemplateUsecase) ListOccurrences(ctx context.Context, recurringTemplateID string, ownerID string, from time.Time, count int32) ([]time.Time, error) {
+	t, err := u.GetRecurringTemplateByID(ctx, recurringTemplateID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.Rule.Occurrences(from, count), nil
+}
+
+// GenerateTasksResult タスク生成の結果
+type GenerateTasksResult struct {
+	// Tasks 今回作成したタスク（作成済みの日付のタスクは含まない）
+	Tasks []*task.Task
+	// Owner タスクのオーナー（タスクが0件の場合はnil）
+	Owner *account.Account
+}
+
+// GenerateTasks 自分のすべてのテンプレートから、指定した日付からdays日間のタスクを作成
+// テンプレートと日付の組み合わせごとに一度だけ作成するため、繰り返し実行しても重複しない
+func (u *RecurringTemplateUsecase) GenerateTasks(ctx context.Context, ownerID string, from time.Time, days int32) (*GenerateTasksResult, error) {
+	templates, err := u.recurringTemplateRepo.ListRecurringTemplates(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GenerateTasksResult{Tasks: []*task.Task{}}
+	if len(templates) == 0 {
+		return result, nil
+	}
+
+	// テンプレートの作成後に削除されたカテゴリとアウトプットテンプレートは、未設定としてタスクを作成する
+	ownedReferences, err := u.ownedReferences(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range templates {
+		for _, date := range t.Rule.OccurrencesWithin(from, days) {
+			created, err := u.taskRepo.CreateRecurringTask(ctx, t.ID, ownerID, t.Title, date, ownedReferences.apply(t.TaskItemInputs()))
+			if err != nil {
+				// 作成済みの日付は飛ばす
+				if domainerrors.IsConflict(err) {
+					continue
+				}
+				return nil, err
+			}
+			result.Tasks = append(result.Tasks, created)
+			u.eventRecorder.RecordTaskEvents(task.DiffTask(nil, created, ownerID))
+		}
+	}
+
+	if len(result.Tasks) == 0 {
+		return result, nil
+	}
+
+	// オーナーを取得
+	accounts, err := u.accountRepo.GetAccountsByIDs(ctx, []string{ownerID})
+	if err != nil {
+		return nil, err
+	}
+	if len(accounts) == 0 {
+		return nil, domainerrors.NotFound("Owner account not found")
+	}
+	result.Owner = accounts[0]
+
+	return result, nil
+}
+
+// validate テンプレートの定義と、子タスクのカテゴリ・アウトプットテンプレートがオーナーのものであることを確認
+func (u *RecurringTemplateUsecase) validate(ctx context.Context, ownerID string, title string, rule recurringtemplate.Rule, taskItems []recurringtemplate.Item) error {
+	if err := recurringtemplate.Validate(title, rule, taskItems); err != nil {
+		return err
+	}
+
+	categoryIDs := make([]*string, 0, len(taskItems))
+	outputTemplateIDs := make([]*string, 0, len(taskItems))
+	for _, item := range taskItems {
+		categoryIDs = append(categoryIDs, item.CategoryID)
+		outputTemplateIDs = append(outputTemplateIDs, item.OutputTemplateID)
+	}
+	if err := ensureCategoriesOwnedBy(ctx, u.categoryRepo, ownerID, categoryIDs); err != nil {
+		return err
+	}
+	return ensureOutputTemplatesOwnedBy(ctx, u.outputTemplateRepo, ownerID, outputTemplateIDs)
+}
+
+// referenceSet オーナーが現在所有しているカテゴリとアウトプットテンプレート
+type referenceSet struct {
+	categoryIDs       map[string]bool
+	outputTemplateIDs map[string]bool
+}
+
+// ownedReferences オーナーが現在所有しているカテゴリとアウトプットテンプレートを取得
+func (u *RecurringTemplateUsecase) ownedReferences(ctx context.Context, ownerID string) (*referenceSet, error) {
+	categories, err := u.categoryRepo.ListCategories(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	outputTemplates, err := u.outputTemplateRepo.ListOutputTemplates(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := &referenceSet{
+		categoryIDs:       make(map[string]bool, len(categories)),
+		outputTemplateIDs: make(map[string]bool, len(outputTemplates)),
+	}
+	for _, c := range categories {
+		refs.categoryIDs[c.ID] = true
+	}
+	for _, t := range outputTemplates {
+		refs.outputTemplateIDs[t.ID] = true
+	}
+	return refs, nil
+}
+
+// apply 存在しないカテゴリとアウトプットテンプレートの参照を外す
+func (s *referenceSet) apply(items []task.CreateTaskItemInput) []task.CreateTaskItemInput {
+	for i := range items {
+		if id := items[i].CategoryID; id != nil && !s.categoryIDs[*id] {
+			items[i].CategoryID = nil
+		}
+		if id := items[i].OutputTemplateID; id != nil && !s.outputTemplateIDs[*id] {
+			items[i].OutputTemplateID = nil
+		}
+	}
+	return items
+}
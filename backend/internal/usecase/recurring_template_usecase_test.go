@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"task-management-system/backend/internal/domain/account"
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/recurringtemplate"
+	"task-management-system/backend/internal/domain/task"
+	"task-management-system/backend/internal/port/repository"
+)
+
+const (
+	aliceRecurringTemplateID = "f1a2b3c4-d5e6-4f70-8a9b-0c1d2e3f4a01"
+	bobRecurringTemplateID   = "f2b3c4d5-e6f7-4a81-9b0c-1d2e3f4a5b02"
+	deletedCategoryID        = "f3c4d5e6-f7a8-4b92-8c1d-2e3f4a5b6c03"
+)
+
+// fakeRecurringTemplateRepository テスト用の繰り返しテンプレートリポジトリ
+type fakeRecurringTemplateRepository struct {
+	repository.RecurringTemplateRepository
+	templates []*recurringtemplate.RecurringTemplate
+}
+
+func (r *fakeRecurringTemplateRepository) ListRecurringTemplates(ctx context.Context, ownerID string) ([]*recurringtemplate.RecurringTemplate, error) {
+	result := []*recurringtemplate.RecurringTemplate{}
+	for _, t := range r.templates {
+		if t.OwnerID == ownerID {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeRecurringTemplateRepository) GetRecurringTemplateByID(ctx context.Context, recurringTemplateID string) (*recurringtemplate.RecurringTemplate, error) {
+	for _, t := range r.templates {
+		if t.ID == recurringTemplateID {
+			return t, nil
+		}
+	}
+	return nil, domainerrors.NotFound("Recurring template not found")
+}
+
+// fakeRecurringTaskRepository テンプレートと日付ごとに一度だけタスクを作成するテスト用のタスクリポジトリ
+type fakeRecurringTaskRepository struct {
+	repository.TaskRepository
+	created map[string]*task.Task
+}
+
+func (r *fakeRecurringTaskRepository) CreateRecurringTask(ctx context.Context, recurringTemplateID string, ownerID string, title string, date time.Time, taskItems []task.CreateTaskItemInput) (*task.Task, error) {
+	key := recurringTemplateID + "/" + date.Format(time.DateOnly)
+	if _, ok := r.created[key]; ok {
+		return nil, domainerrors.Conflict("Task for this date has already been generated")
+	}
+
+	items := make([]task.TaskItem, 0, len(taskItems))
+	for _, input := range taskItems {
+		items = append(items, task.TaskItem{Content: input.Content, CategoryID: input.CategoryID, Status: input.Status})
+	}
+	t := &task.Task{ID: key, OwnerID: ownerID, Title: title, Date: date, TaskItems: items}
+	r.created[key] = t
+	return t, nil
+}
+
+func newTestRecurringTemplateUsecase() (*RecurringTemplateUsecase, *fakeRecurringTaskRepository) {
+	startsOn := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	categoryID := aliceCategoryID
+	deletedID := deletedCategoryID
+
+	recurringTemplateRepo := &fakeRecurringTemplateRepository{
+		templates: []*recurringtemplate.RecurringTemplate{
+			{
+				ID:      aliceRecurringTemplateID,
+				OwnerID: aliceID,
+				Title:   "平日のルーティン",
+				Rule:    recurringtemplate.Rule{Frequency: recurringtemplate.FrequencyWeekdays, StartsOn: startsOn},
+				TaskItems: []recurringtemplate.Item{
+					{Content: "メールを確認する", CategoryID: &categoryID},
+					{Content: "日報を書く", Order: 1, CategoryID: &deletedID},
+				},
+			},
+			{
+				ID:        bobRecurringTemplateID,
+				OwnerID:   bobID,
+				Title:     "毎日",
+				Rule:      recurringtemplate.Rule{Frequency: recurringtemplate.FrequencyDaily, StartsOn: startsOn},
+				TaskItems: []recurringtemplate.Item{{Content: "散歩"}},
+			},
+		},
+	}
+	taskRepo := &fakeRecurringTaskRepository{created: map[string]*task.Task{}}
+	accountRepo := &fakeAccountRepository{accounts: []*account.Account{{ID: aliceID, FirstName: "Alice"}}}
+
+	return NewRecurringTemplateUsecase(&fakeTxManager{}, recurringTemplateRepo, taskRepo, accountRepo, newTestCategoryRepository(), newTestOutputTemplateRepository()), taskRepo
+}
+
+func TestRecurringTemplateUsecase_GenerateTasks(t *testing.T) {
+	u, taskRepo := newTestRecurringTemplateUsecase()
+	from := time.Date(2026, 10, 9, 0, 0, 0, 0, time.UTC) // 金曜日
+
+	result, err := u.GenerateTasks(context.Background(), aliceID, from, 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 平日のみ、自分のテンプレートからのみ作成する
+	var dates []string
+	for _, created := range result.Tasks {
+		dates = append(dates, created.Date.Format(time.DateOnly))
+	}
+	if want := []string{"2026-10-09", "2026-10-12"}; !slices.Equal(dates, want) {
+		t.Errorf("dates = %v, want %v", dates, want)
+	}
+	if result.Owner == nil || result.Owner.ID != aliceID {
+		t.Errorf("owner = %+v, want alice", result.Owner)
+	}
+
+	// 削除されたカテゴリは未設定として作成し、ステータスは未着手にする
+	items := result.Tasks[0].TaskItems
+	if items[0].CategoryID == nil || *items[0].CategoryID != aliceCategoryID {
+		t.Errorf("items[0].CategoryID = %v, want %s", items[0].CategoryID, aliceCategoryID)
+	}
+	if items[1].CategoryID != nil {
+		t.Errorf("items[1].CategoryID = %v, want nil", *items[1].CategoryID)
+	}
+	if items[0].Status != task.StatusNotStarted {
+		t.Errorf("items[0].Status = %s, want NotStarted", items[0].Status)
+	}
+
+	// 繰り返し実行しても、作成済みの日付のタスクは作成しない
+	result, err = u.GenerateTasks(context.Background(), aliceID, from, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Tasks) != 1 || result.Tasks[0].Date.Format(time.DateOnly) != "2026-10-13" {
+		t.Errorf("second run created %+v, want only 2026-10-13", result.Tasks)
+	}
+	if len(taskRepo.created) != 3 {
+		t.Errorf("created %d tasks in total, want 3", len(taskRepo.created))
+	}
+}
+
+func TestRecurringTemplateUsecase_Authorization(t *testing.T) {
+	u, _ := newTestRecurringTemplateUsecase()
+	ctx := context.Background()
+
+	if _, err := u.ListOccurrences(ctx, bobRecurringTemplateID, aliceID, time.Now(), 7); !domainerrors.IsNotFound(err) {
+		t.Errorf("ListOccurrences() err = %v, want NotFound", err)
+	}
+
+	// 他のアカウントのテンプレートは存在しないものとして扱い、変更せずにロールバックする
+	rule := recurringtemplate.Rule{Frequency: recurringtemplate.FrequencyDaily, StartsOn: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)}
+	walk := recurringtemplate.Item{Priority: task.PriorityHigh, Density: task.DensityHigh, DurationTime: task.DurationTime15, Content: "散歩"}
+	if _, err := u.UpdateRecurringTemplate(ctx, bobRecurringTemplateID, aliceID, "書き換え", rule, []recurringtemplate.Item{walk}); !domainerrors.IsNotFound(err) {
+		t.Errorf("UpdateRecurringTemplate() err = %v, want NotFound", err)
+	}
+	if err := u.DeleteRecurringTemplate(ctx, bobRecurringTemplateID, aliceID); !domainerrors.IsNotFound(err) {
+		t.Errorf("DeleteRecurringTemplate() err = %v, want NotFound", err)
+	}
+	if txManager := u.txManager.(*fakeTxManager); txManager.committed != 0 || txManager.rolledBack != 2 {
+		t.Errorf("committed = %d, rolledBack = %d, want 0, 2", txManager.committed, txManager.rolledBack)
+	}
+	if bob, err := u.recurringTemplateRepo.GetRecurringTemplateByID(ctx, bobRecurringTemplateID); err != nil || bob.Title != "毎日" {
+		t.Errorf("another owner's template = %+v, err = %v, want unchanged", bob, err)
+	}
+
+	// 他のアカウントのカテゴリは子タスクに設定できない
+	bobCategory := bobCategoryID
+	walk.CategoryID = &bobCategory
+	items := []recurringtemplate.Item{walk}
+	if _, err := u.CreateRecurringTemplate(ctx, aliceID, "毎日", rule, items); !domainerrors.IsValidation(err) {
+		t.Errorf("CreateRecurringTemplate() err = %v, want Validation", err)
+	}
+}
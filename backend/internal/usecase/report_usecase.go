@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/report"
+	"task-management-system/backend/internal/port/repository"
+)
+
+// ReportUsecase レポートユースケース
+type ReportUsecase struct {
+	reportRepo repository.ReportRepository
+}
+
+// NewReportUsecase レポートユースケースを作成
+func NewReportUsecase(reportRepo repository.ReportRepository) *ReportUsecase {
+	return &ReportUsecase{
+		reportRepo: reportRepo,
+	}
+}
+
+// GetReport 閲覧者自身の期間内のタスクを集計した振り返りレポートを取得
+func (u *ReportUsecase) GetReport(ctx context.Context, viewerID string, period report.Period) (*report.Report, error) {
+	if viewerID == "" {
+		return nil, domainerrors.Forbidden("Viewer is required to get a report")
+	}
+
+	return u.reportRepo.GetReport(ctx, viewerID, period)
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/task"
+)
+
+// canViewTask 閲覧者がタスクを参照できるかどうかを判定
+// タスクの共有機能はまだないため、参照できるのはオーナーのみ
+func canViewTask(t *task.Task, viewerID string) bool {
+	return viewerID != "" && t.OwnerID == viewerID
+}
+
+// authorizeTaskRead 閲覧者がタスクを参照できることを確認
+// 参照できない場合は、タスクの存在自体を知られないようにNotFoundエラーを返す
+func authorizeTaskRead(t *task.Task, viewerID string) error {
+	if !canViewTask(t, viewerID) {
+		return domainerrors.NotFound("Task not found")
+	}
+	return nil
+}
+
+// scopeListTasksCondition 一覧取得の条件を閲覧者が参照できるタスクに限定
+// 閲覧者が参照できるタスクが存在し得ない場合（他人のownerIdを指定した場合など）はfalseを返す
+func scopeListTasksCondition(condition task.ListTasksCondition, viewerID string) (task.ListTasksCondition, bool) {
+	if viewerID == "" {
+		return condition, false
+	}
+	if condition.OwnerID != nil && *condition.OwnerID != viewerID {
+		return condition, false
+	}
+
+	condition.OwnerID = &viewerID
+	return condition, true
+}
@@ -2,69 +2,157 @@ package usecase
 
 import (
 	"context"
-	"fmt"
+	"strings"
+	"time"
 
 	"task-management-system/backend/internal/domain/account"
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/outputtemplate"
 	"task-management-system/backend/internal/domain/task"
+	"task-management-system/backend/internal/port/metrics"
 	"task-management-system/backend/internal/port/repository"
 )
 
 // TaskUsecase タスクユースケース
 type TaskUsecase struct {
-	taskRepo    repository.TaskRepository
-	accountRepo repository.AccountRepository
+	txManager          repository.TxManager
+	taskRepo           repository.TaskRepository
+	accountRepo        repository.AccountRepository
+	categoryRepo       repository.CategoryRepository
+	outputTemplateRepo repository.OutputTemplateRepository
+	eventRecorder      metrics.TaskEventRecorder
 }
 
-// NewTaskUsecase タスクユースケースを作成
-func NewTaskUsecase(taskRepo repository.TaskRepository, accountRepo repository.AccountRepository) *TaskUsecase {
-	return &TaskUsecase{
-		taskRepo:    taskRepo,
-		accountRepo: accountRepo,
+// TaskUsecaseOption タスクユースケースの任意の設定
+type TaskUsecaseOption func(u *TaskUsecase)
+
+// WithTaskEventRecorder 保存したタスクの変更をドメインの指標として記録する（指定しない場合は記録しない）
+func WithTaskEventRecorder(recorder metrics.TaskEventRecorder) TaskUsecaseOption {
+	return func(u *TaskUsecase) {
+		u.eventRecorder = recorder
 	}
 }
 
+// NewTaskUsecase タスクユースケースを作成
+func NewTaskUsecase(txManager repository.TxManager, taskRepo repository.TaskRepository, accountRepo repository.AccountRepository, categoryRepo repository.CategoryRepository, outputTemplateRepo repository.OutputTemplateRepository, opts ...TaskUsecaseOption) *TaskUsecase {
+	u := &TaskUsecase{
+		txManager:          txManager,
+		taskRepo:           taskRepo,
+		accountRepo:        accountRepo,
+		categoryRepo:       categoryRepo,
+		outputTemplateRepo: outputTemplateRepo,
+		eventRecorder:      metrics.NopTaskEventRecorder{},
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+// ListTasksResult タスク一覧取得の結果
+type ListTasksResult struct {
+	Tasks []*task.Task
+	// Owner タスクのオーナー（タスクが0件の場合はnil）
+	Owner *account.Account
+	// NextCursor 次のページのカーソル（次のページがない場合はnil）
+	NextCursor *string
+	// SearchMatches キーワードが一致した箇所のスニペット（タスクIDごと、キーワード検索時のみ）
+	SearchMatches map[string][]task.SearchMatch
+}
+
 // ListTasks タスク一覧を取得
-// 自分のタスクのみを取得するAPIのため、すべてのタスクは同じオーナーを持つ
-func (u *TaskUsecase) ListTasks(ctx context.Context, condition task.ListTasksCondition) ([]*task.Task, *account.Account, error) {
+// 閲覧者が参照できるタスクのみを取得するため、すべてのタスクは同じオーナーを持つ
+func (u *TaskUsecase) ListTasks(ctx context.Context, viewerID string, condition task.ListTasksCondition) (*ListTasksResult, error) {
+	// 空白のみのキーワードは指定なしとして扱う
+	if condition.Keyword != nil {
+		keyword := strings.TrimSpace(*condition.Keyword)
+		condition.Keyword = &keyword
+		if keyword == "" {
+			condition.Keyword = nil
+		}
+	}
+
+	// 並び順を確定（カーソルは発行時と同じ並び順、関連度順の場合は同じキーワードでのみ使用できる）
+	sort, err := task.ParseSortOrder(condition.Sort, condition.Keyword)
+	if err != nil {
+		return nil, err
+	}
+	if condition.Cursor != nil {
+		if err := condition.Cursor.CheckCondition(sort, condition.Keyword); err != nil {
+			return nil, err
+		}
+	}
+	sortValue := string(sort)
+	condition.Sort = &sortValue
+
+	// 検索条件を閲覧者が参照できる範囲に限定
+	scopedCondition, ok := scopeListTasksCondition(condition, viewerID)
+	if !ok {
+		return &ListTasksResult{Tasks: []*task.Task{}}, nil
+	}
+
+	// 次のページの有無を判定するため、1件多く取得
+	limit := scopedCondition.Limit
+	if limit > 0 {
+		scopedCondition.Limit = limit + 1
+	}
+
 	// タスクを取得
-	tasks, err := u.taskRepo.ListTasks(ctx, condition)
+	tasks, err := u.taskRepo.ListTasks(ctx, scopedCondition)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
-	if len(tasks) == 0 {
-		return []*task.Task{}, nil, nil
+	result := &ListTasksResult{Tasks: tasks}
+	if limit > 0 && int32(len(tasks)) > limit {
+		result.Tasks = tasks[:limit]
+		nextCursor := task.NewListTasksCursor(sort, result.Tasks[limit-1], condition.Keyword).Encode()
+		result.NextCursor = &nextCursor
+	}
+
+	if len(result.Tasks) == 0 {
+		result.Tasks = []*task.Task{}
+		return result, nil
+	}
+
+	// キーワードが一致した箇所をハイライト
+	if condition.Keyword != nil {
+		result.SearchMatches = make(map[string][]task.SearchMatch, len(result.Tasks))
+		for _, t := range result.Tasks {
+			result.SearchMatches[t.ID] = t.SearchMatches(*condition.Keyword)
+		}
 	}
 
 	// 最初のタスクのオーナーIDを使用（すべてのタスクは同じオーナーを持つ）
-	ownerID := tasks[0].OwnerID
+	ownerID := result.Tasks[0].OwnerID
 
 	// アカウントを取得
 	accounts, err := u.accountRepo.GetAccountsByIDs(ctx, []string{ownerID})
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	if len(accounts) == 0 {
-		return nil, nil, fmt.Errorf("owner account not found: %s", ownerID)
+		return nil, domainerrors.NotFound("Owner account not found")
 	}
 
-	owner := accounts[0]
+	result.Owner = accounts[0]
 
-	return tasks, owner, nil
+	return result, nil
 }
 
 // GetTaskByID タスクIDでタスクを取得
-func (u *TaskUsecase) GetTaskByID(ctx context.Context, taskID string) (*task.Task, *account.Account, error) {
-	// タスクを取得
+// 閲覧者が参照できないタスクは存在しないものとして扱う
+func (u *TaskUsecase) GetTaskByID(ctx context.Context, taskID string, viewerID string) (*task.Task, *account.Account, error) {
+	// タスクを取得（見つからない場合はNotFoundエラー）
 	t, err := u.taskRepo.GetTaskByID(ctx, taskID)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// タスクが見つからない場合
-	if t == nil {
-		return nil, nil, nil
+	// 参照権限をチェック
+	if err := authorizeTaskRead(t, viewerID); err != nil {
+		return nil, nil, err
 	}
 
 	// オーナーを取得
@@ -74,7 +162,7 @@ func (u *TaskUsecase) GetTaskByID(ctx context.Context, taskID string) (*task.Tas
 	}
 
 	if len(accounts) == 0 {
-		return nil, nil, fmt.Errorf("owner account not found: %s", t.OwnerID)
+		return nil, nil, domainerrors.NotFound("Owner account not found")
 	}
 
 	owner := accounts[0]
@@ -82,13 +170,79 @@ func (u *TaskUsecase) GetTaskByID(ctx context.Context, taskID string) (*task.Tas
 	return t, owner, nil
 }
 
+// GetTaskByTaskItemID タスクアイテムIDからタスクを取得
+// 閲覧者が参照できないタスクは存在しないものとして扱う
+func (u *TaskUsecase) GetTaskByTaskItemID(ctx context.Context, taskItemID string, viewerID string) (*task.Task, *account.Account, error) {
+	t, err := u.taskRepo.GetTaskByTaskItemID(ctx, taskItemID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 参照権限をチェック
+	if err := authorizeTaskRead(t, viewerID); err != nil {
+		return nil, nil, err
+	}
+
+	// オーナーを取得
+	accounts, err := u.accountRepo.GetAccountsByIDs(ctx, []string{t.OwnerID})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(accounts) == 0 {
+		return nil, nil, domainerrors.NotFound("Owner account not found")
+	}
+
+	return t, accounts[0], nil
+}
+
+// GetTaskHistory タスクの変更履歴を古い順に取得
+// 閲覧者が参照できないタスクは存在しないものとして扱う
+func (u *TaskUsecase) GetTaskHistory(ctx context.Context, taskID string, viewerID string) ([]task.Event, error) {
+	t, err := u.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 参照権限をチェック
+	if err := authorizeTaskRead(t, viewerID); err != nil {
+		return nil, err
+	}
+
+	return u.taskRepo.ListTaskEvents(ctx, t.ID)
+}
+
 // CreateTask タスクを作成
 func (u *TaskUsecase) CreateTask(ctx context.Context, ownerID string, title string, date string, taskItems []task.CreateTaskItemInput) (*task.Task, *account.Account, error) {
-	// タスクを作成
-	createdTask, err := u.taskRepo.CreateTask(ctx, ownerID, title, date, taskItems)
+	var createdTask *task.Task
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// タスクアイテムのカテゴリとアウトプットテンプレートがオーナーのものか確認
+		categoryIDs := make([]*string, 0, len(taskItems))
+		outputTemplateIDs := make([]*string, 0, len(taskItems))
+		for _, item := range taskItems {
+			categoryIDs = append(categoryIDs, item.CategoryID)
+			outputTemplateIDs = append(outputTemplateIDs, item.OutputTemplateID)
+		}
+		if err := ensureCategoriesOwnedBy(ctx, u.categoryRepo, ownerID, categoryIDs); err != nil {
+			return err
+		}
+		if err := ensureOutputTemplatesOwnedBy(ctx, u.outputTemplateRepo, ownerID, outputTemplateIDs); err != nil {
+			return err
+		}
+
+		// ステータスに合わせて着手日時と完了日時を設定
+		plannedItems, err := task.PlanNewTaskItems(taskItems, time.Now())
+		if err != nil {
+			return err
+		}
+
+		// タスクを作成
+		createdTask, err = u.taskRepo.CreateTask(ctx, ownerID, title, date, plannedItems)
+		return err
+	})
 	if err != nil {
 		return nil, nil, err
 	}
+	u.recordTaskChanges(nil, createdTask, ownerID)
 
 	// オーナーを取得
 	accounts, err := u.accountRepo.GetAccountsByIDs(ctx, []string{ownerID})
@@ -97,7 +251,7 @@ func (u *TaskUsecase) CreateTask(ctx context.Context, ownerID string, title stri
 	}
 
 	if len(accounts) == 0 {
-		return nil, nil, fmt.Errorf("owner account not found: %s", ownerID)
+		return nil, nil, domainerrors.NotFound("Owner account not found")
 	}
 
 	owner := accounts[0]
@@ -105,13 +259,34 @@ func (u *TaskUsecase) CreateTask(ctx context.Context, ownerID string, title stri
 	return createdTask, owner, nil
 }
 
-// UpdateTask タスクを更新
-func (u *TaskUsecase) UpdateTask(ctx context.Context, taskID string, ownerID string, title string, date string, taskItems []task.UpdateTaskItemInput) (*task.Task, *account.Account, error) {
-	// タスクを更新
-	updatedTask, err := u.taskRepo.UpdateTask(ctx, taskID, ownerID, title, date, taskItems)
+// DuplicateTask タスクを子タスクごと指定した日付に複製
+// 複製元は自分のタスクのみ（他のアカウントのタスクは存在しないものとして扱う）
+func (u *TaskUsecase) DuplicateTask(ctx context.Context, taskID string, ownerID string, date string, options task.DuplicateOptions) (*task.Task, *account.Account, error) {
+	var createdTask *task.Task
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// 複製元のタスクをロックして取得（複製するまでに変更されないようにする）
+		source, err := u.getTaskForUpdate(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := authorizeTaskRead(source, ownerID); err != nil {
+			return err
+		}
+
+		// 複製する子タスクを取得
+		taskItems, err := source.DuplicateTaskItems(options)
+		if err != nil {
+			return err
+		}
+
+		// 複製元と同じトランザクションでタスクを作成
+		createdTask, err = u.taskRepo.CreateTask(ctx, ownerID, source.Title, date, taskItems)
+		return err
+	})
 	if err != nil {
 		return nil, nil, err
 	}
+	u.recordTaskChanges(nil, createdTask, ownerID)
 
 	// オーナーを取得
 	accounts, err := u.accountRepo.GetAccountsByIDs(ctx, []string{ownerID})
@@ -120,52 +295,237 @@ func (u *TaskUsecase) UpdateTask(ctx context.Context, taskID string, ownerID str
 	}
 
 	if len(accounts) == 0 {
-		return nil, nil, fmt.Errorf("owner account not found: %s", ownerID)
+		return nil, nil, domainerrors.NotFound("Owner account not found")
 	}
 
 	owner := accounts[0]
 
-	return updatedTask, owner, nil
+	return createdTask, owner, nil
 }
 
-// DeleteTask タスクを削除
-func (u *TaskUsecase) DeleteTask(ctx context.Context, taskID string, ownerID string) error {
-	// 既存のタスクを取得してオーナーチェック
-	existingTask, err := u.taskRepo.GetTaskByID(ctx, taskID)
-	if err != nil {
+// CarryOverTaskItems 完了していない子タスクを、オーナーの指定した日付のタスクに移動または複製
+// 指定した日付のタスクがない場合は、元のタスクと同じタイトルで作成する
+func (u *TaskUsecase) CarryOverTaskItems(ctx context.Context, taskID string, ownerID string, date time.Time, mode task.CarryOverMode) (*task.Task, *account.Account, error) {
+	var source *task.Task
+	var result *task.CarryOverResult
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// 持ち越し元のタスクを取得してオーナーチェック（他のアカウントのタスクは存在しないものとして扱う）
+		t, err := u.getTaskForUpdate(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := authorizeTaskRead(t, ownerID); err != nil {
+			return err
+		}
+		source = t
+
+		// 持ち越す子タスクを取得
+		input, err := source.PlanCarryOver(date, mode)
+		if err != nil {
+			return err
+		}
+
+		// 子タスクを持ち越す
+		result, err = u.taskRepo.CarryOverTaskItems(ctx, input)
 		return err
+	})
+	if err != nil {
+		return nil, nil, err
 	}
-	if existingTask == nil {
-		return fmt.Errorf("task not found: %s", taskID)
+
+	// 持ち越し元と持ち越し先の変更を記録
+	updatedSource, err := u.taskRepo.GetTaskByID(ctx, source.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	u.recordTaskChanges(source, updatedSource, ownerID)
+	u.recordTaskChanges(result.TargetBefore, result.Target, ownerID)
+
+	// オーナーを取得
+	accounts, err := u.accountRepo.GetAccountsByIDs(ctx, []string{ownerID})
+	if err != nil {
+		return nil, nil, err
 	}
-	if existingTask.OwnerID != ownerID {
-		return fmt.Errorf("you do not have permission to delete this task")
+
+	if len(accounts) == 0 {
+		return nil, nil, domainerrors.NotFound("Owner account not found")
 	}
 
-	// タスクを削除（ON DELETE CASCADEにより、子タスクも自動的に削除される）
-	if err := u.taskRepo.DeleteTask(ctx, taskID); err != nil {
+	owner := accounts[0]
+
+	return result.Target, owner, nil
+}
+
+// UpdateTask タスクを更新
+// expectedVersionを指定した場合、タスクのバージョンが一致しなければPreconditionFailedエラーを返す
+func (u *TaskUsecase) UpdateTask(ctx context.Context, taskID string, ownerID string, title string, date string, taskItems []task.UpdateTaskItemInput, expectedVersion *int64) (*task.Task, *account.Account, error) {
+	var existingTask, updatedTask *task.Task
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// タスクアイテムのカテゴリとアウトプットテンプレートがオーナーのものか確認
+		categoryIDs := make([]*string, 0, len(taskItems))
+		outputTemplateIDs := make([]*string, 0, len(taskItems))
+		for _, item := range taskItems {
+			categoryIDs = append(categoryIDs, item.CategoryID)
+			outputTemplateIDs = append(outputTemplateIDs, item.OutputTemplateID)
+		}
+		if err := ensureCategoriesOwnedBy(ctx, u.categoryRepo, ownerID, categoryIDs); err != nil {
+			return err
+		}
+		if err := ensureOutputTemplatesOwnedBy(ctx, u.outputTemplateRepo, ownerID, outputTemplateIDs); err != nil {
+			return err
+		}
+
+		// 既存のタスクを取得してオーナーチェック
+		var err error
+		existingTask, err = u.getTaskForUpdate(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := authorizeTaskRead(existingTask, ownerID); err != nil {
+			return err
+		}
+		if err := task.CheckVersion(existingTask.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		// 子タスクのステータスの変更が許可されているか確認し、着手日時と完了日時を設定
+		plannedItems, err := existingTask.PlanTaskItemUpdates(taskItems, time.Now())
+		if err != nil {
+			return err
+		}
+
+		// タスクを更新
+		updatedTask, err = u.taskRepo.UpdateTask(ctx, taskID, ownerID, title, date, plannedItems, expectedVersion)
 		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	u.recordTaskChanges(existingTask, updatedTask, ownerID)
+
+	// オーナーを取得
+	accounts, err := u.accountRepo.GetAccountsByIDs(ctx, []string{ownerID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(accounts) == 0 {
+		return nil, nil, domainerrors.NotFound("Owner account not found")
 	}
 
-	return nil
+	owner := accounts[0]
+
+	return updatedTask, owner, nil
 }
 
-// UpdateTaskReview タスクの振り返りを更新
-func (u *TaskUsecase) UpdateTaskReview(ctx context.Context, taskID string, ownerID string, review *string) (*task.Task, *account.Account, error) {
-	// 既存のタスクを取得してオーナーチェック
-	existingTask, err := u.taskRepo.GetTaskByID(ctx, taskID)
+// DeleteTask タスクをゴミ箱に移動
+// ゴミ箱のタスクは一覧や集計に含まれず、元に戻すか保持期間を過ぎるまで子タスクやアウトプットを残す
+func (u *TaskUsecase) DeleteTask(ctx context.Context, taskID string, ownerID string, expectedVersion *int64) error {
+	return u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// 既存のタスクを取得してオーナーチェック
+		existingTask, err := u.getTaskForUpdate(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := authorizeTaskRead(existingTask, ownerID); err != nil {
+			return err
+		}
+		if err := task.CheckVersion(existingTask.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		// タスクをゴミ箱に移動
+		return u.taskRepo.DeleteTask(ctx, taskID, ownerID, expectedVersion)
+	})
+}
+
+// ListTrashedTasks オーナーのゴミ箱のタスクを移動した日時の新しい順に取得
+func (u *TaskUsecase) ListTrashedTasks(ctx context.Context, ownerID string) ([]*task.Task, *account.Account, error) {
+	tasks, err := u.taskRepo.ListTrashedTasks(ctx, ownerID)
 	if err != nil {
 		return nil, nil, err
 	}
-	if existingTask == nil {
-		return nil, nil, fmt.Errorf("task not found")
+
+	// オーナーを取得
+	accounts, err := u.accountRepo.GetAccountsByIDs(ctx, []string{ownerID})
+	if err != nil {
+		return nil, nil, err
 	}
-	if existingTask.OwnerID != ownerID {
-		return nil, nil, fmt.Errorf("you do not have permission to update this task review")
+	if len(accounts) == 0 {
+		return nil, nil, domainerrors.NotFound("Owner account not found")
 	}
 
-	// タスクの振り返りを更新
-	if err := u.taskRepo.UpdateTaskReview(ctx, taskID, review); err != nil {
+	return tasks, accounts[0], nil
+}
+
+// RestoreTask ゴミ箱のタスクを元に戻す
+func (u *TaskUsecase) RestoreTask(ctx context.Context, taskID string, ownerID string) (*task.Task, *account.Account, error) {
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// ゴミ箱のタスクを取得してオーナーチェック
+		trashedTask, err := u.taskRepo.GetTrashedTaskByID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := authorizeTaskRead(trashedTask, ownerID); err != nil {
+			return err
+		}
+
+		// タスクを元に戻す
+		return u.taskRepo.RestoreTask(ctx, taskID, ownerID)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return u.reloadTaskWithOwner(ctx, taskID, ownerID)
+}
+
+// PurgeTask ゴミ箱のタスクを完全に削除
+// ゴミ箱にないタスクは完全に削除できない（先にゴミ箱に移動する）
+func (u *TaskUsecase) PurgeTask(ctx context.Context, taskID string, ownerID string) error {
+	return u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// ゴミ箱のタスクを取得してオーナーチェック
+		trashedTask, err := u.taskRepo.GetTrashedTaskByID(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := authorizeTaskRead(trashedTask, ownerID); err != nil {
+			return err
+		}
+
+		// タスクを完全に削除（子タスクも削除され、変更履歴は残る）
+		return u.taskRepo.PurgeTask(ctx, taskID, ownerID)
+	})
+}
+
+// PurgeExpiredTasks 保持期間を過ぎたゴミ箱のタスクを完全に削除し、削除した件数を返す
+// すべてのオーナーのタスクが対象のため、バックグラウンドの処理から呼び出す
+func (u *TaskUsecase) PurgeExpiredTasks(ctx context.Context, now time.Time, retention time.Duration) (int64, error) {
+	if retention <= 0 {
+		return 0, domainerrors.Validation("Trash retention must be positive")
+	}
+	return u.taskRepo.PurgeTrashedTasks(ctx, now.Add(-retention))
+}
+
+// UpdateTaskReview タスクの振り返りを更新
+func (u *TaskUsecase) UpdateTaskReview(ctx context.Context, taskID string, ownerID string, review *string, expectedVersion *int64) (*task.Task, *account.Account, error) {
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// 既存のタスクを取得してオーナーチェック
+		existingTask, err := u.getTaskForUpdate(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := authorizeTaskRead(existingTask, ownerID); err != nil {
+			return err
+		}
+		if err := task.CheckVersion(existingTask.Version, expectedVersion); err != nil {
+			return err
+		}
+
+		// タスクの振り返りを更新
+		return u.taskRepo.UpdateTaskReview(ctx, taskID, ownerID, review, expectedVersion)
+	})
+	if err != nil {
 		return nil, nil, err
 	}
 
@@ -174,9 +534,6 @@ func (u *TaskUsecase) UpdateTaskReview(ctx context.Context, taskID string, owner
 	if err != nil {
 		return nil, nil, err
 	}
-	if updatedTask == nil {
-		return nil, nil, fmt.Errorf("failed to update task review")
-	}
 
 	// オーナーを取得
 	accounts, err := u.accountRepo.GetAccountsByIDs(ctx, []string{ownerID})
@@ -185,7 +542,7 @@ func (u *TaskUsecase) UpdateTaskReview(ctx context.Context, taskID string, owner
 	}
 
 	if len(accounts) == 0 {
-		return nil, nil, fmt.Errorf("owner account not found: %s", ownerID)
+		return nil, nil, domainerrors.NotFound("Owner account not found")
 	}
 
 	owner := accounts[0]
@@ -194,46 +551,92 @@ func (u *TaskUsecase) UpdateTaskReview(ctx context.Context, taskID string, owner
 }
 
 // UpdateTaskItemOutput タスクアイテムのアウトプットを更新
-func (u *TaskUsecase) UpdateTaskItemOutput(ctx context.Context, taskItemID string, ownerID string, output string) (*task.Task, *account.Account, error) {
-	// タスクアイテムIDからタスクを取得
-	t, err := u.taskRepo.GetTaskByTaskItemID(ctx, taskItemID)
+// テンプレートが設定されたタスクアイテムはセクションをテンプレートに沿って検証し、まとめたテキストも保存する
+func (u *TaskUsecase) UpdateTaskItemOutput(ctx context.Context, taskItemID string, ownerID string, input task.TaskItemOutputInput, expectedVersion *int64) (*task.Task, *account.Account, error) {
+	var existingTask *task.Task
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// タスクアイテムを取得してオーナーチェック
+		t, taskItem, err := u.getTaskItemForUpdate(ctx, taskItemID, ownerID)
+		if err != nil {
+			return err
+		}
+		if err := task.CheckVersion(t.Version, expectedVersion); err != nil {
+			return err
+		}
+		existingTask = t
+
+		// アウトプットをテンプレートに沿って組み立てる
+		output, sections, err := u.buildTaskItemOutput(ctx, taskItem, input)
+		if err != nil {
+			return err
+		}
+
+		// アウトプットを入力するとステータスはCompletedになる
+		change, err := taskItem.PlanStatusChange(task.StatusCompleted, time.Now())
+		if err != nil {
+			return err
+		}
+
+		// タスクアイテムのアウトプットとステータスを更新
+		return u.taskRepo.UpdateTaskItemOutput(ctx, taskItemID, ownerID, output, sections, change, expectedVersion)
+	})
 	if err != nil {
 		return nil, nil, err
 	}
-	if t == nil {
-		return nil, nil, fmt.Errorf("task not found")
-	}
 
-	// オーナーチェック
-	if t.OwnerID != ownerID {
-		return nil, nil, fmt.Errorf("you do not have permission to update this task item")
+	// 更新されたタスクを再取得
+	updatedTask, err := u.taskRepo.GetTaskByID(ctx, existingTask.ID)
+	if err != nil {
+		return nil, nil, err
 	}
+	u.recordTaskChanges(existingTask, updatedTask, ownerID)
 
-	// タスクアイテムが存在するか確認
-	var taskItemExists bool
-	for _, item := range t.TaskItems {
-		if item.ID == taskItemID {
-			taskItemExists = true
-			break
-		}
+	// オーナーを取得
+	accounts, err := u.accountRepo.GetAccountsByIDs(ctx, []string{ownerID})
+	if err != nil {
+		return nil, nil, err
 	}
-	if !taskItemExists {
-		return nil, nil, fmt.Errorf("task item not found")
+
+	if len(accounts) == 0 {
+		return nil, nil, domainerrors.NotFound("Owner account not found")
 	}
 
-	// タスクアイテムのアウトプットを更新（ステータスはCompletedに）
-	if err := u.taskRepo.UpdateTaskItemOutput(ctx, taskItemID, output); err != nil {
+	owner := accounts[0]
+
+	return updatedTask, owner, nil
+}
+
+// ControlTaskItemTimer タスクアイテムのタイマーを操作（開始・一時停止・再開・停止）
+// 開始・再開時に未着手のタスクアイテムは着手中になる
+func (u *TaskUsecase) ControlTaskItemTimer(ctx context.Context, taskItemID string, ownerID string, action task.TimerAction) (*task.Task, *account.Account, error) {
+	var existingTask *task.Task
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// タスクアイテムを取得してオーナーチェック
+		t, taskItem, err := u.getTaskItemForUpdate(ctx, taskItemID, ownerID)
+		if err != nil {
+			return err
+		}
+		existingTask = t
+
+		// タイマーの状態から操作が可能か確認
+		transition, err := taskItem.PlanTimerAction(action)
+		if err != nil {
+			return err
+		}
+
+		// セッションとステータスを更新
+		return u.taskRepo.ApplyTimerTransition(ctx, taskItemID, ownerID, transition, time.Now())
+	})
+	if err != nil {
 		return nil, nil, err
 	}
 
 	// 更新されたタスクを再取得
-	updatedTask, err := u.taskRepo.GetTaskByID(ctx, t.ID)
+	updatedTask, err := u.taskRepo.GetTaskByID(ctx, existingTask.ID)
 	if err != nil {
 		return nil, nil, err
 	}
-	if updatedTask == nil {
-		return nil, nil, fmt.Errorf("failed to update task item")
-	}
+	u.recordTaskChanges(existingTask, updatedTask, ownerID)
 
 	// オーナーを取得
 	accounts, err := u.accountRepo.GetAccountsByIDs(ctx, []string{ownerID})
@@ -242,10 +645,285 @@ func (u *TaskUsecase) UpdateTaskItemOutput(ctx context.Context, taskItemID strin
 	}
 
 	if len(accounts) == 0 {
-		return nil, nil, fmt.Errorf("owner account not found: %s", ownerID)
+		return nil, nil, domainerrors.NotFound("Owner account not found")
 	}
 
 	owner := accounts[0]
 
 	return updatedTask, owner, nil
 }
+
+// AddTaskItem タスクにタスクアイテムを1件追加
+func (u *TaskUsecase) AddTaskItem(ctx context.Context, taskID string, ownerID string, input task.CreateTaskItemInput) (*task.Task, *account.Account, error) {
+	var existingTask *task.Task
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// 既存のタスクを取得してオーナーチェック
+		t, err := u.getTaskForUpdate(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := authorizeTaskRead(t, ownerID); err != nil {
+			return err
+		}
+		existingTask = t
+
+		// 集約のルールに沿って追加できるか確認
+		if err := t.AddTaskItem(input); err != nil {
+			return err
+		}
+		if err := u.ensureReferencesOwnedBy(ctx, ownerID, input.CategoryID, input.OutputTemplateID); err != nil {
+			return err
+		}
+
+		// ステータスに合わせて着手日時と完了日時を設定
+		planned, err := task.PlanNewTaskItem(input, time.Now())
+		if err != nil {
+			return err
+		}
+
+		// タスクアイテムを追加
+		return u.taskRepo.AddTaskItem(ctx, t.ID, ownerID, planned)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updatedTask, owner, err := u.reloadTaskWithOwner(ctx, existingTask.ID, ownerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	u.recordTaskChanges(existingTask, updatedTask, ownerID)
+	return updatedTask, owner, nil
+}
+
+// ReorderTaskItems タスクアイテムを指定した順に並び替え
+func (u *TaskUsecase) ReorderTaskItems(ctx context.Context, taskID string, ownerID string, taskItemIDs []string) (*task.Task, *account.Account, error) {
+	var existingTask *task.Task
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// 既存のタスクを取得してオーナーチェック
+		t, err := u.getTaskForUpdate(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := authorizeTaskRead(t, ownerID); err != nil {
+			return err
+		}
+		existingTask = t
+
+		// 集約のルールに沿って並び替え後の順番を作成
+		orders, err := t.ReorderTaskItems(taskItemIDs)
+		if err != nil {
+			return err
+		}
+
+		// 順番を保存
+		return u.taskRepo.ReorderTaskItems(ctx, t.ID, ownerID, orders)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updatedTask, owner, err := u.reloadTaskWithOwner(ctx, existingTask.ID, ownerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	u.recordTaskChanges(existingTask, updatedTask, ownerID)
+	return updatedTask, owner, nil
+}
+
+// PatchTaskItem タスクアイテムの指定した項目のみを更新
+func (u *TaskUsecase) PatchTaskItem(ctx context.Context, taskItemID string, ownerID string, patch task.TaskItemPatch) (*task.Task, *account.Account, error) {
+	var existingTask *task.Task
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// タスクアイテムを取得してオーナーチェック
+		t, _, err := u.getTaskItemForUpdate(ctx, taskItemID, ownerID)
+		if err != nil {
+			return err
+		}
+		existingTask = t
+
+		// 集約のルールに沿って更新後のタスクアイテムを作成
+		patched, err := t.PatchTaskItem(taskItemID, patch)
+		if err != nil {
+			return err
+		}
+		if err := u.ensureReferencesOwnedBy(ctx, ownerID, patch.CategoryID, patch.OutputTemplateID); err != nil {
+			return err
+		}
+
+		// タスクアイテムを保存
+		return u.taskRepo.SaveTaskItem(ctx, patched, ownerID)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updatedTask, owner, err := u.reloadTaskWithOwner(ctx, existingTask.ID, ownerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	u.recordTaskChanges(existingTask, updatedTask, ownerID)
+	return updatedTask, owner, nil
+}
+
+// DeleteTaskItem タスクアイテムを1件削除
+func (u *TaskUsecase) DeleteTaskItem(ctx context.Context, taskItemID string, ownerID string) (*task.Task, *account.Account, error) {
+	var existingTask *task.Task
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// タスクアイテムを取得してオーナーチェック
+		t, _, err := u.getTaskItemForUpdate(ctx, taskItemID, ownerID)
+		if err != nil {
+			return err
+		}
+		existingTask = t
+
+		// 集約のルールに沿って削除できるか確認
+		if err := t.RemoveTaskItem(taskItemID); err != nil {
+			return err
+		}
+
+		// タスクアイテムを削除
+		return u.taskRepo.DeleteTaskItem(ctx, t.ID, taskItemID, ownerID)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updatedTask, owner, err := u.reloadTaskWithOwner(ctx, existingTask.ID, ownerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	u.recordTaskChanges(existingTask, updatedTask, ownerID)
+	return updatedTask, owner, nil
+}
+
+// ChangeTaskItemStatus タスクアイテムのステータスを変更
+func (u *TaskUsecase) ChangeTaskItemStatus(ctx context.Context, taskItemID string, ownerID string, status task.Status) (*task.Task, *account.Account, error) {
+	var existingTask *task.Task
+	err := u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// タスクアイテムを取得してオーナーチェック
+		t, _, err := u.getTaskItemForUpdate(ctx, taskItemID, ownerID)
+		if err != nil {
+			return err
+		}
+		existingTask = t
+
+		// 集約のルールに沿って変更後のタスクアイテムを作成
+		changed, err := t.ChangeTaskItemStatus(taskItemID, status, time.Now())
+		if err != nil {
+			return err
+		}
+
+		// タスクアイテムを保存
+		return u.taskRepo.SaveTaskItem(ctx, changed, ownerID)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updatedTask, owner, err := u.reloadTaskWithOwner(ctx, existingTask.ID, ownerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	u.recordTaskChanges(existingTask, updatedTask, ownerID)
+	return updatedTask, owner, nil
+}
+
+// ensureReferencesOwnedBy タスクアイテムに設定するカテゴリとアウトプットテンプレートがオーナーのものか確認
+func (u *TaskUsecase) ensureReferencesOwnedBy(ctx context.Context, ownerID string, categoryID *string, outputTemplateID *string) error {
+	if err := ensureCategoriesOwnedBy(ctx, u.categoryRepo, ownerID, []*string{categoryID}); err != nil {
+		return err
+	}
+	return ensureOutputTemplatesOwnedBy(ctx, u.outputTemplateRepo, ownerID, []*string{outputTemplateID})
+}
+
+// recordTaskChanges コミットしたタスクの変更をドメインの指標として記録（beforeがnilの場合は作成）
+func (u *TaskUsecase) recordTaskChanges(before *task.Task, after *task.Task, actorID string) {
+	u.eventRecorder.RecordTaskEvents(task.DiffTask(before, after, actorID))
+}
+
+// reloadTaskWithOwner 更新されたタスクとオーナーを再取得
+func (u *TaskUsecase) reloadTaskWithOwner(ctx context.Context, taskID string, ownerID string) (*task.Task, *account.Account, error) {
+	updatedTask, err := u.taskRepo.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	accounts, err := u.accountRepo.GetAccountsByIDs(ctx, []string{ownerID})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(accounts) == 0 {
+		return nil, nil, domainerrors.NotFound("Owner account not found")
+	}
+
+	return updatedTask, accounts[0], nil
+}
+
+// getTaskForUpdate タスクの行をロックしてからタスクを取得
+// トランザクション内で呼び出すと、確認から更新までの間に他の更新が割り込まない
+func (u *TaskUsecase) getTaskForUpdate(ctx context.Context, taskID string) (*task.Task, error) {
+	if err := u.taskRepo.LockTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+	return u.taskRepo.GetTaskByID(ctx, taskID)
+}
+
+// getTaskItemForUpdate タスクアイテムIDからタスクとタスクアイテムを取得し、更新できるか確認
+// タスクの行をロックしてから取得し直すため、トランザクション内で呼び出す
+func (u *TaskUsecase) getTaskItemForUpdate(ctx context.Context, taskItemID string, ownerID string) (*task.Task, *task.TaskItem, error) {
+	// タスクアイテムIDからタスクを特定し、ロックしてから取得し直す
+	found, err := u.taskRepo.GetTaskByTaskItemID(ctx, taskItemID)
+	if err != nil {
+		return nil, nil, err
+	}
+	t, err := u.getTaskForUpdate(ctx, found.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// オーナーチェック（他のアカウントのタスクは存在しないものとして扱う）
+	if err := authorizeTaskRead(t, ownerID); err != nil {
+		return nil, nil, err
+	}
+
+	// タスクアイテムが存在するか確認
+	for i := range t.TaskItems {
+		if t.TaskItems[i].ID == taskItemID {
+			return t, &t.TaskItems[i], nil
+		}
+	}
+	return nil, nil, domainerrors.NotFound("Task item not found")
+}
+
+// buildTaskItemOutput 入力からタスクアイテムに保存するアウトプットを組み立てる
+// テンプレートがない場合は自由形式のテキストのみを受け付け、sectionsはnilを返す
+func (u *TaskUsecase) buildTaskItemOutput(ctx context.Context, taskItem *task.TaskItem, input task.TaskItemOutputInput) (string, []outputtemplate.FilledSection, error) {
+	if taskItem.OutputTemplateID == nil {
+		if len(input.Sections) > 0 {
+			return "", nil, domainerrors.Validation("Task item has no output template")
+		}
+		if input.Text == nil || *input.Text == "" {
+			return "", nil, domainerrors.Validation("Validation failed").WithDetails(map[string]interface{}{
+				"errors": []outputtemplate.FieldError{{Field: "output", Message: "outputは1文字以上である必要があります"}},
+			})
+		}
+		return *input.Text, nil, nil
+	}
+
+	if input.Text != nil {
+		return "", nil, domainerrors.Validation("Task item with an output template requires sections instead of output")
+	}
+
+	template, err := u.outputTemplateRepo.GetOutputTemplateByID(ctx, *taskItem.OutputTemplateID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sections, err := template.Fill(input.Sections)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return outputtemplate.Flatten(sections), sections, nil
+}
@@ -0,0 +1,1514 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"task-management-system/backend/internal/domain/account"
+	domainerrors "task-management-system/backend/internal/domain/errors"
+	"task-management-system/backend/internal/domain/outputtemplate"
+	"task-management-system/backend/internal/domain/task"
+	"task-management-system/backend/internal/port/repository"
+)
+
+const (
+	aliceID = "0b8f3c1e-6a0d-4f3e-9d8e-2f6c1a7b9e01"
+	bobID   = "5d2e7a9c-1b4f-4c8a-8e3d-9a6b2c4d7f02"
+
+	aliceTaskID = "a1f0c3d2-7b6e-4a59-8c1d-3e2f4a5b6c01"
+	bobTaskID   = "b2e1d4c3-8c7f-4b6a-9d2e-4f3a5b6c7d02"
+)
+
+// fakeTaskRepository テスト用のタスクリポジトリ
+// テストで使用しないメソッドは埋め込んだインターフェース（nil）に委譲されるため、呼び出すとpanicする
+type fakeTaskRepository struct {
+	repository.TaskRepository
+	tasks []*task.Task
+	// carriedOver CarryOverTaskItemsに渡された入力
+	carriedOver []task.CarryOverInput
+	// purgedBefore PurgeTrashedTasksに渡された日時
+	purgedBefore time.Time
+	// events 記録された変更履歴
+	events []task.Event
+	// locked LockTaskでロックしたタスク
+	locked []string
+}
+
+// fakeTxManager トランザクションを開始せずにfnを実行し、実行結果を記録する
+type fakeTxManager struct {
+	committed  int
+	rolledBack int
+}
+
+func (m *fakeTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		m.rolledBack++
+		return err
+	}
+	m.committed++
+	return nil
+}
+
+// LockTask ロックしたタスクを記録する
+func (r *fakeTaskRepository) LockTask(ctx context.Context, taskID string) error {
+	if _, err := r.GetTaskByID(ctx, taskID); err != nil {
+		return err
+	}
+	r.locked = append(r.locked, taskID)
+	return nil
+}
+
+// GetTaskByID ゴミ箱のタスクは存在しないものとして扱う
+func (r *fakeTaskRepository) GetTaskByID(ctx context.Context, taskID string) (*task.Task, error) {
+	for _, t := range r.tasks {
+		if t.ID == taskID && t.DeletedAt == nil {
+			return t, nil
+		}
+	}
+	return nil, domainerrors.NotFound("Task not found")
+}
+
+func (r *fakeTaskRepository) DeleteTask(ctx context.Context, taskID string, actorID string, expectedVersion *int64) error {
+	t, err := r.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if err := task.CheckVersion(t.Version, expectedVersion); err != nil {
+		return err
+	}
+	now := time.Now()
+	t.DeletedAt = &now
+	t.Version++
+	return nil
+}
+
+func (r *fakeTaskRepository) GetTrashedTaskByID(ctx context.Context, taskID string) (*task.Task, error) {
+	for _, t := range r.tasks {
+		if t.ID == taskID && t.DeletedAt != nil {
+			return t, nil
+		}
+	}
+	return nil, domainerrors.NotFound("Task not found in trash")
+}
+
+func (r *fakeTaskRepository) RestoreTask(ctx context.Context, taskID string, actorID string) error {
+	t, err := r.GetTrashedTaskByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	t.DeletedAt = nil
+	t.Version++
+	return nil
+}
+
+func (r *fakeTaskRepository) PurgeTask(ctx context.Context, taskID string, actorID string) error {
+	if _, err := r.GetTrashedTaskByID(ctx, taskID); err != nil {
+		return err
+	}
+	r.tasks = slices.DeleteFunc(r.tasks, func(t *task.Task) bool { return t.ID == taskID })
+	return nil
+}
+
+// PurgeTrashedTasks 渡された日時を記録し、それより前にゴミ箱に移動したタスクを削除する
+func (r *fakeTaskRepository) PurgeTrashedTasks(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	r.purgedBefore = deletedBefore
+	before := len(r.tasks)
+	r.tasks = slices.DeleteFunc(r.tasks, func(t *task.Task) bool { return t.DeletedAt != nil && t.DeletedAt.Before(deletedBefore) })
+	return int64(before - len(r.tasks)), nil
+}
+
+// ListTasks 新しい順（created_at DESC, id DESC）の並び順のみに対応する
+func (r *fakeTaskRepository) ListTasks(ctx context.Context, condition task.ListTasksCondition) ([]*task.Task, error) {
+	result := []*task.Task{}
+	for _, t := range r.tasks {
+		if condition.OwnerID != nil && t.OwnerID != *condition.OwnerID {
+			continue
+		}
+		if c := condition.Cursor; c != nil && !isBefore(t, c.CreatedAt, c.ID) {
+			continue
+		}
+		result = append(result, t)
+	}
+
+	slices.SortFunc(result, func(a, b *task.Task) int {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return b.CreatedAt.Compare(a.CreatedAt)
+		}
+		return strings.Compare(b.ID, a.ID)
+	})
+
+	if condition.Limit > 0 && int32(len(result)) > condition.Limit {
+		result = result[:condition.Limit]
+	}
+	return result, nil
+}
+
+// isBefore 新しい順でタスクがカーソルの位置より後ろにあるかどうかを判定
+func isBefore(t *task.Task, createdAt time.Time, id string) bool {
+	if !t.CreatedAt.Equal(createdAt) {
+		return t.CreatedAt.Before(createdAt)
+	}
+	return t.ID < id
+}
+
+func (r *fakeTaskRepository) GetTaskByTaskItemID(ctx context.Context, taskItemID string) (*task.Task, error) {
+	for _, t := range r.tasks {
+		for _, item := range t.TaskItems {
+			if item.ID == taskItemID {
+				return t, nil
+			}
+		}
+	}
+	return nil, domainerrors.NotFound("Task not found")
+}
+
+// CreateTask 作成したタスクを保持する
+func (r *fakeTaskRepository) CreateTask(ctx context.Context, ownerID string, title string, date string, taskItems []task.CreateTaskItemInput) (*task.Task, error) {
+	d, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return nil, domainerrors.Validation("invalid date format").Wrap(err)
+	}
+
+	created := &task.Task{ID: "created-task", OwnerID: ownerID, Title: title, Date: d}
+	for _, item := range taskItems {
+		created.TaskItems = append(created.TaskItems, task.TaskItem{
+			Content:     item.Content,
+			Output:      item.Output,
+			Order:       item.Order,
+			Status:      item.Status,
+			StartedAt:   item.StartedAt,
+			CompletedAt: item.CompletedAt,
+		})
+	}
+	r.tasks = append(r.tasks, created)
+	return created, nil
+}
+
+// CarryOverTaskItems 入力を記録し、持ち越した子タスクだけを持つタスクを返す
+func (r *fakeTaskRepository) CarryOverTaskItems(ctx context.Context, input task.CarryOverInput) (*task.CarryOverResult, error) {
+	r.carriedOver = append(r.carriedOver, input)
+	target := &task.Task{ID: "target-task", OwnerID: input.OwnerID, Title: input.Title, Date: input.Date, TaskItems: input.TaskItems}
+	return &task.CarryOverResult{Target: target}, nil
+}
+
+// AddTaskItem 子タスクを追加する
+func (r *fakeTaskRepository) AddTaskItem(ctx context.Context, taskID string, actorID string, input task.CreateTaskItemInput) error {
+	for _, t := range r.tasks {
+		if t.ID == taskID {
+			t.TaskItems = append(t.TaskItems, task.TaskItem{
+				ID:          "added-item",
+				TaskID:      taskID,
+				Content:     input.Content,
+				Order:       input.Order,
+				Status:      input.Status,
+				StartedAt:   input.StartedAt,
+				CompletedAt: input.CompletedAt,
+			})
+			return nil
+		}
+	}
+	return domainerrors.NotFound("Task not found")
+}
+
+// SaveTaskItem 子タスクを置き換える
+func (r *fakeTaskRepository) SaveTaskItem(ctx context.Context, taskItem task.TaskItem, actorID string) error {
+	for _, t := range r.tasks {
+		for i := range t.TaskItems {
+			if t.TaskItems[i].ID == taskItem.ID {
+				t.TaskItems[i] = taskItem
+				return nil
+			}
+		}
+	}
+	return domainerrors.NotFound("Task item not found")
+}
+
+// DeleteTaskItem 子タスクを削除する
+func (r *fakeTaskRepository) DeleteTaskItem(ctx context.Context, taskID string, taskItemID string, actorID string) error {
+	for _, t := range r.tasks {
+		if t.ID != taskID {
+			continue
+		}
+		for i := range t.TaskItems {
+			if t.TaskItems[i].ID == taskItemID {
+				t.TaskItems = slices.Delete(t.TaskItems, i, i+1)
+				return nil
+			}
+		}
+	}
+	return domainerrors.NotFound("Task item not found")
+}
+
+// ReorderTaskItems 子タスクの順番を更新する
+func (r *fakeTaskRepository) ReorderTaskItems(ctx context.Context, taskID string, actorID string, orders []task.TaskItemOrder) error {
+	for _, t := range r.tasks {
+		if t.ID != taskID {
+			continue
+		}
+		for _, o := range orders {
+			for i := range t.TaskItems {
+				if t.TaskItems[i].ID == o.TaskItemID {
+					t.TaskItems[i].Order = o.Order
+				}
+			}
+		}
+		return nil
+	}
+	return domainerrors.NotFound("Task not found")
+}
+
+// UpdateTaskReview 振り返りを保存してバージョンを更新し、変更履歴を記録する
+func (r *fakeTaskRepository) UpdateTaskReview(ctx context.Context, taskID string, actorID string, review *string, expectedVersion *int64) error {
+	t, err := r.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if err := task.CheckVersion(t.Version, expectedVersion); err != nil {
+		return err
+	}
+	before := *t
+	t.Review = review
+	t.Version++
+	r.events = append(r.events, task.DiffTask(&before, t, actorID)...)
+	return nil
+}
+
+// UpdateTaskItemOutput アウトプットとステータスを保存してバージョンを更新する
+func (r *fakeTaskRepository) UpdateTaskItemOutput(ctx context.Context, taskItemID string, actorID string, output string, sections []outputtemplate.FilledSection, change task.StatusChange, expectedVersion *int64) error {
+	for _, t := range r.tasks {
+		for i := range t.TaskItems {
+			if t.TaskItems[i].ID == taskItemID {
+				if err := task.CheckVersion(t.Version, expectedVersion); err != nil {
+					return err
+				}
+				t.Version++
+				t.TaskItems[i].Output = &output
+				t.TaskItems[i].OutputSections = sections
+				change.Apply(&t.TaskItems[i])
+				return nil
+			}
+		}
+	}
+	return domainerrors.NotFound("Task item not found")
+}
+
+// ListTaskEvents タスクの変更履歴を記録した順に返す
+func (r *fakeTaskRepository) ListTaskEvents(ctx context.Context, taskID string) ([]task.Event, error) {
+	events := []task.Event{}
+	for _, e := range r.events {
+		if e.TaskID == taskID {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+// ApplyTimerTransition セッションとステータスを更新する
+func (r *fakeTaskRepository) ApplyTimerTransition(ctx context.Context, taskItemID string, actorID string, transition task.TimerTransition, at time.Time) error {
+	for _, t := range r.tasks {
+		for i := range t.TaskItems {
+			item := &t.TaskItems[i]
+			if item.ID != taskItemID {
+				continue
+			}
+			if n := len(item.TimerSessions); transition.EndReason != nil && n > 0 {
+				latest := &item.TimerSessions[n-1]
+				if latest.EndedAt == nil {
+					latest.EndedAt = &at
+				}
+				latest.EndReason = transition.EndReason
+			}
+			if transition.StartSession {
+				item.TimerSessions = append(item.TimerSessions, task.TimerSession{StartedAt: at})
+			}
+			item.Status = transition.Status
+			return nil
+		}
+	}
+	return domainerrors.NotFound("Task item not found")
+}
+
+// fakeAccountRepository テスト用のアカウントリポジトリ
+type fakeAccountRepository struct {
+	repository.AccountRepository
+	accounts []*account.Account
+}
+
+func (r *fakeAccountRepository) GetAccountsByIDs(ctx context.Context, accountIDs []string) ([]*account.Account, error) {
+	result := []*account.Account{}
+	for _, acc := range r.accounts {
+		for _, id := range accountIDs {
+			if acc.ID == id {
+				result = append(result, acc)
+			}
+		}
+	}
+	return result, nil
+}
+
+func newTestTaskUsecase() *TaskUsecase {
+	date := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	taskRepo := &fakeTaskRepository{
+		tasks: []*task.Task{
+			{ID: aliceTaskID, OwnerID: aliceID, Title: "Alice's day", Date: date},
+			{ID: bobTaskID, OwnerID: bobID, Title: "Bob's day", Date: date},
+		},
+	}
+	accountRepo := &fakeAccountRepository{
+		accounts: []*account.Account{
+			{ID: aliceID, FirstName: "Alice"},
+			{ID: bobID, FirstName: "Bob"},
+		},
+	}
+	return NewTaskUsecase(&fakeTxManager{}, taskRepo, accountRepo, &fakeCategoryRepository{}, &fakeOutputTemplateRepository{})
+}
+
+func TestTaskUsecase_GetTaskByID_Authorization(t *testing.T) {
+	tests := []struct {
+		name         string
+		taskID       string
+		viewerID     string
+		wantNotFound bool
+	}{
+		{name: "オーナーは自分のタスクを取得できる", taskID: aliceTaskID, viewerID: aliceID},
+		{name: "他のアカウントのタスクはNotFound", taskID: bobTaskID, viewerID: aliceID, wantNotFound: true},
+		{name: "存在しないタスクもNotFound", taskID: "c3d2e5f4-9d8a-4c7b-8e3f-5a4b6c7d8e03", viewerID: aliceID, wantNotFound: true},
+		{name: "閲覧者が不明な場合はNotFound", taskID: aliceTaskID, viewerID: "", wantNotFound: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestTaskUsecase()
+
+			got, owner, err := u.GetTaskByID(context.Background(), tt.taskID, tt.viewerID)
+			if tt.wantNotFound {
+				if !domainerrors.IsNotFound(err) {
+					t.Fatalf("err = %v, want NotFound", err)
+				}
+				if got != nil || owner != nil {
+					t.Errorf("got task = %v, owner = %v, want nil", got, owner)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ID != tt.taskID {
+				t.Errorf("task ID = %s, want %s", got.ID, tt.taskID)
+			}
+			if owner.ID != tt.viewerID {
+				t.Errorf("owner ID = %s, want %s", owner.ID, tt.viewerID)
+			}
+		})
+	}
+}
+
+func TestTaskUsecase_Mutation_Authorization(t *testing.T) {
+	const bobItemID = "bob-item-1"
+	text := "Aliceのアウトプット"
+	content := "書き換え"
+	review := "Aliceの振り返り"
+
+	tests := []struct {
+		name    string
+		trashed bool
+		mutate  func(u *TaskUsecase) error
+	}{
+		{name: "タスクの更新", mutate: func(u *TaskUsecase) error {
+			_, _, err := u.UpdateTask(context.Background(), bobTaskID, aliceID, "書き換え", "2026-10-01", nil, nil)
+			return err
+		}},
+		{name: "タスクの削除", mutate: func(u *TaskUsecase) error {
+			return u.DeleteTask(context.Background(), bobTaskID, aliceID, nil)
+		}},
+		{name: "ゴミ箱から元に戻す", trashed: true, mutate: func(u *TaskUsecase) error {
+			_, _, err := u.RestoreTask(context.Background(), bobTaskID, aliceID)
+			return err
+		}},
+		{name: "完全に削除", trashed: true, mutate: func(u *TaskUsecase) error {
+			return u.PurgeTask(context.Background(), bobTaskID, aliceID)
+		}},
+		{name: "振り返りの更新", mutate: func(u *TaskUsecase) error {
+			_, _, err := u.UpdateTaskReview(context.Background(), bobTaskID, aliceID, &review, nil)
+			return err
+		}},
+		{name: "子タスクの追加", mutate: func(u *TaskUsecase) error {
+			_, _, err := u.AddTaskItem(context.Background(), bobTaskID, aliceID, task.CreateTaskItemInput{
+				Priority: task.PriorityMedium, Density: task.DensityMedium, DurationTime: task.DurationTime15, Content: content, Order: 2, Status: task.StatusNotStarted,
+			})
+			return err
+		}},
+		{name: "子タスクの並び替え", mutate: func(u *TaskUsecase) error {
+			_, _, err := u.ReorderTaskItems(context.Background(), bobTaskID, aliceID, []string{bobItemID})
+			return err
+		}},
+		{name: "子タスクの部分更新", mutate: func(u *TaskUsecase) error {
+			_, _, err := u.PatchTaskItem(context.Background(), bobItemID, aliceID, task.TaskItemPatch{Content: &content})
+			return err
+		}},
+		{name: "子タスクのステータス変更", mutate: func(u *TaskUsecase) error {
+			_, _, err := u.ChangeTaskItemStatus(context.Background(), bobItemID, aliceID, task.StatusInProgress)
+			return err
+		}},
+		{name: "子タスクのアウトプットの入力", mutate: func(u *TaskUsecase) error {
+			_, _, err := u.UpdateTaskItemOutput(context.Background(), bobItemID, aliceID, task.TaskItemOutputInput{Text: &text}, nil)
+			return err
+		}},
+		{name: "子タスクのタイマー操作", mutate: func(u *TaskUsecase) error {
+			_, _, err := u.ControlTaskItemTimer(context.Background(), bobItemID, aliceID, task.TimerActionStart)
+			return err
+		}},
+		{name: "子タスクの削除", mutate: func(u *TaskUsecase) error {
+			_, _, err := u.DeleteTaskItem(context.Background(), bobItemID, aliceID)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestTaskUsecase()
+			taskRepo := u.taskRepo.(*fakeTaskRepository)
+			txManager := u.txManager.(*fakeTxManager)
+			bobTask := taskRepo.tasks[1]
+			bobTask.TaskItems = []task.TaskItem{
+				{ID: bobItemID, TaskID: bobTaskID, Priority: task.PriorityHigh, Density: task.DensityHigh, DurationTime: task.DurationTime30, Content: "Bob's item", Order: 1, Status: task.StatusNotStarted},
+			}
+			if tt.trashed {
+				deletedAt := time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)
+				bobTask.DeletedAt = &deletedAt
+			}
+
+			// 他のアカウントのタスクは存在を知られないようにNotFoundを返す
+			if err := tt.mutate(u); !domainerrors.IsNotFound(err) {
+				t.Fatalf("err = %v, want NotFound", err)
+			}
+			if txManager.committed != 0 || txManager.rolledBack != 1 {
+				t.Errorf("committed = %d, rolledBack = %d, want 0, 1", txManager.committed, txManager.rolledBack)
+			}
+			if len(taskRepo.tasks) != 2 || bobTask.Title != "Bob's day" || len(bobTask.TaskItems) != 1 {
+				t.Fatalf("another owner's task is modified: %+v", bobTask)
+			}
+			if item := bobTask.TaskItems[0]; item.Content != "Bob's item" || item.Status != task.StatusNotStarted || item.Output != nil {
+				t.Errorf("another owner's task item is modified: %+v", item)
+			}
+			if (bobTask.DeletedAt != nil) != tt.trashed {
+				t.Errorf("deletedAt = %v, want trashed = %t", bobTask.DeletedAt, tt.trashed)
+			}
+		})
+	}
+}
+
+func TestTaskUsecase_ListTasks_Authorization(t *testing.T) {
+	alice := aliceID
+	bob := bobID
+
+	tests := []struct {
+		name        string
+		viewerID    string
+		ownerID     *string
+		wantTaskIDs []string
+	}{
+		{name: "ownerId未指定の場合は自分のタスクのみ", viewerID: aliceID, wantTaskIDs: []string{aliceTaskID}},
+		{name: "ownerIdに自分を指定", viewerID: aliceID, ownerID: &alice, wantTaskIDs: []string{aliceTaskID}},
+		{name: "ownerIdに他のアカウントを指定した場合は空", viewerID: aliceID, ownerID: &bob, wantTaskIDs: []string{}},
+		{name: "他のアカウントから見ても自分のタスクのみ", viewerID: bobID, wantTaskIDs: []string{bobTaskID}},
+		{name: "閲覧者が不明な場合は空", viewerID: "", wantTaskIDs: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestTaskUsecase()
+
+			result, err := u.ListTasks(context.Background(), tt.viewerID, task.ListTasksCondition{OwnerID: tt.ownerID})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tasks := result.Tasks
+
+			if len(tasks) != len(tt.wantTaskIDs) {
+				t.Fatalf("got %d tasks, want %d", len(tasks), len(tt.wantTaskIDs))
+			}
+			for i, got := range tasks {
+				if got.ID != tt.wantTaskIDs[i] {
+					t.Errorf("tasks[%d].ID = %s, want %s", i, got.ID, tt.wantTaskIDs[i])
+				}
+				if got.OwnerID != tt.viewerID {
+					t.Errorf("tasks[%d].OwnerID = %s, want %s", i, got.OwnerID, tt.viewerID)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskUsecase_ListTasks_Pagination(t *testing.T) {
+	createdAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	taskRepo := &fakeTaskRepository{}
+	// 作成日時が同じタスクを含めて、IDによるタイブレークを確認する
+	for i, id := range []string{
+		"00000000-0000-4000-8000-000000000001",
+		"00000000-0000-4000-8000-000000000002",
+		"00000000-0000-4000-8000-000000000003",
+		"00000000-0000-4000-8000-000000000004",
+		"00000000-0000-4000-8000-000000000005",
+	} {
+		taskRepo.tasks = append(taskRepo.tasks, &task.Task{
+			ID:        id,
+			OwnerID:   aliceID,
+			Date:      createdAt,
+			CreatedAt: createdAt.Add(time.Duration(i/2) * time.Hour),
+		})
+	}
+	accountRepo := &fakeAccountRepository{accounts: []*account.Account{{ID: aliceID}}}
+	u := NewTaskUsecase(&fakeTxManager{}, taskRepo, accountRepo, &fakeCategoryRepository{}, &fakeOutputTemplateRepository{})
+
+	var gotIDs []string
+	condition := task.ListTasksCondition{Limit: 2}
+	for page := 0; ; page++ {
+		if page > 5 {
+			t.Fatal("pagination did not terminate")
+		}
+
+		result, err := u.ListTasks(context.Background(), aliceID, condition)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if int32(len(result.Tasks)) > condition.Limit {
+			t.Fatalf("got %d tasks, want at most %d", len(result.Tasks), condition.Limit)
+		}
+		for _, got := range result.Tasks {
+			gotIDs = append(gotIDs, got.ID)
+		}
+
+		if result.NextCursor == nil {
+			break
+		}
+		cursor, err := task.DecodeListTasksCursor(*result.NextCursor)
+		if err != nil {
+			t.Fatalf("failed to decode next cursor: %v", err)
+		}
+		condition.Cursor = cursor
+	}
+
+	wantIDs := []string{
+		"00000000-0000-4000-8000-000000000005",
+		"00000000-0000-4000-8000-000000000004",
+		"00000000-0000-4000-8000-000000000003",
+		"00000000-0000-4000-8000-000000000002",
+		"00000000-0000-4000-8000-000000000001",
+	}
+	if !slices.Equal(gotIDs, wantIDs) {
+		t.Errorf("ids = %v, want %v", gotIDs, wantIDs)
+	}
+}
+
+func TestTaskUsecase_ListTasks_CursorSortMismatch(t *testing.T) {
+	u := newTestTaskUsecase()
+	oldest := string(task.SortOldest)
+
+	_, err := u.ListTasks(context.Background(), aliceID, task.ListTasksCondition{
+		Sort:   &oldest,
+		Cursor: &task.ListTasksCursor{Sort: task.SortNewest, CreatedAt: time.Now(), Date: "2026-10-01", ID: aliceTaskID},
+	})
+	if !domainerrors.IsValidation(err) {
+		t.Fatalf("err = %v, want Validation", err)
+	}
+}
+
+func TestTaskUsecase_ListTasks_CursorKeywordMismatch(t *testing.T) {
+	u := newTestTaskUsecase()
+	meeting := "会議"
+	report := "日報"
+	cursor := task.NewListTasksCursor(task.SortRelevance, &task.Task{ID: aliceTaskID, CreatedAt: time.Now()}, &meeting)
+
+	// 関連度は別のキーワードでは比較できないため、カーソルを発行したときと異なるキーワードでは使用できない
+	_, err := u.ListTasks(context.Background(), aliceID, task.ListTasksCondition{
+		Keyword: &report,
+		Cursor:  &cursor,
+	})
+	if !domainerrors.IsValidation(err) {
+		t.Fatalf("err = %v, want Validation", err)
+	}
+}
+
+func TestTaskUsecase_ListTasks_Keyword(t *testing.T) {
+	u := newTestTaskUsecase()
+
+	keyword := "alice"
+	result, err := u.ListTasks(context.Background(), aliceID, task.ListTasksCondition{Keyword: &keyword})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	matches := result.SearchMatches[aliceTaskID]
+	if len(matches) != 1 || matches[0].Field != task.SearchFieldTitle {
+		t.Errorf("search matches = %+v, want title match", matches)
+	}
+
+	// 空白のみのキーワードは指定なしとして扱い、関連度順も指定できない
+	blank := "  "
+	relevance := string(task.SortRelevance)
+	result, err = u.ListTasks(context.Background(), aliceID, task.ListTasksCondition{Keyword: &blank})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.SearchMatches != nil {
+		t.Errorf("search matches = %+v, want nil", result.SearchMatches)
+	}
+	if _, err := u.ListTasks(context.Background(), aliceID, task.ListTasksCondition{Keyword: &blank, Sort: &relevance}); !domainerrors.IsValidation(err) {
+		t.Errorf("err = %v, want Validation", err)
+	}
+}
+
+func TestTaskUsecase_UpdateTaskItemOutput(t *testing.T) {
+	const (
+		freeItemID     = "e1f0c3d2-7b6e-4a59-8c1d-3e2f4a5b6c01"
+		templateItemID = "e2e1d4c3-8c7f-4b6a-9d2e-4f3a5b6c7d02"
+	)
+	text := "自由形式のアウトプット"
+	templateID := aliceOutputTemplateID
+
+	tests := []struct {
+		name         string
+		taskItemID   string
+		input        task.TaskItemOutputInput
+		wantErr      func(error) bool
+		wantOutput   string
+		wantSections []outputtemplate.FilledSection
+	}{
+		{
+			name:       "テンプレートなしは自由形式で保存",
+			taskItemID: freeItemID,
+			input:      task.TaskItemOutputInput{Text: &text},
+			wantOutput: text,
+		},
+		{
+			name:       "テンプレートなしでoutputが空",
+			taskItemID: freeItemID,
+			input:      task.TaskItemOutputInput{},
+			wantErr:    domainerrors.IsValidation,
+		},
+		{
+			name:       "テンプレートなしでsectionsを指定",
+			taskItemID: freeItemID,
+			input:      task.TaskItemOutputInput{Sections: []outputtemplate.SectionValue{{Key: "learned", Value: "a"}}},
+			wantErr:    domainerrors.IsValidation,
+		},
+		{
+			name:       "テンプレートに沿って保存（テンプレートの順に並ぶ）",
+			taskItemID: templateItemID,
+			input: task.TaskItemOutputInput{Sections: []outputtemplate.SectionValue{
+				{Key: "next", Value: "テストを書く"},
+				{Key: "learned", Value: "キーセットページネーション"},
+			}},
+			wantOutput: "■ 学んだこと\nキーセットページネーション\n\n■ 次にやること\nテストを書く",
+			wantSections: []outputtemplate.FilledSection{
+				{Key: "learned", Label: "学んだこと", Value: "キーセットページネーション"},
+				{Key: "next", Label: "次にやること", Value: "テストを書く"},
+			},
+		},
+		{
+			name:       "必須セクションが未入力",
+			taskItemID: templateItemID,
+			input:      task.TaskItemOutputInput{Sections: []outputtemplate.SectionValue{{Key: "learned", Value: "a"}}},
+			wantErr:    domainerrors.IsValidation,
+		},
+		{
+			name:       "テンプレートありで自由形式を指定",
+			taskItemID: templateItemID,
+			input:      task.TaskItemOutputInput{Text: &text},
+			wantErr:    domainerrors.IsValidation,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskRepo := &fakeTaskRepository{
+				tasks: []*task.Task{{
+					ID:      aliceTaskID,
+					OwnerID: aliceID,
+					TaskItems: []task.TaskItem{
+						{ID: freeItemID, TaskID: aliceTaskID, Status: task.StatusInProgress},
+						{ID: templateItemID, TaskID: aliceTaskID, Status: task.StatusInProgress, OutputTemplateID: &templateID},
+					},
+				}},
+			}
+			accountRepo := &fakeAccountRepository{accounts: []*account.Account{{ID: aliceID}}}
+			u := NewTaskUsecase(&fakeTxManager{}, taskRepo, accountRepo, &fakeCategoryRepository{}, newTestOutputTemplateRepository())
+
+			got, _, err := u.UpdateTaskItemOutput(context.Background(), tt.taskItemID, aliceID, tt.input, nil)
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			item := got.TaskItems[slices.IndexFunc(got.TaskItems, func(item task.TaskItem) bool { return item.ID == tt.taskItemID })]
+			if item.Output == nil || *item.Output != tt.wantOutput {
+				t.Errorf("output = %v, want %q", item.Output, tt.wantOutput)
+			}
+			if !slices.Equal(item.OutputSections, tt.wantSections) {
+				t.Errorf("sections = %v, want %v", item.OutputSections, tt.wantSections)
+			}
+			if item.Status != task.StatusCompleted {
+				t.Errorf("status = %s, want %s", item.Status, task.StatusCompleted)
+			}
+		})
+	}
+}
+
+func TestTaskUsecase_UpdateTaskReview_Version(t *testing.T) {
+	version := func(v int64) *int64 { return &v }
+	review := "よく進んだ"
+
+	tests := []struct {
+		name            string
+		taskID          string
+		expectedVersion *int64
+		wantErr         func(error) bool
+	}{
+		{name: "バージョンが一致する場合は更新できる", taskID: aliceTaskID, expectedVersion: version(3)},
+		{name: "バージョンを確認しない（If-Match: *）", taskID: aliceTaskID, expectedVersion: nil},
+		{name: "他のタブで更新された古いバージョン", taskID: aliceTaskID, expectedVersion: version(2), wantErr: domainerrors.IsPreconditionFailed},
+		{name: "他のアカウントのタスクはバージョンより先に存在しないものとして扱う", taskID: bobTaskID, expectedVersion: version(2), wantErr: domainerrors.IsNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestTaskUsecase()
+			for _, tk := range u.taskRepo.(*fakeTaskRepository).tasks {
+				tk.Version = 3
+			}
+
+			got, _, err := u.UpdateTaskReview(context.Background(), tt.taskID, aliceID, &review, tt.expectedVersion)
+			if tt.wantErr != nil {
+				if !tt.wantErr(err) {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Version != 4 {
+				t.Errorf("version = %d, want 4", got.Version)
+			}
+			if got.Review == nil || *got.Review != review {
+				t.Errorf("review = %v, want %q", got.Review, review)
+			}
+		})
+	}
+}
+
+func TestTaskUsecase_ControlTaskItemTimer(t *testing.T) {
+	const taskItemID = "e1f0c3d2-7b6e-4a59-8c1d-3e2f4a5b6c01"
+
+	taskRepo := &fakeTaskRepository{
+		tasks: []*task.Task{{
+			ID:        aliceTaskID,
+			OwnerID:   aliceID,
+			TaskItems: []task.TaskItem{{ID: taskItemID, TaskID: aliceTaskID, Status: task.StatusNotStarted}},
+		}},
+	}
+	accountRepo := &fakeAccountRepository{accounts: []*account.Account{{ID: aliceID}}}
+	u := NewTaskUsecase(&fakeTxManager{}, taskRepo, accountRepo, &fakeCategoryRepository{}, &fakeOutputTemplateRepository{})
+	ctx := context.Background()
+
+	// 他のアカウントのタスクアイテムは存在しないものとして扱う
+	if _, _, err := u.ControlTaskItemTimer(ctx, taskItemID, bobID, task.TimerActionStart); !domainerrors.IsNotFound(err) {
+		t.Fatalf("err = %v, want NotFound", err)
+	}
+
+	steps := []struct {
+		action       task.TimerAction
+		wantState    task.TimerState
+		wantSessions int
+	}{
+		{action: task.TimerActionStart, wantState: task.TimerStateRunning, wantSessions: 1},
+		{action: task.TimerActionPause, wantState: task.TimerStatePaused, wantSessions: 1},
+		{action: task.TimerActionResume, wantState: task.TimerStateRunning, wantSessions: 2},
+		{action: task.TimerActionStop, wantState: task.TimerStateIdle, wantSessions: 2},
+	}
+	for _, step := range steps {
+		got, _, err := u.ControlTaskItemTimer(ctx, taskItemID, aliceID, step.action)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", step.action, err)
+		}
+		item := got.TaskItems[0]
+		if item.TimerState() != step.wantState {
+			t.Errorf("%s: timer state = %s, want %s", step.action, item.TimerState(), step.wantState)
+		}
+		if len(item.TimerSessions) != step.wantSessions {
+			t.Errorf("%s: got %d sessions, want %d", step.action, len(item.TimerSessions), step.wantSessions)
+		}
+		// 開始時に未着手から着手中になる
+		if item.Status != task.StatusInProgress {
+			t.Errorf("%s: status = %s, want %s", step.action, item.Status, task.StatusInProgress)
+		}
+	}
+
+	// 停止済みのタイマーは一時停止できない
+	if _, _, err := u.ControlTaskItemTimer(ctx, taskItemID, aliceID, task.TimerActionPause); !domainerrors.IsConflict(err) {
+		t.Fatalf("err = %v, want Conflict", err)
+	}
+}
+
+func TestTaskUsecase_DuplicateTask(t *testing.T) {
+	output := "学んだこと"
+	newUsecase := func() *TaskUsecase {
+		taskRepo := &fakeTaskRepository{
+			tasks: []*task.Task{
+				{
+					ID: aliceTaskID, OwnerID: aliceID, Title: "Alice's day",
+					TaskItems: []task.TaskItem{
+						{ID: "item-1", Content: "メールを確認する", Order: 1, Status: task.StatusCompleted, Output: &output},
+						{ID: "item-2", Content: "資料を作る", Order: 2, Status: task.StatusInProgress},
+					},
+				},
+				{ID: bobTaskID, OwnerID: bobID, Title: "Bob's day"},
+			},
+		}
+		accountRepo := &fakeAccountRepository{accounts: []*account.Account{{ID: aliceID, FirstName: "Alice"}}}
+		return NewTaskUsecase(&fakeTxManager{}, taskRepo, accountRepo, &fakeCategoryRepository{}, &fakeOutputTemplateRepository{})
+	}
+
+	t.Run("指定した日付に複製する", func(t *testing.T) {
+		u := newUsecase()
+		created, owner, err := u.DuplicateTask(context.Background(), aliceTaskID, aliceID, "2026-10-20", task.DuplicateOptions{ResetStatus: true, DropOutputs: true, IncompleteOnly: true})
+		if err != nil {
+			t.Fatalf("DuplicateTask() error = %v", err)
+		}
+		if owner.ID != aliceID || created.Title != "Alice's day" || created.Date.Format(time.DateOnly) != "2026-10-20" {
+			t.Errorf("created = %+v, owner = %+v", created, owner)
+		}
+		if len(created.TaskItems) != 1 {
+			t.Fatalf("len(TaskItems) = %d, want 1", len(created.TaskItems))
+		}
+		item := created.TaskItems[0]
+		if item.Content != "資料を作る" || item.Order != 1 || item.Status != task.StatusNotStarted || item.Output != nil {
+			t.Errorf("item = %+v", item)
+		}
+
+		// 複製元をロックしてから、取得と作成を1つのトランザクションで実行する
+		txManager := u.txManager.(*fakeTxManager)
+		if txManager.committed != 1 || txManager.rolledBack != 0 {
+			t.Errorf("committed = %d, rolledBack = %d, want 1, 0", txManager.committed, txManager.rolledBack)
+		}
+		if locked := u.taskRepo.(*fakeTaskRepository).locked; len(locked) != 1 || locked[0] != aliceTaskID {
+			t.Errorf("locked = %v, want [%s]", locked, aliceTaskID)
+		}
+	})
+
+	t.Run("他人のタスクは存在しないものとして扱う", func(t *testing.T) {
+		u := newUsecase()
+		_, _, err := u.DuplicateTask(context.Background(), bobTaskID, aliceID, "2026-10-20", task.DuplicateOptions{})
+		if !domainerrors.IsNotFound(err) {
+			t.Errorf("DuplicateTask() error = %v, want NotFound", err)
+		}
+		if txManager := u.txManager.(*fakeTxManager); txManager.rolledBack != 1 {
+			t.Errorf("rolledBack = %d, want 1", txManager.rolledBack)
+		}
+	})
+}
+
+func TestTaskUsecase_CarryOverTaskItems(t *testing.T) {
+	today := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	tomorrow := today.AddDate(0, 0, 1)
+	newRepository := func() *fakeTaskRepository {
+		return &fakeTaskRepository{
+			tasks: []*task.Task{
+				{
+					ID: aliceTaskID, OwnerID: aliceID, Title: "Alice's day", Date: today,
+					TaskItems: []task.TaskItem{
+						{ID: "item-1", Order: 1, Status: task.StatusCompleted},
+						{ID: "item-2", Order: 2, Status: task.StatusInProgress},
+					},
+				},
+				{ID: bobTaskID, OwnerID: bobID, Title: "Bob's day", Date: today},
+			},
+		}
+	}
+	accountRepo := &fakeAccountRepository{accounts: []*account.Account{{ID: aliceID, FirstName: "Alice"}}}
+
+	t.Run("完了していない子タスクを持ち越す", func(t *testing.T) {
+		taskRepo := newRepository()
+		u := NewTaskUsecase(&fakeTxManager{}, taskRepo, accountRepo, &fakeCategoryRepository{}, &fakeOutputTemplateRepository{})
+
+		target, owner, err := u.CarryOverTaskItems(context.Background(), aliceTaskID, aliceID, tomorrow, task.CarryOverModeCopy)
+		if err != nil {
+			t.Fatalf("CarryOverTaskItems() error = %v", err)
+		}
+		if owner.ID != aliceID || target.ID != "target-task" {
+			t.Errorf("target = %+v, owner = %+v", target, owner)
+		}
+		if len(taskRepo.carriedOver) != 1 {
+			t.Fatalf("CarryOverTaskItems was called %d times, want 1", len(taskRepo.carriedOver))
+		}
+		input := taskRepo.carriedOver[0]
+		if input.SourceTaskID != aliceTaskID || input.Mode != task.CarryOverModeCopy || len(input.TaskItems) != 1 || input.TaskItems[0].ID != "item-2" {
+			t.Errorf("input = %+v", input)
+		}
+	})
+
+	t.Run("他人のタスクは存在しないものとして扱う", func(t *testing.T) {
+		taskRepo := newRepository()
+		u := NewTaskUsecase(&fakeTxManager{}, taskRepo, accountRepo, &fakeCategoryRepository{}, &fakeOutputTemplateRepository{})
+
+		_, _, err := u.CarryOverTaskItems(context.Background(), bobTaskID, aliceID, tomorrow, task.CarryOverModeMove)
+		if !domainerrors.IsNotFound(err) {
+			t.Errorf("CarryOverTaskItems() error = %v, want NotFound", err)
+		}
+		if len(taskRepo.carriedOver) != 0 {
+			t.Errorf("CarryOverTaskItems was called for another owner's task")
+		}
+	})
+}
+
+func TestTaskUsecase_TaskItemOperations(t *testing.T) {
+	date := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	newUsecase := func() (*TaskUsecase, *fakeTaskRepository) {
+		taskRepo := &fakeTaskRepository{
+			tasks: []*task.Task{
+				{
+					ID: aliceTaskID, OwnerID: aliceID, Title: "Alice's day", Date: date,
+					TaskItems: []task.TaskItem{
+						{ID: "alice-item-1", TaskID: aliceTaskID, Priority: task.PriorityHigh, Density: task.DensityHigh, DurationTime: task.DurationTime30, Content: "メールを確認する", Order: 1, Status: task.StatusNotStarted},
+						{ID: "alice-item-2", TaskID: aliceTaskID, Priority: task.PriorityLow, Density: task.DensityLow, DurationTime: task.DurationTime15, Content: "資料を作る", Order: 2, Status: task.StatusNotStarted},
+					},
+				},
+				{
+					ID: bobTaskID, OwnerID: bobID, Title: "Bob's day", Date: date,
+					TaskItems: []task.TaskItem{
+						{ID: "bob-item-1", TaskID: bobTaskID, Priority: task.PriorityHigh, Density: task.DensityHigh, DurationTime: task.DurationTime30, Content: "Bob's item", Order: 1, Status: task.StatusNotStarted},
+					},
+				},
+			},
+		}
+		accountRepo := &fakeAccountRepository{accounts: []*account.Account{{ID: aliceID, FirstName: "Alice"}, {ID: bobID, FirstName: "Bob"}}}
+		return NewTaskUsecase(&fakeTxManager{}, taskRepo, accountRepo, &fakeCategoryRepository{}, &fakeOutputTemplateRepository{}), taskRepo
+	}
+	input := task.CreateTaskItemInput{
+		Priority:     task.PriorityMedium,
+		Density:      task.DensityMedium,
+		DurationTime: task.DurationTime45,
+		Content:      "振り返る",
+		Order:        3,
+		Status:       task.StatusNotStarted,
+	}
+
+	t.Run("子タスクを追加する", func(t *testing.T) {
+		u, _ := newUsecase()
+		updated, owner, err := u.AddTaskItem(context.Background(), aliceTaskID, aliceID, input)
+		if err != nil {
+			t.Fatalf("AddTaskItem() error = %v", err)
+		}
+		if owner.ID != aliceID || len(updated.TaskItems) != 3 {
+			t.Errorf("updated = %+v, owner = %+v", updated, owner)
+		}
+	})
+
+	t.Run("順番が重複する子タスクは追加できない", func(t *testing.T) {
+		u, taskRepo := newUsecase()
+		duplicated := input
+		duplicated.Order = 1
+		if _, _, err := u.AddTaskItem(context.Background(), aliceTaskID, aliceID, duplicated); !domainerrors.IsConflict(err) {
+			t.Errorf("AddTaskItem() error = %v, want Conflict", err)
+		}
+		if len(taskRepo.tasks[0].TaskItems) != 2 {
+			t.Errorf("task item was added")
+		}
+	})
+
+	t.Run("子タスクの一部の項目を更新する", func(t *testing.T) {
+		u, taskRepo := newUsecase()
+		content := "メールを返信する"
+		if _, _, err := u.PatchTaskItem(context.Background(), "alice-item-1", aliceID, task.TaskItemPatch{Content: &content}); err != nil {
+			t.Fatalf("PatchTaskItem() error = %v", err)
+		}
+		item := taskRepo.tasks[0].TaskItems[0]
+		if item.Content != content || item.Priority != task.PriorityHigh || item.Order != 1 {
+			t.Errorf("item = %+v", item)
+		}
+	})
+
+	t.Run("ステータスを変更する", func(t *testing.T) {
+		u, taskRepo := newUsecase()
+		if _, _, err := u.ChangeTaskItemStatus(context.Background(), "alice-item-2", aliceID, task.StatusInProgress); err != nil {
+			t.Fatalf("ChangeTaskItemStatus() error = %v", err)
+		}
+		if got := taskRepo.tasks[0].TaskItems[1].Status; got != task.StatusInProgress {
+			t.Errorf("status = %s, want InProgress", got)
+		}
+	})
+
+	t.Run("完了から未着手には戻せない", func(t *testing.T) {
+		u, taskRepo := newUsecase()
+		if _, _, err := u.ChangeTaskItemStatus(context.Background(), "alice-item-1", aliceID, task.StatusCompleted); err != nil {
+			t.Fatalf("ChangeTaskItemStatus() error = %v", err)
+		}
+		item := taskRepo.tasks[0].TaskItems[0]
+		if item.StartedAt == nil || item.CompletedAt == nil {
+			t.Errorf("timestamps are not set: %+v", item)
+		}
+
+		if _, _, err := u.ChangeTaskItemStatus(context.Background(), "alice-item-1", aliceID, task.StatusNotStarted); !domainerrors.IsConflict(err) {
+			t.Errorf("ChangeTaskItemStatus() error = %v, want Conflict", err)
+		}
+		if got := taskRepo.tasks[0].TaskItems[0].Status; got != task.StatusCompleted {
+			t.Errorf("status = %s, want Completed", got)
+		}
+	})
+
+	t.Run("子タスクを削除する", func(t *testing.T) {
+		u, _ := newUsecase()
+		updated, _, err := u.DeleteTaskItem(context.Background(), "alice-item-1", aliceID)
+		if err != nil {
+			t.Fatalf("DeleteTaskItem() error = %v", err)
+		}
+		if len(updated.TaskItems) != 1 || updated.TaskItems[0].ID != "alice-item-2" {
+			t.Errorf("TaskItems = %+v", updated.TaskItems)
+		}
+	})
+
+	t.Run("最後の子タスクは削除できない", func(t *testing.T) {
+		u, _ := newUsecase()
+		if _, _, err := u.DeleteTaskItem(context.Background(), "bob-item-1", bobID); !domainerrors.IsValidation(err) {
+			t.Errorf("DeleteTaskItem() error = %v, want Validation", err)
+		}
+	})
+
+	t.Run("子タスクを並び替える", func(t *testing.T) {
+		u, taskRepo := newUsecase()
+		if _, _, err := u.ReorderTaskItems(context.Background(), aliceTaskID, aliceID, []string{"alice-item-2", "alice-item-1"}); err != nil {
+			t.Fatalf("ReorderTaskItems() error = %v", err)
+		}
+		items := taskRepo.tasks[0].TaskItems
+		if items[0].Order != 2 || items[1].Order != 1 {
+			t.Errorf("TaskItems = %+v", items)
+		}
+	})
+
+	t.Run("すべての子タスクを含まない並び替えはできない", func(t *testing.T) {
+		u, _ := newUsecase()
+		if _, _, err := u.ReorderTaskItems(context.Background(), aliceTaskID, aliceID, []string{"alice-item-2"}); !domainerrors.IsValidation(err) {
+			t.Errorf("ReorderTaskItems() error = %v, want Validation", err)
+		}
+	})
+
+	t.Run("他人の子タスクは存在しないものとして扱う", func(t *testing.T) {
+		u, taskRepo := newUsecase()
+		content := "書き換え"
+		if _, _, err := u.AddTaskItem(context.Background(), bobTaskID, aliceID, input); !domainerrors.IsNotFound(err) {
+			t.Errorf("AddTaskItem() error = %v, want NotFound", err)
+		}
+		if _, _, err := u.PatchTaskItem(context.Background(), "bob-item-1", aliceID, task.TaskItemPatch{Content: &content}); !domainerrors.IsNotFound(err) {
+			t.Errorf("PatchTaskItem() error = %v, want NotFound", err)
+		}
+		if _, _, err := u.ChangeTaskItemStatus(context.Background(), "bob-item-1", aliceID, task.StatusCompleted); !domainerrors.IsNotFound(err) {
+			t.Errorf("ChangeTaskItemStatus() error = %v, want NotFound", err)
+		}
+		if _, _, err := u.DeleteTaskItem(context.Background(), "bob-item-1", aliceID); !domainerrors.IsNotFound(err) {
+			t.Errorf("DeleteTaskItem() error = %v, want NotFound", err)
+		}
+		if _, _, err := u.ReorderTaskItems(context.Background(), bobTaskID, aliceID, []string{"bob-item-1"}); !domainerrors.IsNotFound(err) {
+			t.Errorf("ReorderTaskItems() error = %v, want NotFound", err)
+		}
+		if item := taskRepo.tasks[1].TaskItems[0]; item.Content != "Bob's item" || item.Status != task.StatusNotStarted {
+			t.Errorf("another owner's task item is modified: %+v", item)
+		}
+	})
+}
+
+func TestTaskUsecase_CreateTaskItemStatusTimes(t *testing.T) {
+	newInput := func(status task.Status) task.CreateTaskItemInput {
+		return task.CreateTaskItemInput{
+			Priority:     task.PriorityMedium,
+			Density:      task.DensityMedium,
+			DurationTime: task.DurationTime30,
+			Content:      "レビューする",
+			Order:        1,
+			Status:       status,
+		}
+	}
+
+	tests := []struct {
+		name            string
+		create          func(u *TaskUsecase, input task.CreateTaskItemInput) (*task.Task, error)
+		status          task.Status
+		wantStartedAt   bool
+		wantCompletedAt bool
+	}{
+		{name: "タスクの作成で未着手の子タスク", create: createTaskWithItem, status: task.StatusNotStarted},
+		{name: "タスクの作成で着手中の子タスク", create: createTaskWithItem, status: task.StatusInProgress, wantStartedAt: true},
+		{name: "タスクの作成で完了の子タスク", create: createTaskWithItem, status: task.StatusCompleted, wantStartedAt: true, wantCompletedAt: true},
+		{name: "子タスクの追加で着手中の子タスク", create: addTaskItem, status: task.StatusInProgress, wantStartedAt: true},
+		{name: "子タスクの追加で完了の子タスク", create: addTaskItem, status: task.StatusCompleted, wantStartedAt: true, wantCompletedAt: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.create(newTestTaskUsecase(), newInput(tt.status))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			item := got.TaskItems[len(got.TaskItems)-1]
+			if (item.StartedAt != nil) != tt.wantStartedAt {
+				t.Errorf("StartedAt = %v, want set = %v", item.StartedAt, tt.wantStartedAt)
+			}
+			if (item.CompletedAt != nil) != tt.wantCompletedAt {
+				t.Errorf("CompletedAt = %v, want set = %v", item.CompletedAt, tt.wantCompletedAt)
+			}
+		})
+	}
+}
+
+// createTaskWithItem 子タスクを1件持つタスクを作成
+func createTaskWithItem(u *TaskUsecase, input task.CreateTaskItemInput) (*task.Task, error) {
+	created, _, err := u.CreateTask(context.Background(), aliceID, "新しいタスク", "2026-10-02", []task.CreateTaskItemInput{input})
+	return created, err
+}
+
+// addTaskItem Aliceのタスクに子タスクを追加
+func addTaskItem(u *TaskUsecase, input task.CreateTaskItemInput) (*task.Task, error) {
+	updated, _, err := u.AddTaskItem(context.Background(), aliceTaskID, aliceID, input)
+	return updated, err
+}
+
+func TestTaskUsecase_Trash(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ゴミ箱に移動したタスクは取得できず、元に戻すと取得できる", func(t *testing.T) {
+		u := newTestTaskUsecase()
+
+		if err := u.DeleteTask(ctx, aliceTaskID, aliceID, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, _, err := u.GetTaskByID(ctx, aliceTaskID, aliceID); !domainerrors.IsNotFound(err) {
+			t.Fatalf("expected NotFound error, got %v", err)
+		}
+
+		restored, _, err := u.RestoreTask(ctx, aliceTaskID, aliceID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if restored.DeletedAt != nil {
+			t.Errorf("deletedAt = %v, want nil", restored.DeletedAt)
+		}
+		if _, _, err := u.GetTaskByID(ctx, aliceTaskID, aliceID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("他のアカウントのゴミ箱のタスクは元に戻せず、完全に削除できない", func(t *testing.T) {
+		u := newTestTaskUsecase()
+		if err := u.DeleteTask(ctx, bobTaskID, bobID, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, _, err := u.RestoreTask(ctx, bobTaskID, aliceID); !domainerrors.IsNotFound(err) {
+			t.Fatalf("expected NotFound error, got %v", err)
+		}
+		if err := u.PurgeTask(ctx, bobTaskID, aliceID); !domainerrors.IsNotFound(err) {
+			t.Fatalf("expected NotFound error, got %v", err)
+		}
+	})
+
+	t.Run("ゴミ箱にないタスクは完全に削除できない", func(t *testing.T) {
+		u := newTestTaskUsecase()
+
+		if err := u.PurgeTask(ctx, aliceTaskID, aliceID); !domainerrors.IsNotFound(err) {
+			t.Fatalf("expected NotFound error, got %v", err)
+		}
+		if _, _, err := u.GetTaskByID(ctx, aliceTaskID, aliceID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("保持期間を過ぎたタスクのみ完全に削除する", func(t *testing.T) {
+		u := newTestTaskUsecase()
+		taskRepo := u.taskRepo.(*fakeTaskRepository)
+		now := time.Date(2026, 10, 31, 12, 0, 0, 0, time.UTC)
+		expired := now.Add(-31 * 24 * time.Hour)
+		recent := now.Add(-24 * time.Hour)
+		taskRepo.tasks[0].DeletedAt = &expired
+		taskRepo.tasks[1].DeletedAt = &recent
+
+		purged, err := u.PurgeExpiredTasks(ctx, now, 30*24*time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if purged != 1 {
+			t.Errorf("purged = %d, want 1", purged)
+		}
+		if want := now.Add(-30 * 24 * time.Hour); !taskRepo.purgedBefore.Equal(want) {
+			t.Errorf("deletedBefore = %v, want %v", taskRepo.purgedBefore, want)
+		}
+		if _, err := taskRepo.GetTrashedTaskByID(ctx, bobTaskID); err != nil {
+			t.Errorf("recently trashed task should remain: %v", err)
+		}
+	})
+
+	t.Run("保持期間が0以下", func(t *testing.T) {
+		u := newTestTaskUsecase()
+		if _, err := u.PurgeExpiredTasks(ctx, time.Now(), 0); !domainerrors.IsValidation(err) {
+			t.Fatalf("expected Validation error, got %v", err)
+		}
+	})
+}
+
+func TestTaskUsecase_GetTaskHistory(t *testing.T) {
+	ctx := context.Background()
+	review := "よくできた"
+
+	t.Run("オーナーは変更履歴を取得できる", func(t *testing.T) {
+		u := newTestTaskUsecase()
+		if _, _, err := u.UpdateTaskReview(ctx, aliceTaskID, aliceID, &review, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		events, err := u.GetTaskHistory(ctx, aliceTaskID, aliceID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(events) != 1 {
+			t.Fatalf("len = %d, want 1", len(events))
+		}
+		if events[0].Type != task.EventTypeTaskReviewUpdated || events[0].ActorID != aliceID {
+			t.Errorf("event = %+v", events[0])
+		}
+		if got := events[0].Diff["review"]; got.Before != nil || got.After != review {
+			t.Errorf("review change = %+v", got)
+		}
+	})
+
+	t.Run("他のアカウントのタスクの変更履歴はNotFound", func(t *testing.T) {
+		u := newTestTaskUsecase()
+		if _, err := u.GetTaskHistory(ctx, bobTaskID, aliceID); !domainerrors.IsNotFound(err) {
+			t.Fatalf("expected NotFound error, got %v", err)
+		}
+	})
+
+	t.Run("ゴミ箱のタスクの変更履歴はNotFound", func(t *testing.T) {
+		u := newTestTaskUsecase()
+		if err := u.DeleteTask(ctx, aliceTaskID, aliceID, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := u.GetTaskHistory(ctx, aliceTaskID, aliceID); !domainerrors.IsNotFound(err) {
+			t.Fatalf("expected NotFound error, got %v", err)
+		}
+	})
+}
+
+func TestTaskUsecase_Transaction(t *testing.T) {
+	ctx := context.Background()
+	review := "よくできた"
+
+	t.Run("ロックしてから確認と更新を1つのトランザクションで実行する", func(t *testing.T) {
+		u := newTestTaskUsecase()
+		txManager := u.txManager.(*fakeTxManager)
+		taskRepo := u.taskRepo.(*fakeTaskRepository)
+
+		if _, _, err := u.UpdateTaskReview(ctx, aliceTaskID, aliceID, &review, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if txManager.committed != 1 || txManager.rolledBack != 0 {
+			t.Errorf("committed = %d, rolledBack = %d, want 1, 0", txManager.committed, txManager.rolledBack)
+		}
+		if len(taskRepo.locked) != 1 || taskRepo.locked[0] != aliceTaskID {
+			t.Errorf("locked = %v, want [%s]", taskRepo.locked, aliceTaskID)
+		}
+	})
+
+	t.Run("確認でエラーになった場合はロールバックする", func(t *testing.T) {
+		u := newTestTaskUsecase()
+		txManager := u.txManager.(*fakeTxManager)
+
+		if _, _, err := u.UpdateTaskReview(ctx, bobTaskID, aliceID, &review, nil); !domainerrors.IsNotFound(err) {
+			t.Fatalf("expected NotFound error, got %v", err)
+		}
+		if txManager.committed != 0 || txManager.rolledBack != 1 {
+			t.Errorf("committed = %d, rolledBack = %d, want 0, 1", txManager.committed, txManager.rolledBack)
+		}
+	})
+}
+
+// recordingEventRecorder 記録されたタスクの変更履歴を保持する
+type recordingEventRecorder struct {
+	events []task.Event
+}
+
+func (r *recordingEventRecorder) RecordTaskEvents(events []task.Event) {
+	r.events = append(r.events, events...)
+}
+
+// snapshotTaskRepository 取得したタスクを複製して返す（保存しても取得済みのタスクは変わらない）
+type snapshotTaskRepository struct {
+	*fakeTaskRepository
+}
+
+func (r snapshotTaskRepository) GetTaskByID(ctx context.Context, taskID string) (*task.Task, error) {
+	t, err := r.fakeTaskRepository.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := *t
+	snapshot.TaskItems = slices.Clone(t.TaskItems)
+	return &snapshot, nil
+}
+
+func TestTaskUsecase_EventRecorder(t *testing.T) {
+	date := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	newUsecase := func() (*TaskUsecase, *recordingEventRecorder) {
+		taskRepo := &fakeTaskRepository{
+			tasks: []*task.Task{
+				{
+					ID: aliceTaskID, OwnerID: aliceID, Title: "Alice's day", Date: date,
+					TaskItems: []task.TaskItem{{ID: "alice-item-1", TaskID: aliceTaskID, Content: "メールを確認する", Order: 1, Status: task.StatusInProgress}},
+				},
+				{
+					ID: bobTaskID, OwnerID: bobID, Title: "Bob's day", Date: date,
+					TaskItems: []task.TaskItem{{ID: "bob-item-1", TaskID: bobTaskID, Content: "Bob's item", Order: 1, Status: task.StatusInProgress}},
+				},
+			},
+		}
+		accountRepo := &fakeAccountRepository{accounts: []*account.Account{{ID: aliceID}, {ID: bobID}}}
+		recorder := &recordingEventRecorder{}
+		u := NewTaskUsecase(&fakeTxManager{}, snapshotTaskRepository{taskRepo}, accountRepo, &fakeCategoryRepository{}, &fakeOutputTemplateRepository{}, WithTaskEventRecorder(recorder))
+		return u, recorder
+	}
+
+	t.Run("作成したタスクを記録する", func(t *testing.T) {
+		u, recorder := newUsecase()
+		if _, _, err := u.CreateTask(context.Background(), aliceID, "新しいタスク", "2026-10-02", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(recorder.events) != 1 || recorder.events[0].Type != task.EventTypeTaskCreated {
+			t.Errorf("events = %+v, want [TaskCreated]", recorder.events)
+		}
+	})
+
+	t.Run("完了した子タスクの変更を記録する", func(t *testing.T) {
+		u, recorder := newUsecase()
+		if _, _, err := u.ChangeTaskItemStatus(context.Background(), "alice-item-1", aliceID, task.StatusCompleted); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(recorder.events) != 1 || recorder.events[0].Type != task.EventTypeTaskItemUpdated {
+			t.Fatalf("events = %+v, want [TaskItemUpdated]", recorder.events)
+		}
+		if change := recorder.events[0].Diff["status"]; change.After != string(task.StatusCompleted) {
+			t.Errorf("status change = %+v, want Completed", change)
+		}
+	})
+
+	t.Run("ロールバックした変更は記録しない", func(t *testing.T) {
+		u, recorder := newUsecase()
+		if _, _, err := u.ChangeTaskItemStatus(context.Background(), "bob-item-1", aliceID, task.StatusCompleted); err == nil {
+			t.Fatal("expected error, got nil")
+		}
+		if len(recorder.events) != 0 {
+			t.Errorf("events = %+v, want none", recorder.events)
+		}
+	})
+}
+
+func TestTaskUsecase_EventRecorder_TaskItemOperations(t *testing.T) {
+	date := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	content := "資料を見直す"
+	newItemInput := task.CreateTaskItemInput{
+		Priority:     task.PriorityMedium,
+		Density:      task.DensityMedium,
+		DurationTime: task.DurationTime15,
+		Content:      "振り返る",
+		Order:        3,
+		Status:       task.StatusNotStarted,
+	}
+
+	tests := []struct {
+		name      string
+		mutate    func(u *TaskUsecase) error
+		wantTypes []task.EventType
+	}{
+		{
+			name: "子タスクの追加",
+			mutate: func(u *TaskUsecase) error {
+				_, _, err := u.AddTaskItem(context.Background(), aliceTaskID, aliceID, newItemInput)
+				return err
+			},
+			wantTypes: []task.EventType{task.EventTypeTaskItemAdded},
+		},
+		{
+			name: "子タスクの部分更新",
+			mutate: func(u *TaskUsecase) error {
+				_, _, err := u.PatchTaskItem(context.Background(), "alice-item-2", aliceID, task.TaskItemPatch{Content: &content})
+				return err
+			},
+			wantTypes: []task.EventType{task.EventTypeTaskItemUpdated},
+		},
+		{
+			name: "子タスクの並び替え",
+			mutate: func(u *TaskUsecase) error {
+				_, _, err := u.ReorderTaskItems(context.Background(), aliceTaskID, aliceID, []string{"alice-item-2", "alice-item-1"})
+				return err
+			},
+			wantTypes: []task.EventType{task.EventTypeTaskItemUpdated, task.EventTypeTaskItemUpdated},
+		},
+		{
+			name: "子タスクの削除",
+			mutate: func(u *TaskUsecase) error {
+				_, _, err := u.DeleteTaskItem(context.Background(), "alice-item-2", aliceID)
+				return err
+			},
+			wantTypes: []task.EventType{task.EventTypeTaskItemRemoved},
+		},
+		{
+			name: "タイマーの開始",
+			mutate: func(u *TaskUsecase) error {
+				_, _, err := u.ControlTaskItemTimer(context.Background(), "alice-item-2", aliceID, task.TimerActionStart)
+				return err
+			},
+			wantTypes: []task.EventType{task.EventTypeTaskItemUpdated},
+		},
+		{
+			name: "持ち越しで作成したタスク",
+			mutate: func(u *TaskUsecase) error {
+				_, _, err := u.CarryOverTaskItems(context.Background(), aliceTaskID, aliceID, date.AddDate(0, 0, 1), task.CarryOverModeCopy)
+				return err
+			},
+			wantTypes: []task.EventType{task.EventTypeTaskCreated, task.EventTypeTaskItemAdded, task.EventTypeTaskItemAdded},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskRepo := &fakeTaskRepository{
+				tasks: []*task.Task{{
+					ID: aliceTaskID, OwnerID: aliceID, Title: "Alice's day", Date: date,
+					TaskItems: []task.TaskItem{
+						{ID: "alice-item-1", TaskID: aliceTaskID, Priority: task.PriorityHigh, Density: task.DensityHigh, DurationTime: task.DurationTime30, Content: "メールを確認する", Order: 1, Status: task.StatusInProgress},
+						{ID: "alice-item-2", TaskID: aliceTaskID, Priority: task.PriorityLow, Density: task.DensityLow, DurationTime: task.DurationTime15, Content: "資料を作る", Order: 2, Status: task.StatusNotStarted},
+					},
+				}},
+			}
+			accountRepo := &fakeAccountRepository{accounts: []*account.Account{{ID: aliceID, FirstName: "Alice"}}}
+			recorder := &recordingEventRecorder{}
+			u := NewTaskUsecase(&fakeTxManager{}, snapshotTaskRepository{taskRepo}, accountRepo, &fakeCategoryRepository{}, &fakeOutputTemplateRepository{}, WithTaskEventRecorder(recorder))
+
+			if err := tt.mutate(u); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := make([]task.EventType, 0, len(recorder.events))
+			for _, e := range recorder.events {
+				got = append(got, e.Type)
+			}
+			if !slices.Equal(got, tt.wantTypes) {
+				t.Errorf("event types = %v, want %v", got, tt.wantTypes)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+-- Drop indexes
+DROP INDEX IF EXISTS task_items_category_id_idx;
+DROP INDEX IF EXISTS categories_owner_name_idx;
+
+-- Drop category_id from task_items
+ALTER TABLE task_items DROP COLUMN IF EXISTS category_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS categories;
@@ -0,0 +1,19 @@
+-- Create categories table
+CREATE TABLE categories (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE ON UPDATE NO ACTION,
+    name TEXT NOT NULL CHECK (char_length(name) BETWEEN 1 AND 50),
+    color TEXT NOT NULL CHECK (color ~ '^#[0-9A-Fa-f]{6}$'),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Create unique index on owner_id and name (case-insensitive)
+CREATE UNIQUE INDEX categories_owner_name_idx ON categories (owner_id, lower(name));
+
+-- Add category_id to task_items (uncategorized when the category is deleted)
+ALTER TABLE task_items
+    ADD COLUMN category_id UUID REFERENCES categories(id) ON DELETE SET NULL ON UPDATE NO ACTION;
+
+-- Create index on category_id
+CREATE INDEX task_items_category_id_idx ON task_items (category_id);
@@ -0,0 +1,11 @@
+-- Drop indexes
+DROP INDEX IF EXISTS task_items_output_template_id_idx;
+DROP INDEX IF EXISTS output_templates_owner_name_idx;
+
+-- Drop output template columns from task_items
+ALTER TABLE task_items
+    DROP COLUMN IF EXISTS output_sections,
+    DROP COLUMN IF EXISTS output_template_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS output_templates;
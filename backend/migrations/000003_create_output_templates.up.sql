@@ -0,0 +1,22 @@
+-- Create output_templates table
+CREATE TABLE output_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE ON UPDATE NO ACTION,
+    name TEXT NOT NULL CHECK (char_length(name) BETWEEN 1 AND 50),
+    sections JSONB NOT NULL CHECK (jsonb_typeof(sections) = 'array'),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Create unique index on owner_id and name (case-insensitive)
+CREATE UNIQUE INDEX output_templates_owner_name_idx ON output_templates (owner_id, lower(name));
+
+-- Add output template columns to task_items
+-- output_sections keeps a snapshot of the submitted sections (key, label, value) so that
+-- outputs stay readable after the template is changed or deleted
+ALTER TABLE task_items
+    ADD COLUMN output_template_id UUID REFERENCES output_templates(id) ON DELETE SET NULL ON UPDATE NO ACTION,
+    ADD COLUMN output_sections JSONB;
+
+-- Create index on output_template_id
+CREATE INDEX task_items_output_template_id_idx ON task_items (output_template_id);
@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS task_item_sessions_running_idx;
+DROP INDEX IF EXISTS task_item_sessions_task_item_id_idx;
+
+-- Drop tables
+DROP TABLE IF EXISTS task_item_sessions;
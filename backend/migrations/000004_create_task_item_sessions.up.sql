@@ -0,0 +1,19 @@
+-- Create task_item_sessions table
+-- A session is one continuous run of the timer on a task item. Pausing ends the running session
+-- and resuming starts a new one, so the actual time spent is the sum of all sessions.
+CREATE TABLE task_item_sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_item_id UUID NOT NULL REFERENCES task_items(id) ON DELETE CASCADE ON UPDATE NO ACTION,
+    started_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ,
+    end_reason TEXT CHECK (end_reason IN ('Paused', 'Stopped')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK ((ended_at IS NULL) = (end_reason IS NULL)),
+    CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+-- Create index on task_item_id and started_at
+CREATE INDEX task_item_sessions_task_item_id_idx ON task_item_sessions (task_item_id, started_at);
+
+-- Allow at most one running session per task item
+CREATE UNIQUE INDEX task_item_sessions_running_idx ON task_item_sessions (task_item_id) WHERE ended_at IS NULL;
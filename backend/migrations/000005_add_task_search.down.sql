@@ -0,0 +1,19 @@
+-- Drop indexes
+DROP INDEX IF EXISTS task_items_output_trgm_idx;
+DROP INDEX IF EXISTS task_items_content_trgm_idx;
+DROP INDEX IF EXISTS tasks_review_trgm_idx;
+DROP INDEX IF EXISTS tasks_title_trgm_idx;
+DROP INDEX IF EXISTS task_items_search_vector_idx;
+DROP INDEX IF EXISTS tasks_search_vector_idx;
+
+-- Drop triggers and functions
+DROP TRIGGER IF EXISTS task_items_search_vector_trigger ON task_items;
+DROP TRIGGER IF EXISTS tasks_search_vector_trigger ON tasks;
+DROP FUNCTION IF EXISTS task_items_search_vector_update();
+DROP FUNCTION IF EXISTS tasks_search_vector_update();
+
+-- Drop columns
+ALTER TABLE task_items DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE tasks DROP COLUMN IF EXISTS search_vector;
+
+DROP EXTENSION IF EXISTS pg_trgm;
@@ -0,0 +1,49 @@
+-- Enable trigram matching (fallback for text that is not split into words, such as Japanese)
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Add search vectors
+-- The 'simple' configuration is used because the text is mostly Japanese, which has no stemming dictionary
+ALTER TABLE tasks ADD COLUMN search_vector TSVECTOR NOT NULL DEFAULT ''::tsvector;
+ALTER TABLE task_items ADD COLUMN search_vector TSVECTOR NOT NULL DEFAULT ''::tsvector;
+
+-- Keep the search vectors up to date
+-- Titles and contents are weighted higher than reviews and outputs when ranking results
+CREATE FUNCTION tasks_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('simple', coalesce(NEW.title, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(NEW.review, '')), 'C');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tasks_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF title, review ON tasks
+    FOR EACH ROW EXECUTE FUNCTION tasks_search_vector_update();
+
+CREATE FUNCTION task_items_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('simple', coalesce(NEW.content, '')), 'B') ||
+        setweight(to_tsvector('simple', coalesce(NEW.output, '')), 'C');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER task_items_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF content, output ON task_items
+    FOR EACH ROW EXECUTE FUNCTION task_items_search_vector_update();
+
+-- Backfill existing rows through the triggers
+UPDATE tasks SET title = title;
+UPDATE task_items SET content = content;
+
+-- Create full-text search indexes
+CREATE INDEX tasks_search_vector_idx ON tasks USING GIN (search_vector);
+CREATE INDEX task_items_search_vector_idx ON task_items USING GIN (search_vector);
+
+-- Create trigram indexes for substring matching
+CREATE INDEX tasks_title_trgm_idx ON tasks USING GIN (title gin_trgm_ops);
+CREATE INDEX tasks_review_trgm_idx ON tasks USING GIN (review gin_trgm_ops);
+CREATE INDEX task_items_content_trgm_idx ON task_items USING GIN (content gin_trgm_ops);
+CREATE INDEX task_items_output_trgm_idx ON task_items USING GIN (output gin_trgm_ops);
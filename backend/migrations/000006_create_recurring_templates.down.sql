@@ -0,0 +1,10 @@
+-- Drop indexes
+DROP INDEX IF EXISTS tasks_owner_recurring_template_date_idx;
+DROP INDEX IF EXISTS recurring_templates_owner_id_idx;
+
+-- Drop recurring template column from tasks
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS recurring_template_id;
+
+-- Drop tables
+DROP TABLE IF EXISTS recurring_templates;
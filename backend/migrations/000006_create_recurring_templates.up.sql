@@ -0,0 +1,26 @@
+-- Create recurring_templates table
+-- A recurring template describes a task (title and task items) that is created for every date matching the rule
+CREATE TABLE recurring_templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    owner_id UUID NOT NULL REFERENCES accounts(id) ON DELETE CASCADE ON UPDATE NO ACTION,
+    title TEXT NOT NULL CHECK (char_length(title) BETWEEN 1 AND 100),
+    frequency TEXT NOT NULL CHECK (frequency IN ('Daily', 'Weekdays', 'Weekly')),
+    by_day TEXT[] NOT NULL DEFAULT '{}' CHECK (by_day <@ ARRAY['MO', 'TU', 'WE', 'TH', 'FR', 'SA', 'SU']),
+    starts_on DATE NOT NULL,
+    ends_on DATE,
+    task_items JSONB NOT NULL CHECK (jsonb_typeof(task_items) = 'array'),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (ends_on IS NULL OR ends_on >= starts_on),
+    CHECK ((frequency = 'Weekly') = (cardinality(by_day) > 0))
+);
+
+-- Create index on owner_id
+CREATE INDEX recurring_templates_owner_id_idx ON recurring_templates (owner_id);
+
+-- Add recurring template column to tasks
+ALTER TABLE tasks
+    ADD COLUMN recurring_template_id UUID REFERENCES recurring_templates(id) ON DELETE SET NULL ON UPDATE NO ACTION;
+
+-- Generate at most one task per template and date, so that generation can be retried safely
+CREATE UNIQUE INDEX tasks_owner_recurring_template_date_idx ON tasks (owner_id, recurring_template_id, date);
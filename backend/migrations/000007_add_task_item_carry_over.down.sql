@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS tasks_owner_date_idx;
+DROP INDEX IF EXISTS task_items_carried_over_from_task_id_idx;
+
+-- Drop carried_over_from_task_id from task_items
+ALTER TABLE task_items DROP COLUMN IF EXISTS carried_over_from_task_id;
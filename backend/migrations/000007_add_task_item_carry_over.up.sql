@@ -0,0 +1,9 @@
+-- Record the task each task item was carried over from (kept as NULL when the source task is deleted)
+ALTER TABLE task_items
+    ADD COLUMN carried_over_from_task_id UUID REFERENCES tasks(id) ON DELETE SET NULL ON UPDATE NO ACTION;
+
+-- Create index on carried_over_from_task_id
+CREATE INDEX task_items_carried_over_from_task_id_idx ON task_items (carried_over_from_task_id);
+
+-- Create index to find the owner's task for a date
+CREATE INDEX tasks_owner_date_idx ON tasks (owner_id, date);
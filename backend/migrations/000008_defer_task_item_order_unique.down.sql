@@ -0,0 +1,4 @@
+-- Restore the non-deferrable unique index
+ALTER TABLE task_items DROP CONSTRAINT IF EXISTS task_items_task_order_idx;
+
+CREATE UNIQUE INDEX task_items_task_order_idx ON task_items (task_id, "order");
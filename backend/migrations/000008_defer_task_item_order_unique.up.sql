@@ -0,0 +1,6 @@
+-- Replace the unique index with a deferrable unique constraint so that the order of
+-- several task items can be swapped in a single statement
+DROP INDEX IF EXISTS task_items_task_order_idx;
+
+ALTER TABLE task_items
+    ADD CONSTRAINT task_items_task_order_idx UNIQUE (task_id, "order") DEFERRABLE INITIALLY IMMEDIATE;
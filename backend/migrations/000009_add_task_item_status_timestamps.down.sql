@@ -0,0 +1,7 @@
+-- Drop check constraint
+ALTER TABLE task_items DROP CONSTRAINT IF EXISTS task_items_completed_at_check;
+
+-- Drop started_at and completed_at from task_items
+ALTER TABLE task_items
+    DROP COLUMN IF EXISTS completed_at,
+    DROP COLUMN IF EXISTS started_at;
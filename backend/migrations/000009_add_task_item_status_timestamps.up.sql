@@ -0,0 +1,20 @@
+-- Record when each task item was first started and when it was completed
+ALTER TABLE task_items
+    ADD COLUMN started_at TIMESTAMPTZ,
+    ADD COLUMN completed_at TIMESTAMPTZ;
+
+-- Backfill from the timer sessions, falling back to the last update for items without sessions
+UPDATE task_items ti
+SET started_at = COALESCE(
+    (SELECT MIN(s.started_at) FROM task_item_sessions s WHERE s.task_item_id = ti.id),
+    ti.updated_at
+)
+WHERE ti.status IN ('InProgress', 'Completed');
+
+UPDATE task_items
+SET completed_at = updated_at
+WHERE status = 'Completed';
+
+-- Only completed task items have a completion time
+ALTER TABLE task_items
+    ADD CONSTRAINT task_items_completed_at_check CHECK (completed_at IS NULL OR status = 'Completed');
@@ -0,0 +1,2 @@
+-- Drop version from tasks
+ALTER TABLE tasks DROP COLUMN IF EXISTS version;
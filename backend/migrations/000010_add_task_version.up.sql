@@ -0,0 +1,3 @@
+-- Add a version to tasks for optimistic concurrency control (returned as the ETag)
+-- The version is incremented whenever the task or one of its task items is changed
+ALTER TABLE tasks ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
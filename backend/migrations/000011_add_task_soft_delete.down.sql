@@ -0,0 +1,6 @@
+-- Permanently delete trashed tasks before dropping the column
+DELETE FROM tasks WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS tasks_deleted_at_idx;
+DROP INDEX IF EXISTS tasks_owner_deleted_at_idx;
+ALTER TABLE tasks DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,7 @@
+-- Move deleted tasks to the trash instead of deleting them
+-- Trashed tasks are excluded from all queries, and are permanently deleted after the retention period
+ALTER TABLE tasks ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- Used to list the trash and to purge expired tasks
+CREATE INDEX tasks_owner_deleted_at_idx ON tasks (owner_id, deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX tasks_deleted_at_idx ON tasks (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,6 @@
+-- Drop trigger and function
+DROP TRIGGER IF EXISTS task_events_reject_update_trigger ON task_events;
+DROP FUNCTION IF EXISTS task_events_reject_update();
+
+-- Drop task_events table
+DROP TABLE IF EXISTS task_events;
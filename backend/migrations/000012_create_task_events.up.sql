@@ -0,0 +1,37 @@
+-- Create task_events table
+-- An append-only history of changes to a task and its task items. Each event is written in the same
+-- transaction as the change itself and stores the changed fields as {"field": {"before": ..., "after": ...}}.
+-- Events were deleted together with the task when it was purged from the trash; 000013 drops the
+-- foreign key so that the history of purged tasks is kept for auditing.
+CREATE TABLE task_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE ON UPDATE NO ACTION,
+    -- The task item the event refers to (not a foreign key, so the history survives item deletion)
+    task_item_id UUID,
+    actor_id UUID NOT NULL REFERENCES accounts(id) ON DELETE NO ACTION ON UPDATE NO ACTION,
+    event_type TEXT NOT NULL CHECK (event_type IN (
+        'TaskCreated',
+        'TaskUpdated',
+        'TaskItemAdded',
+        'TaskItemUpdated',
+        'TaskItemRemoved',
+        'TaskItemOutputUpdated',
+        'TaskReviewUpdated'
+    )),
+    diff JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Create index on task_id and created_at
+CREATE INDEX task_events_task_id_idx ON task_events (task_id, created_at);
+
+-- Reject updates so that the history cannot be rewritten
+CREATE FUNCTION task_events_reject_update() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'task_events is append-only';
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER task_events_reject_update_trigger
+    BEFORE UPDATE ON task_events
+    FOR EACH ROW EXECUTE FUNCTION task_events_reject_update();
@@ -0,0 +1,23 @@
+-- Restore index on task_id and created_at
+DROP INDEX IF EXISTS task_events_task_id_seq_idx;
+CREATE INDEX task_events_task_id_idx ON task_events (task_id, created_at);
+
+-- Drop the history of purged tasks and the trash operations
+DELETE FROM task_events e WHERE NOT EXISTS (SELECT 1 FROM tasks t WHERE t.id = e.task_id);
+DELETE FROM task_events WHERE event_type IN ('TaskDeleted', 'TaskRestored', 'TaskPurged');
+
+ALTER TABLE task_events DROP CONSTRAINT task_events_event_type_check;
+ALTER TABLE task_events ADD CONSTRAINT task_events_event_type_check CHECK (event_type IN (
+    'TaskCreated',
+    'TaskUpdated',
+    'TaskItemAdded',
+    'TaskItemUpdated',
+    'TaskItemRemoved',
+    'TaskItemOutputUpdated',
+    'TaskReviewUpdated'
+));
+
+ALTER TABLE task_events
+    ADD CONSTRAINT task_events_task_id_fkey FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE ON UPDATE NO ACTION;
+
+ALTER TABLE task_events DROP COLUMN seq;
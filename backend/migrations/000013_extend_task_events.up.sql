@@ -0,0 +1,24 @@
+-- Record trash operations and keep the history of purged tasks
+-- Events written in the same transaction share created_at, so they are ordered by a sequence instead.
+-- The history is kept for auditing after the task is purged, so task_id is no longer a foreign key.
+ALTER TABLE task_events ADD COLUMN seq BIGSERIAL NOT NULL;
+
+ALTER TABLE task_events DROP CONSTRAINT task_events_task_id_fkey;
+
+ALTER TABLE task_events DROP CONSTRAINT task_events_event_type_check;
+ALTER TABLE task_events ADD CONSTRAINT task_events_event_type_check CHECK (event_type IN (
+    'TaskCreated',
+    'TaskUpdated',
+    'TaskItemAdded',
+    'TaskItemUpdated',
+    'TaskItemRemoved',
+    'TaskItemOutputUpdated',
+    'TaskReviewUpdated',
+    'TaskDeleted',
+    'TaskRestored',
+    'TaskPurged'
+));
+
+-- Replace index on task_id and created_at with task_id and seq
+DROP INDEX task_events_task_id_idx;
+CREATE INDEX task_events_task_id_seq_idx ON task_events (task_id, seq);
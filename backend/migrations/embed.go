@@ -0,0 +1,10 @@
+// Package migrations データベースのマイグレーションファイル
+// バイナリに埋め込み、作業ディレクトリに依存せずにマイグレーションを適用・確認できるようにする
+package migrations
+
+import "embed"
+
+// FS マイグレーションファイル（000001_name.up.sql、000001_name.down.sql）
+//
+//go:embed *.sql
+var FS embed.FS
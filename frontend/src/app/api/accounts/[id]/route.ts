@@ -1,4 +1,5 @@
 import { type NextRequest, NextResponse } from "next/server";
+import { getAccountIdServer } from "@/features/auth/servers/auth.server";
 import { getAccountByIdQuery } from "../../../../external/handler/account.query.server";
 
 /**
@@ -12,6 +13,14 @@ export async function GET(
   try {
     const { id } = await params;
 
+    // ログイン中のアカウントのみ取得できる（GoのAPIにはこのアカウントのJWTを送る）
+    if (!(await getAccountIdServer())) {
+      return NextResponse.json(
+        { error: "Unauthorized" },
+        { status: 401 },
+      );
+    }
+
     // ハンドラーを呼び出し
     const account = await getAccountByIdQuery(id);
 
@@ -1,20 +1,20 @@
 import { type NextRequest, NextResponse } from "next/server";
+import { getAccountIdServer } from "@/features/auth/servers/auth.server";
 import { getCurrentAccountQuery } from "../../../../external/handler/account.query.server";
 
 /**
  * Account API Route
  * GET /api/accounts/me - 現在のアカウント取得
  */
-export async function GET(request: NextRequest) {
+export async function GET(_request: NextRequest) {
   try {
-    // TODO: 認証情報からaccountIdを取得（現在はリクエストから取得する必要がある）
-    // 認証実装後は、セッションやJWTからaccountIdを取得する
-    const accountId = request.headers.get("x-account-id") || "";
+    // ログイン中のアカウントを取得（GoのAPIにはこのアカウントのJWTを送る）
+    const accountId = await getAccountIdServer();
 
     if (!accountId) {
       return NextResponse.json(
-        { error: "Account ID is required" },
-        { status: 400 },
+        { error: "Unauthorized" },
+        { status: 401 },
       );
     }
 
@@ -1,4 +1,5 @@
 import { type NextRequest, NextResponse } from "next/server";
+import { getAccountIdServer } from "@/features/auth/servers/auth.server";
 import {
   updateTaskReviewCommand,
   ValidationError,
@@ -15,14 +16,13 @@ export async function PUT(
   try {
     const { id } = await params;
 
-    // TODO: 認証情報からownerIdを取得（現在はリクエストから取得する必要がある）
-    // 認証実装後は、セッションやJWTからownerIdを取得する
-    const ownerId = request.headers.get("x-owner-id") || "";
+    // ログイン中のアカウントをオーナーとする（GoのAPIにはこのアカウントのJWTを送る）
+    const ownerId = await getAccountIdServer();
 
     if (!ownerId) {
       return NextResponse.json(
-        { error: "Owner ID is required" },
-        { status: 400 },
+        { error: "Unauthorized" },
+        { status: 401 },
       );
     }
 
@@ -30,7 +30,7 @@ export async function PUT(
     const body = await request.json();
 
     // ハンドラーを呼び出し（バリデーション含む）
-    const result = await updateTaskReviewCommand(id, ownerId, body);
+    const result = await updateTaskReviewCommand(id, body);
 
     return NextResponse.json(result, { status: 200 });
   } catch (error) {
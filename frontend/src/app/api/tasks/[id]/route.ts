@@ -6,6 +6,7 @@
  */
 
 import { type NextRequest, NextResponse } from "next/server";
+import { getAccountIdServer } from "@/features/auth/servers/auth.server";
 import {
   deleteTaskCommand,
   updateTaskCommand,
@@ -24,8 +25,18 @@ export async function GET(
   try {
     const { id } = await params;
 
+    // ログイン中のアカウントを取得（GoのAPIにはこのアカウントのJWTを送る）
+    const accountId = await getAccountIdServer();
+
+    if (!accountId) {
+      return NextResponse.json(
+        { error: "Unauthorized" },
+        { status: 401 },
+      );
+    }
+
     // ハンドラーを呼び出し
-    const task = await getTaskByIdQuery(id);
+    const task = await getTaskByIdQuery({ id }, accountId);
 
     // タスクが存在しない場合は404を返す
     if (!task) {
@@ -59,19 +70,18 @@ export async function PUT(
     // リクエストボディを取得
     const body = await request.json();
 
-    // TODO: 認証情報からownerIdを取得（現在はリクエストから取得する必要がある）
-    // 認証実装後は、セッションやJWTからownerIdを取得する
-    const ownerId = request.headers.get("x-owner-id") || "";
+    // ログイン中のアカウントをオーナーとする（GoのAPIにはこのアカウントのJWTを送る）
+    const ownerId = await getAccountIdServer();
 
     if (!ownerId) {
       return NextResponse.json(
-        { error: "Owner ID is required" },
-        { status: 400 },
+        { error: "Unauthorized" },
+        { status: 401 },
       );
     }
 
     // ハンドラーを呼び出し（バリデーション含む）
-    const task = await updateTaskCommand(id, ownerId, body);
+    const task = await updateTaskCommand(id, body);
 
     return NextResponse.json(task, { status: 200 });
   } catch (error) {
@@ -115,25 +125,24 @@ export async function PUT(
  * タスクを削除
  */
 export async function DELETE(
-  request: NextRequest,
+  _request: NextRequest,
   { params }: { params: Promise<{ id: string }> },
 ) {
   try {
     const { id } = await params;
 
-    // TODO: 認証情報からownerIdを取得（現在はリクエストから取得する必要がある）
-    // 認証実装後は、セッションやJWTからownerIdを取得する
-    const ownerId = request.headers.get("x-owner-id") || "";
+    // ログイン中のアカウントをオーナーとする（GoのAPIにはこのアカウントのJWTを送る）
+    const ownerId = await getAccountIdServer();
 
     if (!ownerId) {
       return NextResponse.json(
-        { error: "Owner ID is required" },
-        { status: 400 },
+        { error: "Unauthorized" },
+        { status: 401 },
       );
     }
 
     // ハンドラーを呼び出し（オーナーチェック含む）
-    const result = await deleteTaskCommand(id, ownerId);
+    const result = await deleteTaskCommand(id);
 
     return NextResponse.json(result, { status: 200 });
   } catch (error) {
@@ -5,6 +5,7 @@
  */
 
 import { type NextRequest, NextResponse } from "next/server";
+import { getAccountIdServer } from "@/features/auth/servers/auth.server";
 import {
   createTaskCommand,
   ValidationError,
@@ -18,6 +19,16 @@ import type { TaskFilters } from "../../../external/dto/task.dto";
  */
 export async function GET(request: NextRequest) {
   try {
+    // ログイン中のアカウントを取得（GoのAPIにはこのアカウントのJWTを送る）
+    const accountId = await getAccountIdServer();
+
+    if (!accountId) {
+      return NextResponse.json(
+        { error: "Unauthorized" },
+        { status: 401 },
+      );
+    }
+
     // クエリパラメータを取得
     const searchParams = request.nextUrl.searchParams;
     const filters: TaskFilters = {
@@ -28,7 +39,7 @@ export async function GET(request: NextRequest) {
     };
 
     // ハンドラーを呼び出し
-    const tasks = await listTaskQuery(filters);
+    const tasks = await listTaskQuery(filters, accountId);
 
     return NextResponse.json(tasks, { status: 200 });
   } catch (error) {
@@ -49,19 +60,18 @@ export async function POST(request: NextRequest) {
     // リクエストボディを取得
     const body = await request.json();
 
-    // TODO: 認証情報からownerIdを取得（現在はリクエストから取得する必要がある）
-    // 認証実装後は、セッションやJWTからownerIdを取得する
-    const ownerId = request.headers.get("x-owner-id") || "";
+    // ログイン中のアカウントをオーナーとする（GoのAPIにはこのアカウントのJWTを送る）
+    const ownerId = await getAccountIdServer();
 
     if (!ownerId) {
       return NextResponse.json(
-        { error: "Owner ID is required" },
-        { status: 400 },
+        { error: "Unauthorized" },
+        { status: 401 },
       );
     }
 
     // ハンドラーを呼び出し（バリデーション含む）
-    const task = await createTaskCommand(ownerId, body);
+    const task = await createTaskCommand(body);
 
     return NextResponse.json(task, { status: 201 });
   } catch (error) {
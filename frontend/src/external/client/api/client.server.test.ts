@@ -0,0 +1,108 @@
+import { createHmac } from "node:crypto";
+import { afterEach, beforeEach, describe, expect, it, vi } from "vitest";
+import { configureApiClient, signAccessToken } from "./client.server";
+import { OpenAPI } from "./generated";
+import type { ApiRequestOptions } from "./generated/core/ApiRequestOptions";
+import { getAccountIdServer } from "@/features/auth/servers/auth.server";
+
+// モックの設定
+vi.mock("@/features/auth/servers/auth.server", () => ({
+  getAccountIdServer: vi.fn(),
+}));
+
+const decode = (segment: string) =>
+  JSON.parse(Buffer.from(segment, "base64url").toString());
+
+const requestTo = (url: string): ApiRequestOptions => ({ method: "GET", url });
+
+describe("client.server", () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+    vi.stubEnv("AUTH_JWT_ALGORITHM", "HS256");
+    vi.stubEnv("AUTH_JWT_SECRET", "test-secret");
+    vi.stubEnv("AUTH_SERVICE_TOKEN", "service-token");
+  });
+
+  afterEach(() => {
+    vi.unstubAllEnvs();
+  });
+
+  describe("signAccessToken", () => {
+    it("アカウントIDをsubに設定し、有効期限付きのJWTをHS256で署名する", () => {
+      const now = Date.UTC(2026, 0, 15);
+      const token = signAccessToken("account-123", now);
+      const [header, payload, signature] = token.split(".");
+
+      expect(decode(header)).toEqual({ alg: "HS256", typ: "JWT" });
+      expect(decode(payload)).toEqual({
+        sub: "account-123",
+        iat: now / 1000,
+        exp: now / 1000 + 60,
+      });
+      expect(signature).toBe(
+        createHmac("sha256", "test-secret")
+          .update(`${header}.${payload}`)
+          .digest("base64url"),
+      );
+    });
+
+    it("issとaudを設定した場合はクレームに含める", () => {
+      vi.stubEnv("AUTH_JWT_ISSUER", "task-management-frontend");
+      vi.stubEnv("AUTH_JWT_AUDIENCE", "task-management-api");
+
+      const payload = decode(signAccessToken("account-123").split(".")[1]);
+
+      expect(payload.iss).toBe("task-management-frontend");
+      expect(payload.aud).toBe("task-management-api");
+    });
+
+    it("シークレットがない場合はエラーがスローされる", () => {
+      vi.stubEnv("AUTH_JWT_SECRET", "");
+
+      expect(() => signAccessToken("account-123")).toThrow("AUTH_JWT_SECRET");
+    });
+  });
+
+  describe("configureApiClient", () => {
+    beforeEach(() => {
+      configureApiClient();
+    });
+
+    it("ログイン中のアカウントのJWTを送る", async () => {
+      vi.mocked(getAccountIdServer).mockResolvedValue("account-123");
+
+      const resolveToken = OpenAPI.TOKEN as (
+        options: ApiRequestOptions,
+      ) => Promise<string>;
+      const token = await resolveToken(requestTo("/api/tasks"));
+
+      expect(decode(token.split(".")[1]).sub).toBe("account-123");
+    });
+
+    it("未ログインの場合はJWTを送らない", async () => {
+      vi.mocked(getAccountIdServer).mockResolvedValue(null);
+
+      const resolveToken = OpenAPI.TOKEN as (
+        options: ApiRequestOptions,
+      ) => Promise<string>;
+
+      expect(await resolveToken(requestTo("/api/tasks"))).toBe("");
+    });
+
+    it("内部APIにはJWTの代わりにサービスの資格情報を送る", async () => {
+      const resolveToken = OpenAPI.TOKEN as (
+        options: ApiRequestOptions,
+      ) => Promise<string>;
+      const resolveHeaders = OpenAPI.HEADERS as (
+        options: ApiRequestOptions,
+      ) => Promise<Record<string, string>>;
+
+      expect(await resolveToken(requestTo("/api/accounts/by-email"))).toBe("");
+      expect(await resolveHeaders(requestTo("/api/accounts/by-email"))).toEqual(
+        { "X-Service-Token": "service-token" },
+      );
+      expect(await resolveHeaders(requestTo("/api/tasks"))).toEqual({});
+      expect(getAccountIdServer).not.toHaveBeenCalled();
+    });
+  });
+});
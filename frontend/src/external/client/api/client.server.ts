@@ -0,0 +1,119 @@
+import "server-only";
+
+/**
+ * Go API Client (Server Only)
+ * GoのAPIクライアントの設定（サーバー側のみ）
+ *
+ * - ログイン中のアカウントのIDを sub に設定したJWTを Authorization ヘッダーで送る
+ * - better-auth がトークン発行前に呼び出す内部APIには、JWTの代わりにサービスの資格情報（X-Service-Token）を送る
+ *
+ * 環境変数はGoのAPIと同じ値を設定する（AUTH_JWT_ALGORITHM、AUTH_JWT_SECRET、AUTH_JWT_ISSUER、AUTH_JWT_AUDIENCE、AUTH_SERVICE_TOKEN）
+ * RS256の場合は AUTH_JWT_PRIVATE_KEY に、GoのAPIに設定した公開鍵と対になる秘密鍵（PEM）を設定する
+ */
+
+import { createHmac, createSign } from "node:crypto";
+import { OpenAPI } from "./generated";
+import type { ApiRequestOptions } from "./generated/core/ApiRequestOptions";
+
+/** JWTの有効期間（秒）。リクエストごとに発行するため短くする */
+const ACCESS_TOKEN_TTL_SECONDS = 60;
+
+/** サービスの資格情報を送るヘッダー */
+const SERVICE_TOKEN_HEADER = "X-Service-Token";
+
+/** サービスの資格情報で認証する内部API（better-auth がトークン発行前に呼び出す） */
+const SERVICE_ROUTES = ["/api/accounts/auth", "/api/accounts/by-email"];
+
+const isServiceRoute = (options: ApiRequestOptions) =>
+  SERVICE_ROUTES.includes(options.url);
+
+const base64url = (value: string) => Buffer.from(value).toString("base64url");
+
+/**
+ * アカウントのJWTを発行
+ * @param accountId アカウントID（sub クレーム）
+ * @param now 発行日時（ミリ秒）
+ * @returns 署名したJWT
+ */
+export function signAccessToken(accountId: string, now = Date.now()): string {
+  const algorithm = process.env.AUTH_JWT_ALGORITHM || "HS256";
+  const issuedAt = Math.floor(now / 1000);
+
+  const header = base64url(JSON.stringify({ alg: algorithm, typ: "JWT" }));
+  const payload = base64url(
+    JSON.stringify({
+      sub: accountId,
+      iat: issuedAt,
+      exp: issuedAt + ACCESS_TOKEN_TTL_SECONDS,
+      ...(process.env.AUTH_JWT_ISSUER && { iss: process.env.AUTH_JWT_ISSUER }),
+      ...(process.env.AUTH_JWT_AUDIENCE && {
+        aud: process.env.AUTH_JWT_AUDIENCE,
+      }),
+    }),
+  );
+  const signingInput = `${header}.${payload}`;
+
+  switch (algorithm) {
+    case "HS256": {
+      const secret = process.env.AUTH_JWT_SECRET;
+      if (!secret) {
+        throw new Error("AUTH_JWT_SECRET is required for HS256");
+      }
+      const signature = createHmac("sha256", secret)
+        .update(signingInput)
+        .digest("base64url");
+      return `${signingInput}.${signature}`;
+    }
+    case "RS256": {
+      // 環境変数では改行を \n と書けるようにする
+      const privateKey = process.env.AUTH_JWT_PRIVATE_KEY?.replace(/\\n/g, "\n");
+      if (!privateKey) {
+        throw new Error("AUTH_JWT_PRIVATE_KEY is required for RS256");
+      }
+      const signature = createSign("RSA-SHA256")
+        .update(signingInput)
+        .sign(privateKey, "base64url");
+      return `${signingInput}.${signature}`;
+    }
+    default:
+      throw new Error(`Unsupported AUTH_JWT_ALGORITHM: ${algorithm}`);
+  }
+}
+
+/**
+ * リクエストごとにログイン中のアカウントのJWTを発行（未ログインと内部APIの場合は送らない）
+ */
+async function resolveToken(options: ApiRequestOptions): Promise<string> {
+  if (isServiceRoute(options)) {
+    return "";
+  }
+
+  // better-auth の設定はこのクライアントで内部APIを呼び出すため、循環して読み込まないように呼び出し時に読み込む
+  const { getAccountIdServer } = await import(
+    "@/features/auth/servers/auth.server"
+  );
+  const accountId = await getAccountIdServer();
+  return accountId ? signAccessToken(accountId) : "";
+}
+
+/**
+ * 内部APIにのみサービスの資格情報を送る
+ */
+async function resolveHeaders(
+  options: ApiRequestOptions,
+): Promise<Record<string, string>> {
+  if (!isServiceRoute(options)) {
+    return {};
+  }
+  return { [SERVICE_TOKEN_HEADER]: process.env.AUTH_SERVICE_TOKEN || "" };
+}
+
+/**
+ * GoのAPIのベースURLと認証情報を設定
+ */
+export function configureApiClient() {
+  // GoのAPIベースURLを環境変数から設定（デフォルト: http://localhost:8080）
+  OpenAPI.BASE = process.env.API_BASE_URL || "http://localhost:8080";
+  OpenAPI.TOKEN = resolveToken;
+  OpenAPI.HEADERS = resolveHeaders;
+}
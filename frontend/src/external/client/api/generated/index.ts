@@ -24,6 +24,7 @@ export type { Models_Task_DeleteTaskResponse } from './models/Models_Task_Delete
 export { Models_Task_Density } from './models/Models_Task_Density';
 export { Models_Task_Priority } from './models/Models_Task_Priority';
 export { Models_Task_Status } from './models/Models_Task_Status';
+export type { Models_Task_ListTaskResponse } from './models/Models_Task_ListTaskResponse';
 export type { Models_Task_Task } from './models/Models_Task_Task';
 export { Models_Task_TaskItem } from './models/Models_Task_TaskItem';
 export { Models_Task_TaskItemResponse } from './models/Models_Task_TaskItemResponse';
@@ -0,0 +1,13 @@
+/* generated using openapi-typescript-codegen -- do no edit */
+/* istanbul ignore file */
+/* tslint:disable */
+ 
+import type { Models_Task_TaskResponse } from './Models_Task_TaskResponse';
+/**
+ * タスク一覧レスポンス
+ */
+export type Models_Task_ListTaskResponse = {
+    items: Array<Models_Task_TaskResponse>;
+    nextCursor?: string;
+};
+
@@ -5,6 +5,7 @@
 import type { Models_Task_CreateTaskRequest } from '../models/Models_Task_CreateTaskRequest';
 import type { Models_Task_DeleteTaskRequest } from '../models/Models_Task_DeleteTaskRequest';
 import type { Models_Task_DeleteTaskResponse } from '../models/Models_Task_DeleteTaskResponse';
+import type { Models_Task_ListTaskResponse } from '../models/Models_Task_ListTaskResponse';
 import type { Models_Task_TaskResponse } from '../models/Models_Task_TaskResponse';
 import type { Models_Task_UpdateTaskRequest } from '../models/Models_Task_UpdateTaskRequest';
 import type { Models_Task_UpdateTaskReviewRequest } from '../models/Models_Task_UpdateTaskReviewRequest';
@@ -17,21 +18,27 @@ export class TasksService {
 
     /**
      * Get task list
-     * タスク一覧を取得します。クエリパラメータでフィルタリング可能です。
-     * @returns Models_Task_TaskResponse The request has succeeded.
+     * 自分が所有するタスクの一覧を取得します。クエリパラメータでフィルタリング可能です。limitで1ページの件数（1〜100、デフォルト50）を指定し、次のページはレスポンスのnextCursorをcursorに指定して取得します。
+     * @returns Models_Task_ListTaskResponse The request has succeeded.
      * @throws ApiError
      */
     public static tasksListTasks({
         yearMonth,
         ownerId,
         q,
+        categoryId,
         sort,
+        limit,
+        cursor,
     }: {
         yearMonth?: string,
         ownerId?: string,
         q?: string,
+        categoryId?: string,
         sort?: string,
-    }): CancelablePromise<Array<Models_Task_TaskResponse>> {
+        limit?: number,
+        cursor?: string,
+    }): CancelablePromise<Models_Task_ListTaskResponse> {
         return __request(OpenAPI, {
             method: 'GET',
             url: '/api/tasks',
@@ -39,9 +46,13 @@ export class TasksService {
                 'year-month': yearMonth,
                 'ownerId': ownerId,
                 'q': q,
+                'categoryId': categoryId,
                 'sort': sort,
+                'limit': limit,
+                'cursor': cursor,
             },
             errors: {
+                400: `Bad Request エラー（400）`,
                 401: `Unauthorized エラー（401）`,
             },
         });
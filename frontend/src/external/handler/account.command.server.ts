@@ -10,13 +10,11 @@ import { CreateOrGetAccountRequestSchema } from "../dto/account.dto";
 // import { accountService } from "../service/account.service";
 // import { toAccountResponse } from "./account.converter";
 import { AccountsService } from "../client/api/generated";
-import { OpenAPI } from "../client/api/generated";
+import { configureApiClient } from "../client/api/client.server";
 import type { Models_Account_CreateOrGetAccountRequest } from "../client/api/generated";
 
-// GoのAPIベースURLを環境変数から設定（デフォルト: http://localhost:8080）
-if (typeof process !== "undefined" && process.env) {
-  OpenAPI.BASE = process.env.API_BASE_URL || "http://localhost:8080";
-}
+// GoのAPIのベースURLと認証情報を設定
+configureApiClient();
 
 /**
  * バリデーションエラー
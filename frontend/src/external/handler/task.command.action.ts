@@ -5,30 +5,30 @@ import { createTaskCommand, deleteTaskCommand, updateTaskCommand, updateTaskRevi
 import type { CreateTaskRequest, UpdateTaskRequest, UpdateTaskReviewRequest, UpdateTaskItemOutputRequest } from "../dto/task.dto";
 
 export async function createTaskCommandAction(request: CreateTaskRequest) {
-  return withAuth(({ accountId }) => createTaskCommand(accountId, request));
+  return withAuth(() => createTaskCommand(request));
 }
 
 export async function updateTaskCommandAction(
   taskId: string,
   request: UpdateTaskRequest,
 ) {
-  return withAuth(({ accountId }) => updateTaskCommand(taskId, accountId, request));
+  return withAuth(() => updateTaskCommand(taskId, request));
 }
 
 export async function deleteTaskCommandAction(taskId: string) {
-  return withAuth(({ accountId }) => deleteTaskCommand(taskId, accountId));
+  return withAuth(() => deleteTaskCommand(taskId));
 }
 
 export async function updateTaskReviewCommandAction(
   taskId: string,
   request: UpdateTaskReviewRequest,
 ) {
-  return withAuth(({ accountId }) => updateTaskReviewCommand(taskId, accountId, request));
+  return withAuth(() => updateTaskReviewCommand(taskId, request));
 }
 
 export async function updateTaskItemOutputCommandAction(
   taskItemId: string,
   request: UpdateTaskItemOutputRequest,
 ) {
-  return withAuth(({ accountId }) => updateTaskItemCommand(taskItemId, accountId, request));
+  return withAuth(() => updateTaskItemCommand(taskItemId, request));
 }
\ No newline at end of file
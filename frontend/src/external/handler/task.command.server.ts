@@ -22,7 +22,7 @@ import {
 // import { accountService } from "../service/account.service";
 // import { taskService } from "../service/task.service";
 // import { toTaskResponse } from "./task.converter";
-import { OpenAPI } from "../client/api/generated";
+import { configureApiClient } from "../client/api/client.server";
 import { TasksService } from "../client/api/generated";
 import { TaskItemsService } from "../client/api/generated";
 import type {
@@ -33,10 +33,8 @@ import type {
   Models_Task_UpdateTaskReviewRequest,
 } from "../client/api/generated";
 
-// GoのAPIベースURLを環境変数から設定（デフォルト: http://localhost:8080）
-if (typeof process !== "undefined" && process.env) {
-  OpenAPI.BASE = process.env.API_BASE_URL || "http://localhost:8080";
-}
+// GoのAPIのベースURLと認証情報を設定
+configureApiClient();
 
 /**
  * バリデーションエラー
@@ -52,13 +50,11 @@ export class ValidationError extends Error {
 
 /**
  * タスクを作成
- * @param ownerId オーナーID
  * @param request タスク作成リクエスト（未検証）
  * @returns 作成されたタスク
  * @throws {ValidationError} バリデーションエラー時
  */
 export async function createTaskCommand(
-  ownerId: string,
   request: CreateTaskRequest,
 ): Promise<CreateTaskResponse> {
   // バリデーション
@@ -74,10 +70,7 @@ export async function createTaskCommand(
 
   // GoのAPIエンドポイントを呼び出し
   const response = await TasksService.tasksCreateTask({
-    requestBody: {
-      ...parseResult.data,
-      ownerId: ownerId,
-    } as Models_Task_CreateTaskRequest,
+    requestBody: parseResult.data as Models_Task_CreateTaskRequest,
   });
 
   return response as CreateTaskResponse;
@@ -108,14 +101,12 @@ export async function createTaskCommand(
 /**
  * タスクを更新
  * @param taskId タスクID
- * @param ownerId オーナーID
  * @param request タスク更新リクエスト（未検証）
  * @returns 更新されたタスク
  * @throws {ValidationError} バリデーションエラー時
  */
 export async function updateTaskCommand(
   taskId: string,
-  ownerId: string,
   request: unknown,
 ): Promise<UpdateTaskResponse> {
   // バリデーション
@@ -132,10 +123,7 @@ export async function updateTaskCommand(
   // GoのAPIエンドポイントを呼び出し
   const response = await TasksService.tasksUpdateTask({
     taskId: taskId,
-    requestBody: {
-      ...parseResult.data,
-      ownerId: ownerId,
-    } as Models_Task_UpdateTaskRequest,
+    requestBody: parseResult.data as Models_Task_UpdateTaskRequest,
   });
 
   return response as UpdateTaskResponse;
@@ -167,19 +155,16 @@ export async function updateTaskCommand(
 /**
  * タスクを削除
  * @param taskId タスクID
- * @param ownerId オーナーID
  * @returns 削除結果
  */
 export async function deleteTaskCommand(
   taskId: string,
-  ownerId: string,
 ): Promise<DeleteTaskResponse> {
   // GoのAPIエンドポイントを呼び出し
   const response = await TasksService.tasksDeleteTask({
     taskId: taskId,
-    requestBody: {
-      ownerId: ownerId,
-    } as Models_Task_DeleteTaskRequest,
+    // オーナーはJWTのアカウントで判定するため、本文は空で送る
+    requestBody: {} as Models_Task_DeleteTaskRequest,
   });
 
   return response as DeleteTaskResponse;
@@ -194,14 +179,12 @@ export async function deleteTaskCommand(
 /**
  * 子タスクのアウトプットを更新
  * @param taskItemId 子タスクID
- * @param ownerId オーナーID
  * @param request 子タスク更新リクエスト（未検証）
  * @returns 更新されたタスク
  * @throws {ValidationError} バリデーションエラー時
  */
 export async function updateTaskItemCommand(
   taskItemId: string,
-  ownerId: string,
   request: unknown,
 ): Promise<UpdateTaskItemOutputResponse> {
   // バリデーション
@@ -218,10 +201,7 @@ export async function updateTaskItemCommand(
   // GoのAPIエンドポイントを呼び出し
   const response = await TaskItemsService.taskItemsUpdateTaskItemOutput({
     taskItemId: taskItemId,
-    requestBody: {
-      ...parseResult.data,
-      ownerId: ownerId,
-    } as Models_Task_UpdateTaskItemOutputRequest,
+    requestBody: parseResult.data as Models_Task_UpdateTaskItemOutputRequest,
   });
 
   return response as UpdateTaskItemOutputResponse;
@@ -248,14 +228,12 @@ export async function updateTaskItemCommand(
 /**
  * タスクの振り返りを更新
  * @param taskId タスクID
- * @param ownerId オーナーID
  * @param request タスク振り返り更新リクエスト（未検証）
  * @returns 更新されたタスク
  * @throws {ValidationError} バリデーションエラー時
  */
 export async function updateTaskReviewCommand(
   taskId: string,
-  ownerId: string,
   request: unknown,
 ): Promise<UpdateTaskReviewResponse> {
   // バリデーション
@@ -272,10 +250,7 @@ export async function updateTaskReviewCommand(
   // GoのAPIエンドポイントを呼び出し
   const response = await TasksService.tasksUpdateTaskReview({
     taskId: taskId,
-    requestBody: {
-      ...parseResult.data,
-      ownerId: ownerId,
-    } as Models_Task_UpdateTaskReviewRequest,
+    requestBody: parseResult.data as Models_Task_UpdateTaskReviewRequest,
   });
 
   return response as UpdateTaskReviewResponse;
@@ -37,9 +37,9 @@ describe("task.query.server", () => {
     describe("認証チェック", () => {
       it("requireAuthServerが呼ばれる", async () => {
         const mockTasks: ListTaskResponse = [];
-        vi.mocked(TasksService.tasksListTasks).mockResolvedValue(
-          mockTasks as never,
-        );
+        vi.mocked(TasksService.tasksListTasks).mockResolvedValue({
+          items: mockTasks,
+        } as never);
         vi.mocked(requireAuthServer).mockResolvedValue(undefined);
 
         await listTaskQuery({});
@@ -91,9 +91,9 @@ describe("task.query.server", () => {
         ];
 
         vi.mocked(requireAuthServer).mockResolvedValue(undefined);
-        vi.mocked(TasksService.tasksListTasks).mockResolvedValue(
-          mockTasks as never,
-        );
+        vi.mocked(TasksService.tasksListTasks).mockResolvedValue({
+          items: mockTasks,
+        } as never);
 
         const result = await listTaskQuery({});
 
@@ -109,9 +109,9 @@ describe("task.query.server", () => {
         const mockTasks: ListTaskResponse = [];
 
         vi.mocked(requireAuthServer).mockResolvedValue(undefined);
-        vi.mocked(TasksService.tasksListTasks).mockResolvedValue(
-          mockTasks as never,
-        );
+        vi.mocked(TasksService.tasksListTasks).mockResolvedValue({
+          items: mockTasks,
+        } as never);
 
         const result = await listTaskQuery({});
 
@@ -131,9 +131,9 @@ describe("task.query.server", () => {
         const mockTasks: ListTaskResponse = [];
 
         vi.mocked(requireAuthServer).mockResolvedValue(undefined);
-        vi.mocked(TasksService.tasksListTasks).mockResolvedValue(
-          mockTasks as never,
-        );
+        vi.mocked(TasksService.tasksListTasks).mockResolvedValue({
+          items: mockTasks,
+        } as never);
 
         await listTaskQuery(filters);
 
@@ -142,6 +142,8 @@ describe("task.query.server", () => {
           ownerId: "account-123",
           q: "テスト",
           sort: "newest",
+          limit: 100,
+          cursor: undefined,
         });
       });
 
@@ -152,9 +154,9 @@ describe("task.query.server", () => {
         const mockTasks: ListTaskResponse = [];
 
         vi.mocked(requireAuthServer).mockResolvedValue(undefined);
-        vi.mocked(TasksService.tasksListTasks).mockResolvedValue(
-          mockTasks as never,
-        );
+        vi.mocked(TasksService.tasksListTasks).mockResolvedValue({
+          items: mockTasks,
+        } as never);
 
         await listTaskQuery(filters, accountId);
 
@@ -163,9 +165,38 @@ describe("task.query.server", () => {
           ownerId: accountId,
           q: undefined,
           sort: undefined,
+          limit: 100,
+          cursor: undefined,
         });
       });
     });
+
+    describe("ページング", () => {
+      it("nextCursorがなくなるまで次のページを取得し、すべてのタスクを返す", async () => {
+        const firstPage = [{ id: "task-1" }, { id: "task-2" }];
+        const secondPage = [{ id: "task-3" }];
+
+        vi.mocked(requireAuthServer).mockResolvedValue(undefined);
+        vi.mocked(TasksService.tasksListTasks)
+          .mockResolvedValueOnce({
+            items: firstPage,
+            nextCursor: "cursor-1",
+          } as never)
+          .mockResolvedValueOnce({ items: secondPage } as never);
+
+        const result = await listTaskQuery({ sort: "date-asc" }, "account-123");
+
+        expect(result.map((task) => task.id)).toEqual([
+          "task-1",
+          "task-2",
+          "task-3",
+        ]);
+        expect(TasksService.tasksListTasks).toHaveBeenCalledTimes(2);
+        expect(TasksService.tasksListTasks).toHaveBeenLastCalledWith(
+          expect.objectContaining({ sort: "date-asc", cursor: "cursor-1" }),
+        );
+      });
+    });
   });
 
   describe("getTaskByIdQuery", () => {
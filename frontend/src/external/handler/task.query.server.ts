@@ -18,13 +18,14 @@ import { GetTaskByIdRequestSchema } from "../dto/task.dto";
 // import { taskService } from "../service/task.service";
 import { ValidationError } from "./task.command.server";
 import { TasksService } from "../client/api/generated";
-import { OpenAPI } from "../client/api/generated";
+import { configureApiClient } from "../client/api/client.server";
 import { requireAuthServer } from "@/features/auth/servers/redirect.server";
 
-// GoのAPIベースURLを環境変数から設定（デフォルト: http://localhost:8080）
-if (typeof process !== "undefined" && process.env) {
-  OpenAPI.BASE = process.env.API_BASE_URL || "http://localhost:8080";
-}
+// GoのAPIのベースURLと認証情報を設定
+configureApiClient();
+
+/** タスク一覧を取得する際の1ページの件数（APIの上限） */
+const LIST_TASKS_PAGE_SIZE = 100;
 
 /**
  * タスク一覧を取得
@@ -41,14 +42,23 @@ export async function listTaskQuery(
   const ownerId = filters.ownerId || accountId;
 
   // GoのAPIエンドポイントを呼び出し（/api/tasks）
-  const response = await TasksService.tasksListTasks({
-    yearMonth: filters["year-month"],
-    ownerId: ownerId,
-    q: filters.q,
-    sort: filters.sort,
-  });
+  // APIは1ページずつ返すため、nextCursorがなくなるまで取得してすべてのタスクを返す
+  const tasks: ListTaskResponse = [];
+  let cursor: string | undefined;
+  do {
+    const response = await TasksService.tasksListTasks({
+      yearMonth: filters["year-month"],
+      ownerId: ownerId,
+      q: filters.q,
+      sort: filters.sort,
+      limit: LIST_TASKS_PAGE_SIZE,
+      cursor,
+    });
+    tasks.push(...(response.items as ListTaskResponse));
+    cursor = response.nextCursor;
+  } while (cursor);
 
-  return response as ListTaskResponse;
+  return tasks;
 
   // 以下は既存のサービス層呼び出し（コメントアウト）
   // // サービスを呼び出し（ドメインエンティティを取得）
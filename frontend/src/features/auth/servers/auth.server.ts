@@ -7,4 +7,16 @@ export async function getSessionServer(): Promise<Session | null> {
   return await auth.api.getSession({
     headers: await headers(),
   });
-}
\ No newline at end of file
+}
+
+/**
+ * ログイン中のアカウントIDを取得（未ログインの場合はnull）
+ * リダイレクトしないため、Route HandlerとAPIクライアントから使用する
+ */
+export async function getAccountIdServer(): Promise<string | null> {
+  const session = await getSessionServer();
+  if (!session?.account || session.error) {
+    return null;
+  }
+  return session.account.id;
+}